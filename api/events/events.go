@@ -0,0 +1,155 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Event is an event sent by a controller, in its cloudevent representation
+type Event struct {
+	Id              string                 `json:"id,omitempty"`
+	Source          string                 `json:"source,omitempty"`
+	SpecVersion     string                 `json:"specversion,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	Data            map[string]interface{} `json:"data,omitempty"`
+	DataContentType string                 `json:"datacontentype,omitempty"`
+	DataSchema      string                 `json:"dataschema,omitempty"`
+	Time            time.Time              `json:"time,omitempty"`
+
+	raw json.RawMessage
+}
+
+// GetRaw returns the event's JSON exactly as it was sent by the controller
+func (e *Event) GetRaw() json.RawMessage {
+	return e.raw
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
+
+// Tail streams the live events of the controller which handles the request,
+// calling fn for each event received. Tail blocks until the ctx is done, the
+// controller ends the stream or fn returns an error. The client's timeout
+// does not apply to the stream; use the ctx to bound it.
+//
+// Audit events are sent with their sensitive and secret data redacted.
+func (c *Client) Tail(ctx context.Context, fn func(*Event) error, opt ...Option) error {
+	if fn == nil {
+		return fmt.Errorf("nil event func passed into Tail request")
+	}
+	if c.client == nil {
+		return fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	// the client's timeout would end the stream, so it's only bound by ctx
+	client := c.client.Clone()
+	client.SetClientTimeout(0)
+
+	req, err := client.NewRequest(ctx, "GET", "events:tail", nil, apiOpts...)
+	if err != nil {
+		return fmt.Errorf("error creating Tail request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	q := url.Values{}
+	for _, t := range opts.withEventTypes {
+		q.Add("type", t)
+	}
+	if opts.withFilter != "" {
+		q.Set("filter", opts.withFilter)
+	}
+	if len(q) > 0 {
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := client.Do(req, apiOpts...)
+	if err != nil {
+		return fmt.Errorf("error performing client request during Tail call: %w", err)
+	}
+	if resp.StatusCode() >= 400 {
+		apiErr, err := resp.Decode(nil)
+		if err != nil {
+			return fmt.Errorf("error decoding Tail response: %w", err)
+		}
+		return apiErr
+	}
+	body := resp.HttpResponse().Body
+	defer body.Close()
+
+	err = readEvents(body, func(data []byte) error {
+		e := new(Event)
+		if err := json.Unmarshal(data, e); err != nil {
+			return fmt.Errorf("error decoding tailed event: %w; event was %s", err, data)
+		}
+		e.raw = data
+		return fn(e)
+	})
+	switch {
+	case err == nil, ctx.Err() != nil:
+		return nil
+	default:
+		return err
+	}
+}
+
+// readEvents reads server-sent events from r, calling fn with the data of
+// each event until r is exhausted.  Comments and fields other than data are
+// ignored.
+func readEvents(r io.Reader, fn func([]byte) error) error {
+	reader := bufio.NewReader(r)
+	var data bytes.Buffer
+	for {
+		line, err := reader.ReadString('\n')
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("error reading tailed events: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			// a blank line dispatches the event
+			if data.Len() == 0 {
+				continue
+			}
+			b := make([]byte, data.Len())
+			copy(b, data.Bytes())
+			data.Reset()
+			if err := fn(b); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
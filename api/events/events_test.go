@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Tail(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/events:tail" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("filter") == "bad" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"kind":"InvalidArgument","message":"Error in provided request."}`)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"type\":\"observation\",\"data\":{\"name\":\"first\"}}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"2\",\"type\":\"audit\",\n")
+		fmt.Fprint(w, "data: \"data\":{\"name\":\"second\"}}\n\n")
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(nil)
+	require.NoError(t, err)
+	require.NoError(t, client.SetAddr(srv.URL))
+	ec := NewClient(client)
+
+	t.Run("events", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		var got []*Event
+		err := ec.Tail(context.Background(), func(e *Event) error {
+			got = append(got, e)
+			return nil
+		}, WithEventTypes("observation", "audit"), WithFilter(` "/data/name" != "" `))
+		require.NoError(err)
+		assert.Equal("filter=%22%2Fdata%2Fname%22+%21%3D+%22%22&type=observation&type=audit", gotQuery)
+		require.Len(got, 2)
+		assert.Equal("1", got[0].Id)
+		assert.Equal("observation", got[0].Type)
+		assert.Equal("first", got[0].Data["name"])
+		assert.Equal(`{"id":"1","type":"observation","data":{"name":"first"}}`, string(got[0].GetRaw()))
+		assert.Equal("2", got[1].Id)
+		assert.Equal("audit", got[1].Type)
+		assert.Equal("second", got[1].Data["name"])
+	})
+	t.Run("func-error", func(t *testing.T) {
+		assert := assert.New(t)
+		calls := 0
+		err := ec.Tail(context.Background(), func(e *Event) error {
+			calls++
+			return fmt.Errorf("stop")
+		})
+		assert.EqualError(err, "stop")
+		assert.Equal(1, calls)
+	})
+	t.Run("api-error", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := ec.Tail(context.Background(), func(e *Event) error { return nil }, WithFilter("bad"))
+		require.Error(err)
+		apiErr := api.AsServerError(err)
+		require.NotNil(apiErr)
+		assert.Equal(http.StatusBadRequest, apiErr.Response().StatusCode())
+	})
+	t.Run("missing-func", func(t *testing.T) {
+		assert.Error(t, ec.Tail(context.Background(), nil))
+	})
+}
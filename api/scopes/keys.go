@@ -0,0 +1,189 @@
+package scopes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Key is one of a scope's keys along with all of its versions.
+type Key struct {
+	Id          string        `json:"id,omitempty"`
+	Scope       *ScopeInfo    `json:"scope,omitempty"`
+	Purpose     string        `json:"purpose,omitempty"`
+	CreatedTime time.Time     `json:"created_time,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Versions    []*KeyVersion `json:"versions,omitempty"`
+	KmsName     string        `json:"kms_name,omitempty"`
+}
+
+// KeyVersion is a single version of a Key.
+type KeyVersion struct {
+	Id             string    `json:"id,omitempty"`
+	Version        uint32    `json:"version,omitempty"`
+	CreatedTime    time.Time `json:"created_time,omitempty"`
+	ReferenceCount uint64    `json:"reference_count,omitempty"`
+}
+
+// KeyVersionDestructionJob reports the progress of destroying a Key Version.
+type KeyVersionDestructionJob struct {
+	KeyVersionId   string     `json:"key_version_id,omitempty"`
+	Scope          *ScopeInfo `json:"scope,omitempty"`
+	CreatedTime    time.Time  `json:"created_time,omitempty"`
+	CompletedCount int64      `json:"completed_count,omitempty"`
+	TotalCount     int64      `json:"total_count,omitempty"`
+}
+
+type KeyListResult struct {
+	Items    []*Key
+	response *api.Response
+}
+
+func (n KeyListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type KeyVersionDestructionJobListResult struct {
+	Items    []*KeyVersionDestructionJob
+	response *api.Response
+}
+
+func (n KeyVersionDestructionJobListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyVersionDestructionJobListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// KeyVersionDestructionResult contains the state of a key version
+// destruction, either "pending" or "completed".
+type KeyVersionDestructionResult struct {
+	State    string `json:"state,omitempty"`
+	response *api.Response
+}
+
+func (n KeyVersionDestructionResult) GetItem() interface{} {
+	return n.State
+}
+
+func (n KeyVersionDestructionResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListKeys lists the root key and the data keys of the scope with the given
+// id, along with all of their versions.
+func (c *Client) ListKeys(ctx context.Context, scopeId string, opt ...Option) (*KeyListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeys request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-keys", url.PathEscape(scopeId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeys request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeys call: %w", err)
+	}
+
+	target := new(KeyListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// DestroyKeyVersion destroys the key version with the given id of one of the
+// keys of the scope with the given id. Data encrypted by the version is
+// re-encrypted with the current version of its key first, so the destruction
+// may complete in the background; its progress is reported by
+// ListKeyVersionDestructionJobs.
+func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string, opt ...Option) (*KeyVersionDestructionResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into DestroyKeyVersion request")
+	}
+	if keyVersionId == "" {
+		return nil, fmt.Errorf("empty keyVersionId value passed into DestroyKeyVersion request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["key_version_id"] = keyVersionId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:destroy-key-version", url.PathEscape(scopeId)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DestroyKeyVersion request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DestroyKeyVersion call: %w", err)
+	}
+
+	target := new(KeyVersionDestructionResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DestroyKeyVersion response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ListKeyVersionDestructionJobs lists the pending destructions of the versions
+// of the keys of the scope with the given id.
+func (c *Client) ListKeyVersionDestructionJobs(ctx context.Context, scopeId string, opt ...Option) (*KeyVersionDestructionJobListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeyVersionDestructionJobs request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-key-version-destruction-jobs", url.PathEscape(scopeId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeyVersionDestructionJobs request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeyVersionDestructionJobs call: %w", err)
+	}
+
+	target := new(KeyVersionDestructionJobListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeyVersionDestructionJobs response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
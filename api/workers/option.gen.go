@@ -87,6 +87,18 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithActivationTokenTtl(inActivationTokenTtl uint32) Option {
+	return func(o *options) {
+		o.postMap["activation_token_ttl"] = inActivationTokenTtl
+	}
+}
+
+func DefaultActivationTokenTtl() Option {
+	return func(o *options) {
+		o.postMap["activation_token_ttl"] = nil
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -13,22 +13,31 @@ import (
 )
 
 type Worker struct {
-	Id                       string              `json:"id,omitempty"`
-	ScopeId                  string              `json:"scope_id,omitempty"`
-	Scope                    *scopes.ScopeInfo   `json:"scope,omitempty"`
-	Name                     string              `json:"name,omitempty"`
-	Description              string              `json:"description,omitempty"`
-	CreatedTime              time.Time           `json:"created_time,omitempty"`
-	UpdatedTime              time.Time           `json:"updated_time,omitempty"`
-	Version                  uint32              `json:"version,omitempty"`
-	Address                  string              `json:"address,omitempty"`
-	CanonicalTags            map[string][]string `json:"canonical_tags,omitempty"`
-	ConfigTags               map[string][]string `json:"config_tags,omitempty"`
-	LastStatusTime           time.Time           `json:"last_status_time,omitempty"`
-	WorkerGeneratedAuthToken string              `json:"worker_generated_auth_token,omitempty"`
-	ActiveConnectionCount    uint32              `json:"active_connection_count,omitempty"`
-	Type                     string              `json:"type,omitempty"`
-	AuthorizedActions        []string            `json:"authorized_actions,omitempty"`
+	Id                                 string              `json:"id,omitempty"`
+	ScopeId                            string              `json:"scope_id,omitempty"`
+	Scope                              *scopes.ScopeInfo   `json:"scope,omitempty"`
+	Name                               string              `json:"name,omitempty"`
+	Description                        string              `json:"description,omitempty"`
+	CreatedTime                        time.Time           `json:"created_time,omitempty"`
+	UpdatedTime                        time.Time           `json:"updated_time,omitempty"`
+	Version                            uint32              `json:"version,omitempty"`
+	Address                            string              `json:"address,omitempty"`
+	CanonicalTags                      map[string][]string `json:"canonical_tags,omitempty"`
+	ConfigTags                         map[string][]string `json:"config_tags,omitempty"`
+	LastStatusTime                     time.Time           `json:"last_status_time,omitempty"`
+	WorkerGeneratedAuthToken           string              `json:"worker_generated_auth_token,omitempty"`
+	ActiveConnectionCount              uint32              `json:"active_connection_count,omitempty"`
+	Type                               string              `json:"type,omitempty"`
+	CertificateExpiration              time.Time           `json:"certificate_expiration,omitempty"`
+	LastRotationTime                   time.Time           `json:"last_rotation_time,omitempty"`
+	LastRotationError                  string              `json:"last_rotation_error,omitempty"`
+	RotateAuthRequested                bool                `json:"rotate_auth_requested,omitempty"`
+	ReleaseVersion                     string              `json:"release_version,omitempty"`
+	Features                           []string            `json:"features,omitempty"`
+	ControllerGeneratedActivationToken string              `json:"controller_generated_activation_token,omitempty"`
+	ActivationTokenTtl                 uint32              `json:"activation_token_ttl,omitempty"`
+	ActivationTokenExpiration          time.Time           `json:"activation_token_expiration,omitempty"`
+	AuthorizedActions                  []string            `json:"authorized_actions,omitempty"`
 
 	response *api.Response
 }
@@ -1,86 +1,104 @@
 package globals
 
 const (
-	IdField                              = "id"
-	VersionField                         = "version"
-	NameField                            = "name"
-	DescriptionField                     = "description"
-	CreatedTimeField                     = "created_time"
-	UpdatedTimeField                     = "updated_time"
-	TypeField                            = "type"
-	AttributesField                      = "attributes"
-	ScopeIdField                         = "scope_id"
-	ScopeField                           = "scope"
-	AuthMethodIdField                    = "auth_method_id"
-	AccountIdField                       = "account_id"
-	UserIdField                          = "user_id"
-	IsPrimaryField                       = "is_primary"
-	AuthorizedActionsField               = "authorized_actions"
-	AuthorizedCollectionActionsField     = "authorized_collection_actions"
-	ExpirationTimeField                  = "expiration_time"
-	ApproximateLastUsedTimeField         = "approximate_last_used_time"
-	MembersField                         = "members"
-	MemberIdsField                       = "member_ids"
-	HostCatalogIdField                   = "host_catalog_id"
-	HostSetIdsField                      = "host_set_ids"
-	HostSourceIdsField                   = "host_source_ids"
-	HostIdsField                         = "host_ids"
-	PrincipalIdsField                    = "principal_ids"
-	PrincipalsField                      = "principals"
-	GrantScopeIdField                    = "grant_scope_id"
-	GrantsField                          = "grants"
-	GrantStringsField                    = "grant_strings"
-	PrimaryAuthMethodIdField             = "primary_auth_method_id"
-	TargetIdField                        = "target_id"
-	HostIdField                          = "host_id"
-	HostSetIdField                       = "host_set_id"
-	HostSetsField                        = "host_sets"
-	HostSourcesField                     = "host_sources"
-	AuthTokenIdField                     = "auth_token_id"
-	EndpointField                        = "endpoint"
-	CertificateField                     = "certificate"
-	TerminationReasonField               = "termination_reason"
-	StatusField                          = "status"
-	StatesField                          = "states"
-	SessionConnectionLimitField          = "session_connection_limit"
-	SessionMaxSecondsField               = "session_max_seconds"
-	WorkerFilterField                    = "worker_filter"
-	AccountIdsField                      = "account_ids"
-	AccountsField                        = "accounts"
-	LoginNameField                       = "login_name"
-	FullNameField                        = "full_name"
-	PrimaryAccountIdField                = "primary_account_id"
-	EmailField                           = "email"
-	ManagedGroupIdsField                 = "managed_group_ids"
-	FilterField                          = "filter"
-	CredentialStoreIdField               = "credential_store_id"
-	ApplicationCredentialLibraryIdsField = "application_credential_library_ids"
-	ApplicationCredentialLibrariesField  = "application_credential_libraries"
-	ApplicationCredentialSourceIdsField  = "application_credential_source_ids"
-	ApplicationCredentialSourcesField    = "application_credential_sources"
-	PreferredEndpointsField              = "preferred_endpoints"
-	SyncIntervalSecondsField             = "sync_interval_seconds"
-	PluginIdField                        = "plugin_id"
-	PluginField                          = "plugin"
-	PluginNameField                      = "plugin_name"
-	IpAddressesField                     = "ip_addresses"
-	DnsNamesField                        = "dns_names"
-	SecretsHmacField                     = "secrets_hmac"
-	ExternalIdField                      = "external_id"
-	EgressCredentialSourceIdsField       = "egress_credential_source_ids"
-	EgressCredentialSourcesField         = "egress_credential_sources"
-	ConnectionsField                     = "connections"
-	CredentialTypeField                  = "credential_type"
-	CredentialMappingOverridesField      = "credential_mapping_overrides"
-	MetricNamespace                      = "boundary"
-	LastStatusTimeField                  = "last_status_time"
-	AddressField                         = "address"
-	CanonicalAddressField                = "canonical_address"
-	TagsField                            = "tags"
-	CanonicalTagsField                   = "canonical_tags"
-	ConfigTagsField                      = "config_tags"
-	ConfigurationField                   = "configuration"
-	WorkerGeneratedAuthTokenField        = "worker_generated_auth_token"
-	WorkerProvidedConfigurationField     = "worker_provided_configuration"
-	ActiveConnectionCountField           = "active_connection_count"
+	IdField                                 = "id"
+	VersionField                            = "version"
+	NameField                               = "name"
+	DescriptionField                        = "description"
+	CreatedTimeField                        = "created_time"
+	UpdatedTimeField                        = "updated_time"
+	TypeField                               = "type"
+	AttributesField                         = "attributes"
+	ScopeIdField                            = "scope_id"
+	ScopeField                              = "scope"
+	AuthMethodIdField                       = "auth_method_id"
+	AccountIdField                          = "account_id"
+	UserIdField                             = "user_id"
+	IsPrimaryField                          = "is_primary"
+	AuthorizedActionsField                  = "authorized_actions"
+	AuthorizedCollectionActionsField        = "authorized_collection_actions"
+	ExpirationTimeField                     = "expiration_time"
+	ApproximateLastUsedTimeField            = "approximate_last_used_time"
+	MembersField                            = "members"
+	MemberIdsField                          = "member_ids"
+	HostCatalogIdField                      = "host_catalog_id"
+	HostSetIdsField                         = "host_set_ids"
+	HostSourceIdsField                      = "host_source_ids"
+	HostIdsField                            = "host_ids"
+	PrincipalIdsField                       = "principal_ids"
+	PrincipalsField                         = "principals"
+	GrantScopeIdField                       = "grant_scope_id"
+	GrantsField                             = "grants"
+	GrantStringsField                       = "grant_strings"
+	PrimaryAuthMethodIdField                = "primary_auth_method_id"
+	TargetIdField                           = "target_id"
+	HostIdField                             = "host_id"
+	HostSetIdField                          = "host_set_id"
+	HostSetsField                           = "host_sets"
+	HostSourcesField                        = "host_sources"
+	AuthTokenIdField                        = "auth_token_id"
+	EndpointField                           = "endpoint"
+	CertificateField                        = "certificate"
+	TerminationReasonField                  = "termination_reason"
+	StatusField                             = "status"
+	StatesField                             = "states"
+	SessionConnectionLimitField             = "session_connection_limit"
+	SessionMaxSecondsField                  = "session_max_seconds"
+	WorkerFilterField                       = "worker_filter"
+	AccountIdsField                         = "account_ids"
+	AccountsField                           = "accounts"
+	LoginNameField                          = "login_name"
+	FullNameField                           = "full_name"
+	PrimaryAccountIdField                   = "primary_account_id"
+	EmailField                              = "email"
+	ManagedGroupIdsField                    = "managed_group_ids"
+	FilterField                             = "filter"
+	CredentialStoreIdField                  = "credential_store_id"
+	ApplicationCredentialLibraryIdsField    = "application_credential_library_ids"
+	ApplicationCredentialLibrariesField     = "application_credential_libraries"
+	ApplicationCredentialSourceIdsField     = "application_credential_source_ids"
+	ApplicationCredentialSourcesField       = "application_credential_sources"
+	PreferredEndpointsField                 = "preferred_endpoints"
+	SyncIntervalSecondsField                = "sync_interval_seconds"
+	PluginIdField                           = "plugin_id"
+	PluginField                             = "plugin"
+	PluginNameField                         = "plugin_name"
+	IpAddressesField                        = "ip_addresses"
+	DnsNamesField                           = "dns_names"
+	SecretsHmacField                        = "secrets_hmac"
+	ExternalIdField                         = "external_id"
+	EgressCredentialSourceIdsField          = "egress_credential_source_ids"
+	EgressCredentialSourcesField            = "egress_credential_sources"
+	ConnectionsField                        = "connections"
+	CredentialTypeField                     = "credential_type"
+	CredentialMappingOverridesField         = "credential_mapping_overrides"
+	MetricNamespace                         = "boundary"
+	LastStatusTimeField                     = "last_status_time"
+	AddressField                            = "address"
+	CanonicalAddressField                   = "canonical_address"
+	TagsField                               = "tags"
+	CanonicalTagsField                      = "canonical_tags"
+	ConfigTagsField                         = "config_tags"
+	ConfigurationField                      = "configuration"
+	WorkerGeneratedAuthTokenField           = "worker_generated_auth_token"
+	WorkerProvidedConfigurationField        = "worker_provided_configuration"
+	ActiveConnectionCountField              = "active_connection_count"
+	CertificateExpirationField              = "certificate_expiration"
+	LastRotationTimeField                   = "last_rotation_time"
+	LastRotationErrorField                  = "last_rotation_error"
+	RotateAuthRequestedField                = "rotate_auth_requested"
+	ReleaseVersionField                     = "release_version"
+	FeaturesField                           = "features"
+	ControllerGeneratedActivationTokenField = "controller_generated_activation_token"
+	ActivationTokenTtlField                 = "activation_token_ttl"
+	ActivationTokenExpirationField          = "activation_token_expiration"
+	NextScheduledRunField                   = "next_scheduled_run"
+	LastRunField                            = "last_run"
+	RunsField                               = "runs"
+	TableField                              = "table"
+	ResourceIdField                         = "resource_id"
+	OperationField                          = "operation"
+	MetadataField                           = "metadata"
+	MessagesField                           = "messages"
+	ConsecutiveFailuresField                = "consecutive_failures"
 )
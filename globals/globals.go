@@ -6,6 +6,11 @@ const (
 	TcpProxyV1     = "boundary-tcp-proxy-v1"
 	ServiceTokenV1 = "s1"
 	SessionPrefix  = "s_"
+
+	// ControllerLedActivationTokenPrefix is the prefix of the activation
+	// tokens generated by the controller for the controller led node
+	// enrollment flow, which workers decode into their registration nonce.
+	ControllerLedActivationTokenPrefix = "wact_"
 )
 
 type (
@@ -2,6 +2,7 @@ package globals
 
 const (
 	KmsPurposeRoot              = "root"
+	KmsPurposeScopeRoot         = "scope-root"
 	KmsPurposeWorkerAuth        = "worker-auth"
 	KmsPurposeWorkerAuthStorage = "worker-auth-storage"
 	KmsPurposeRecovery          = "recovery"
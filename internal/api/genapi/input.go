@@ -14,7 +14,9 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/oplog"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -709,6 +711,71 @@ var inputStructs = []*structInfo{
 		fieldFilter:         []string{"private_key"},
 		recursiveListing:    true,
 	},
+	{
+		inProto: &jobs.JobRun{},
+		outFile: "jobs/job_run.gen.go",
+	},
+	{
+		inProto: &jobs.Job{},
+		outFile: "jobs/job.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "jobs",
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	{
+		inProto: &oplog.Message{},
+		outFile: "oplog/message.gen.go",
+	},
+	{
+		inProto: &oplog.Entry{},
+		outFile: "oplog/entry.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+		},
+		extraFields: []fieldInfo{
+			{
+				Name:        "ResourceId",
+				ProtoName:   "resource_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "ResourceScopeId",
+				ProtoName:   "resource_scope_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "Table",
+				ProtoName:   "table",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "StartTime",
+				ProtoName:   "start_time",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "EndTime",
+				ProtoName:   "end_time",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+		},
+		createResponseTypes: true,
+	},
 	{
 		inProto: &workers.Worker{},
 		outFile: "workers/worker.gen.go",
@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn(defaultAuthMethodTableName, authMethodRewrapFn)
+}
+
+// authMethodRewrapFn decrypts the client secrets of the auth methods encrypted
+// with the data key version and re-encrypts them with the current version of
+// the scope's database key.
+func authMethodRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "oidc.authMethodRewrapFn"
+	var authMethods []*AuthMethod
+	if err := reader.SearchWhere(ctx, &authMethods, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up auth methods"))
+	}
+	if len(authMethods) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for the data key version"))
+	}
+	currentWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get current database wrapper"))
+	}
+	for _, am := range authMethods {
+		if err := am.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := am.encrypt(ctx, currentWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		// only the encrypted columns are updated, which doesn't bump the
+		// version, and a row updated since it was read is left for the next
+		// rewrap
+		if _, err := writer.Update(ctx, am, []string{"CtClientSecret", "ClientSecretHmac", "KeyId"}, nil, db.WithVersion(&am.Version)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method"))
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/boundary/internal/libs/crypto"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	aead "github.com/hashicorp/go-kms-wrapping/v2/aead"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/multi"
 	"github.com/mr-tron/base58"
 	"google.golang.org/protobuf/proto"
 )
@@ -184,6 +185,13 @@ func requestWrappingWrapper(ctx context.Context, k *kms.Kms, scopeId, authMethod
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oidc wrapper"))
 	}
+	// derive from the requested version of the DEK, which isn't the latest
+	// version once the scope's keys have been rotated.
+	if pooled, ok := oidcWrapper.(*multi.PooledWrapper); ok && opts.withKeyId != "" {
+		if w := pooled.WrapperForKeyId(opts.withKeyId); w != nil {
+			oidcWrapper = w
+		}
+	}
 
 	// What derived key are we looking for?
 	keyId, err := oidcWrapper.KeyId(ctx)
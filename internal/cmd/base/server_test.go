@@ -35,6 +35,7 @@ func TestServer_SetupKMSes(t *testing.T) {
 	tests := []struct {
 		name            string
 		purposes        []string
+		config          map[string]string
 		wantErrContains string
 	}{
 		{
@@ -61,6 +62,16 @@ func TestServer_SetupKMSes(t *testing.T) {
 				globals.KmsPurposeWorkerAuthStorage, globals.KmsPurposeConfig,
 			},
 		},
+		{
+			name:     "scope root purpose",
+			purposes: []string{globals.KmsPurposeScopeRoot},
+			config:   map[string]string{"name": "tenant"},
+		},
+		{
+			name:            "scope root purpose missing name",
+			purposes:        []string{globals.KmsPurposeScopeRoot},
+			wantErrContains: "missing 'name'",
+		},
 	}
 	logger := hclog.Default()
 	serLock := new(sync.Mutex)
@@ -72,6 +83,7 @@ func TestServer_SetupKMSes(t *testing.T) {
 					{
 						Type:    "aead",
 						Purpose: tt.purposes,
+						Config:  tt.config,
 					},
 				},
 			}
@@ -96,6 +108,8 @@ func TestServer_SetupKMSes(t *testing.T) {
 					assert.NotNil(s.WorkerAuthStorageKms)
 				case globals.KmsPurposeRecovery:
 					assert.NotNil(s.RecoveryKms)
+				case globals.KmsPurposeScopeRoot:
+					assert.NotNil(s.ScopeRootKmses[tt.config["name"]])
 				}
 			}
 		})
@@ -26,6 +26,7 @@ import (
 	berrors "github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
@@ -90,6 +91,7 @@ type Server struct {
 	WorkerAuthKms        wrapping.Wrapper
 	WorkerAuthStorageKms wrapping.Wrapper
 	RecoveryKms          wrapping.Wrapper
+	ScopeRootKmses       map[string]wrapping.Wrapper
 	Kms                  *kms.Kms
 	SecureRandomReader   io.Reader
 
@@ -139,7 +141,16 @@ type Server struct {
 	DatabaseMaxOpenConnections int
 	DevDatabaseCleanupFunc     func() error
 
-	Database *db.DB
+	// DatabaseReadReplicaUrls are the URLs of read replicas of the database
+	// which read-only requests are routed to.
+	DatabaseReadReplicaUrls []string
+
+	// DatabaseMaxReplicaLag is the amount a read replica can lag behind the
+	// database before reads are routed to the database instead.
+	DatabaseMaxReplicaLag time.Duration
+
+	Database             *db.DB
+	ReadReplicaDatabases []*db.DB
 
 	// StatusGracePeriodDuration represents the period of time (as a
 	// duration) that the controller will wait before marking
@@ -229,6 +240,11 @@ func (b *Server) SetupEventing(logger hclog.Logger, serializationLock *sync.Mute
 		return berrors.WrapDeprecated(err, op, berrors.WithMsg("unable to create eventer"))
 	}
 	b.Eventer = e
+	b.ShutdownFuncs = append(b.ShutdownFuncs, func() error {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return e.Close(shutdownCtx)
+	})
 
 	if err := event.InitSysEventer(logger, serializationLock, serverName, event.WithEventer(e)); err != nil {
 		return berrors.WrapDeprecated(err, op, berrors.WithMsg("unable to initialize system eventer"))
@@ -237,6 +253,33 @@ func (b *Server) SetupEventing(logger hclog.Logger, serializationLock *sync.Mute
 	return nil
 }
 
+// SetupTracing will setup the export of traces to the collector in the
+// config, and install the tracer provider as the global provider.  Nothing is
+// done when the config is nil.
+func (b *Server) SetupTracing(ctx context.Context, conf *tracing.Config, serverName string) error {
+	const op = "base.(Server).SetupTracing"
+	if conf == nil {
+		return nil
+	}
+	if serverName == "" {
+		return berrors.New(ctx, berrors.InvalidParameter, op, "missing server name")
+	}
+	tp, err := tracing.NewTracerProvider(ctx, conf, tracing.WithInstanceId(serverName))
+	if err != nil {
+		return berrors.Wrap(ctx, err, op, berrors.WithMsg("unable to create tracer provider"))
+	}
+	tracing.SetTracerProvider(tp)
+	b.ShutdownFuncs = append(b.ShutdownFuncs, func() error {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return tp.Shutdown(shutdownCtx)
+	})
+
+	b.InfoKeys = append(b.InfoKeys, "tracing endpoint")
+	b.Info["tracing endpoint"] = conf.OtlpEndpoint
+	return nil
+}
+
 // AddEventerToContext will add the server eventer to the context provided
 func (b *Server) AddEventerToContext(ctx context.Context) (context.Context, error) {
 	const op = "base.(Server).AddEventerToContext"
@@ -566,6 +609,10 @@ func (b *Server) SetupKMSes(ctx context.Context, ui cli.Ui, config *config.Confi
 					continue
 				}
 			case globals.KmsPurposeRoot, globals.KmsPurposeConfig, globals.KmsPurposeWorkerAuthStorage:
+			case globals.KmsPurposeScopeRoot:
+				if kms.Config["name"] == "" {
+					return fmt.Errorf("KMS block with purpose %q missing 'name'", purpose)
+				}
 			case globals.KmsPurposeRecovery:
 				if config.Controller != nil && config.DevRecoveryKey != "" {
 					kms.Config["key"] = config.DevRecoveryKey
@@ -634,6 +681,15 @@ func (b *Server) SetupKMSes(ctx context.Context, ui cli.Ui, config *config.Confi
 				b.WorkerAuthStorageKms = wrapper
 			case globals.KmsPurposeRecovery:
 				b.RecoveryKms = wrapper
+			case globals.KmsPurposeScopeRoot:
+				name := kms.Config["name"]
+				if _, ok := b.ScopeRootKmses[name]; ok {
+					return fmt.Errorf("Duplicate KMS block name %q for purpose %q", name, purpose)
+				}
+				if b.ScopeRootKmses == nil {
+					b.ScopeRootKmses = make(map[string]wrapping.Wrapper)
+				}
+				b.ScopeRootKmses[name] = wrapper
 			case globals.KmsPurposeConfig:
 				// Do nothing, can be set in same file but not needed at runtime
 			default:
@@ -684,6 +740,13 @@ func (b *Server) ConnectToDatabase(ctx context.Context, dialect string) error {
 		return fmt.Errorf("unable to create db object with dialect %s: %w", dialect, err)
 	}
 	b.Database = dbase
+	for i, u := range b.DatabaseReadReplicaUrls {
+		replica, err := db.Open(dbType, u, opts...)
+		if err != nil {
+			return fmt.Errorf("unable to create db object for read replica %d with dialect %s: %w", i, dialect, err)
+		}
+		b.ReadReplicaDatabases = append(b.ReadReplicaDatabases, replica)
+	}
 	return nil
 }
 
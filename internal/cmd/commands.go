@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/audit"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -13,12 +14,15 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/credentialstorescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
 	"github.com/hashicorp/boundary/internal/cmd/commands/dev"
+	"github.com/hashicorp/boundary/internal/cmd/commands/eventscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/groupscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/jobscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
 	"github.com/hashicorp/boundary/internal/cmd/commands/managedgroupscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplogcmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
@@ -234,6 +238,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"audit": func() (cli.Command, error) {
+			return &audit.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"audit verify": func() (cli.Command, error) {
+			return &audit.VerifyCommand{
+				Server: base.NewServer(base.NewCommand(ui)),
+			}, nil
+		},
+
 		"config": func() (cli.Command, error) {
 			return &config.Command{
 				Command: base.NewCommand(ui),
@@ -328,6 +343,22 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database verify": func() (cli.Command, error) {
+			return &database.VerifyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"events": func() (cli.Command, error) {
+			return &eventscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"events tail": func() (cli.Command, error) {
+			return &eventscmd.TailCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"credential-libraries": func() (cli.Command, error) {
 			return &credentiallibrariescmd.Command{
@@ -725,6 +756,30 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"jobs": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"jobs read": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"jobs list": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"jobs run-now": func() (cli.Command, error) {
+			return &jobscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "run-now",
+			}, nil
+		},
+
 		"logout": func() (cli.Command, error) {
 			return &logout.LogoutCommand{
 				Command: base.NewCommand(ui),
@@ -779,6 +834,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"oplog": func() (cli.Command, error) {
+			return &oplogcmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog list": func() (cli.Command, error) {
+			return &oplogcmd.ListCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
 				Command: base.NewCommand(ui),
@@ -874,6 +940,36 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"scopes rotate-keys": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-keys",
+			}, nil
+		},
+		"scopes list-keys": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-keys",
+			}, nil
+		},
+		"scopes destroy-key-version": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "destroy-key-version",
+			}, nil
+		},
+		"scopes list-key-version-destruction-jobs": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-key-version-destruction-jobs",
+			}, nil
+		},
+		"scopes set-kms": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-kms",
+			}, nil
+		},
 		"scopes delete": func() (cli.Command, error) {
 			return &scopescmd.Command{
 				Command: base.NewCommand(ui),
@@ -1090,6 +1186,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"workers create controller-led": func() (cli.Command, error) {
+			return &workerscmd.ControllerLedCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"workers read": func() (cli.Command, error) {
 			return &workerscmd.Command{
 				Command: base.NewCommand(ui),
@@ -1102,6 +1204,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"workers rotate-auth": func() (cli.Command, error) {
+			return &workerscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-auth",
+			}, nil
+		},
+		"workers revoke-activation-token": func() (cli.Command, error) {
+			return &workerscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-activation-token",
+			}, nil
+		},
 		"workers delete": func() (cli.Command, error) {
 			return &workerscmd.Command{
 				Command: base.NewCommand(ui),
@@ -0,0 +1,333 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	kms_plugin_assets "github.com/hashicorp/boundary/plugins/kms"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-secure-stdlib/configutil/v2"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/go-secure-stdlib/pluginutil/v2"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*VerifyCommand)(nil)
+	_ cli.CommandAutocomplete = (*VerifyCommand)(nil)
+)
+
+type VerifyCommand struct {
+	*base.Server
+
+	Config *config.Config
+
+	// This will be intialized, if needed, in ParseFlagsAndConfig when
+	// instantiating a config wrapper, if requested. It's then called as a
+	// deferred function on the Run method.
+	configWrapperCleanupFunc func() error
+
+	flagConfig    string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagFiles     []string
+}
+
+func (c *VerifyCommand) Synopsis() string {
+	return "Verify the chained audit events written by a file sink"
+}
+
+func (c *VerifyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary audit verify [options]",
+		"",
+		"  Verify the chained audit events of a file sink with hash_chain enabled in its audit_config:",
+		"",
+		"    $ boundary audit verify -config=controller.hcl -file=audit.log",
+		"",
+		"  The audit keys used to chain the events are read from the database configured in the controller configuration file. To verify a rotated log, specify each of its files with -file, in the order they were written. Any events which are missing or have been modified, and any lines which are not chained, are reported, and the command exits with an error if problems are found.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *VerifyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the controller configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f = set.NewFlagSet("Verify Options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:       "file",
+		Target:     &c.flagFiles,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "Path to an audit event file to verify. May be specified multiple times, in the order the files were written.",
+	})
+
+	return set
+}
+
+func (c *VerifyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VerifyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VerifyCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.configWrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error finalizing config kms: %w", err))
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	if err := c.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	serverName, err := os.Hostname()
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Unable to determine hostname: %w", err).Error())
+		return base.CommandCliError
+	}
+	serverName = fmt.Sprintf("%s/boundary-audit-verify", serverName)
+	// The sinks in the configuration aren't used, so this command never
+	// writes to the audit files being verified.
+	if err := c.SetupEventing(c.Logger, c.StderrLock, serverName); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if err := c.SetupKMSes(c.Context, c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	c.DatabaseMaxOpenConnections = c.Config.Controller.Database.MaxOpenConnections
+
+	urlToParse := c.Config.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return base.CommandUserError
+	}
+	c.DatabaseUrl, err = parseutil.ParsePath(urlToParse)
+	if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return base.CommandUserError
+	}
+	if err := c.ConnectToDatabase(c.Context, dialect); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer func() {
+		if err := c.Database.Close(c.Context); err != nil {
+			c.UI.Warn(fmt.Errorf("Error closing database: %w", err).Error())
+		}
+	}()
+
+	rw := db.New(c.Database)
+	kmsCache, err := kms.New(c.Context, rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return base.CommandCliError
+	}
+	if err := kmsCache.AddExternalWrappers(c.Context, kms.WithRootWrapper(c.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	keyFn := func(ctx context.Context, keyId string) (wrapping.Wrapper, error) {
+		return kmsCache.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeAudit, kms.WithKeyId(keyId))
+	}
+	report, err := event.VerifyAuditChain(c.Context, keyFn, c.flagFiles...)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error verifying audit events: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(report)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(printReport(report))
+	}
+
+	if !report.Valid() {
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+func printReport(report *event.AuditChainReport) string {
+	ret := []string{
+		"",
+		"Audit chain verification:",
+		fmt.Sprintf("  Chains:              %d", len(report.Chains)),
+		fmt.Sprintf("  Problems:            %d", len(report.Problems)),
+		fmt.Sprintf("  Unchained Lines:     %d", report.Unchained),
+	}
+	for _, ch := range report.Chains {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Chain ID:            %s", ch.ChainId),
+			fmt.Sprintf("    First Sequence:    %d", ch.FirstSeq),
+			fmt.Sprintf("    Last Sequence:     %d", ch.LastSeq),
+			fmt.Sprintf("    Events:            %d", ch.Events),
+			fmt.Sprintf("    Checkpoints:       %d", ch.Checkpoints),
+		)
+	}
+	if len(report.Problems) > 0 {
+		ret = append(ret, "", "  Problems:")
+		for _, p := range report.Problems {
+			ret = append(ret, fmt.Sprintf("    %s", p))
+		}
+	}
+	return strings.Join(ret, "\n")
+}
+
+func (c *VerifyCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case len(c.flagFiles) == 0:
+		c.UI.Error("Must specify an audit event file using -file")
+		return base.CommandUserError
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, cleanupFunc, err := wrapper.GetWrapperFromPath(
+		c.Context,
+		wrapperPath,
+		globals.KmsPurposeConfig,
+		configutil.WithPluginOptions(
+			pluginutil.WithPluginsMap(kms_plugin_assets.BuiltinKmsPlugins()),
+			pluginutil.WithPluginsFilesystem(kms_plugin_assets.KmsPluginPrefix, kms_plugin_assets.FileSystem()),
+		),
+		configutil.WithLogger(hclog.NewNullLogger()),
+	)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if wrapper != nil {
+		c.configWrapperCleanupFunc = cleanupFunc
+		if ifWrapper, ok := wrapper.(wrapping.InitFinalizer); ok {
+			if err := ifWrapper.Init(c.Context); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+				c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+				return base.CommandUserError
+			}
+			c.configWrapperCleanupFunc = func() error {
+				if err := ifWrapper.Finalize(context.Background()); err != nil && !errors.Is(err, wrapping.ErrFunctionNotImplemented) {
+					c.UI.Warn(fmt.Errorf("Could not finalize kms: %w", err).Error())
+				}
+				if cleanupFunc != nil {
+					return cleanupFunc()
+				}
+				return nil
+			}
+		}
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
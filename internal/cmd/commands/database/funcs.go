@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/db/common"
@@ -11,24 +12,24 @@ import (
 	"github.com/mitchellh/cli"
 )
 
-// migrateDatabase updates the schema to the most recent version known by the binary.
-// It owns the reporting to the UI any errors.
-// We expect the database already to be initialized iff initialized is set to true.
+// lockDatabase opens a connection to the database, creates a schema manager
+// for it and captures an exclusive lock on the database for the remainder of
+// the command. It owns the reporting to the UI any errors.
 // Returns a cleanup function which must be called even if an error is returned and
 // an error code where a non-zero value indicates an error happened.
-func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, maxOpenConns int) (func(), int) {
+func lockDatabase(ctx context.Context, ui cli.Ui, dialect, u string, maxOpenConns int) (*schema.Manager, func(), int) {
 	noop := func() {}
 	// This database is used to keep an exclusive lock on the database for the
 	// remainder of the command
 	dBase, err := common.SqlOpen(dialect, u)
 	if err != nil {
 		ui.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
-		return noop, 2
+		return nil, noop, 2
 	}
 	dBase.SetMaxOpenConns(maxOpenConns)
 	if err := dBase.PingContext(ctx); err != nil {
 		ui.Error(fmt.Sprintf("Unable to connect to the database at %q", u))
-		return noop, 2
+		return nil, noop, 2
 	}
 	man, err := schema.NewManager(ctx, schema.Dialect(dialect), dBase)
 	if err != nil {
@@ -37,17 +38,30 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 		} else {
 			ui.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
 		}
-		return noop, 2
+		return nil, noop, 2
 	}
 	// This is an advisory lock on the DB which is released when the DB session ends.
 	if err := man.ExclusiveLock(ctx); err != nil {
 		ui.Error("Unable to capture a lock on the database.")
-		return noop, 2
+		return nil, noop, 2
 	}
 	unlock := func() {
 		// We don't report anything since this should resolve itself anyways.
 		_ = man.ExclusiveUnlock(ctx)
 	}
+	return man, unlock, 0
+}
+
+// migrateDatabase updates the schema to the most recent version known by the binary.
+// It owns the reporting to the UI any errors.
+// We expect the database already to be initialized iff initialized is set to true.
+// Returns a cleanup function which must be called even if an error is returned and
+// an error code where a non-zero value indicates an error happened.
+func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initialized bool, maxOpenConns int) (func(), int) {
+	man, unlock, errCode := lockDatabase(ctx, ui, dialect, u, maxOpenConns)
+	if errCode != 0 {
+		return unlock, errCode
+	}
 
 	st, err := man.CurrentState(ctx)
 	if err != nil {
@@ -83,6 +97,266 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, initiali
 	return unlock, 0
 }
 
+// dryRunMigrateDatabase runs the migrations needed to bring the schema to the
+// most recent version known by the binary inside of a transaction which is
+// rolled back, and reports the schema versions of each edition along with the
+// migrations that were run, how long they took and any failure.
+// It owns the reporting to the UI any errors.
+// Returns a cleanup function which must be called even if an error is returned and
+// an error code where a non-zero value indicates an error happened or a
+// migration failed.
+func dryRunMigrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, maxOpenConns int) (func(), int) {
+	man, unlock, errCode := lockDatabase(ctx, ui, dialect, u, maxOpenConns)
+	if errCode != 0 {
+		return unlock, errCode
+	}
+
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return unlock, 2
+	}
+	if !st.Initialized {
+		ui.Output(base.WrapAtLength("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database."))
+		return unlock, -1
+	}
+	res, err := man.DryRunMigrations(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error running database migrations: %w", err).Error())
+		return unlock, 2
+	}
+
+	info := newDryRunInfo(res)
+	switch base.Format(ui) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			ui.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return unlock, 2
+		}
+		ui.Output(string(b))
+	default:
+		ui.Output(generateDryRunTableOutput(info))
+	}
+
+	if res.Failed() != nil {
+		return unlock, 2
+	}
+	return unlock, 0
+}
+
+// EditionInfo is the schema versions of an edition reported by a migration
+// dry run or a schema verification.
+type EditionInfo struct {
+	Name            string `json:"name"`
+	DatabaseVersion int    `json:"database_version"`
+	BinaryVersion   int    `json:"binary_version"`
+}
+
+// DryRunMigrationInfo is the outcome of a migration reported by a migration
+// dry run.
+type DryRunMigrationInfo struct {
+	Edition        string  `json:"edition"`
+	Version        int     `json:"version"`
+	File           string  `json:"file,omitempty"`
+	DurationMillis float64 `json:"duration_ms"`
+	Error          string  `json:"error,omitempty"`
+}
+
+// DryRunInfo is the report of a migration dry run.
+type DryRunInfo struct {
+	Editions       []EditionInfo         `json:"editions"`
+	Migrations     []DryRunMigrationInfo `json:"migrations"`
+	DurationMillis float64               `json:"duration_ms"`
+	Success        bool                  `json:"success"`
+}
+
+func newEditionInfos(st *schema.State) []EditionInfo {
+	editions := make([]EditionInfo, 0, len(st.Editions))
+	for _, e := range st.Editions {
+		editions = append(editions, EditionInfo{
+			Name:            e.Name,
+			DatabaseVersion: e.DatabaseSchemaVersion,
+			BinaryVersion:   e.BinarySchemaVersion,
+		})
+	}
+	return editions
+}
+
+func newDryRunInfo(res *schema.DryRunResult) *DryRunInfo {
+	info := &DryRunInfo{
+		Editions:       newEditionInfos(res.State),
+		Migrations:     make([]DryRunMigrationInfo, 0, len(res.Results)),
+		DurationMillis: durationMillis(res.Duration),
+		Success:        res.Failed() == nil,
+	}
+	for _, r := range res.Results {
+		m := DryRunMigrationInfo{
+			Edition:        r.Edition,
+			Version:        r.Version,
+			File:           r.Filename,
+			DurationMillis: durationMillis(r.Duration),
+		}
+		if r.Error != nil {
+			m.Error = r.Error.Error()
+		}
+		info.Migrations = append(info.Migrations, m)
+	}
+	return info
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func editionVersionsOutput(editions []EditionInfo) []string {
+	ret := []string{"", "Schema versions:"}
+	for _, e := range editions {
+		ret = append(ret,
+			fmt.Sprintf("  Edition %q:", e.Name),
+			fmt.Sprintf("    Database version:    %d", e.DatabaseVersion),
+			fmt.Sprintf("    Binary version:      %d", e.BinaryVersion),
+		)
+	}
+	return ret
+}
+
+func generateDryRunTableOutput(in *DryRunInfo) string {
+	ret := editionVersionsOutput(in.Editions)
+
+	ret = append(ret, "")
+	if len(in.Migrations) == 0 {
+		ret = append(ret, "The database schema is up to date; there are no migrations to run.")
+		return base.WrapForHelpText(ret)
+	}
+	ret = append(ret, "Migrations:")
+	for _, m := range in.Migrations {
+		name := m.File
+		if name == "" {
+			name = fmt.Sprintf("version %d", m.Version)
+		}
+		status := "ok"
+		if m.Error != "" {
+			status = "FAILED"
+		}
+		ret = append(ret, fmt.Sprintf("  %s %s: %s (%.3fms)", m.Edition, name, status, m.DurationMillis))
+		if m.Error != "" {
+			ret = append(ret, fmt.Sprintf("    Error: %s", m.Error))
+		}
+	}
+
+	ret = append(ret, "")
+	if in.Success {
+		ret = append(ret, fmt.Sprintf("Dry run completed successfully in %.3fms. All changes were rolled back.", in.DurationMillis))
+	} else {
+		ret = append(ret, fmt.Sprintf("Dry run failed after %.3fms. All changes were rolled back.", in.DurationMillis))
+	}
+	return base.WrapForHelpText(ret)
+}
+
+// verifyDatabase compares the schema of the database against the schema
+// expected for the migration version recorded in the database and reports
+// any differences.
+// It owns the reporting to the UI any errors.
+// Returns a cleanup function which must be called even if an error is returned and
+// an error code where a non-zero value indicates an error happened or the
+// schema has drifted.
+func verifyDatabase(ctx context.Context, ui cli.Ui, dialect, u string, maxOpenConns int) (func(), int) {
+	man, unlock, errCode := lockDatabase(ctx, ui, dialect, u, maxOpenConns)
+	if errCode != 0 {
+		return unlock, errCode
+	}
+
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return unlock, 2
+	}
+	if !st.Initialized {
+		ui.Output(base.WrapAtLength("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database."))
+		return unlock, -1
+	}
+	res, err := man.VerifySchema(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error verifying database schema: %w", err).Error())
+		return unlock, 2
+	}
+
+	info := newVerifyInfo(res)
+	switch base.Format(ui) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			ui.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return unlock, 2
+		}
+		ui.Output(string(b))
+	default:
+		ui.Output(generateVerifyTableOutput(info))
+	}
+
+	if res.Drifted() {
+		return unlock, 1
+	}
+	return unlock, 0
+}
+
+// VerifyDifferenceInfo is a difference between the database schema and the
+// expected schema reported by a schema verification.
+type VerifyDifferenceInfo struct {
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// VerifyInfo is the report of a schema verification.
+type VerifyInfo struct {
+	Editions    []EditionInfo          `json:"editions"`
+	Differences []VerifyDifferenceInfo `json:"differences"`
+	Drifted     bool                   `json:"drifted"`
+}
+
+func newVerifyInfo(res *schema.VerifyResult) *VerifyInfo {
+	info := &VerifyInfo{
+		Editions:    newEditionInfos(res.State),
+		Differences: make([]VerifyDifferenceInfo, 0, len(res.Differences)),
+		Drifted:     res.Drifted(),
+	}
+	for _, d := range res.Differences {
+		info.Differences = append(info.Differences, VerifyDifferenceInfo{
+			Type:     string(d.Type),
+			Kind:     d.Kind,
+			Name:     d.Name,
+			Expected: d.Expected,
+			Actual:   d.Actual,
+		})
+	}
+	return info
+}
+
+func generateVerifyTableOutput(in *VerifyInfo) string {
+	ret := editionVersionsOutput(in.Editions)
+
+	ret = append(ret, "")
+	if !in.Drifted {
+		ret = append(ret, "The database schema matches the schema expected for its version.")
+		return base.WrapForHelpText(ret)
+	}
+	ret = append(ret, fmt.Sprintf("The database schema has drifted; found %d differences:", len(in.Differences)))
+	for _, d := range in.Differences {
+		ret = append(ret, "", fmt.Sprintf("  %s %s %q", d.Type, d.Kind, d.Name))
+		if d.Expected != "" {
+			ret = append(ret, fmt.Sprintf("    Expected:    %s", d.Expected))
+		}
+		if d.Actual != "" {
+			ret = append(ret, fmt.Sprintf("    Actual:      %s", d.Actual))
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
+
 type RoleInfo struct {
 	RoleId string `json:"scope_id"`
 	Name   string `json:"name"`
@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/db/common"
@@ -202,6 +204,196 @@ func TestMigrateDatabase(t *testing.T) {
 	}
 }
 
+func TestNewDryRunInfo(t *testing.T) {
+	state := &schema.State{
+		Initialized: true,
+		Editions: []schema.EditionState{
+			{
+				Name:                  "oss",
+				DatabaseSchemaVersion: 1,
+				BinarySchemaVersion:   3,
+				DatabaseSchemaState:   schema.Behind,
+			},
+		},
+	}
+	cases := []struct {
+		name        string
+		result      *schema.DryRunResult
+		want        *DryRunInfo
+		wantOutputs []string
+	}{
+		{
+			name: "up-to-date",
+			result: &schema.DryRunResult{
+				State:    state,
+				Duration: time.Millisecond,
+			},
+			want: &DryRunInfo{
+				Editions:       []EditionInfo{{Name: "oss", DatabaseVersion: 1, BinaryVersion: 3}},
+				Migrations:     []DryRunMigrationInfo{},
+				DurationMillis: 1,
+				Success:        true,
+			},
+			wantOutputs: []string{"there are no migrations to run"},
+		},
+		{
+			name: "success",
+			result: &schema.DryRunResult{
+				State: state,
+				Results: []schema.MigrationResult{
+					{
+						Migration: schema.Migration{Edition: "oss", Version: 2, Filename: "0/02_two.up.sql"},
+						Duration:  2 * time.Millisecond,
+					},
+					{
+						Migration: schema.Migration{Edition: "oss", Version: 3},
+						Duration:  time.Millisecond,
+					},
+				},
+				Duration: 3 * time.Millisecond,
+			},
+			want: &DryRunInfo{
+				Editions: []EditionInfo{{Name: "oss", DatabaseVersion: 1, BinaryVersion: 3}},
+				Migrations: []DryRunMigrationInfo{
+					{Edition: "oss", Version: 2, File: "0/02_two.up.sql", DurationMillis: 2},
+					{Edition: "oss", Version: 3, DurationMillis: 1},
+				},
+				DurationMillis: 3,
+				Success:        true,
+			},
+			wantOutputs: []string{"oss 0/02_two.up.sql: ok", "oss version 3: ok", "Dry run completed successfully"},
+		},
+		{
+			name: "failure",
+			result: &schema.DryRunResult{
+				State: state,
+				Results: []schema.MigrationResult{
+					{
+						Migration: schema.Migration{Edition: "oss", Version: 2, Filename: "0/02_two.up.sql"},
+						Duration:  2 * time.Millisecond,
+						Error:     fmt.Errorf("relation does not exist"),
+					},
+				},
+				Duration: 2 * time.Millisecond,
+			},
+			want: &DryRunInfo{
+				Editions: []EditionInfo{{Name: "oss", DatabaseVersion: 1, BinaryVersion: 3}},
+				Migrations: []DryRunMigrationInfo{
+					{Edition: "oss", Version: 2, File: "0/02_two.up.sql", DurationMillis: 2, Error: "relation does not exist"},
+				},
+				DurationMillis: 2,
+				Success:        false,
+			},
+			wantOutputs: []string{"oss 0/02_two.up.sql: FAILED", "Error: relation does not exist", "Dry run failed"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newDryRunInfo(tc.result)
+			assert.Equal(t, tc.want, got)
+			out := generateDryRunTableOutput(got)
+			assert.Contains(t, out, `Edition "oss"`)
+			for _, w := range tc.wantOutputs {
+				assert.Contains(t, out, w)
+			}
+		})
+	}
+}
+
+func TestVerifyDatabase(t *testing.T) {
+	ctx := context.Background()
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+
+	ui := cli.NewMockUi()
+	clean, errCode := verifyDatabase(ctx, ui, dialect, u, 10)
+	clean()
+	assert.EqualValues(t, 0, errCode, ui.ErrorWriter.String())
+	assert.Contains(t, ui.OutputWriter.String(), "matches the schema expected")
+
+	dBase, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+	_, err = dBase.ExecContext(ctx, `create index iam_scope_name_drift_ix on iam_scope (name);`)
+	require.NoError(t, err)
+
+	ui = cli.NewMockUi()
+	clean, errCode = verifyDatabase(ctx, ui, dialect, u, 10)
+	clean()
+	assert.EqualValues(t, 1, errCode, ui.ErrorWriter.String())
+	assert.Contains(t, ui.OutputWriter.String(), `unexpected index "iam_scope_name_drift_ix"`)
+}
+
+func TestNewVerifyInfo(t *testing.T) {
+	state := &schema.State{
+		Initialized: true,
+		Editions: []schema.EditionState{
+			{
+				Name:                  "oss",
+				DatabaseSchemaVersion: 2,
+				BinarySchemaVersion:   2,
+				DatabaseSchemaState:   schema.Equal,
+			},
+		},
+	}
+	cases := []struct {
+		name        string
+		result      *schema.VerifyResult
+		want        *VerifyInfo
+		wantOutputs []string
+	}{
+		{
+			name:   "no-drift",
+			result: &schema.VerifyResult{State: state},
+			want: &VerifyInfo{
+				Editions:    []EditionInfo{{Name: "oss", DatabaseVersion: 2, BinaryVersion: 2}},
+				Differences: []VerifyDifferenceInfo{},
+			},
+			wantOutputs: []string{"matches the schema expected"},
+		},
+		{
+			name: "drift",
+			result: &schema.VerifyResult{
+				State: state,
+				Differences: []schema.Difference{
+					{Type: schema.Missing, Kind: "column", Name: "foo.bar", Expected: "text"},
+					{Type: schema.Unexpected, Kind: "index", Name: "foo_ix", Actual: "CREATE INDEX foo_ix ON foo USING btree (id)"},
+				},
+			},
+			want: &VerifyInfo{
+				Editions: []EditionInfo{{Name: "oss", DatabaseVersion: 2, BinaryVersion: 2}},
+				Differences: []VerifyDifferenceInfo{
+					{Type: "missing", Kind: "column", Name: "foo.bar", Expected: "text"},
+					{Type: "unexpected", Kind: "index", Name: "foo_ix", Actual: "CREATE INDEX foo_ix ON foo USING btree (id)"},
+				},
+				Drifted: true,
+			},
+			wantOutputs: []string{
+				"found 2 differences",
+				`missing column "foo.bar"`,
+				"Expected:    text",
+				`unexpected index "foo_ix"`,
+				"Actual:      CREATE INDEX foo_ix ON foo USING btree (id)",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newVerifyInfo(tc.result)
+			assert.Equal(t, tc.want, got)
+			out := generateVerifyTableOutput(got)
+			assert.Contains(t, out, `Edition "oss"`)
+			for _, w := range tc.wantOutputs {
+				assert.Contains(t, out, w)
+			}
+		})
+	}
+}
+
 func TestVerifyOplogIsEmpty(t *testing.T) {
 	dialect := "postgres"
 	ctx := context.Background()
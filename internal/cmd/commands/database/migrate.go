@@ -50,6 +50,7 @@ type MigrateCommand struct {
 	flagLogFormat          string
 	flagMigrationUrl       string
 	flagAllowDevMigrations bool
+	flagDryRun             bool
 }
 
 func (c *MigrateCommand) Synopsis() string {
@@ -64,6 +65,10 @@ func (c *MigrateCommand) Help() string {
 		"",
 		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
 		"",
+		"  Report the migrations that would be run, running them inside of a transaction that is rolled back:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -dry-run",
+		"",
 		"  For a full list of examples, please see the documentation.",
 	}) + c.Flags().Help()
 }
@@ -117,6 +122,12 @@ func (c *MigrateCommand) Flags() *base.FlagSets {
 		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for migration. This can allow different permissions for the user running initialization or migration vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  `If set, reports the current and target schema versions of each edition and the migrations that would be run, and runs them inside of a transaction that is rolled back, reporting how long each took and any failure. The database schema is left unchanged.`,
+	})
+
 	return set
 }
 
@@ -256,6 +267,15 @@ plugins {
 		return base.CommandUserError
 	}
 
+	if c.flagDryRun {
+		clean, errCode := dryRunMigrateDatabase(c.Context, c.UI, dialect, migrationUrl, c.Config.Controller.Database.MaxOpenConnections)
+		defer clean()
+		if errCode != 0 {
+			return errCode
+		}
+		return base.CommandSuccess
+	}
+
 	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, true, c.Config.Controller.Database.MaxOpenConnections)
 	defer clean()
 	if errCode != 0 {
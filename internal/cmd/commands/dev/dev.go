@@ -574,6 +574,11 @@ func (c *Command) Run(args []string) int {
 		return base.CommandCliError
 	}
 
+	if err := c.SetupTracing(c.Context, c.Config.Tracing, serverName); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
 	if c.flagRecoveryKey != "" {
 		c.Config.DevRecoveryKey = c.flagRecoveryKey
 	}
@@ -763,7 +768,7 @@ func (c *Command) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	opsServer, err := ops.NewServer(c.Logger, c.controller, c.Listeners...)
+	opsServer, err := ops.NewServer(c.Logger, c.controller, c.worker, c.Listeners...)
 	if err != nil {
 		c.UI.Error(fmt.Errorf("Failed to start ops listeners: %w", err).Error())
 		return base.CommandCliError
@@ -805,9 +810,14 @@ func (c *Command) Run(args []string) int {
 		}
 
 		if shutdownTriggered {
+			var shutdownWait time.Duration
 			if c.Config.Controller != nil {
-				c.opsServer.WaitIfHealthExists(c.Config.Controller.GracefulShutdownWaitDuration, c.UI)
+				shutdownWait = c.Config.Controller.GracefulShutdownWaitDuration
+			}
+			if c.Config.Worker != nil && c.Config.Worker.GracefulShutdownWaitDuration > shutdownWait {
+				shutdownWait = c.Config.Worker.GracefulShutdownWaitDuration
 			}
+			c.opsServer.WaitIfHealthExists(shutdownWait, c.UI)
 
 			if !c.flagControllerOnly {
 				if !c.flagWorkerAuthStorageSkipCleanup && c.worker.WorkerAuthStorage != nil {
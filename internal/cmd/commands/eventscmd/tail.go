@@ -0,0 +1,137 @@
+package eventscmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/events"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*TailCommand)(nil)
+	_ cli.CommandAutocomplete = (*TailCommand)(nil)
+)
+
+type TailCommand struct {
+	*base.Command
+
+	flagTypes  []string
+	flagFilter string
+}
+
+func (c *TailCommand) Synopsis() string {
+	return "Stream the live events of a controller"
+}
+
+func (c *TailCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary events tail [options]",
+		"",
+		"  Stream the live events of the controller that handles the request, until interrupted. Example:",
+		"",
+		`    $ boundary events tail -type observation -filter '"/data/request_info/method" == "POST"'`,
+		"",
+		"  Events are streamed from a single controller; when controllers are behind a load balancer, run the command against each controller to see all of their events. Audit events are streamed with their sensitive and secret data redacted. Tailing events requires the \"tail\" action on the \"event\" type in the global scope.",
+		"",
+		"  With the JSON output format, each event is printed on its own line as a cloudevent.",
+	}) + c.Flags().Help()
+}
+
+func (c *TailCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:       "type",
+		Target:     &c.flagTypes,
+		Completion: complete.PredictSet("audit", "observation", "error", "system", "*"),
+		Usage:      `The type of events to stream: "audit", "observation", "error", "system" or "*" for every type. May be specified multiple times. Defaults to every type.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "filter",
+		Target: &c.flagFilter,
+		Usage:  "A boolean expression used to filter the streamed events. The expression is evaluated against each event's cloudevent representation, in the same way as an event sink's allow filters. For more information, see the event filtering documentation.",
+	})
+
+	return set
+}
+
+func (c *TailCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *TailCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *TailCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []events.Option
+	if len(c.flagTypes) > 0 {
+		opts = append(opts, events.WithEventTypes(c.flagTypes...))
+	}
+	if c.flagFilter != "" {
+		opts = append(opts, events.WithFilter(c.flagFilter))
+	}
+
+	format := base.Format(c.UI)
+	err = events.NewClient(client).Tail(c.Context, func(e *events.Event) error {
+		switch format {
+		case "json":
+			c.UI.Output(string(e.GetRaw()))
+		default:
+			c.UI.Output(printEventTable(e))
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing tail on events")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to tail events: %w", err))
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+// printEventTable returns a single line for the event with its time, type and
+// data.
+func printEventTable(e *events.Event) string {
+	var data string
+	if len(e.Data) > 0 {
+		b, err := json.Marshal(e.Data)
+		if err != nil {
+			data = fmt.Sprintf("%v", e.Data)
+		} else {
+			data = string(b)
+		}
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s [%s] %s", e.Time.Format(time.RFC3339Nano), e.Type, data))
+}
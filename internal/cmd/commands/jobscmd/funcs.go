@@ -0,0 +1,213 @@
+package jobscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/jobs"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"run-now": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "run-now":
+		return wordwrap.WrapString("Run a job within Boundary now", base.TermWidth)
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary jobs [sub command] [options] [args]",
+			"",
+			"  This command allows operations on the jobs run by Boundary controllers. Jobs only exist in the global scope. Example:",
+			"",
+			"    List jobs:",
+			"",
+			`      $ boundary jobs list -scope-id global`,
+			"",
+			"  Please see the jobs subcommand help for detailed usage information.",
+		})
+	case "run-now":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary jobs run-now [options] [args]",
+			"",
+			"  Run the job specified by ID, which is its name, as soon as a controller next checks for jobs to run, rather than waiting for its next scheduled run. A job which is already running is not interrupted. Example:",
+			"",
+			`    $ boundary jobs run-now -id session_cleanup`,
+			"",
+			"",
+		})
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, jobClient *jobs.Client, _ uint32, opts []jobs.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "run-now":
+		return jobClient.RunNow(c.Context, c.FlagId, opts...)
+	}
+	return origResult, origError
+}
+
+func (c *Command) printListTable(items []*jobs.Job) string {
+	if len(items) == 0 {
+		return "No jobs found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Job information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                        %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                        %s", "(not available)"),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:             %s", item.Description),
+			)
+		}
+		if !item.NextScheduledRun.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Next Scheduled Run:      %s", item.NextScheduledRun.Local().Format(time.RFC1123)),
+			)
+		}
+		if item.LastRun != nil {
+			output = append(output,
+				fmt.Sprintf("    Last Run Status:         %s", item.LastRun.Status),
+				fmt.Sprintf("    Last Run Controller ID:  %s", item.LastRun.ControllerId),
+				fmt.Sprintf("    Last Run Progress:       %d/%d", item.LastRun.CompletedCount, item.LastRun.TotalCount),
+			)
+			if item.LastRun.ErrorMessage != "" {
+				output = append(output,
+					fmt.Sprintf("    Last Run Error:          %s", item.LastRun.ErrorMessage),
+				)
+			}
+		}
+		if item.ConsecutiveFailures > 0 {
+			output = append(output,
+				fmt.Sprintf("    Consecutive Failures:    %d", item.ConsecutiveFailures),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*jobs.Job)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if !item.NextScheduledRun.IsZero() {
+		nonAttributeMap["Next Scheduled Run"] = item.NextScheduledRun.Local().Format(time.RFC1123)
+	}
+	nonAttributeMap["Consecutive Failures"] = item.ConsecutiveFailures
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	runs := item.Runs
+	if len(runs) == 0 && item.LastRun != nil {
+		runs = []*jobs.JobRun{item.LastRun}
+	}
+	var runsMaps []map[string]interface{}
+	for _, run := range runs {
+		m := map[string]interface{}{
+			"ID":            run.Id,
+			"Status":        run.Status,
+			"Controller ID": run.ControllerId,
+			"Progress":      fmt.Sprintf("%d/%d", run.CompletedCount, run.TotalCount),
+			"Start Time":    run.CreatedTime.Local().Format(time.RFC1123),
+		}
+		if !run.EndTime.IsZero() {
+			m["End Time"] = run.EndTime.Local().Format(time.RFC1123)
+			m["Duration"] = (time.Duration(run.DurationSeconds) * time.Second).String()
+		}
+		if run.ErrorMessage != "" {
+			m["Error"] = run.ErrorMessage
+		}
+		runsMaps = append(runsMaps, m)
+	}
+	if len(runsMaps) > 0 {
+		if l := len("Controller ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
+	ret := []string{
+		"",
+		"Job information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(runsMaps) > 0 {
+		ret = append(ret,
+			"",
+			"  Recent Runs:",
+		)
+		for _, m := range runsMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
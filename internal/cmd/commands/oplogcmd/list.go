@@ -0,0 +1,252 @@
+package oplogcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/oplog"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListCommand)(nil)
+)
+
+type ListCommand struct {
+	*base.Command
+
+	flagResourceId      string
+	flagResourceScopeId string
+	flagTable           string
+	flagStartTime       string
+	flagEndTime         string
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List the entries of the oplog"
+}
+
+func (c *ListCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog list [options]",
+		"",
+		"  List the entries of the oplog, newest first, with the writes each entry recorded. Example:",
+		"",
+		`    $ boundary oplog list -resource-id r_1234567890 -start-time 2022-06-01T00:00:00Z`,
+		"",
+		"  Each write is shown as the row which was written: the row after a create or update, or the row before a delete. Secrets held by the rows are not returned. Listing the oplog requires the \"list\" action on the \"oplog\" type in the global scope.",
+	}) + c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "oplog entry", map[string][]string{"list": {"scope-id", "filter"}}, "list")
+
+	f.StringVar(&base.StringVar{
+		Name:   "resource-id",
+		Target: &c.flagResourceId,
+		Usage:  "If set, only the entries which wrote the resource with this ID are listed.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-scope-id",
+		Target: &c.flagResourceScopeId,
+		Usage:  "If set, only the entries which wrote resources in the scope with this ID are listed.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "table",
+		Target: &c.flagTable,
+		Usage:  `If set, only the entries for resources of this table are listed, for example "iam_role".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "start-time",
+		Target: &c.flagStartTime,
+		Usage:  "If set, only the entries written at or after this time, in RFC 3339 format, are listed.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &c.flagEndTime,
+		Usage:  "If set, only the entries written before this time, in RFC 3339 format, are listed.",
+	})
+
+	return set
+}
+
+func (c *ListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+
+	var opts []oplog.Option
+	if c.FlagFilter != "" {
+		opts = append(opts, oplog.WithFilter(c.FlagFilter))
+	}
+	if c.flagResourceId != "" {
+		opts = append(opts, oplog.WithResourceId(c.flagResourceId))
+	}
+	if c.flagResourceScopeId != "" {
+		opts = append(opts, oplog.WithResourceScopeId(c.flagResourceScopeId))
+	}
+	if c.flagTable != "" {
+		opts = append(opts, oplog.WithTable(c.flagTable))
+	}
+	for _, t := range []struct{ name, value string }{{"start-time", c.flagStartTime}, {"end-time", c.flagEndTime}} {
+		if t.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t.value); err != nil {
+			c.PrintCliError(fmt.Errorf("Error parsing -%s as an RFC 3339 time: %w", t.name, err))
+			return base.CommandUserError
+		}
+	}
+	if c.flagStartTime != "" {
+		opts = append(opts, oplog.WithStartTime(c.flagStartTime))
+	}
+	if c.flagEndTime != "" {
+		opts = append(opts, oplog.WithEndTime(c.flagEndTime))
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := oplog.NewClient(client).List(c.Context, c.FlagScopeId, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing list on oplog entries")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to list oplog entries: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItems(result); !ok {
+			return base.CommandCliError
+		}
+	case "table":
+		c.UI.Output(printListTable(result.Items))
+	}
+	return base.CommandSuccess
+}
+
+func printListTable(items []*oplog.Entry) string {
+	if len(items) == 0 {
+		return "No oplog entries found"
+	}
+
+	output := []string{
+		"",
+		"Oplog entry information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                    %d", item.Id),
+		)
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:        %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if item.Operation != "" {
+			output = append(output,
+				fmt.Sprintf("    Operation:           %s", item.Operation),
+			)
+		}
+		if item.Table != "" {
+			output = append(output,
+				fmt.Sprintf("    Table:               %s", item.Table),
+			)
+		}
+		if item.ResourceId != "" {
+			output = append(output,
+				fmt.Sprintf("    Resource ID:         %s", item.ResourceId),
+			)
+		}
+		if item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if len(item.Messages) == 0 {
+			output = append(output,
+				"    Messages:            (not available)",
+			)
+			continue
+		}
+		output = append(output,
+			"    Messages:",
+		)
+		for _, m := range item.Messages {
+			output = append(output,
+				fmt.Sprintf("      %s %s", m.Operation, m.TypeName),
+			)
+			if len(m.FieldMaskPaths) > 0 {
+				output = append(output,
+					fmt.Sprintf("        Fields:          %v", m.FieldMaskPaths),
+				)
+			}
+			if len(m.NullPaths) > 0 {
+				output = append(output,
+					fmt.Sprintf("        Null Fields:     %v", m.NullPaths),
+				)
+			}
+			if m.Before != nil {
+				output = append(output,
+					fmt.Sprintf("        Before:          %s", rowString(m.Before)),
+				)
+			}
+			if m.After != nil {
+				output = append(output,
+					fmt.Sprintf("        After:           %s", rowString(m.After)),
+				)
+			}
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+// rowString returns the row as compact JSON.
+func rowString(row map[string]interface{}) string {
+	b, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Sprintf("%v", row)
+	}
+	return string(b)
+}
@@ -0,0 +1,50 @@
+package oplogcmd
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Interact with the oplog of writes made by controllers"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog [sub command] [options] [args]",
+		"",
+		"  This command allows operations on the oplog, which records every write made by Boundary controllers. The oplog only exists in the global scope. Example:",
+		"",
+		"    List the changes made to a role:",
+		"",
+		`      $ boundary oplog list -resource-id r_1234567890`,
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	return nil
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
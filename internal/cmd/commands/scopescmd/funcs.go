@@ -8,31 +8,123 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
 )
 
 const (
 	flagPrimaryAuthMethodIdName     = "primary-auth-method-id"
 	flagSkipAdminRoleCreationName   = "skip-admin-role-creation"
 	flagSkipDefaultRoleCreationName = "skip-default-role-creation"
+	flagKeyVersionIdName            = "key-version-id"
+	flagKmsNameName                 = "kms-name"
 )
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create":                            {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
+		"update":                            {flagPrimaryAuthMethodIdName},
+		"rotate-keys":                       {"id"},
+		"list-keys":                         {"id"},
+		"destroy-key-version":               {"id", flagKeyVersionIdName},
+		"list-key-version-destruction-jobs": {"id"},
+		"set-kms":                           {"id", flagKmsNameName},
 	}
 }
 
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate-keys":
+		return wordwrap.WrapString("Rotate the keys of a scope within Boundary", base.TermWidth)
+	case "list-keys":
+		return wordwrap.WrapString("List the keys of a scope within Boundary", base.TermWidth)
+	case "destroy-key-version":
+		return wordwrap.WrapString("Destroy a version of a key of a scope within Boundary", base.TermWidth)
+	case "list-key-version-destruction-jobs":
+		return wordwrap.WrapString("List the pending key version destructions of a scope within Boundary", base.TermWidth)
+	case "set-kms":
+		return wordwrap.WrapString("Set the KMS which encrypts the root key of a scope within Boundary", base.TermWidth)
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "rotate-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes rotate-keys [options] [args]",
+			"",
+			"  Rotate the keys of the scope specified by ID. Newly encrypted data uses the new key versions immediately, and existing data is re-encrypted in the background. Example:",
+			"",
+			`    $ boundary scopes rotate-keys -id o_1234567890`,
+			"",
+			"",
+		})
+	case "list-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-keys [options] [args]",
+			"",
+			"  List the root key and the data keys of the scope specified by ID, along with all of their versions and the number of data key versions or rows each version encrypts. Example:",
+			"",
+			`    $ boundary scopes list-keys -id o_1234567890`,
+			"",
+			"",
+		})
+	case "destroy-key-version":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes destroy-key-version [options] [args]",
+			"",
+			"  Destroy a version of one of the keys of the scope specified by ID. Data encrypted by the version is re-encrypted with the current version of its key first, which may happen in the background; use list-key-version-destruction-jobs to follow its progress. The current version of a key cannot be destroyed; rotate the scope's keys first. Example:",
+			"",
+			`    $ boundary scopes destroy-key-version -id o_1234567890 -key-version-id kdkv_1234567890`,
+			"",
+			"",
+		})
+	case "list-key-version-destruction-jobs":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-key-version-destruction-jobs [options] [args]",
+			"",
+			"  List the pending key version destructions of the scope specified by ID, along with their progress. Example:",
+			"",
+			`    $ boundary scopes list-key-version-destruction-jobs -id o_1234567890`,
+			"",
+			"",
+		})
+	case "set-kms":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes set-kms [options] [args]",
+			"",
+			`  Move the root key of the scope specified by ID to the controller's "scope-root" KMS with the given name, re-encrypting all of the root key's versions with it. Omitting the name moves the root key back to the "root" KMS. Example:`,
+			"",
+			`    $ boundary scopes set-kms -id o_1234567890 -kms-name tenant-a`,
+			"",
+			"",
+		})
+	default:
+		return helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
 type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagKeyVersionId            string
+	flagKmsName                 string
+
+	keysResult        *scopes.KeyListResult
+	destructionResult *scopes.KeyVersionDestructionResult
+	jobsResult        *scopes.KeyVersionDestructionJobListResult
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -50,6 +142,18 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSkipDefaultRoleCreation,
 				Usage:  "If set, a role granting the anonymous user access to log into auth methods and a few other actions within the newly-created scope will not automatically be created",
 			})
+		case flagKeyVersionIdName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKeyVersionIdName,
+				Target: &c.flagKeyVersionId,
+				Usage:  "The ID of the key version to destroy",
+			})
+		case flagKmsNameName:
+			f.StringVar(&base.StringVar{
+				Name:   flagKmsNameName,
+				Target: &c.flagKmsName,
+				Usage:  `The name of the "scope-root" KMS to encrypt the scope's root key with. If not set, the "root" KMS is used`,
+			})
 		case flagPrimaryAuthMethodIdName:
 			f.StringVar(&base.StringVar{
 				Name:   flagPrimaryAuthMethodIdName,
@@ -74,6 +178,148 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, scopeClient *scopes.Client, _ uint32, opts []scopes.Option) (api.GenericResult, error) {
+	var err error
+	switch c.Func {
+	case "rotate-keys":
+		return scopeClient.RotateKeys(c.Context, c.FlagId, opts...)
+	case "set-kms":
+		return scopeClient.SetKms(c.Context, c.FlagId, c.flagKmsName, opts...)
+	case "list-keys":
+		c.plural = "keys of scope"
+		c.keysResult, err = scopeClient.ListKeys(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "destroy-key-version":
+		c.plural = "key version of scope"
+		c.destructionResult, err = scopeClient.DestroyKeyVersion(c.Context, c.FlagId, c.flagKeyVersionId, opts...)
+		return nil, err
+	case "list-key-version-destruction-jobs":
+		c.plural = "key version destruction jobs of scope"
+		c.jobsResult, err = scopeClient.ListKeyVersionDestructionJobs(c.Context, c.FlagId, opts...)
+		return nil, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "list-keys":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printKeysTable(c.keysResult.Items))
+		case "json":
+			if ok := c.PrintJsonItems(c.keysResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+
+	case "destroy-key-version":
+		switch base.Format(c.UI) {
+		case "table":
+			switch c.destructionResult.State {
+			case "completed":
+				c.UI.Output("The key version was destroyed.")
+			default:
+				c.UI.Output("The key version will be destroyed once the data it encrypts has been re-encrypted. Use list-key-version-destruction-jobs to follow its progress.")
+			}
+		case "json":
+			if ok := c.PrintJsonItem(c.destructionResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+
+	case "list-key-version-destruction-jobs":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printKeyVersionDestructionJobsTable(c.jobsResult.Items))
+		case "json":
+			if ok := c.PrintJsonItems(c.jobsResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func printKeysTable(items []*scopes.Key) string {
+	if len(items) == 0 {
+		return "No keys found"
+	}
+	output := []string{
+		"",
+		"Key information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:               %s", item.Id),
+			fmt.Sprintf("    Type:           %s", item.Type),
+			fmt.Sprintf("    Purpose:        %s", item.Purpose),
+		)
+		if item.KmsName != "" {
+			output = append(output,
+				fmt.Sprintf("    KMS Name:       %s", item.KmsName),
+			)
+		}
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:   %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(item.Versions) > 0 {
+			output = append(output,
+				"    Versions:",
+			)
+		}
+		for _, v := range item.Versions {
+			output = append(output,
+				fmt.Sprintf("      ID:           %s", v.Id),
+				fmt.Sprintf("        Version:    %d", v.Version),
+				fmt.Sprintf("        References: %d", v.ReferenceCount),
+			)
+			if !v.CreatedTime.IsZero() {
+				output = append(output,
+					fmt.Sprintf("        Created:    %s", v.CreatedTime.Local().Format(time.RFC1123)),
+				)
+			}
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printKeyVersionDestructionJobsTable(items []*scopes.KeyVersionDestructionJob) string {
+	if len(items) == 0 {
+		return "No key version destruction jobs found"
+	}
+	output := []string{
+		"",
+		"Key version destruction job information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Key Version ID:    %s", item.KeyVersionId),
+			fmt.Sprintf("    Completed Count: %d", item.CompletedCount),
+			fmt.Sprintf("    Total Count:     %d", item.TotalCount),
+		)
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:    %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
 func (c *Command) printListTable(items []*scopes.Scope) string {
 	if len(items) == 0 {
 		return "No child scopes found"
@@ -170,6 +170,11 @@ func (c *Command) Run(args []string) int {
 		return base.CommandUserError
 	}
 
+	if err := c.SetupTracing(c.Context, c.Config.Tracing, serverName); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
 	// Initialize status grace period (0 denotes using env or default
 	// here)
 	c.SetStatusGracePeriodDuration(0)
@@ -410,6 +415,15 @@ func (c *Command) Run(args []string) int {
 			return base.CommandUserError
 		}
 		c.DatabaseMaxOpenConnections = c.Config.Controller.Database.MaxOpenConnections
+		for _, u := range c.Config.Controller.Database.ReadReplicaUrls {
+			replicaUrl, err := parseutil.ParsePath(u)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				c.UI.Error(fmt.Errorf("Error parsing database read replica url: %w", err).Error())
+				return base.CommandUserError
+			}
+			c.DatabaseReadReplicaUrls = append(c.DatabaseReadReplicaUrls, replicaUrl)
+		}
+		c.DatabaseMaxReplicaLag = c.Config.Controller.Database.MaxReplicaLagDuration
 
 		if err := c.ConnectToDatabase(c.Context, "postgres"); err != nil {
 			c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
@@ -523,7 +537,7 @@ func (c *Command) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	opsServer, err := ops.NewServer(c.Logger, c.controller, c.Listeners...)
+	opsServer, err := ops.NewServer(c.Logger, c.controller, c.worker, c.Listeners...)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return base.CommandCliError
@@ -722,9 +736,14 @@ func (c *Command) WaitForInterrupt() int {
 				}
 			}()
 
+			var shutdownWait time.Duration
 			if c.Config.Controller != nil {
-				c.opsServer.WaitIfHealthExists(c.Config.Controller.GracefulShutdownWaitDuration, c.UI)
+				shutdownWait = c.Config.Controller.GracefulShutdownWaitDuration
+			}
+			if c.Config.Worker != nil && c.Config.Worker.GracefulShutdownWaitDuration > shutdownWait {
+				shutdownWait = c.Config.Worker.GracefulShutdownWaitDuration
 			}
+			c.opsServer.WaitIfHealthExists(shutdownWait, c.UI)
 
 			// Do worker shutdown
 			if c.Config.Worker != nil {
@@ -819,6 +838,14 @@ func (c *Command) Reload(newConf *config.Config) error {
 		}
 	}
 
+	// Reopen the event sinks, so files moved by external log rotation are
+	// recreated
+	if c.Eventer != nil {
+		if err := c.Eventer.Reopen(); err != nil {
+			reloadErrors = multierror.Append(reloadErrors, fmt.Errorf("error encountered reopening event sinks: %w", err))
+		}
+	}
+
 	if newConf != nil && c.worker != nil {
 		c.worker.ParseAndStoreTags(newConf.Worker.Tags)
 	}
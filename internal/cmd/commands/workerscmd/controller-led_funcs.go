@@ -0,0 +1,81 @@
+package workerscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraControllerLedActionsFlagsMapFunc = extraControllerLedActionsFlagsMapFuncImpl
+	extraControllerLedFlagsFunc = extraControllerLedFlagsFuncImpl
+	extraControllerLedFlagsHandlingFunc = extraControllerLedFlagsHandlingFuncImpl
+	executeExtraControllerLedActions = executeExtraControllerLedActionsImpl
+}
+
+type extraControllerLedCmdVars struct {
+	flagActivationTokenTtl time.Duration
+}
+
+func extraControllerLedActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"activation-token-ttl"},
+	}
+}
+
+func (c *ControllerLedCommand) extraControllerLedHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary workers create controller-led [options] [args]",
+			"",
+			"  Create a worker using the controller-led approach. The returned activation token can be used once, before it expires, by setting it as the worker's controller_generated_activation_token configuration value. Example:",
+			"",
+			`    $ boundary workers create controller-led -name us-east-1-1 -activation-token-ttl 1h`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraControllerLedFlagsFuncImpl(c *ControllerLedCommand, set *base.FlagSets, f *base.FlagSet) {
+	f = set.NewFlagSet("Worker Creation Options")
+
+	for _, name := range flagsControllerLedMap[c.Func] {
+		switch name {
+		case "activation-token-ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:   "activation-token-ttl",
+				Target: &c.flagActivationTokenTtl,
+				Usage:  "How long the generated activation token remains valid. Defaults to 24 hours.",
+			})
+		}
+	}
+}
+
+func extraControllerLedFlagsHandlingFuncImpl(c *ControllerLedCommand, _ *base.FlagSets, opts *[]workers.Option) bool {
+	switch {
+	case c.flagActivationTokenTtl < 0:
+		c.UI.Error("Activation token ttl must not be negative")
+		return false
+	case c.flagActivationTokenTtl > 0 && c.flagActivationTokenTtl < time.Second:
+		c.UI.Error(fmt.Sprintf("Activation token ttl must be at least one second, got %s", c.flagActivationTokenTtl))
+		return false
+	case c.flagActivationTokenTtl > 0:
+		*opts = append(*opts, workers.WithActivationTokenTtl(uint32(c.flagActivationTokenTtl.Seconds())))
+	}
+	return true
+}
+
+func executeExtraControllerLedActionsImpl(c *ControllerLedCommand, origResult api.GenericResult, origError error, workerClient *workers.Client, version uint32, opts []workers.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "create":
+		return workerClient.CreateControllerLed(c.Context, c.FlagScopeId, opts...)
+	}
+	return origResult, origError
+}
@@ -8,8 +8,32 @@ import (
 	"github.com/hashicorp/boundary/api/workers"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"rotate-auth":             {"id", "version"},
+		"revoke-activation-token": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate-auth":
+		return wordwrap.WrapString("Request credential rotation for a worker within Boundary", base.TermWidth)
+	case "revoke-activation-token":
+		return wordwrap.WrapString("Revoke the unredeemed activation token of a worker within Boundary", base.TermWidth)
+	}
+	return ""
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -25,12 +49,42 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the workers subcommand help for detailed usage information.",
 		})
+	case "rotate-auth":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary workers rotate-auth [options] [args]",
+			"",
+			"  Request that the PKI worker specified by ID rotate its credentials the next time it reports its status, rather than waiting for its next scheduled rotation. Example:",
+			"",
+			`    $ boundary workers rotate-auth -id w_1234567890`,
+			"",
+			"",
+		})
+	case "revoke-activation-token":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary workers revoke-activation-token [options] [args]",
+			"",
+			"  Revoke the activation token generated for the worker specified by ID by the create controller-led command, if it has not yet been used. Example:",
+			"",
+			`    $ boundary workers revoke-activation-token -id w_1234567890`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
 	return helpStr + c.Flags().Help()
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, workerClient *workers.Client, version uint32, opts []workers.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "rotate-auth":
+		return workerClient.RotateAuth(c.Context, c.FlagId, version, opts...)
+	case "revoke-activation-token":
+		return workerClient.RevokeActivationToken(c.Context, c.FlagId, opts...)
+	}
+	return origResult, origError
+}
+
 func (c *Command) printListTable(items []*workers.Worker) string {
 	if len(items) == 0 {
 		return "No workers found"
@@ -89,6 +143,11 @@ func (c *Command) printListTable(items []*workers.Worker) string {
 				fmt.Sprintf("    Last Status Time:        %s", item.LastStatusTime.Format(time.RFC1123)),
 			)
 		}
+		if item.ReleaseVersion != "" {
+			output = append(output,
+				fmt.Sprintf("    Release Version:         %s", item.ReleaseVersion),
+			)
+		}
 
 		if len(item.AuthorizedActions) > 0 {
 			output = append(output,
@@ -131,6 +190,27 @@ func printItemTable(result api.GenericResult) string {
 	if !item.LastStatusTime.IsZero() {
 		nonAttributeMap["Last Status Time"] = item.LastStatusTime
 	}
+	if item.ReleaseVersion != "" {
+		nonAttributeMap["Release Version"] = item.ReleaseVersion
+	}
+	if !item.CertificateExpiration.IsZero() {
+		nonAttributeMap["Certificate Expiration"] = item.CertificateExpiration.Local().Format(time.RFC1123)
+	}
+	if !item.LastRotationTime.IsZero() {
+		nonAttributeMap["Last Rotation Time"] = item.LastRotationTime.Local().Format(time.RFC1123)
+	}
+	if item.LastRotationError != "" {
+		nonAttributeMap["Last Rotation Error"] = item.LastRotationError
+	}
+	if item.RotateAuthRequested {
+		nonAttributeMap["Rotate Auth Requested"] = item.RotateAuthRequested
+	}
+	if item.ControllerGeneratedActivationToken != "" {
+		nonAttributeMap["Controller-Generated Activation Token"] = item.ControllerGeneratedActivationToken
+	}
+	if !item.ActivationTokenExpiration.IsZero() {
+		nonAttributeMap["Activation Token Expiration"] = item.ActivationTokenExpiration.Local().Format(time.RFC1123)
+	}
 
 	resultMap := result.GetResponse().Map
 	if count, ok := resultMap[globals.ActiveConnectionCountField]; ok {
@@ -180,6 +260,14 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	if len(item.Features) > 0 {
+		ret = append(ret,
+			"",
+			"  Features:",
+			base.WrapSlice(4, item.Features),
+		)
+	}
+
 	if len(item.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
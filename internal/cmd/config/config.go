@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
+	"github.com/hashicorp/boundary/internal/scheduler"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	configutil "github.com/hashicorp/go-secure-stdlib/configutil/v2"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
@@ -122,6 +125,9 @@ type Config struct {
 	// Eventing configuration for the controller
 	Eventing *event.EventerConfig `hcl:"events"`
 
+	// Tracing configuration, from the "tracing" block within "events"
+	Tracing *tracing.Config `hcl:"-"`
+
 	// Plugin-related options
 	Plugins Plugins `hcl:"plugins"`
 
@@ -162,6 +168,29 @@ type Controller struct {
 	//
 	// TODO: This field is currently internal.
 	SchedulerRunJobInterval time.Duration `hcl:"-"`
+
+	// Jobs overrides the schedules of the jobs run by the controller's
+	// scheduler, keyed by job name.
+	Jobs []*Job `hcl:"job"`
+
+	// JobRunRetention is the amount of time the history of a job run is kept
+	// after the run ends, denoted by time.Duration
+	JobRunRetention         interface{} `hcl:"job_run_retention"`
+	JobRunRetentionDuration time.Duration
+}
+
+// Job is the configuration of a job run by the controller's scheduler.
+type Job struct {
+	// Name is the name of the job.
+	Name string `hcl:",key"`
+
+	// Schedule is the cron expression used to compute the next run time of the
+	// job, overriding the schedule or interval the job is registered with.
+	Schedule string `hcl:"schedule"`
+
+	// TimeZone is the name of the time zone the schedule is evaluated in. If
+	// empty the schedule is evaluated in UTC.
+	TimeZone string `hcl:"time_zone"`
 }
 
 func (c *Controller) InitNameIfEmpty() error {
@@ -212,6 +241,18 @@ type Worker struct {
 
 	// AuthStoragePath represents the location a worker stores its node credentials, if set
 	AuthStoragePath string `hcl:"auth_storage_path"`
+
+	// ControllerGeneratedActivationToken is a token generated by the
+	// controller for the controller led node enrollment flow. It can be a
+	// string pointing to an env var or file. If set and the worker has no
+	// credentials yet, the worker presents it to be authorized.
+	ControllerGeneratedActivationToken string `hcl:"controller_generated_activation_token"`
+
+	// GracefulShutdownWait is the amount of time that we'll wait before actually
+	// starting the Worker shutdown. This allows the health endpoint to
+	// return a status code to indicate that the instance is shutting down.
+	GracefulShutdownWait         interface{} `hcl:"graceful_shutdown_wait_duration"`
+	GracefulShutdownWaitDuration time.Duration
 }
 
 type Database struct {
@@ -219,6 +260,16 @@ type Database struct {
 	MigrationUrl          string      `hcl:"migration_url"`
 	MaxOpenConnections    int         `hcl:"-"`
 	MaxOpenConnectionsRaw interface{} `hcl:"max_open_connections"`
+
+	// ReadReplicaUrls are the URLs of read replicas of the database which
+	// read-only requests are routed to.
+	ReadReplicaUrls []string `hcl:"read_replica_urls"`
+
+	// MaxReplicaLag is the amount a read replica can lag behind the database
+	// before reads are routed to the database instead, denoted by
+	// time.Duration
+	MaxReplicaLag         interface{} `hcl:"max_replica_lag"`
+	MaxReplicaLagDuration time.Duration
 }
 
 type Plugins struct {
@@ -370,6 +421,31 @@ func Parse(d string) (*Config, error) {
 			result.Controller.GracefulShutdownWaitDuration = t
 		}
 
+		if result.Controller.JobRunRetention != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.JobRunRetention)
+			if err != nil {
+				return result, err
+			}
+			if t < 0 {
+				return nil, errors.New("Controller job run retention must not be negative")
+			}
+			result.Controller.JobRunRetentionDuration = t
+		}
+
+		jobNames := make(map[string]bool, len(result.Controller.Jobs))
+		for _, j := range result.Controller.Jobs {
+			if j.Name == "" {
+				return nil, errors.New("Controller job name must be set")
+			}
+			if jobNames[j.Name] {
+				return nil, fmt.Errorf("Controller job %q is defined more than once", j.Name)
+			}
+			jobNames[j.Name] = true
+			if _, err := scheduler.ParseSchedule(context.Background(), j.Schedule, j.TimeZone); err != nil {
+				return nil, fmt.Errorf("Error parsing schedule of controller job %q: %w", j.Name, err)
+			}
+		}
+
 		if result.Controller.Database != nil {
 			if result.Controller.Database.MaxOpenConnectionsRaw != nil {
 				switch t := result.Controller.Database.MaxOpenConnectionsRaw.(type) {
@@ -389,6 +465,16 @@ func Parse(d string) (*Config, error) {
 						reflect.TypeOf(t).String())
 				}
 			}
+			if result.Controller.Database.MaxReplicaLag != "" {
+				t, err := parseutil.ParseDurationSecond(result.Controller.Database.MaxReplicaLag)
+				if err != nil {
+					return result, err
+				}
+				if t < 0 {
+					return nil, errors.New("Database max replica lag must not be negative")
+				}
+				result.Controller.Database.MaxReplicaLagDuration = t
+			}
 		}
 	}
 
@@ -413,6 +499,19 @@ func Parse(d string) (*Config, error) {
 			return nil, errors.New("Worker description contains non-printable characters")
 		}
 
+		result.Worker.ControllerGeneratedActivationToken, err = parseutil.ParsePath(result.Worker.ControllerGeneratedActivationToken)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return nil, fmt.Errorf("Error parsing worker activation token: %w", err)
+		}
+
+		if result.Worker.GracefulShutdownWait != "" {
+			t, err := parseutil.ParseDurationSecond(result.Worker.GracefulShutdownWait)
+			if err != nil {
+				return result, err
+			}
+			result.Worker.GracefulShutdownWaitDuration = t
+		}
+
 		if result.Worker.TagsRaw != nil {
 			switch t := result.Worker.TagsRaw.(type) {
 			// We allow `tags` to be a simple string containing a URL with schema.
@@ -563,6 +662,9 @@ func Parse(d string) (*Config, error) {
 		if result.Eventing, err = parseEventing(eventList.Items[0]); err != nil {
 			return nil, fmt.Errorf(`error parsing "events": %w`, err)
 		}
+		if result.Tracing, err = parseTracing(eventList.Items[0]); err != nil {
+			return nil, fmt.Errorf(`error parsing "events": %w`, err)
+		}
 	default:
 		return nil, fmt.Errorf(`too many "events" nodes (max 1, got %d)`, len(eventList.Items))
 	}
@@ -577,6 +679,29 @@ func Parse(d string) (*Config, error) {
 	return result, nil
 }
 
+func parseTracing(eventObj *ast.ObjectItem) (*tracing.Config, error) {
+	eventObjType, ok := eventObj.Val.(*ast.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf(`error interpreting "events" node as an object type`)
+	}
+	tracingList := eventObjType.List.Filter("tracing")
+	switch len(tracingList.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf(`too many "tracing" nodes (max 1, got %d)`, len(tracingList.Items))
+	}
+	var result tracing.Config
+	if err := hcl.DecodeObject(&result, tracingList.Items[0].Val); err != nil {
+		return nil, fmt.Errorf(`error decoding "tracing" node: %w`, err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // supportControllersRawConfig returns either initialUpstreamsRaw or controllersRaw depending on which is populated. Errors when both fields are populated.
 //
 func supportControllersRawConfig(initialUpstreamsRaw, controllersRaw any) (any, error) {
@@ -663,6 +788,12 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 				s.Type = event.StderrSink
 			case s.FileConfig != nil:
 				s.Type = event.FileSink
+			case s.SyslogConfig != nil:
+				s.Type = event.SyslogSink
+			case s.HttpConfig != nil:
+				s.Type = event.HttpSink
+			case s.KafkaConfig != nil:
+				s.Type = event.KafkaSink
 			default:
 				return nil, fmt.Errorf("sink type could not be determined")
 			}
@@ -684,6 +815,61 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 			}
 		}
 
+		// parse the flush interval string specified in an http config into a time.Duration
+		if s.HttpConfig != nil && s.HttpConfig.FlushIntervalHCL != "" {
+			var err error
+			s.HttpConfig.FlushInterval, err = parseutil.ParseDurationSecond(s.HttpConfig.FlushIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse flush interval %s", s.HttpConfig.FlushIntervalHCL)
+			}
+		}
+
+		// parse the flush interval string specified in a kafka config into a time.Duration
+		if s.KafkaConfig != nil && s.KafkaConfig.FlushIntervalHCL != "" {
+			var err error
+			s.KafkaConfig.FlushInterval, err = parseutil.ParseDurationSecond(s.KafkaConfig.FlushIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse flush interval %s", s.KafkaConfig.FlushIntervalHCL)
+			}
+		}
+
+		// the sasl password of a kafka config may be read from the env or a file
+		if s.KafkaConfig != nil && s.KafkaConfig.SaslPassword != "" {
+			var err error
+			s.KafkaConfig.SaslPassword, err = parseutil.ParsePath(s.KafkaConfig.SaslPassword)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				return nil, fmt.Errorf("error parsing kafka sasl password: %w", err)
+			}
+		}
+
+		// parse the checkpoint interval string specified in an audit config into a time.Duration
+		if s.AuditConfig != nil && s.AuditConfig.HashChainCheckpointIntervalHCL != "" {
+			var err error
+			s.AuditConfig.HashChainCheckpointInterval, err = parseutil.ParseDurationSecond(s.AuditConfig.HashChainCheckpointIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse hash chain checkpoint interval %s", s.AuditConfig.HashChainCheckpointIntervalHCL)
+			}
+		}
+
+		// decode the rule blocks of a sampling config, which hcl can't decode
+		// into a slice of a nested block
+		if s.SamplingConfig != nil {
+			rules, err := parseSamplingRules(item)
+			if err != nil {
+				return nil, err
+			}
+			s.SamplingConfig.Rules = rules
+		}
+
+		// parse the summary interval string specified in a sampling config into a time.Duration
+		if s.SamplingConfig != nil && s.SamplingConfig.SummaryIntervalHCL != "" {
+			var err error
+			s.SamplingConfig.SummaryInterval, err = parseutil.ParseDurationSecond(s.SamplingConfig.SummaryIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse sampling summary interval %s", s.SamplingConfig.SummaryIntervalHCL)
+			}
+		}
+
 		// parse map into event types
 		if s.AuditConfig != nil && s.AuditConfig.FilterOverridesHCL != nil {
 			s.AuditConfig.FilterOverrides = make(map[event.DataClassification]event.FilterOperation, len(s.AuditConfig.FilterOverridesHCL))
@@ -692,6 +878,14 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 			}
 		}
 
+		// parse map into field classification overrides
+		if s.AuditConfig != nil && s.AuditConfig.FieldOverridesHCL != nil {
+			s.AuditConfig.FieldOverrides = make(event.AuditFieldOverrides, len(s.AuditConfig.FieldOverridesHCL))
+			for k, v := range s.AuditConfig.FieldOverridesHCL {
+				s.AuditConfig.FieldOverrides[k] = event.DataClassification(v)
+			}
+		}
+
 		if err := s.Validate(); err != nil {
 			return nil, err
 		}
@@ -705,6 +899,30 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 	return &result, nil
 }
 
+// parseSamplingRules decodes the "rule" blocks of the "sampling_config" block
+// of an events sink
+func parseSamplingRules(sink *ast.ObjectItem) ([]*event.SamplingRule, error) {
+	sinkObj, ok := sink.Val.(*ast.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf(`error interpreting "sink" node as an object type`)
+	}
+	var rules []*event.SamplingRule
+	for _, samplingItem := range sinkObj.List.Filter("sampling_config").Items {
+		samplingObj, ok := samplingItem.Val.(*ast.ObjectType)
+		if !ok {
+			return nil, fmt.Errorf(`error interpreting "sampling_config" node as an object type`)
+		}
+		for i, ruleItem := range samplingObj.List.Filter("rule").Items {
+			var r event.SamplingRule
+			if err := hcl.DecodeObject(&r, ruleItem.Val); err != nil {
+				return nil, fmt.Errorf("error decoding sampling rule entry %d: %w", i, err)
+			}
+			rules = append(rules, &r)
+		}
+	}
+	return rules, nil
+}
+
 // Sanitized returns a copy of the config with all values that are considered
 // sensitive stripped. It also strips all `*Raw` values that are mainly
 // used for parsing.
@@ -387,6 +387,16 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"jobs": {
+		{
+			ResourceType:     resource.Job.String(),
+			Pkg:              "jobs",
+			StdActions:       []string{"read", "list"},
+			Container:        "Scope",
+			HasExtraHelpFunc: true,
+			HasId:            true,
+		},
+	},
 	"managedgroups": {
 		{
 			ResourceType:   resource.ManagedGroup.String(),
@@ -432,6 +442,7 @@ var inputStructs = map[string][]*cmdInfo{
 			Pkg:                 "scopes",
 			StdActions:          []string{"create", "read", "update", "delete", "list"},
 			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
 			HasId:               true,
 			Container:           "Scope",
 			HasName:             true,
@@ -504,7 +515,7 @@ var inputStructs = map[string][]*cmdInfo{
 			Container:        "Scope",
 			HasName:          true,
 			HasDescription:   true,
-			VersionedActions: []string{"update"},
+			VersionedActions: []string{"update", "rotate-auth"},
 		},
 		{
 			ResourceType:          resource.Worker.String(),
@@ -521,5 +532,20 @@ var inputStructs = map[string][]*cmdInfo{
 			NeedsSubtypeInCreate:  true,
 			SkipClientCallActions: []string{"create"},
 		},
+		{
+			ResourceType:          resource.Worker.String(),
+			Pkg:                   "workers",
+			StdActions:            []string{"create"},
+			SubActionPrefix:       "controller-led",
+			HasExtraCommandVars:   true,
+			SkipNormalHelp:        true,
+			HasExtraHelpFunc:      true,
+			HasId:                 true,
+			HasName:               true,
+			Container:             "Scope",
+			HasDescription:        true,
+			NeedsSubtypeInCreate:  true,
+			SkipClientCallActions: []string{"create"},
+		},
 	},
 }
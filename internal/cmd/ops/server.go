@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/daemon/controller"
+	"github.com/hashicorp/boundary/internal/daemon/worker"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
@@ -26,6 +27,7 @@ import (
 type Server struct {
 	bundles    []*opsBundle
 	controller *controller.Controller
+	worker     *worker.Worker
 }
 
 type opsBundle struct {
@@ -36,7 +38,9 @@ type opsBundle struct {
 
 // NewServer iterates through all the listeners and sets up HTTP Servers for each, along with individual handlers.
 // If Controller is set-up, NewServer will set-up a health endpoint for it.
-func NewServer(l hclog.Logger, c *controller.Controller, listeners ...*base.ServerListener) (*Server, error) {
+// If Worker is set-up, NewServer will set-up a worker health endpoint, which
+// is also served as the main health endpoint when there is no Controller.
+func NewServer(l hclog.Logger, c *controller.Controller, w *worker.Worker, listeners ...*base.ServerListener) (*Server, error) {
 	const op = "ops.NewServer()"
 	if l == nil {
 		return nil, fmt.Errorf("%s: missing logger", op)
@@ -54,7 +58,7 @@ func NewServer(l hclog.Logger, c *controller.Controller, listeners ...*base.Serv
 			return nil, fmt.Errorf("%s: missing ops listener", op)
 		}
 
-		h, err := createOpsHandler(ln.Config, c)
+		h, err := createOpsHandler(ln.Config, c, w)
 		if err != nil {
 			return nil, err
 		}
@@ -67,7 +71,7 @@ func NewServer(l hclog.Logger, c *controller.Controller, listeners ...*base.Serv
 		bundles = append(bundles, b)
 	}
 
-	return &Server{bundles, c}, nil
+	return &Server{bundles, c, w}, nil
 }
 
 // Starts all goroutines that were set-up in NewServer.
@@ -110,9 +114,10 @@ func (s *Server) Shutdown() error {
 }
 
 // WaitIfHealthExists waits for a configurable period of time `d` if the health endpoint has been
-// configured (i.e the Controller exists and ops listeners have been set-up)
+// configured (i.e the Controller or Worker exists and ops listeners have been set-up)
 func (s *Server) WaitIfHealthExists(d time.Duration, ui cli.Ui) {
-	if s.controller == nil || s.controller.HealthService == nil {
+	controllerHealth := s.controller != nil && s.controller.HealthService != nil
+	if !controllerHealth && s.worker == nil {
 		return
 	}
 	if len(s.bundles) == 0 {
@@ -124,18 +129,32 @@ func (s *Server) WaitIfHealthExists(d time.Duration, ui cli.Ui) {
 	// This is to give time for health to report unhealthy and for external
 	// systems to pick up on that.
 	ui.Output(fmt.Sprintf("==> Health is enabled, waiting %s before shutdown", d.String()))
-	s.controller.HealthService.StartServiceUnavailableReplies()
+	if controllerHealth {
+		s.controller.HealthService.StartServiceUnavailableReplies()
+	}
+	if s.worker != nil {
+		s.worker.StartServiceUnavailableReplies()
+	}
 	<-time.After(d)
 }
 
-func createOpsHandler(lncfg *listenerutil.ListenerConfig, c *controller.Controller) (http.Handler, error) {
+func createOpsHandler(lncfg *listenerutil.ListenerConfig, c *controller.Controller, w *worker.Worker) (http.Handler, error) {
 	mux := http.NewServeMux()
+	var controllerHealth bool
 	if c != nil && c.HealthService != nil {
 		h, err := c.GetHealthHandler(lncfg)
 		if err != nil {
 			return nil, err
 		}
 		mux.Handle("/health", h)
+		controllerHealth = true
+	}
+	if w != nil {
+		h := w.HealthHandler()
+		mux.Handle("/health/worker", h)
+		if !controllerHealth {
+			mux.Handle("/health", h)
+		}
 	}
 
 	mux.Handle("/metrics", promhttp.Handler())
@@ -93,7 +93,7 @@ func TestNewServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, err := NewServer(tt.logger, tt.c, tt.listeners...)
+			s, err := NewServer(tt.logger, tt.c, nil, tt.listeners...)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, s)
@@ -265,7 +265,7 @@ func TestNewServerIntegration(t *testing.T) {
 			err := bs.SetupListeners(nil, &configutil.SharedConfig{Listeners: tt.listeners}, []string{"ops"})
 			require.NoError(t, err)
 
-			s, err := NewServer(hclog.Default(), nil, bs.Listeners...)
+			s, err := NewServer(hclog.Default(), nil, nil, bs.Listeners...)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, s)
@@ -562,7 +562,7 @@ func TestHealthEndpointLifecycle(t *testing.T) {
 	t.Cleanup(tc.Shutdown)
 
 	// Controller has started and is set onto our Command object, start ops.
-	opsServer, err := NewServer(hclog.Default(), tc.Controller(), tc.Config().Listeners...)
+	opsServer, err := NewServer(hclog.Default(), tc.Controller(), nil, tc.Config().Listeners...)
 	require.NoError(t, err)
 	opsServer.Start()
 
@@ -699,7 +699,7 @@ func TestCreateOpsHandler(t *testing.T) {
 				c = tc.Controller()
 			}
 
-			h, err := createOpsHandler(tt.lncfg, c)
+			h, err := createOpsHandler(tt.lncfg, c, nil)
 			if tt.expErr {
 				require.EqualError(t, err, tt.expErrMsg)
 				require.Nil(t, h)
@@ -0,0 +1,50 @@
+package static
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn(allocUsernamePasswordCredential().TableName(), usernamePasswordCredentialRewrapFn)
+}
+
+// usernamePasswordCredentialRewrapFn decrypts the passwords of the username
+// password credentials encrypted with the data key version and re-encrypts
+// them with the current version of the scope's database key.
+func usernamePasswordCredentialRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsCache *kms.Kms) error {
+	const op = "static.usernamePasswordCredentialRewrapFn"
+	var creds []*UsernamePasswordCredential
+	if err := reader.SearchWhere(ctx, &creds, "key_id = ?", []interface{}{dataKeyVersionId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up username password credentials"))
+	}
+	if len(creds) == 0 {
+		return nil
+	}
+	oldWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(dataKeyVersionId))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper for the data key version"))
+	}
+	currentWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get current database wrapper"))
+	}
+	for _, c := range creds {
+		if err := c.decrypt(ctx, oldWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := c.encrypt(ctx, currentWrapper); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		// only the encrypted columns are updated, which doesn't bump the
+		// version, and a row updated since it was read is left for the next
+		// rewrap
+		if _, err := writer.Update(ctx, c, []string{"CtPassword", "PasswordHmac", "KeyId"}, nil, db.WithVersion(&c.Version)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update username password credential"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/hashicorp/nodeenrollment/protocol"
+	"github.com/hashicorp/nodeenrollment/registration"
+	"github.com/hashicorp/nodeenrollment/types"
+)
+
+// NewFetchNodeCredentialsFn returns a function which fetches node credentials
+// for a node, first authorizing it if it is not yet authorized and the
+// registration nonce in its request is a controller led activation token
+// which can be redeemed.
+func NewFetchNodeCredentialsFn(serversRepoFn common.ServersRepoFactory) (protocol.FetchCredsFn, error) {
+	const op = "cluster.handlers.NewFetchNodeCredentialsFn"
+	if serversRepoFn == nil {
+		return nil, fmt.Errorf("%s: missing servers repository", op)
+	}
+	return func(
+		ctx context.Context,
+		storage nodeenrollment.Storage,
+		req *types.FetchNodeCredentialsRequest,
+		opt ...nodeenrollment.Option,
+	) (*types.FetchNodeCredentialsResponse, error) {
+		resp, err := registration.FetchNodeCredentials(ctx, storage, req, opt...)
+		if err != nil || len(resp.GetEncryptedNodeCredentials()) > 0 {
+			return resp, err
+		}
+
+		// The node isn't authorized yet, so see if it presented an activation
+		// token
+		serversRepo, err := serversRepoFn()
+		if err != nil {
+			return nil, fmt.Errorf("%s: error getting servers repo: %w", op, err)
+		}
+		if _, err := serversRepo.AuthorizeWorkerWithActivationToken(ctx, req); err != nil {
+			if errors.IsNotFoundError(err) {
+				return resp, nil
+			}
+			return nil, fmt.Errorf("%s: error authorizing worker with activation token: %w", op, err)
+		}
+		return registration.FetchNodeCredentials(ctx, storage, req, opt...)
+	}, nil
+}
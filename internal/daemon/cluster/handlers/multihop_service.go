@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/multihop"
+	"github.com/hashicorp/nodeenrollment/protocol"
 	"github.com/hashicorp/nodeenrollment/registration"
 	"github.com/hashicorp/nodeenrollment/rotation"
 	"github.com/hashicorp/nodeenrollment/tls"
@@ -18,15 +19,18 @@ import (
 type multihopServiceServer struct {
 	multihop.UnimplementedMultihopServiceServer
 
-	storage nodeenrollment.Storage
-	direct  bool
-	client  *atomic.Value
-	options []nodeenrollment.Option
+	storage      nodeenrollment.Storage
+	direct       bool
+	client       *atomic.Value
+	fetchCredsFn protocol.FetchCredsFn
+	options      []nodeenrollment.Option
 }
 
 // NewMultihopServiceServer creates a new service implementing
 // MultihopServiceServer, storing values used for the implementing functions.
-func NewMultihopServiceServer(storage nodeenrollment.Storage, direct bool, client *atomic.Value, opt ...nodeenrollment.Option) (*multihopServiceServer, error) {
+// If fetchCredsFn is nil, credentials are fetched directly from storage when
+// running on a controller.
+func NewMultihopServiceServer(storage nodeenrollment.Storage, direct bool, client *atomic.Value, fetchCredsFn protocol.FetchCredsFn, opt ...nodeenrollment.Option) (*multihopServiceServer, error) {
 	const op = "cluster.handlers.NewMultihopServiceServer"
 
 	switch {
@@ -36,11 +40,16 @@ func NewMultihopServiceServer(storage nodeenrollment.Storage, direct bool, clien
 		return nil, fmt.Errorf("%s: running on worker and nil client provided", op)
 	}
 
+	if fetchCredsFn == nil {
+		fetchCredsFn = registration.FetchNodeCredentials
+	}
+
 	return &multihopServiceServer{
-		storage: storage,
-		direct:  direct,
-		client:  client,
-		options: opt,
+		storage:      storage,
+		direct:       direct,
+		client:       client,
+		fetchCredsFn: fetchCredsFn,
+		options:      opt,
 	}, nil
 }
 
@@ -53,7 +62,7 @@ func (m *multihopServiceServer) FetchNodeCredentials(ctx context.Context, req *t
 	const op = "cluster.handlers.(multihopServiceServer).FetchNodeCredentials"
 	switch m.direct {
 	case true:
-		return registration.FetchNodeCredentials(ctx, m.storage, req, m.options...)
+		return m.fetchCredsFn(ctx, m.storage, req, m.options...)
 
 	default:
 		client := m.client.Load()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
@@ -17,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/multi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -96,11 +98,26 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		server.WithAddress(wStat.GetAddress()),
 		server.WithWorkerTags(workerTags...))
 	opts := []server.Option{server.WithUpdateTags(req.GetUpdateTags())}
+	if wStat.GetReleaseVersion() != "" {
+		opts = append(opts,
+			server.WithReleaseVersion(wStat.GetReleaseVersion()),
+			server.WithFeatures(wStat.GetFeatures()...))
+	}
 	if wStat.GetPublicId() != "" {
 		opts = append(opts, server.WithPublicId(wStat.GetPublicId()))
 	}
 	if wStat.GetKeyId() != "" {
 		opts = append(opts, server.WithKeyId(wStat.GetKeyId()))
+		rotationStatus := &server.AuthRotationStatus{
+			LastRotationError: wStat.GetLastRotationError(),
+		}
+		if wStat.GetCertificateExpiration() != nil {
+			rotationStatus.CertificateExpiration = timestamp.New(wStat.GetCertificateExpiration().AsTime())
+		}
+		if wStat.GetLastRotationTime() != nil {
+			rotationStatus.LastRotationTime = timestamp.New(wStat.GetLastRotationTime().AsTime())
+		}
+		opts = append(opts, server.WithAuthRotationStatus(rotationStatus))
 	}
 	wrk, err := serverRepo.UpsertWorkerStatus(ctx, wConf, opts...)
 	if err != nil {
@@ -124,6 +141,7 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 	ret := &pbs.StatusResponse{
 		CalculatedUpstreams: responseControllers,
 		WorkerId:            wrk.GetPublicId(),
+		RotateAuth:          wrk.GetRotateAuthRequested(),
 	}
 
 	stateReport := make([]session.StateReport, 0, len(req.GetJobs()))
@@ -303,6 +321,13 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting sessions wrapper: %v", err)
 	}
+	// The scope's keys may have been rotated since the session was created, so
+	// derive from the key version the session was created with.
+	if pooled, ok := wrapper.(*multi.PooledWrapper); ok {
+		if w := pooled.WrapperForKeyId(sessionInfo.KeyId); w != nil {
+			wrapper = w
+		}
+	}
 
 	// Derive the private key, which should match. Deriving on both ends allows
 	// us to not store it in the DB.
@@ -487,6 +487,10 @@ func (b *testMockBroker) SetSuccessThreshold(t eventlogger.EventType, successThr
 	return nil
 }
 
+func (b *testMockBroker) RemovePipeline(t eventlogger.EventType, id eventlogger.PipelineID) error {
+	return nil
+}
+
 type eventJson struct {
 	CreatedAt string                 `json:"created_at"`
 	EventType string                 `json:"event_type"`
@@ -16,6 +16,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
@@ -139,7 +140,9 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 
 	ret.v = v
 
-	v.ctx = ctx
+	// Authorization must not be decided by a read replica lagging behind
+	// revoked grants, so the verifier's lookups always read from the primary.
+	v.ctx = db.WithoutReplicaReads(ctx)
 
 	ea := &event.Auth{}
 	defer event.WriteAudit(ctx, op, event.WithAuth(ea))
@@ -494,7 +497,7 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		return
 	}
 
-	u, _, err := iamRepo.LookupUser(ctx, userId)
+	u, _, err := iamRepo.LookupUser(v.ctx, userId)
 	if err != nil {
 		retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
 		return
@@ -18,6 +18,8 @@ import (
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/tests/api"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/eventlogger/filters/encrypt"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -257,3 +259,48 @@ func TestVerify_AuditEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestVerify_ReplicaReads(t *testing.T) {
+	ctx := context.Background()
+	// the primary and the "replica" are separate databases, so a lookup made
+	// against the replica doesn't find the user, scope or grants.
+	conn, _ := db.TestSetup(t, "postgres")
+	replica, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	replicaReader, err := db.NewReplicaReader(ctx, conn, []*db.DB{replica})
+	require.NoError(t, err)
+
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, testKms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(replicaReader, rw, testKms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, testKms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := authtoken.TestAuthToken(t, conn, testKms, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=*")
+	_ = iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+	encToken, err := authtoken.EncryptToken(ctx, testKms, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "http://127.0.0.1/v1/users?scope_id="+o.GetPublicId(), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s_%s", at.GetPublicId(), encToken))
+	requestInfo := authpb.RequestInfo{
+		Path:   req.URL.Path,
+		Method: req.Method,
+	}
+	requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(ctx, testKms, req)
+
+	verifierCtx := NewVerifierContext(db.WithReplicaReads(ctx), iamRepoFn, tokenRepoFn, serversRepoFn, testKms, &requestInfo)
+	res := Verify(verifierCtx, WithScopeId(o.GetPublicId()), WithType(resource.User), WithAction(action.List))
+	require.NoError(t, res.Error)
+	assert.Equal(t, at.GetIamUserId(), res.UserId)
+	assert.Equal(t, o.GetPublicId(), res.Scope.Id)
+}
@@ -8,7 +8,9 @@ import (
 	pluginhost "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog/query"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
@@ -19,7 +21,9 @@ type (
 	VaultCredentialRepoFactory   = func() (*vault.Repository, error)
 	StaticCredentialRepoFactory  = func() (*credstatic.Repository, error)
 	IamRepoFactory               func() (*iam.Repository, error)
+	JobRepoFactory               func() (*job.Repository, error)
 	OidcAuthRepoFactory          = oidc.OidcRepoFactory
+	OplogRepoFactory             func() (*query.Repository, error)
 	PasswordAuthRepoFactory      func() (*password.Repository, error)
 	ServersRepoFactory           func() (*server.Repository, error)
 	StaticRepoFactory            func() (*static.Repository, error)
@@ -22,7 +22,9 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/oplog/query"
 	"github.com/hashicorp/boundary/internal/plugin/host"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/scheduler"
@@ -70,7 +72,9 @@ type Controller struct {
 	VaultCredentialRepoFn   common.VaultCredentialRepoFactory
 	StaticCredentialRepoFn  common.StaticCredentialRepoFactory
 	IamRepoFn               common.IamRepoFactory
+	JobRepoFn               common.JobRepoFactory
 	OidcRepoFn              common.OidcAuthRepoFactory
+	OplogRepoFn             common.OplogRepoFactory
 	PasswordAuthRepoFn      common.PasswordAuthRepoFactory
 	ServersRepoFn           common.ServersRepoFactory
 	SessionRepoFn           common.SessionRepoFactory
@@ -94,6 +98,7 @@ type Controller struct {
 
 func New(ctx context.Context, conf *Config) (*Controller, error) {
 	metric.InitializeApiCollectors(conf.PrometheusRegisterer)
+	scheduler.InitializeCollectors(conf.PrometheusRegisterer)
 	c := &Controller{
 		conf:                    conf,
 		logger:                  conf.Logger.Named("controller"),
@@ -208,6 +213,14 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 
 	// Set up repo stuff
 	dbase := db.New(c.conf.Database)
+	// Reads of read-only requests are routed to the read replicas, if any.
+	// The kms, jobs, auth tokens and worker auth always read from the database
+	// since they must read their own writes, and so do the authorization
+	// lookups of auth.Verify.
+	replicaReader, err := db.NewReplicaReader(ctx, c.conf.Database, c.conf.ReadReplicaDatabases, db.WithMaxReplicaLag(c.conf.DatabaseMaxReplicaLag))
+	if err != nil {
+		return nil, fmt.Errorf("error creating read replica reader: %w", err)
+	}
 	c.kms, err = kms.New(ctx, dbase, dbase)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms cache: %w", err)
@@ -215,6 +228,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	if err := c.kms.AddExternalWrappers(
 		ctx,
 		kms.WithRootWrapper(c.conf.RootKms),
+		kms.WithScopeRootWrappers(c.conf.ScopeRootKmses),
 		kms.WithWorkerAuthWrapper(c.conf.WorkerAuthKms),
 		kms.WithRecoveryWrapper(c.conf.RecoveryKms),
 	); err != nil {
@@ -256,21 +270,36 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	if c.conf.RawConfig.Controller.SchedulerRunJobInterval > 0 {
 		schedulerOpts = append(schedulerOpts, scheduler.WithRunJobsInterval(c.conf.RawConfig.Controller.SchedulerRunJobInterval))
 	}
+	if c.conf.RawConfig.Controller.JobRunRetentionDuration > 0 {
+		schedulerOpts = append(schedulerOpts, scheduler.WithRunRetention(c.conf.RawConfig.Controller.JobRunRetentionDuration))
+	}
+	if len(c.conf.RawConfig.Controller.Jobs) > 0 {
+		jobSchedules := make(map[string]*scheduler.Schedule, len(c.conf.RawConfig.Controller.Jobs))
+		for _, j := range c.conf.RawConfig.Controller.Jobs {
+			s, err := scheduler.ParseSchedule(ctx, j.Schedule, j.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing schedule of job %q: %w", j.Name, err)
+			}
+			jobSchedules[j.Name] = s
+		}
+		schedulerOpts = append(schedulerOpts, scheduler.WithJobSchedules(jobSchedules))
+	}
 	c.scheduler, err = scheduler.New(c.conf.RawConfig.Controller.Name, jobRepoFn, schedulerOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new scheduler: %w", err)
 	}
+	c.JobRepoFn = jobRepoFn
 	c.IamRepoFn = func() (*iam.Repository, error) {
-		return iam.NewRepository(dbase, dbase, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader))
+		return iam.NewRepository(replicaReader, dbase, c.kms, iam.WithRandomReader(c.conf.SecureRandomReader))
 	}
 	c.StaticHostRepoFn = func() (*static.Repository, error) {
-		return static.NewRepository(dbase, dbase, c.kms)
+		return static.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.PluginHostRepoFn = func() (*pluginhost.Repository, error) {
-		return pluginhost.NewRepository(dbase, dbase, c.kms, c.scheduler, c.conf.HostPlugins)
+		return pluginhost.NewRepository(replicaReader, dbase, c.kms, c.scheduler, c.conf.HostPlugins)
 	}
 	c.HostPluginRepoFn = func() (*host.Repository, error) {
-		return host.NewRepository(dbase, dbase, c.kms)
+		return host.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms,
@@ -278,28 +307,31 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 			authtoken.WithTokenTimeToStaleDuration(c.conf.RawConfig.Controller.AuthTokenTimeToStaleDuration))
 	}
 	c.VaultCredentialRepoFn = func() (*vault.Repository, error) {
-		return vault.NewRepository(dbase, dbase, c.kms, c.scheduler)
+		return vault.NewRepository(replicaReader, dbase, c.kms, c.scheduler)
 	}
 	c.StaticCredentialRepoFn = func() (*credstatic.Repository, error) {
-		return credstatic.NewRepository(ctx, dbase, dbase, c.kms)
+		return credstatic.NewRepository(ctx, replicaReader, dbase, c.kms)
 	}
 	c.ServersRepoFn = func() (*server.Repository, error) {
-		return server.NewRepository(dbase, dbase, c.kms)
+		return server.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.OidcRepoFn = func() (*oidc.Repository, error) {
-		return oidc.NewRepository(ctx, dbase, dbase, c.kms)
+		return oidc.NewRepository(ctx, replicaReader, dbase, c.kms)
+	}
+	c.OplogRepoFn = func() (*query.Repository, error) {
+		return query.NewRepository(ctx, replicaReader, c.kms)
 	}
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
-		return password.NewRepository(dbase, dbase, c.kms)
+		return password.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.TargetRepoFn = func() (*target.Repository, error) {
-		return target.NewRepository(dbase, dbase, c.kms)
+		return target.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.SessionRepoFn = func() (*session.Repository, error) {
-		return session.NewRepository(dbase, dbase, c.kms)
+		return session.NewRepository(replicaReader, dbase, c.kms)
 	}
 	c.ConnectionRepoFn = func() (*session.ConnectionRepository, error) {
-		return session.NewConnectionRepository(ctx, dbase, dbase, c.kms)
+		return session.NewConnectionRepository(ctx, replicaReader, dbase, c.kms)
 	}
 	c.WorkerAuthRepoStorageFn = func() (*server.WorkerAuthRepositoryStorage, error) {
 		return server.NewRepositoryStorage(ctx, dbase, dbase, c.kms)
@@ -382,6 +414,12 @@ func (c *Controller) registerJobs() error {
 	if err := serversjob.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
 		return err
 	}
+	if err := kmsjob.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
+		return err
+	}
+	if err := c.scheduler.RegisterJobs(c.baseContext); err != nil {
+		return err
+	}
 
 	return nil
 }
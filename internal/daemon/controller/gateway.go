@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
@@ -33,6 +34,7 @@ func gatewayDialOptions(lis grpcServerListener) []grpc.DialOption {
 		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
 			return lis.Dial()
 		}),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
 	}
 }
 
@@ -74,7 +76,9 @@ func newGrpcServer(
 		grpc.MaxSendMsgSize(math.MaxInt32),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
+				tracing.UnaryServerInterceptor(),              // continue the trace of the http request
 				requestCtxInterceptor,                         // populated requestInfo from headers into the request ctx
+				replicaReadsInterceptor(ctx),                  // allow list and read requests to read from read replicas
 				errorInterceptor(ctx),                         // convert domain and api errors into headers for the http proxy
 				subtypes.AttributeTransformerInterceptor(ctx), // convert to/from generic attributes from/to subtype specific attributes
 				auditRequestInterceptor(ctx),                  // before we get started, audit the request
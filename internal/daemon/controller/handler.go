@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
@@ -24,12 +25,15 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentiallibraries"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentials"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentialstores"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/events"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/health"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/hosts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/jobs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/oplog"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
@@ -50,6 +54,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 )
 
 type HandlerProperties struct {
@@ -68,6 +73,15 @@ func (c *Controller) apiHandler(props HandlerProperties) (http.Handler, error) {
 		return nil, err
 	}
 	mux.Handle("/v1/", grpcGwMux)
+	tailHandler, err := events.NewTailHandler(props.CancelCtx, c.conf.Eventer,
+		grpc_middleware.ChainUnaryServer(
+			auditRequestInterceptor(props.CancelCtx),  // before we get started, audit the request
+			auditResponseInterceptor(props.CancelCtx), // as we finish, audit the response
+		))
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(events.TailPath, wrapHandlerWithRequestContext(tailHandler, c))
 	mux.Handle("/", handleUi(c))
 
 	corsWrappedHandler := wrapHandlerWithCors(mux, props)
@@ -78,7 +92,8 @@ func (c *Controller) apiHandler(props HandlerProperties) (http.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	metricsHandler := metric.InstrumentApiHandler(eventsHandler)
+	tracingHandler := tracing.HttpHandler(eventsHandler, "controller.api")
+	metricsHandler := metric.InstrumentApiHandler(tracingHandler)
 
 	return metricsHandler, nil
 }
@@ -153,7 +168,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterAuthTokenServiceServer(s, authtoks)
 	}
 	if _, ok := currentServices[services.ScopeService_ServiceDesc.ServiceName]; !ok {
-		os, err := scopes.NewService(c.IamRepoFn)
+		os, err := scopes.NewService(c.IamRepoFn, c.scheduler)
 		if err != nil {
 			return fmt.Errorf("failed to create scope handler service: %w", err)
 		}
@@ -239,6 +254,20 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		}
 		services.RegisterCredentialServiceServer(s, c)
 	}
+	if _, ok := currentServices[services.JobService_ServiceDesc.ServiceName]; !ok {
+		js, err := jobs.NewService(c.baseContext, c.JobRepoFn, c.scheduler)
+		if err != nil {
+			return fmt.Errorf("failed to create job handler service: %w", err)
+		}
+		services.RegisterJobServiceServer(s, js)
+	}
+	if _, ok := currentServices[services.OplogService_ServiceDesc.ServiceName]; !ok {
+		ols, err := oplog.NewService(c.baseContext, c.OplogRepoFn)
+		if err != nil {
+			return fmt.Errorf("failed to create oplog handler service: %w", err)
+		}
+		services.RegisterOplogServiceServer(s, ols)
+	}
 	if _, ok := s.GetServiceInfo()[opsservices.HealthService_ServiceDesc.ServiceName]; !ok {
 		hs := health.NewService()
 		opsservices.RegisterHealthServiceServer(s, hs)
@@ -302,6 +331,12 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterCredentialServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register credential service handler: %w", err)
 	}
+	if err := services.RegisterJobServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register job service handler: %w", err)
+	}
+	if err := services.RegisterOplogServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register oplog service handler: %w", err)
+	}
 
 	return nil
 }
@@ -331,8 +366,16 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 		// Set the Cache-Control header for all responses returned
 		w.Header().Set("Cache-Control", "no-store")
 
-		// Start with the request context and our timeout
-		ctx, cancelFunc := context.WithTimeout(r.Context(), maxRequestDuration)
+		// Start with the request context and our timeout.  Event tails are
+		// long lived streams, so they're only bound by the client's request.
+		var ctx context.Context
+		var cancelFunc context.CancelFunc
+		switch r.URL.Path {
+		case events.TailPath:
+			ctx, cancelFunc = context.WithCancel(r.Context())
+		default:
+			ctx, cancelFunc = context.WithTimeout(r.Context(), maxRequestDuration)
+		}
 		defer cancelFunc()
 
 		// Add a size limiter if desired
@@ -380,6 +423,30 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 	})
 }
 
+// wrapHandlerWithRequestContext sets up the same request ctx for the handler
+// as the grpc server's requestCtxInterceptor does for grpc services.  It's
+// used for handlers which are served directly rather than via the
+// grpc-gateway, and must be wrapped by wrapHandlerWithCommonFuncs.
+func wrapHandlerWithRequestContext(h http.Handler, c *Controller) http.Handler {
+	const op = "controller.wrapHandlerWithRequestContext"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		requestInfo, err := decodeRequestInfo(ctx, r.Header.Get("Grpc-Metadata-"+requestInfoMdKey), c.apiGrpcGatewayTicket)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error decoding request info"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reqCtx, err := newRequestContext(ctx, requestInfo, c.IamRepoFn, c.AuthTokenRepoFn, c.ServersRepoFn, c.kms, c.conf.Eventer)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error creating request context"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		h.ServeHTTP(w, r.Clone(reqCtx))
+	})
+}
+
 func wrapHandlerWithCors(h http.Handler, props HandlerProperties) http.Handler {
 	allowedMethods := []string{
 		http.MethodDelete,
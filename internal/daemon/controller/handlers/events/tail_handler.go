@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TailPath is the path of the API endpoint which streams events.
+	TailPath = "/v1/events:tail"
+
+	// keepAliveInterval is how often a comment is written to an idle stream,
+	// so proxies and load balancers don't close it.
+	keepAliveInterval = 15 * time.Second
+)
+
+// CollectionActions contains the set of actions that can be performed on
+// this collection
+var CollectionActions = action.ActionSet{
+	action.Tail,
+}
+
+// TailHandler streams the events of the controller that handles the request
+// to authorized clients as server-sent events.  Each event is sent as a JSON
+// formatted cloudevent in the data field of a server-sent event.
+//
+// The request supports the query parameters: "type" which may be repeated to
+// tail more than one event type (defaulting to every type) and "filter" which
+// is a bexpr filter evaluated against each cloudevent in the same way as an
+// event sink's allow filters.
+type TailHandler struct {
+	eventer     *event.Eventer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var _ http.Handler = (*TailHandler)(nil)
+
+// NewTailHandler returns a new TailHandler for the eventer.  The ctx of the
+// requests it serves must contain an auth verifier.  Each tail is run by the
+// interceptor, so the tail's request and response are audited in the same way
+// as the requests served by the grpc gateway.
+func NewTailHandler(ctx context.Context, eventer *event.Eventer, interceptor grpc.UnaryServerInterceptor) (*TailHandler, error) {
+	const op = "events.NewTailHandler"
+	if eventer == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing eventer")
+	}
+	if interceptor == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing interceptor")
+	}
+	return &TailHandler{eventer: eventer, interceptor: interceptor}, nil
+}
+
+// ServeHTTP implements http.Handler and streams events until the request's
+// ctx is done.
+func (h *TailHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "events.(TailHandler).ServeHTTP"
+	ctx := r.Context()
+	if r.Method != http.MethodGet {
+		writeError(ctx, w, r, status.Errorf(codes.Unimplemented, "Method %s is not supported.", r.Method))
+		return
+	}
+	authResults := auth.Verify(ctx, auth.WithScopeId(scope.Global.String()), auth.WithType(resource.Event), auth.WithAction(action.Tail))
+	if authResults.Error != nil {
+		writeError(ctx, w, r, authResults.Error)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(ctx, w, r, errors.New(ctx, errors.Internal, op, "response writer does not support streaming"))
+		return
+	}
+
+	_, err := h.interceptor(ctx, tailRequest(r), &grpc.UnaryServerInfo{Server: h, FullMethod: TailPath},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return h.tail(ctx, w, flusher, req.(*pbs.TailEventsRequest))
+		})
+	if err != nil {
+		writeError(ctx, w, r, err)
+	}
+}
+
+// tail streams events to the client until the ctx is done.  An error is only
+// returned before the stream has started.
+func (h *TailHandler) tail(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, req *pbs.TailEventsRequest) (interface{}, error) {
+	const op = "events.(TailHandler).tail"
+	types, opts, err := tailParams(req)
+	if err != nil {
+		return nil, err
+	}
+	events, err := h.eventer.Tail(ctx, types, opts...)
+	if err != nil {
+		if errors.Is(err, event.ErrInvalidParameter) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"filter": "This field could not be parsed."})
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	resp := &pbs.TailEventsResponse{}
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return resp, nil
+		case e, ok := <-events:
+			if !ok {
+				return resp, nil
+			}
+			_, err = fmt.Fprintf(w, "data: %s\n\n", e)
+			resp.EventsSent++
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err != nil {
+			// the client has most likely gone away
+			return resp, nil
+		}
+		flusher.Flush()
+	}
+}
+
+// tailRequest returns the tail request from the request's query parameters:
+// "type" which may be repeated and "filter".
+func tailRequest(r *http.Request) *pbs.TailEventsRequest {
+	query := r.URL.Query()
+	return &pbs.TailEventsRequest{
+		Types:  query["type"],
+		Filter: query.Get("filter"),
+	}
+}
+
+// tailParams returns the event types and options for the tail request.
+func tailParams(req *pbs.TailEventsRequest) ([]event.Type, []event.Option, error) {
+	var types []event.Type
+	for _, t := range req.GetTypes() {
+		et := event.Type(t)
+		if err := et.Validate(); err != nil {
+			return nil, nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"type": fmt.Sprintf("%q is not a valid event type.", t)})
+		}
+		types = append(types, et)
+	}
+	if len(types) == 0 {
+		types = []event.Type{event.EveryType}
+	}
+	var opts []event.Option
+	if filter := req.GetFilter(); filter != "" {
+		opts = append(opts, event.WithAllow(filter))
+	}
+	return types, opts, nil
+}
+
+// writeError writes the error in the same format as errors returned by the
+// grpc gateway.
+func writeError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	handlers.ErrorHandler()(ctx, nil, handlers.JSONMarshaler(), w, r, err)
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func testInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(ctx, req)
+}
+
+func TestNewTailHandler(t *testing.T) {
+	t.Run("missing-eventer", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		h, err := NewTailHandler(context.Background(), nil, testInterceptor)
+		require.Error(err)
+		assert.Nil(h)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-interceptor", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		h, err := NewTailHandler(context.Background(), &event.Eventer{}, nil)
+		require.Error(err)
+		assert.Nil(h)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestTailHandler_ServeHTTP_Method(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	h, err := NewTailHandler(context.Background(), &event.Eventer{}, testInterceptor)
+	require.NoError(err)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, TailPath, nil))
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func Test_tailParams(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           url.Values
+		wantTypes       []event.Type
+		wantOpts        int
+		wantErrContains string
+	}{
+		{
+			name:      "defaults",
+			wantTypes: []event.Type{event.EveryType},
+		},
+		{
+			name:      "types-and-filter",
+			query:     url.Values{"type": {"observation", "audit"}, "filter": {`"/data/name" == "foo"`}},
+			wantTypes: []event.Type{event.ObservationType, event.AuditType},
+			wantOpts:  1,
+		},
+		{
+			name:            "invalid-type",
+			query:           url.Values{"type": {"observation", "bad"}},
+			wantErrContains: "is not a valid event type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			r := httptest.NewRequest(http.MethodGet, TailPath+"?"+tt.query.Encode(), nil)
+			types, opts, err := tailParams(tailRequest(r))
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantTypes, types)
+			assert.Len(opts, tt.wantOpts)
+		})
+	}
+}
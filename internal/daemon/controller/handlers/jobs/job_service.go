@@ -0,0 +1,421 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
+	"google.golang.org/grpc/codes"
+)
+
+// recentRunsLimit is the number of runs returned when reading a job.
+const recentRunsLimit = 10
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.RunNow,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.JobServiceServer interface.
+type Service struct {
+	pbs.UnimplementedJobServiceServer
+
+	repoFn    common.JobRepoFactory
+	scheduler *scheduler.Scheduler
+}
+
+// NewService returns a job service which handles job related requests to boundary.
+func NewService(ctx context.Context, repoFn common.JobRepoFactory, scheduler *scheduler.Scheduler) (Service, error) {
+	const op = "jobs.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing job repository")
+	}
+	if scheduler == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing scheduler")
+	}
+	return Service{repoFn: repoFn, scheduler: scheduler}, nil
+}
+
+var _ pbs.JobServiceServer = Service{}
+
+// ListJobs implements the interface pbs.JobServiceServer.
+func (s Service) ListJobs(ctx context.Context, req *pbs.ListJobsRequest) (*pbs.ListJobsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	jl, err := s.listFromRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(jl) == 0 {
+		return &pbs.ListJobsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Job, 0, len(jl))
+	res := perms.Resource{
+		Type:    resource.Job,
+		ScopeId: scope.Global.String(),
+	}
+	// The latest runs are fetched at most once, and only if any listed job
+	// outputs its last run.
+	var latestRuns map[jobKey]*job.Run
+	for _, j := range jl {
+		res.Id = j.GetName()
+		authorizedActions := authResults.FetchActionSetForId(ctx, j.GetName(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		var runs []*job.Run
+		if outputFields.Has(globals.LastRunField) {
+			if latestRuns == nil {
+				if latestRuns, err = s.listLatestRunsFromRepo(ctx); err != nil {
+					return nil, err
+				}
+			}
+			if r, ok := latestRuns[jobKey{pluginId: j.GetPluginId(), name: j.GetName()}]; ok {
+				runs = []*job.Run{r}
+			}
+		}
+		item, err := toProto(ctx, j, runs, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListJobsResponse{Items: finalItems}, nil
+}
+
+// GetJob implements the interface pbs.JobServiceServer.
+func (s Service) GetJob(ctx context.Context, req *pbs.GetJobRequest) (*pbs.GetJobResponse, error) {
+	const op = "jobs.(Service).GetJob"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.listRunsFromRepo(ctx, j.GetName(), recentRunsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, j.GetName(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, j, runs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if outputFields.Has(globals.RunsField) {
+		item.Runs, err = runsToProto(runs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pbs.GetJobResponse{Item: item}, nil
+}
+
+// RunJobNow implements the interface pbs.JobServiceServer.
+func (s Service) RunJobNow(ctx context.Context, req *pbs.RunJobNowRequest) (*pbs.RunJobNowResponse, error) {
+	const op = "jobs.(Service).RunJobNow"
+
+	if err := validateRunNowRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RunNow)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.scheduler.UpdateJobNextRunInAtLeast(ctx, req.GetId(), 0, scheduler.WithRunNow(true)); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Job %q doesn't exist.", req.GetId())
+		}
+		return nil, err
+	}
+	j, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.listRunsFromRepo(ctx, j.GetName(), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, j.GetName(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, j, runs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RunJobNowResponse{Item: item}, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context) ([]*job.Job, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	jl, err := repo.ListJobs(ctx, job.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+	return jl, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, name string) (*job.Job, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	j, err := repo.LookupJob(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, handlers.NotFoundErrorf("Job %q doesn't exist.", name)
+	}
+	return j, nil
+}
+
+func (s Service) listRunsFromRepo(ctx context.Context, name string, limit int) ([]*job.Run, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := repo.ListRuns(ctx, job.WithName(name), job.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// jobKey identifies a job by its plugin id and name.
+type jobKey struct {
+	pluginId string
+	name     string
+}
+
+func (s Service) listLatestRunsFromRepo(ctx context.Context) (map[jobKey]*job.Run, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	runs, err := repo.ListLatestRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[jobKey]*job.Run, len(runs))
+	for _, r := range runs {
+		latest[jobKey{pluginId: r.GetJobPluginId(), name: r.GetJobName()}] = r
+	}
+	return latest, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	opts := []auth.Option{auth.WithType(resource.Job), auth.WithAction(a), auth.WithScopeId(scope.Global.String())}
+	switch a {
+	case action.List:
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		j, err := repo.LookupJob(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if j == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		opts = append(opts, auth.WithId(id))
+	}
+	return auth.Verify(ctx, opts...)
+}
+
+// toProto returns the proto of the job, with the first of the runs, which are
+// expected newest first, as its last run.
+func toProto(ctx context.Context, in *job.Job, runs []*job.Run, opt ...handlers.Option) (*pb.Job, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building job proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Job{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetName()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = scope.Global.String()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.DescriptionField) {
+		out.Description = in.GetDescription()
+	}
+	if outputFields.Has(globals.NextScheduledRunField) {
+		out.NextScheduledRun = in.GetNextScheduledRun().GetTimestamp()
+	}
+	if outputFields.Has(globals.ConsecutiveFailuresField) {
+		out.ConsecutiveFailures = in.GetConsecutiveFailures()
+	}
+	if outputFields.Has(globals.LastRunField) && len(runs) > 0 {
+		out.LastRun = runToProto(runs[0])
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+func runsToProto(in []*job.Run) ([]*pb.JobRun, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make([]*pb.JobRun, 0, len(in))
+	for _, r := range in {
+		if r == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "nil job run found when building job proto")
+		}
+		out = append(out, runToProto(r))
+	}
+	return out, nil
+}
+
+func runToProto(in *job.Run) *pb.JobRun {
+	out := &pb.JobRun{
+		Id:             in.GetPrivateId(),
+		Status:         in.GetStatus(),
+		ControllerId:   in.GetControllerId(),
+		CreatedTime:    in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:    in.GetUpdateTime().GetTimestamp(),
+		CompletedCount: in.GetCompletedCount(),
+		TotalCount:     in.GetTotalCount(),
+		ErrorMessage:   in.GetErrorMessage(),
+	}
+	if in.GetEndTime() != nil {
+		out.EndTime = in.GetEndTime().GetTimestamp()
+		d := in.GetEndTime().AsTime().Sub(in.GetCreateTime().AsTime())
+		out.DurationSeconds = uint32(d.Round(time.Second).Seconds())
+	}
+	return out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetJobRequest) error {
+	return validateId(req.GetId())
+}
+
+func validateRunNowRequest(req *pbs.RunJobNowRequest) error {
+	return validateId(req.GetId())
+}
+
+func validateId(id string) error {
+	if id == "" {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{globals.IdField: "Missing job id."})
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListJobsRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' when listing."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
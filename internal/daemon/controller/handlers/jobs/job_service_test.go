@@ -0,0 +1,232 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/jobs"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "run-now"}
+
+var globalScopeInfo = &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
+
+func TestNewService(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	repoFn := func() (*job.Repository, error) {
+		return nil, nil
+	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
+
+	_, err := NewService(context.Background(), nil, sche)
+	assert.Error(t, err)
+	_, err = NewService(context.Background(), repoFn, nil)
+	assert.Error(t, err)
+	_, err = NewService(context.Background(), repoFn, sche)
+	assert.NoError(t, err)
+}
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrap)
+	repo, err := job.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*job.Repository, error) {
+		return repo, nil
+	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
+
+	j, err := repo.UpsertJob(context.Background(), "test-job", "test job description")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.GetJobRequest
+		res  *pbs.GetJobResponse
+		err  error
+	}{
+		{
+			name: "Get an existing Job",
+			req:  &pbs.GetJobRequest{Id: j.GetName()},
+			res: &pbs.GetJobResponse{Item: &pb.Job{
+				Id:                j.GetName(),
+				ScopeId:           scope.Global.String(),
+				Scope:             globalScopeInfo,
+				Description:       j.GetDescription(),
+				NextScheduledRun:  j.GetNextScheduledRun().GetTimestamp(),
+				AuthorizedActions: testAuthorizedActions,
+			}},
+		},
+		{
+			name: "Get a non-existent Job",
+			req:  &pbs.GetJobRequest{Id: "doesnt-exist"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Missing id",
+			req:  &pbs.GetJobRequest{},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewService(context.Background(), repoFn, sche)
+			require.NoError(t, err, "Couldn't create new job service.")
+
+			got, err := s.GetJob(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tc.err), "GetJob(%+v) got error %v, wanted %v", tc.req, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, cmp.Diff(got, tc.res, protocmp.Transform()), "GetJob(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrap)
+	repo, err := job.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*job.Repository, error) {
+		return repo, nil
+	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
+
+	var wantJobs []*pb.Job
+	for _, name := range []string{"job-1", "job-2"} {
+		j, err := repo.UpsertJob(context.Background(), name, "description")
+		require.NoError(t, err)
+		wantJobs = append(wantJobs, &pb.Job{
+			Id:                j.GetName(),
+			ScopeId:           scope.Global.String(),
+			Scope:             globalScopeInfo,
+			Description:       j.GetDescription(),
+			NextScheduledRun:  j.GetNextScheduledRun().GetTimestamp(),
+			AuthorizedActions: testAuthorizedActions,
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListJobsRequest
+		res  *pbs.ListJobsResponse
+		err  error
+	}{
+		{
+			name: "List all Jobs",
+			req:  &pbs.ListJobsRequest{ScopeId: scope.Global.String()},
+			res:  &pbs.ListJobsResponse{Items: wantJobs},
+		},
+		{
+			name: "Filter to one Job",
+			req:  &pbs.ListJobsRequest{ScopeId: scope.Global.String(), Filter: `"/item/id"=="job-2"`},
+			res:  &pbs.ListJobsResponse{Items: wantJobs[1:]},
+		},
+		{
+			name: "Filter to no Jobs",
+			req:  &pbs.ListJobsRequest{ScopeId: scope.Global.String(), Filter: `"/item/id"=="doesnt-exist"`},
+			res:  &pbs.ListJobsResponse{},
+		},
+		{
+			name: "Non global scope",
+			req:  &pbs.ListJobsRequest{ScopeId: "o_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad filter",
+			req:  &pbs.ListJobsRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewService(context.Background(), repoFn, sche)
+			require.NoError(t, err, "Couldn't create new job service.")
+
+			got, err := s.ListJobs(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tc.err), "ListJobs(%+v) got error %v, wanted %v", tc.req, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(got, tc.res, protocmp.Transform(), protocmp.SortRepeated(func(x, y *pb.Job) bool {
+				return x.GetId() < y.GetId()
+			})), "ListJobs(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestRunNow(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrap)
+	repo, err := job.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*job.Repository, error) {
+		return repo, nil
+	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
+
+	j, err := repo.UpsertJob(context.Background(), "test-job", "test job description", job.WithNextRunIn(time.Hour))
+	require.NoError(t, err)
+
+	s, err := NewService(context.Background(), repoFn, sche)
+	require.NoError(t, err, "Couldn't create new job service.")
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+
+	got, err := s.RunJobNow(ctx, &pbs.RunJobNowRequest{Id: j.GetName()})
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(&pb.Job{
+		Id:                j.GetName(),
+		ScopeId:           scope.Global.String(),
+		Scope:             globalScopeInfo,
+		Description:       j.GetDescription(),
+		AuthorizedActions: testAuthorizedActions,
+	}, got.GetItem(), protocmp.Transform(), protocmp.IgnoreFields(&pb.Job{}, "next_scheduled_run")))
+	assert.True(t, got.GetItem().GetNextScheduledRun().AsTime().Before(j.GetNextScheduledRun().GetTimestamp().AsTime()))
+
+	_, err = s.RunJobNow(ctx, &pbs.RunJobNowRequest{Id: "doesnt-exist"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)))
+
+	_, err = s.RunJobNow(ctx, &pbs.RunJobNowRequest{})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
@@ -0,0 +1,244 @@
+package oplog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/oplog/query"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/oplog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CollectionActions contains the set of actions that can be performed on
+// this collection
+var CollectionActions = action.ActionSet{
+	action.List,
+}
+
+// Service handles request as described by the pbs.OplogServiceServer interface.
+type Service struct {
+	pbs.UnimplementedOplogServiceServer
+
+	repoFn common.OplogRepoFactory
+}
+
+// NewService returns an oplog service which handles oplog related requests to boundary.
+func NewService(ctx context.Context, repoFn common.OplogRepoFactory) (Service, error) {
+	const op = "oplog.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing oplog repository")
+	}
+	return Service{repoFn: repoFn}, nil
+}
+
+var _ pbs.OplogServiceServer = Service{}
+
+// ListOplogEntries implements the interface pbs.OplogServiceServer.
+func (s Service) ListOplogEntries(ctx context.Context, req *pbs.ListOplogEntriesRequest) (*pbs.ListOplogEntriesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	el, err := s.listFromRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(el) == 0 {
+		return &pbs.ListOplogEntriesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	res := perms.Resource{
+		Type:    resource.Oplog,
+		ScopeId: scope.Global.String(),
+	}
+	outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+	finalItems := make([]*pb.Entry, 0, len(el))
+	for _, e := range el {
+		item, err := toProto(ctx, e, handlers.WithOutputFields(&outputFields))
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListOplogEntriesResponse{Items: finalItems}, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, req *pbs.ListOplogEntriesRequest) ([]*query.Entry, error) {
+	opts := []query.Option{
+		query.WithResourcePublicId(req.GetResourceId()),
+		query.WithScopeId(req.GetResourceScopeId()),
+		query.WithTable(req.GetTable()),
+	}
+	// The times have been validated, so they can be parsed without checking
+	// for errors.
+	if req.GetStartTime() != "" {
+		t, _ := time.Parse(time.RFC3339, req.GetStartTime())
+		opts = append(opts, query.WithStartTime(t))
+	}
+	if req.GetEndTime() != "" {
+		t, _ := time.Parse(time.RFC3339, req.GetEndTime())
+		opts = append(opts, query.WithEndTime(t))
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	el, err := repo.ListEntries(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return el, nil
+}
+
+func (s Service) authResult(ctx context.Context, a action.Type) auth.VerifyResults {
+	opts := []auth.Option{auth.WithType(resource.Oplog), auth.WithAction(a), auth.WithScopeId(scope.Global.String())}
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *query.Entry, opt ...handlers.Option) (*pb.Entry, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building oplog entry proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Entry{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.Id
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.ScopeId()
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = timestamppb.New(in.CreateTime)
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.Version
+	}
+	if outputFields.Has(globals.TableField) {
+		out.Table = in.AggregateName
+	}
+	if outputFields.Has(globals.ResourceIdField) {
+		out.ResourceId = in.ResourcePublicId()
+	}
+	if outputFields.Has(globals.OperationField) {
+		if v := in.Metadata["op-type"]; len(v) > 0 {
+			out.Operation = operationName(v[0])
+		}
+	}
+	if outputFields.Has(globals.MetadataField) && len(in.Metadata) > 0 {
+		md := make(map[string]interface{}, len(in.Metadata))
+		for k, vals := range in.Metadata {
+			l := make([]interface{}, 0, len(vals))
+			for _, v := range vals {
+				l = append(l, v)
+			}
+			md[k] = l
+		}
+		var err error
+		if out.Metadata, err = structpb.NewStruct(md); err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("failed building oplog entry metadata: %v", err))
+		}
+	}
+	if outputFields.Has(globals.MessagesField) && len(in.Messages) > 0 {
+		out.Messages = make([]*pb.Message, 0, len(in.Messages))
+		for _, m := range in.Messages {
+			pm, err := messageToProto(m)
+			if err != nil {
+				return nil, err
+			}
+			out.Messages = append(out.Messages, pm)
+		}
+	}
+	return &out, nil
+}
+
+// messageToProto returns the proto of the message. The oplog only records the
+// row which was written, so it is returned as the row before a delete and the
+// row after any other operation.
+func messageToProto(in *query.Message) (*pb.Message, error) {
+	out := &pb.Message{
+		TypeName:       in.TypeName,
+		Operation:      operationName(in.OpType.String()),
+		FieldMaskPaths: in.FieldMaskPaths,
+		NullPaths:      in.SetToNullPaths,
+	}
+	row, err := handlers.ProtoToStruct(in.Message)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, fmt.Sprintf("failed building oplog message proto: %v", err))
+	}
+	switch out.Operation {
+	case "delete", "delete_items":
+		out.Before = row
+	default:
+		out.After = row
+	}
+	return out, nil
+}
+
+// operationName returns the API name of an oplog op type, for example
+// "create_items" for "OP_TYPE_CREATE_ITEMS".
+func operationName(opType string) string {
+	return strings.ToLower(strings.TrimPrefix(opType, "OP_TYPE_"))
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateListRequest(req *pbs.ListOplogEntriesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' when listing."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	var start, end time.Time
+	if req.GetStartTime() != "" {
+		var err error
+		if start, err = time.Parse(time.RFC3339, req.GetStartTime()); err != nil {
+			badFields["start_time"] = "Must be a time in RFC 3339 format."
+		}
+	}
+	if req.GetEndTime() != "" {
+		var err error
+		if end, err = time.Parse(time.RFC3339, req.GetEndTime()); err != nil {
+			badFields["end_time"] = "Must be a time in RFC 3339 format."
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		badFields["end_time"] = "Must be after the start time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
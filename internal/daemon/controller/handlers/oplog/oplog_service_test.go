@@ -0,0 +1,200 @@
+package oplog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/query"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewService(t *testing.T) {
+	repoFn := func() (*query.Repository, error) {
+		return nil, nil
+	}
+
+	_, err := NewService(context.Background(), nil)
+	assert.Error(t, err)
+	_, err = NewService(context.Background(), repoFn)
+	assert.NoError(t, err)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	kms := kms.TestKms(t, conn, wrap)
+	repo, err := query.NewRepository(ctx, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*query.Repository, error) {
+		return repo, nil
+	}
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	role, err := iam.NewRole(org.PublicId)
+	require.NoError(t, err)
+	role, err = iamRepo.CreateRole(ctx, role)
+	require.NoError(t, err)
+	_, err = iamRepo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=*;actions=read"})
+	require.NoError(t, err)
+	_, err = iamRepo.DeleteRoleGrants(ctx, role.PublicId, role.Version+1, []string{"id=*;type=*;actions=read"})
+	require.NoError(t, err)
+
+	s, err := NewService(ctx, repoFn)
+	require.NoError(t, err, "Couldn't create new oplog service.")
+	authCtx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+
+	t.Run("resource", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(authCtx, &pbs.ListOplogEntriesRequest{ScopeId: scope.Global.String(), ResourceId: role.PublicId})
+		require.NoError(err)
+		require.Len(got.GetItems(), 3)
+
+		// newest first: the deleted grants, the added grants, then the role's
+		// creation.
+		deleted, added, created := got.GetItems()[0], got.GetItems()[1], got.GetItems()[2]
+		for _, e := range got.GetItems() {
+			assert.NotZero(e.GetId())
+			assert.Equal(org.PublicId, e.GetScopeId())
+			assert.Equal("iam_role", e.GetTable())
+			assert.Equal(role.PublicId, e.GetResourceId())
+			assert.NotNil(e.GetCreatedTime())
+			assert.NotNil(e.GetMetadata())
+		}
+		assert.Equal("delete", deleted.GetOperation())
+		assert.Equal("create", added.GetOperation())
+		assert.Equal("create", created.GetOperation())
+
+		require.Len(added.GetMessages(), 2)
+		assert.Equal("update", added.GetMessages()[0].GetOperation())
+		assert.Equal([]string{"Version"}, added.GetMessages()[0].GetFieldMaskPaths())
+		grant := added.GetMessages()[1]
+		assert.Equal("iam_role_grant", grant.GetTypeName())
+		assert.Equal("create_items", grant.GetOperation())
+		assert.Nil(grant.GetBefore())
+		assert.Equal("id=*;type=*;actions=read", grant.GetAfter().GetFields()["raw_grant"].GetStringValue())
+
+		require.Len(deleted.GetMessages(), 2)
+		grant = deleted.GetMessages()[1]
+		assert.Equal("iam_role_grant", grant.GetTypeName())
+		assert.Equal("delete_items", grant.GetOperation())
+		assert.Nil(grant.GetAfter())
+		assert.Equal(role.PublicId, grant.GetBefore().GetFields()["role_id"].GetStringValue())
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogEntries(authCtx, &pbs.ListOplogEntriesRequest{
+			ScopeId:         scope.Global.String(),
+			ResourceScopeId: org.PublicId,
+			Table:           "iam_role",
+			StartTime:       time.Now().Add(-time.Hour).Format(time.RFC3339),
+			EndTime:         time.Now().Add(time.Hour).Format(time.RFC3339),
+			Filter:          `"/item/operation"=="delete"`,
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal(role.PublicId, got.GetItems()[0].GetResourceId())
+
+		got, err = s.ListOplogEntries(authCtx, &pbs.ListOplogEntriesRequest{
+			ScopeId:    scope.Global.String(),
+			ResourceId: role.PublicId,
+			StartTime:  time.Now().Add(time.Hour).Format(time.RFC3339),
+		})
+		require.NoError(err)
+		assert.Empty(got.GetItems())
+	})
+
+	badCases := []struct {
+		name string
+		req  *pbs.ListOplogEntriesRequest
+	}{
+		{
+			name: "Non global scope",
+			req:  &pbs.ListOplogEntriesRequest{ScopeId: org.PublicId},
+		},
+		{
+			name: "Bad filter",
+			req:  &pbs.ListOplogEntriesRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`},
+		},
+		{
+			name: "Bad start time",
+			req:  &pbs.ListOplogEntriesRequest{ScopeId: scope.Global.String(), StartTime: "yesterday"},
+		},
+		{
+			name: "Bad end time",
+			req:  &pbs.ListOplogEntriesRequest{ScopeId: scope.Global.String(), EndTime: "2022-01-01"},
+		},
+		{
+			name: "End time before start time",
+			req: &pbs.ListOplogEntriesRequest{
+				ScopeId:   scope.Global.String(),
+				StartTime: "2022-01-02T00:00:00Z",
+				EndTime:   "2022-01-01T00:00:00Z",
+			},
+		},
+	}
+	for _, tc := range badCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.ListOplogEntries(authCtx, tc.req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "ListOplogEntries(%+v) got error %v, wanted %v", tc.req, err, codes.InvalidArgument)
+		})
+	}
+}
+
+func Test_messageToProto(t *testing.T) {
+	row := &iamstore.Role{PublicId: "r_1234567890", Name: "auditors"}
+	tests := []struct {
+		opType     oplog.OpType
+		wantOp     string
+		wantBefore bool
+	}{
+		{opType: oplog.OpType_OP_TYPE_CREATE, wantOp: "create"},
+		{opType: oplog.OpType_OP_TYPE_UPDATE, wantOp: "update"},
+		{opType: oplog.OpType_OP_TYPE_CREATE_ITEMS, wantOp: "create_items"},
+		{opType: oplog.OpType_OP_TYPE_DELETE, wantOp: "delete", wantBefore: true},
+		{opType: oplog.OpType_OP_TYPE_DELETE_ITEMS, wantOp: "delete_items", wantBefore: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantOp, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := messageToProto(&query.Message{
+				TypeName:       "iam_role",
+				OpType:         tt.opType,
+				FieldMaskPaths: []string{"Name"},
+				Message:        row,
+			})
+			require.NoError(err)
+			assert.Equal("iam_role", got.GetTypeName())
+			assert.Equal(tt.wantOp, got.GetOperation())
+			assert.Equal([]string{"Name"}, got.GetFieldMaskPaths())
+			want, other := got.GetAfter(), got.GetBefore()
+			if tt.wantBefore {
+				want, other = other, want
+			}
+			assert.Nil(other)
+			require.NotNil(want)
+			assert.Equal("r_1234567890", want.GetFields()["public_id"].GetStringValue())
+			assert.Equal("auditors", want.GetFields()["name"].GetStringValue())
+		})
+	}
+}
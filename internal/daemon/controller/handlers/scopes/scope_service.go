@@ -16,8 +16,11 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentialstores"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/events"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/jobs"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/oplog"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
@@ -27,16 +30,29 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+const (
+	keyVersionIdField = "key_version_id"
+	kmsNameField      = "kms_name"
+
+	keyVersionDestructionPending   = "pending"
+	keyVersionDestructionCompleted = "completed"
+)
+
 var (
 	maskManager handlers.MaskManager
 
@@ -47,6 +63,24 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RotateKeys,
+		action.ListKeys,
+		action.DestroyKeyVersion,
+		action.ListKeyVersionDestructionJobs,
+		action.SetKms,
+	}
+
+	// globalIdActions contains the set of actions that can be performed on
+	// the global scope, which can't be deleted
+	globalIdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.RotateKeys,
+		action.ListKeys,
+		action.DestroyKeyVersion,
+		action.ListKeyVersionDestructionJobs,
+		action.SetKms,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -60,7 +94,10 @@ var (
 		scope.Global.String(): {
 			resource.AuthMethod: authmethods.CollectionActions,
 			resource.AuthToken:  authtokens.CollectionActions,
+			resource.Event:      events.CollectionActions,
 			resource.Group:      groups.CollectionActions,
+			resource.Job:        jobs.CollectionActions,
+			resource.Oplog:      oplog.CollectionActions,
 			resource.Role:       roles.CollectionActions,
 			resource.Scope:      CollectionActions,
 			resource.User:       users.CollectionActions,
@@ -98,16 +135,20 @@ func init() {
 type Service struct {
 	pbs.UnimplementedScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn    common.IamRepoFactory
+	scheduler *scheduler.Scheduler
 }
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, scheduler *scheduler.Scheduler) (Service, error) {
 	const op = "scopes.(Service).NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if scheduler == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing scheduler")
+	}
+	return Service{repoFn: repo, scheduler: scheduler}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -228,7 +269,7 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	act := IdActions
 	// Can't delete global so elide it
 	if p.GetPublicId() == scope.Global.String() {
-		act = act[0:3]
+		act = globalIdActions
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), act).Strings()))
@@ -355,6 +396,148 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// RotateScopeKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateScopeKeys(ctx context.Context, req *pbs.RotateScopeKeysRequest) (*pbs.RotateScopeKeysResponse, error) {
+	if err := validateRotateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.rotateKeysInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.keyActionOutput(ctx, authResults, p)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RotateScopeKeysResponse{Item: item}, nil
+}
+
+// SetScopeKms implements the interface pbs.ScopeServiceServer.
+func (s Service) SetScopeKms(ctx context.Context, req *pbs.SetScopeKmsRequest) (*pbs.SetScopeKmsResponse, error) {
+	if err := validateSetKmsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetKms)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.setKmsInRepo(ctx, req.GetId(), req.GetKmsName())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.keyActionOutput(ctx, authResults, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetScopeKmsResponse{Item: item}, nil
+}
+
+// keyActionOutput builds the scope returned by the actions changing the keys of
+// a scope.
+func (s Service) keyActionOutput(ctx context.Context, authResults auth.VerifyResults, p *iam.Scope) (*pb.Scope, error) {
+	const op = "scopes.(Service).keyActionOutput"
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	act := IdActions
+	if p.GetPublicId() == scope.Global.String() {
+		act = globalIdActions
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), act).Strings()))
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[p.Type], p.GetPublicId(), "")
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+	}
+
+	return ToProto(ctx, p, outputOpts...)
+}
+
+// ListKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) ListKeys(ctx context.Context, req *pbs.ListKeysRequest) (*pbs.ListKeysResponse, error) {
+	if err := validateListKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	keys, err := s.listKeysFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*pb.Key, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, keyToProto(k, authResults.Scope))
+	}
+	return &pbs.ListKeysResponse{Items: items}, nil
+}
+
+// DestroyKeyVersion implements the interface pbs.ScopeServiceServer.
+func (s Service) DestroyKeyVersion(ctx context.Context, req *pbs.DestroyKeyVersionRequest) (*pbs.DestroyKeyVersionResponse, error) {
+	if err := validateDestroyKeyVersionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DestroyKeyVersion)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	destroyed, err := s.destroyKeyVersionInRepo(ctx, req.GetId(), req.GetKeyVersionId())
+	if err != nil {
+		return nil, err
+	}
+	state := keyVersionDestructionPending
+	if destroyed {
+		state = keyVersionDestructionCompleted
+	}
+	return &pbs.DestroyKeyVersionResponse{State: state}, nil
+}
+
+// ListKeyVersionDestructionJobs implements the interface pbs.ScopeServiceServer.
+func (s Service) ListKeyVersionDestructionJobs(ctx context.Context, req *pbs.ListKeyVersionDestructionJobsRequest) (*pbs.ListKeyVersionDestructionJobsResponse, error) {
+	if err := validateListKeyVersionDestructionJobsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeyVersionDestructionJobs)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	jobs, err := s.listKeyVersionDestructionJobsFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*pb.KeyVersionDestructionJob, 0, len(jobs))
+	for _, j := range jobs {
+		items = append(items, &pb.KeyVersionDestructionJob{
+			KeyVersionId:   j.KeyVersionId,
+			Scope:          authResults.Scope,
+			CreatedTime:    timestamppb.New(j.CreateTime),
+			CompletedCount: j.CompletedCount,
+			TotalCount:     j.TotalCount,
+		})
+	}
+	return &pbs.ListKeyVersionDestructionJobsResponse{Items: items}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -490,6 +673,109 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
+func (s Service) rotateKeysInRepo(ctx context.Context, id string) (*iam.Scope, error) {
+	const op = "scopes.(Service).rotateKeysInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.RotateScopeKeys(ctx, id); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", id)
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to rotate scope keys"))
+	}
+	// Existing data is re-encrypted with the new key versions in the
+	// background, so have the job start as soon as possible rather than
+	// waiting for its next scheduled run. The keys have been rotated, so a
+	// failure to do so only delays the re-encryption until that run.
+	if err := s.scheduler.UpdateJobNextRunInAtLeast(ctx, kmsjob.RewrapJobName, 0, scheduler.WithRunNow(true)); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to trigger the rewrap job, data will be re-encrypted on its next scheduled run", "scope_id", id))
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) setKmsInRepo(ctx context.Context, id, kmsName string) (*iam.Scope, error) {
+	const op = "scopes.(Service).setKmsInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.SetScopeKms(ctx, id, kmsName); err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", id)
+		case errors.Match(errors.T(errors.KeyNotFound), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{
+				kmsNameField: fmt.Sprintf("No KMS named %q is configured.", kmsName),
+			})
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to set scope kms"))
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) listKeysFromRepo(ctx context.Context, id string) ([]*kms.Key, error) {
+	const op = "scopes.(Service).listKeysFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := repo.ListScopeKeys(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", id)
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list scope keys"))
+	}
+	return keys, nil
+}
+
+func (s Service) destroyKeyVersionInRepo(ctx context.Context, id, keyVersionId string) (bool, error) {
+	const op = "scopes.(Service).destroyKeyVersionInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	destroyed, err := repo.DestroyScopeKeyVersion(ctx, id, keyVersionId)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return false, handlers.NotFoundErrorf("Key version %q doesn't exist in scope %q.", keyVersionId, id)
+		case errors.Match(errors.T(errors.InvalidParameter), err):
+			return false, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{
+				keyVersionIdField: "The current version of a key, or a version encrypting data which can't be re-encrypted, can't be destroyed.",
+			})
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to destroy scope key version"))
+	}
+	if !destroyed {
+		// The version is destroyed by the rewrap job once the data it
+		// encrypts has been re-encrypted, so have the job start as soon as
+		// possible rather than waiting for its next scheduled run.
+		if err := s.scheduler.UpdateJobNextRunInAtLeast(ctx, kmsjob.RewrapJobName, 0, scheduler.WithRunNow(true)); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to trigger the rewrap job, the key version will be destroyed on its next scheduled run", "scope_id", id, "key_version_id", keyVersionId))
+		}
+	}
+	return destroyed, nil
+}
+
+func (s Service) listKeyVersionDestructionJobsFromRepo(ctx context.Context, id string) ([]*kms.KeyVersionDestructionJob, error) {
+	const op = "scopes.(Service).listKeyVersionDestructionJobsFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := repo.ListScopeKeyVersionDestructionJobs(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", id)
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list scope key version destruction jobs"))
+	}
+	return jobs, nil
+}
+
 func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -541,6 +827,26 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
+func keyToProto(in *kms.Key, scp *pb.ScopeInfo) *pb.Key {
+	out := &pb.Key{
+		Id:          in.Id,
+		Scope:       scp,
+		Purpose:     in.Purpose.String(),
+		CreatedTime: timestamppb.New(in.CreateTime),
+		Type:        string(in.Type),
+		KmsName:     in.WrapperName,
+	}
+	for _, v := range in.Versions {
+		out.Versions = append(out.Versions, &pb.KeyVersion{
+			Id:             v.Id,
+			Version:        v.Version,
+			CreatedTime:    timestamppb.New(v.CreateTime),
+			ReferenceCount: uint64(v.References),
+		})
+	}
+	return out
+}
+
 func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Scope, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -709,6 +1015,72 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	return nil
 }
 
+func validateRotateKeysRequest(req *pbs.RotateScopeKeysRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateSetKmsRequest(req *pbs.SetScopeKmsRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListKeysRequest(req *pbs.ListKeysRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateDestroyKeyVersionRequest(req *pbs.DestroyKeyVersionRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if req.GetKeyVersionId() == "" {
+		badFields[keyVersionIdField] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListKeyVersionDestructionJobsRequest(req *pbs.ListKeyVersionDestructionJobsRequest) error {
+	badFields := map[string]string{}
+	validateScopeId(req.GetId(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+// validateScopeId adds an entry to badFields if id isn't the global scope or a
+// well formed org or project id.
+func validateScopeId(id string, badFields map[string]string) {
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Org.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Project.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	badFields := map[string]string{}
 	id := req.GetId()
@@ -16,6 +16,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"google.golang.org/genproto/protobuf/field_mask"
@@ -30,9 +31,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "rotate-keys", "list-keys", "destroy-key-version", "list-key-version-destruction-jobs", "set-kms"}
 
-func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), *scheduler.Scheduler) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -40,6 +41,7 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
 
 	oRes, pRes := iam.TestScopes(t, iamRepo)
 
@@ -56,7 +58,7 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	require.NoError(t, err)
 	pRes, _, err = repo.UpdateScope(context.Background(), pRes, 1, []string{"Name", "Description"})
 	require.NoError(t, err)
-	return oRes, pRes, repoFn
+	return oRes, pRes, repoFn, sche
 }
 
 var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
@@ -71,12 +73,27 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"events": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("tail"),
+		},
+	},
 	"groups": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
 		},
 	},
+	"jobs": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("list"),
+		},
+	},
+	"oplogs": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -98,6 +115,7 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 	"workers": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create:worker-led"),
+			structpb.NewStringValue("create:controller-led"),
 			structpb.NewStringValue("list"),
 		},
 	},
@@ -180,7 +198,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 }
 
 func TestGet(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
 	toMerge := &pbs.GetScopeRequest{
 		Id: proj.GetPublicId(),
 	}
@@ -268,7 +286,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetScopeRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, sche)
 			require.NoError(err, "Couldn't create new project service.")
 
 			got, gErr := s.GetScope(auth.DisabledAuthTestContext(repoFn, tc.scopeId), req)
@@ -288,6 +306,7 @@ func TestList(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	sche := scheduler.TestScheduler(t, conn, wrap)
 	repo, err := repoFn()
 	require.NoError(t, err)
 
@@ -349,7 +368,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, sche)
 			require.NoError(err, "Couldn't create new role service.")
 
 			// Test with non-anonymous listing first
@@ -463,7 +482,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, sche)
 			require.NoError(err, "Couldn't create new role service.")
 
 			// Test with non-anonymous listing first
@@ -490,9 +509,9 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, sche)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	cases := []struct {
@@ -564,9 +583,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, sche)
 	require.NoError(err, "Error when getting new scopes service")
 	ctx := auth.DisabledAuthTestContext(repoFn, org.GetPublicId())
 	req := &pbs.DeleteScopeRequest{
@@ -589,9 +608,236 @@ func TestDelete_twice(t *testing.T) {
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
 }
 
+func TestRotateKeys(t *testing.T) {
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, sche)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.RotateScopeKeysRequest
+		wantId  string
+		err     error
+	}{
+		{
+			name:    "Rotate an existing project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateScopeKeysRequest{Id: proj.GetPublicId()},
+			wantId:  proj.GetPublicId(),
+		},
+		{
+			name:    "Rotate an existing org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.RotateScopeKeysRequest{Id: org.GetPublicId()},
+			wantId:  org.GetPublicId(),
+		},
+		{
+			name:    "Rotate global",
+			scopeId: scope.Global.String(),
+			req:     &pbs.RotateScopeKeysRequest{Id: scope.Global.String()},
+			wantId:  scope.Global.String(),
+		},
+		{
+			name:    "Rotate a non existing project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateScopeKeysRequest{Id: "p_doesntexis"},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Bad id formatting",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateScopeKeysRequest{Id: "bad_format"},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.RotateScopeKeys(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RotateScopeKeys(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.wantId, got.GetItem().GetId())
+		})
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, sche)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.ListKeysRequest
+		err     error
+	}{
+		{
+			name:    "List keys of a project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: proj.GetPublicId()},
+		},
+		{
+			name:    "List keys of an org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.ListKeysRequest{Id: org.GetPublicId()},
+		},
+		{
+			name:    "List keys of global",
+			scopeId: scope.Global.String(),
+			req:     &pbs.ListKeysRequest{Id: scope.Global.String()},
+		},
+		{
+			name:    "List keys of a non existing project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: "p_doesntexis"},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Bad id formatting",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: "bad_format"},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ListKeys(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListKeys(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			require.NotEmpty(got.GetItems())
+			root := got.GetItems()[0]
+			assert.Equal("kek", root.GetType())
+			assert.Equal("rootKey", root.GetPurpose())
+			assert.Equal(tc.req.GetId(), root.GetScope().GetId())
+			for _, k := range got.GetItems() {
+				assert.NotEmpty(k.GetVersions(), "key %s has no versions", k.GetId())
+			}
+		})
+	}
+}
+
+func TestDestroyKeyVersion(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, _, repoFn, sche := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, sche)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(repoFn, scope.Global.String())
+
+	keys, err := s.ListKeys(ctx, &pbs.ListKeysRequest{Id: org.GetPublicId()})
+	require.NoError(err)
+	require.NotEmpty(keys.GetItems())
+	root := keys.GetItems()[0]
+	require.Len(root.GetVersions(), 1)
+	oldRootVersion := root.GetVersions()[0].GetId()
+
+	// The current version of a key can't be destroyed
+	_, err = s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: oldRootVersion})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+
+	_, err = s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: org.GetPublicId()})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+
+	_, err = s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: "krkv_doesntexist"})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)))
+
+	_, err = s.RotateScopeKeys(ctx, &pbs.RotateScopeKeysRequest{Id: org.GetPublicId()})
+	require.NoError(err)
+
+	got, err := s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: oldRootVersion})
+	require.NoError(err)
+	assert.Equal("completed", got.GetState())
+
+	keys, err = s.ListKeys(ctx, &pbs.ListKeysRequest{Id: org.GetPublicId()})
+	require.NoError(err)
+	root = keys.GetItems()[0]
+	require.Len(root.GetVersions(), 1)
+	assert.NotEqual(oldRootVersion, root.GetVersions()[0].GetId())
+	// every data key version is now encrypted by the remaining root key version
+	var dataVersions uint64
+	for _, k := range keys.GetItems()[1:] {
+		dataVersions += uint64(len(k.GetVersions()))
+	}
+	assert.Equal(dataVersions, root.GetVersions()[0].GetReferenceCount())
+
+	jobs, err := s.ListKeyVersionDestructionJobs(ctx, &pbs.ListKeyVersionDestructionJobsRequest{Id: org.GetPublicId()})
+	require.NoError(err)
+	assert.Empty(jobs.GetItems())
+}
+
+func TestSetKms(t *testing.T) {
+	org, _, repoFn, sche := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, sche)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name string
+		req  *pbs.SetScopeKmsRequest
+		err  error
+	}{
+		{
+			name: "Set the root kms of an org",
+			req:  &pbs.SetScopeKmsRequest{Id: org.GetPublicId()},
+		},
+		{
+			name: "Set the root kms of global",
+			req:  &pbs.SetScopeKmsRequest{Id: scope.Global.String()},
+		},
+		{
+			name: "Set an unconfigured kms",
+			req:  &pbs.SetScopeKmsRequest{Id: org.GetPublicId(), KmsName: "doesntexist"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Set the kms of a non existing org",
+			req:  &pbs.SetScopeKmsRequest{Id: "o_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad id formatting",
+			req:  &pbs.SetScopeKmsRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.SetScopeKms(auth.DisabledAuthTestContext(repoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetScopeKms(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetId(), got.GetItem().GetId())
+
+			keys, err := s.ListKeys(auth.DisabledAuthTestContext(repoFn, scope.Global.String()), &pbs.ListKeysRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(keys.GetItems()[0].GetKmsName())
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
+	defaultOrg, defaultProj, repoFn, sche := createDefaultScopesAndRepo(t)
 	defaultProjCreated := defaultProj.GetCreateTime().GetTimestamp().AsTime()
 	toMerge := &pbs.CreateScopeRequest{}
 
@@ -774,7 +1020,7 @@ func TestCreate(t *testing.T) {
 				req := proto.Clone(toMerge).(*pbs.CreateScopeRequest)
 				proto.Merge(req, tc.req)
 
-				s, err := scopes.NewService(repoFn)
+				s, err := scopes.NewService(repoFn, sche)
 				require.NoError(err, "Error when getting new project service.")
 
 				if name != "" {
@@ -848,8 +1094,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
-	tested, err := scopes.NewService(repoFn)
+	org, proj, repoFn, sche := createDefaultScopesAndRepo(t)
+	tested, err := scopes.NewService(repoFn, sche)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	iamRepo, err := repoFn()
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/version"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -33,9 +34,10 @@ type attributeFunc func(interface{}) Attributes
 type setAttributeFunc func(target.Target, *pb.Target) error
 
 type registryEntry struct {
-	maskManager handlers.MaskManager
-	attrFunc    attributeFunc
-	setAttrFunc setAttributeFunc
+	maskManager            handlers.MaskManager
+	attrFunc               attributeFunc
+	setAttrFunc            setAttributeFunc
+	requiredWorkerFeatures []version.Feature
 }
 
 type registry struct {
@@ -91,14 +93,29 @@ func (r registry) setAttributes(s subtypes.Subtype, in target.Target, out *pb.Ta
 	return re.setAttrFunc(in, out)
 }
 
+// requiredWorkerFeatures returns the features a worker must support to proxy
+// sessions for targets of the given subtype. An error is returned if the
+// provided subtype is not registered.
+func (r *registry) requiredWorkerFeatures(s subtypes.Subtype) ([]version.Feature, error) {
+	re, err := r.get(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return re.requiredWorkerFeatures, nil
+}
+
 var subtypeRegistry = registry{}
 
-// Register registers a subtype for used by the service handler.
-func Register(s subtypes.Subtype, maskManager handlers.MaskManager, af attributeFunc, sf setAttributeFunc) {
+// Register registers a subtype for used by the service handler. Any provided
+// features are required of a worker for it to be selected to proxy sessions
+// for targets of the subtype.
+func Register(s subtypes.Subtype, maskManager handlers.MaskManager, af attributeFunc, sf setAttributeFunc, requiredWorkerFeatures ...version.Feature) {
 	if _, existed := subtypeRegistry.LoadOrStore(s, &registryEntry{
-		maskManager: maskManager,
-		attrFunc:    af,
-		setAttrFunc: sf,
+		maskManager:            maskManager,
+		attrFunc:               af,
+		setAttrFunc:            sf,
+		requiredWorkerFeatures: requiredWorkerFeatures,
 	}); existed {
 		panic(fmt.Sprintf("subtype %s already registered", s))
 	}
@@ -35,6 +35,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/version"
 	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/mitchellh/pointerstructure"
@@ -821,6 +822,17 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 			"No workers are available to handle this session, or all have been filtered.")
 	}
 
+	requiredFeatures, err := subtypeRegistry.requiredWorkerFeatures(t.GetType())
+	if err != nil {
+		return nil, err
+	}
+	selectedWorkers = workerList(selectedWorkers).supporting(requiredFeatures...)
+	if len(selectedWorkers) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(
+			codes.FailedPrecondition,
+			"No workers which support the features required by this target are available to handle this session.")
+	}
+
 	requestedId := req.GetHostId()
 	staticHostRepo, err := s.staticHostRepoFn()
 	if err != nil {
@@ -1938,6 +1950,28 @@ func (w workerList) workerInfos() []*pb.WorkerInfo {
 	return ret
 }
 
+// supporting returns a new workerList containing only the workers from the
+// original workerList which support all of the provided features.
+func (w workerList) supporting(features ...version.Feature) workerList {
+	if len(features) == 0 {
+		return w
+	}
+	var ret []*server.Worker
+	for _, worker := range w {
+		supported := true
+		for _, f := range features {
+			if !worker.SupportsFeature(f) {
+				supported = false
+				break
+			}
+		}
+		if supported {
+			ret = append(ret, worker)
+		}
+	}
+	return ret
+}
+
 // filtered returns a new workerList where all elements contained in it are the
 // ones which from the original workerList that pass the evaluator's evaluation.
 func (w workerList) filtered(eval *bexpr.Evaluator) (workerList, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/version"
 	"github.com/hashicorp/go-bexpr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,17 @@ func TestWorkerList_Addresses(t *testing.T) {
 	assert.Equal(t, workerInfos, tested.workerInfos())
 }
 
+func TestWorkerList_Supporting(t *testing.T) {
+	legacy := server.NewWorker(scope.Global.String(), server.WithName("legacy"))
+	reporting := server.NewWorker(scope.Global.String(), server.WithName("reporting"))
+	reporting.ReleaseVersion = "0.9.1"
+	workers := workerList{legacy, reporting}
+
+	assert.Equal(t, workers, workers.supporting())
+	assert.Equal(t, workerList{legacy}, workers.supporting(version.TcpProxyFeature))
+	assert.Empty(t, workers.supporting(version.TcpProxyFeature, version.RotateAuthFeature))
+}
+
 func TestWorkerList_Filter(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
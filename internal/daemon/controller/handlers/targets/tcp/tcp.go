@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/target/tcp"
 	"github.com/hashicorp/boundary/internal/target/tcp/store"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/version"
 )
 
 const defaultPortField = "attributes.default_port"
@@ -84,5 +85,5 @@ func init() {
 		panic(err)
 	}
 
-	targets.Register(tcp.Subtype, maskManager, newAttribute, setAttributes)
+	targets.Register(tcp.Subtype, maskManager, newAttribute, setAttributes, version.TcpProxyFeature)
 }
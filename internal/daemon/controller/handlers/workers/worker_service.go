@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -44,12 +45,15 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RotateAuth,
+		action.RevokeActivationToken,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
 	// this collection
 	CollectionActions = action.ActionSet{
 		action.CreateWorkerLed,
+		action.CreateControllerLed,
 		action.List,
 	}
 )
@@ -216,7 +220,7 @@ func (s Service) CreateWorkerLed(ctx context.Context, req *pbs.CreateWorkerLedRe
 	if err := proto.Unmarshal(reqBytes, creds); err != nil {
 		return nil, fmt.Errorf("%s: error unmarshaling node_credentials_token: %w", op, err)
 	}
-	created, err := s.createInRepo(ctx, req.GetItem(), creds)
+	created, err := s.createInRepo(ctx, req.GetItem(), server.WithFetchNodeCredentialsRequest(creds))
 	if err != nil {
 		return nil, fmt.Errorf("%s: error creating worker: %w", op, err)
 	}
@@ -243,6 +247,47 @@ func (s Service) CreateWorkerLed(ctx context.Context, req *pbs.CreateWorkerLedRe
 	return &pbs.CreateWorkerLedResponse{Item: item}, nil
 }
 
+// CreateControllerLed implements the interface pbs.WorkerServiceServer.
+func (s Service) CreateControllerLed(ctx context.Context, req *pbs.CreateControllerLedRequest) (*pbs.CreateControllerLedResponse, error) {
+	const op = "workers.(Service).CreateControllerLed"
+
+	if err := validateCreateControllerLedRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.CreateControllerLed)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	created, err := s.createInRepo(ctx, req.GetItem(),
+		server.WithCreateControllerLedActivationToken(true),
+		server.WithActivationTokenTtl(time.Duration(req.GetItem().GetActivationTokenTtl().GetValue())*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error creating worker: %w", op, err)
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, created.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, created, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateControllerLedResponse{Item: item}, nil
+}
+
 // DeleteWorker implements the interface pbs.WorkerServiceServer.
 func (s Service) DeleteWorker(ctx context.Context, req *pbs.DeleteWorkerRequest) (*pbs.DeleteWorkerResponse, error) {
 	if err := validateDeleteRequest(req); err != nil {
@@ -297,6 +342,82 @@ func (s Service) UpdateWorker(ctx context.Context, req *pbs.UpdateWorkerRequest)
 	return &pbs.UpdateWorkerResponse{Item: item}, nil
 }
 
+// RotateWorkerAuth implements the interface pbs.WorkerServiceServer.
+func (s Service) RotateWorkerAuth(ctx context.Context, req *pbs.RotateWorkerAuthRequest) (*pbs.RotateWorkerAuthResponse, error) {
+	const op = "workers.(Service).RotateWorkerAuth"
+
+	if err := validateRotateAuthRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateAuth)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.rotateAuthInRepo(ctx, req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RotateWorkerAuthResponse{Item: item}, nil
+}
+
+// RevokeWorkerActivationToken implements the interface pbs.WorkerServiceServer.
+func (s Service) RevokeWorkerActivationToken(ctx context.Context, req *pbs.RevokeWorkerActivationTokenRequest) (*pbs.RevokeWorkerActivationTokenResponse, error) {
+	const op = "workers.(Service).RevokeWorkerActivationToken"
+
+	if err := validateRevokeActivationTokenRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeActivationToken)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.revokeActivationTokenInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, w.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, w, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RevokeWorkerActivationTokenResponse{Item: item}, nil
+}
+
 func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*server.Worker, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -327,7 +448,7 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*server.Worker, er
 	return w, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, worker *pb.Worker, creds *types.FetchNodeCredentialsRequest) (*server.Worker, error) {
+func (s Service) createInRepo(ctx context.Context, worker *pb.Worker, opt ...server.Option) (*server.Worker, error) {
 	const op = "workers.(Service).createInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
@@ -338,7 +459,7 @@ func (s Service) createInRepo(ctx context.Context, worker *pb.Worker, creds *typ
 		server.WithName(worker.GetName().GetValue()),
 		server.WithDescription(worker.GetDescription().GetValue()),
 	)
-	retWorker, err := repo.CreateWorker(ctx, newWorker, server.WithFetchNodeCredentialsRequest(creds))
+	retWorker, err := repo.CreateWorker(ctx, newWorker, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create worker"))
 	}
@@ -390,6 +511,41 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return out, nil
 }
 
+func (s Service) rotateAuthInRepo(ctx context.Context, id string, version uint32) (*server.Worker, error) {
+	const op = "workers.(Service).rotateAuthInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.RequestWorkerAuthRotation(ctx, id, version)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("Worker %q doesn't exist or incorrect version provided.", id)
+		case errors.Match(errors.T(errors.InvalidParameter), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{globals.IdField: "Credentials can only be rotated for pki workers."})
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to request worker auth rotation"))
+	}
+	return out, nil
+}
+
+func (s Service) revokeActivationTokenInRepo(ctx context.Context, id string) (*server.Worker, error) {
+	const op = "workers.(Service).revokeActivationTokenInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.RevokeWorkerActivationTokens(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Worker %q doesn't exist or has no activation token which can be revoked.", id)
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke worker activation token"))
+	}
+	return out, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -401,7 +557,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Worker), auth.WithAction(a)}
 	switch a {
-	case action.List, action.CreateWorkerLed:
+	case action.List, action.CreateWorkerLed, action.CreateControllerLed:
 		parentId = id
 	default:
 		w, err := repo.LookupWorker(ctx, id)
@@ -456,11 +612,12 @@ func toProto(ctx context.Context, in *server.Worker, opt ...handlers.Option) (*p
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 		if in.Type == KmsWorkerType && out.AuthorizedActions != nil {
-			// KMS workers cannot be updated through the API
+			// KMS workers cannot be updated, have their credentials rotated
+			// or have activation tokens through the API
 			allActions := out.AuthorizedActions
 			out.AuthorizedActions = make([]string, 0, len(allActions))
 			for _, act := range allActions {
-				if act != action.Update.String() {
+				if act != action.Update.String() && act != action.RotateAuth.String() && act != action.RevokeActivationToken.String() {
 					out.AuthorizedActions = append(out.AuthorizedActions, act)
 				}
 			}
@@ -478,6 +635,30 @@ func toProto(ctx context.Context, in *server.Worker, opt ...handlers.Option) (*p
 	if outputFields.Has(globals.ActiveConnectionCountField) {
 		out.ActiveConnectionCount = &wrapperspb.UInt32Value{Value: in.ActiveConnectionCount()}
 	}
+	if outputFields.Has(globals.CertificateExpirationField) && in.GetCertificateExpiration() != nil {
+		out.CertificateExpiration = in.GetCertificateExpiration().GetTimestamp()
+	}
+	if outputFields.Has(globals.LastRotationTimeField) && in.GetLastRotationTime() != nil {
+		out.LastRotationTime = in.GetLastRotationTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.LastRotationErrorField) {
+		out.LastRotationError = in.GetLastRotationError()
+	}
+	if outputFields.Has(globals.RotateAuthRequestedField) {
+		out.RotateAuthRequested = in.GetRotateAuthRequested()
+	}
+	if outputFields.Has(globals.ReleaseVersionField) && in.GetReleaseVersion() != "" {
+		out.ReleaseVersion = in.GetReleaseVersion()
+	}
+	if outputFields.Has(globals.FeaturesField) && len(in.GetFeatures()) > 0 {
+		out.Features = in.GetFeatures()
+	}
+	if outputFields.Has(globals.ControllerGeneratedActivationTokenField) && in.GetControllerGeneratedActivationToken() != "" {
+		out.ControllerGeneratedActivationToken = in.GetControllerGeneratedActivationToken()
+	}
+	if outputFields.Has(globals.ActivationTokenExpirationField) && in.GetActivationTokenExpiration() != nil {
+		out.ActivationTokenExpiration = in.GetActivationTokenExpiration().GetTimestamp()
+	}
 	if outputFields.Has(globals.ConfigTagsField) && len(in.GetConfigTags()) > 0 {
 		var err error
 		out.ConfigTags, err = tagsToMapProto(in.GetConfigTags())
@@ -518,9 +699,9 @@ func tagsToMapProto(in map[string][]string) (map[string]*structpb.ListValue, err
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetWorkerRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, server.WorkerPrefix)
 }
@@ -570,45 +751,111 @@ func validateUpdateRequest(req *pbs.UpdateWorkerRequest) error {
 	}, server.WorkerPrefix)
 }
 
+func validateRotateAuthRequest(req *pbs.RotateWorkerAuthRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), server.WorkerPrefix) {
+		badFields[globals.IdField] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRevokeActivationTokenRequest(req *pbs.RevokeWorkerActivationTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), server.WorkerPrefix) {
+		badFields[globals.IdField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateCreateRequest(req *pbs.CreateWorkerLedRequest) error {
-	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+	return validateCreateItem(req.GetItem(), func(badFields map[string]string) {
+		if req.GetItem().WorkerGeneratedAuthToken == nil {
+			badFields[globals.WorkerGeneratedAuthTokenField] = "Cannot be empty."
+		}
+		if req.GetItem().ActivationTokenTtl != nil {
+			badFields[globals.ActivationTokenTtlField] = "This field can only be provided with the create:controller-led action."
+		}
+	})
+}
+
+func validateCreateControllerLedRequest(req *pbs.CreateControllerLedRequest) error {
+	return validateCreateItem(req.GetItem(), func(badFields map[string]string) {
+		if req.GetItem().WorkerGeneratedAuthToken != nil {
+			badFields[globals.WorkerGeneratedAuthTokenField] = "This field can only be provided with the create:worker-led action."
+		}
+		if ttl := req.GetItem().ActivationTokenTtl; ttl != nil && ttl.GetValue() == 0 {
+			badFields[globals.ActivationTokenTtlField] = "Must be greater than zero."
+		}
+	})
+}
+
+// validateCreateItem validates the fields of a worker being created which are
+// common to the create actions. The provided function can add action specific
+// bad fields.
+func validateCreateItem(item *pb.Worker, fn func(badFields map[string]string)) error {
+	return handlers.ValidateCreateRequest(item, func() map[string]string {
 		const (
 			mustBeGlobalMsg  = "Must be 'global'"
-			cannotBeEmptyMsg = "Cannot be empty."
 			readOnlyFieldMsg = "This is a read only field."
 		)
 		badFields := map[string]string{}
-		if scope.Global.String() != req.GetItem().GetScopeId() {
+		if scope.Global.String() != item.GetScopeId() {
 			badFields[globals.ScopeIdField] = mustBeGlobalMsg
 		}
-		// FIXME: in the future, we won't require this token since we'll support
-		// the server led flow where a token is returned when a worker is created.
-		if req.GetItem().WorkerGeneratedAuthToken == nil {
-			badFields[globals.WorkerGeneratedAuthTokenField] = cannotBeEmptyMsg
-		}
-		if req.GetItem().Address != "" {
+		fn(badFields)
+		if item.Address != "" {
 			badFields[globals.CanonicalAddressField] = readOnlyFieldMsg
 		}
-		if req.GetItem().CanonicalTags != nil {
+		if item.CanonicalTags != nil {
 			badFields[globals.CanonicalTagsField] = readOnlyFieldMsg
 		}
-		if req.GetItem().ConfigTags != nil {
+		if item.ConfigTags != nil {
 			badFields[globals.ConfigTagsField] = readOnlyFieldMsg
 		}
-		if req.GetItem().LastStatusTime != nil {
+		if item.LastStatusTime != nil {
 			badFields[globals.LastStatusTimeField] = readOnlyFieldMsg
 		}
-		if req.GetItem().AuthorizedActions != nil {
+		if item.CertificateExpiration != nil {
+			badFields[globals.CertificateExpirationField] = readOnlyFieldMsg
+		}
+		if item.LastRotationTime != nil {
+			badFields[globals.LastRotationTimeField] = readOnlyFieldMsg
+		}
+		if item.LastRotationError != "" {
+			badFields[globals.LastRotationErrorField] = readOnlyFieldMsg
+		}
+		if item.ReleaseVersion != "" {
+			badFields[globals.ReleaseVersionField] = readOnlyFieldMsg
+		}
+		if item.Features != nil {
+			badFields[globals.FeaturesField] = readOnlyFieldMsg
+		}
+		if item.ControllerGeneratedActivationToken != "" {
+			badFields[globals.ControllerGeneratedActivationTokenField] = readOnlyFieldMsg
+		}
+		if item.ActivationTokenExpiration != nil {
+			badFields[globals.ActivationTokenExpirationField] = readOnlyFieldMsg
+		}
+		if item.AuthorizedActions != nil {
 			badFields[globals.AuthorizedActionsField] = readOnlyFieldMsg
 		}
-		nameString := req.GetItem().GetName().String()
+		nameString := item.GetName().String()
 		if !strutil.Printable(nameString) {
 			badFields[globals.NameField] = "Name contains non-printable characters."
 		}
 		if strings.ToLower(nameString) != nameString {
 			badFields[globals.NameField] = "Name must be all lowercase."
 		}
-		descriptionString := req.GetItem().GetDescription().String()
+		descriptionString := item.GetDescription().String()
 		if !strutil.Printable(descriptionString) {
 			badFields[globals.DescriptionField] = "Description contains non-printable characters."
 		}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
@@ -37,7 +39,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "rotate-auth", "revoke-activation-token"}
 
 func structListValue(t *testing.T, ss ...string) *structpb.ListValue {
 	t.Helper()
@@ -85,7 +87,7 @@ func TestGet(t *testing.T) {
 		Description:           wrapperspb.String(kmsWorker.GetDescription()),
 		Address:               kmsWorker.GetAddress(),
 		ActiveConnectionCount: &wrapperspb.UInt32Value{Value: 0},
-		AuthorizedActions:     strutil.StrListDelete(kmsAuthzActions, action.Update.String()),
+		AuthorizedActions:     strutil.StrListDelete(strutil.StrListDelete(kmsAuthzActions, action.Update.String()), action.RotateAuth.String()),
 		LastStatusTime:        kmsWorker.GetLastStatusTime().GetTimestamp(),
 		CanonicalTags: map[string]*structpb.ListValue{
 			"key": structListValue(t, "val"),
@@ -217,7 +219,7 @@ func TestList(t *testing.T) {
 			UpdatedTime:           w.UpdateTime.GetTimestamp(),
 			Version:               w.GetVersion(),
 			Name:                  wrapperspb.String(w.GetName()),
-			AuthorizedActions:     strutil.StrListDelete(kmsAuthzActions, action.Update.String()),
+			AuthorizedActions:     strutil.StrListDelete(strutil.StrListDelete(kmsAuthzActions, action.Update.String()), action.RotateAuth.String()),
 			ActiveConnectionCount: &wrapperspb.UInt32Value{Value: 0},
 			Address:               w.GetAddress(),
 			Type:                  KmsWorkerType,
@@ -1295,3 +1297,235 @@ func TestCreateWorkerLed(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateWorkerAuth(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+
+	workerService, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	pkiWorker := server.TestPkiWorker(t, conn, wrapper)
+	kmsWorker := server.TestKmsWorker(t, conn, wrapper)
+
+	cases := []struct {
+		name    string
+		req     *pbs.RotateWorkerAuthRequest
+		errCode codes.Code
+	}{
+		{
+			name:    "Missing version",
+			req:     &pbs.RotateWorkerAuthRequest{Id: pkiWorker.GetPublicId()},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name:    "Bad id prefix",
+			req:     &pbs.RotateWorkerAuthRequest{Id: "j_1234567890", Version: pkiWorker.GetVersion()},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name:    "KMS worker",
+			req:     &pbs.RotateWorkerAuthRequest{Id: kmsWorker.GetPublicId(), Version: kmsWorker.GetVersion()},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name:    "Bad version",
+			req:     &pbs.RotateWorkerAuthRequest{Id: pkiWorker.GetPublicId(), Version: pkiWorker.GetVersion() + 1},
+			errCode: codes.NotFound,
+		},
+		{
+			name: "Success",
+			req:  &pbs.RotateWorkerAuthRequest{Id: pkiWorker.GetPublicId(), Version: pkiWorker.GetVersion()},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := workerService.RotateWorkerAuth(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.errCode != codes.OK {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, handlers.ApiErrorWithCode(tc.errCode)), "RotateWorkerAuth(%+v) got error %v, wanted %v", tc.req, gErr, tc.errCode)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Equal(t, pkiWorker.GetPublicId(), got.GetItem().GetId())
+			assert.True(t, got.GetItem().GetRotateAuthRequested())
+			assert.Equal(t, pkiWorker.GetVersion(), got.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestCreateControllerLed(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+
+	workerService, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		req     *pbs.CreateControllerLedRequest
+		wantTtl time.Duration
+		errCode codes.Code
+	}{
+		{
+			name: "Non global scope",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId: "o_1234567890",
+			}},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "Worker generated auth token",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId:                  scope.Global.String(),
+				WorkerGeneratedAuthToken: wrapperspb.String("token"),
+			}},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "Zero ttl",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId:            scope.Global.String(),
+				ActivationTokenTtl: wrapperspb.UInt32(0),
+			}},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "Activation token provided",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId:                            scope.Global.String(),
+				ControllerGeneratedActivationToken: "wact_foo",
+			}},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "Default ttl",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId: scope.Global.String(),
+				Name:    wrapperspb.String("default-ttl"),
+			}},
+			wantTtl: server.DefaultActivationTokenTtl,
+		},
+		{
+			name: "Provided ttl",
+			req: &pbs.CreateControllerLedRequest{Item: &pb.Worker{
+				ScopeId:            scope.Global.String(),
+				Name:               wrapperspb.String("provided-ttl"),
+				ActivationTokenTtl: wrapperspb.UInt32(600),
+			}},
+			wantTtl: 10 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := workerService.CreateControllerLed(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.errCode != codes.OK {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, handlers.ApiErrorWithCode(tc.errCode)), "CreateControllerLed(%+v) got error %v, wanted %v", tc.req, gErr, tc.errCode)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Equal(t, PkiWorkerType, got.GetItem().GetType())
+			assert.True(t, strings.HasPrefix(got.GetItem().GetControllerGeneratedActivationToken(), globals.ControllerLedActivationTokenPrefix))
+			assert.WithinDuration(t, time.Now().Add(tc.wantTtl), got.GetItem().GetActivationTokenExpiration().AsTime(), time.Minute)
+			assert.Nil(t, got.GetItem().GetActivationTokenTtl())
+
+			// The token is never returned again
+			w, err := repo.LookupWorker(ctx, got.GetItem().GetId())
+			require.NoError(t, err)
+			assert.Empty(t, w.GetControllerGeneratedActivationToken())
+		})
+	}
+}
+
+func TestRevokeWorkerActivationToken(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := server.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*server.Repository, error) {
+		return repo, nil
+	}
+
+	workerService, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	tokenWorker, err := repo.CreateWorker(ctx, server.NewWorker(scope.Global.String()), server.WithCreateControllerLedActivationToken(true))
+	require.NoError(t, err)
+	noTokenWorker := server.TestPkiWorker(t, conn, wrapper)
+
+	cases := []struct {
+		name    string
+		req     *pbs.RevokeWorkerActivationTokenRequest
+		errCode codes.Code
+	}{
+		{
+			name:    "Bad id prefix",
+			req:     &pbs.RevokeWorkerActivationTokenRequest{Id: "j_1234567890"},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name:    "No activation token",
+			req:     &pbs.RevokeWorkerActivationTokenRequest{Id: noTokenWorker.GetPublicId()},
+			errCode: codes.NotFound,
+		},
+		{
+			name: "Success",
+			req:  &pbs.RevokeWorkerActivationTokenRequest{Id: tokenWorker.GetPublicId()},
+		},
+		{
+			name:    "Already revoked",
+			req:     &pbs.RevokeWorkerActivationTokenRequest{Id: tokenWorker.GetPublicId()},
+			errCode: codes.NotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := workerService.RevokeWorkerActivationToken(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.errCode != codes.OK {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, handlers.ApiErrorWithCode(tc.errCode)), "RevokeWorkerActivationToken(%+v) got error %v, wanted %v", tc.req, gErr, tc.errCode)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Equal(t, tc.req.GetId(), got.GetItem().GetId())
+		})
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"reflect"
 	"runtime/debug"
+	"strings"
 
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	commonSrv "github.com/hashicorp/boundary/internal/daemon/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
@@ -90,52 +92,15 @@ func requestCtxInterceptor(
 			return nil, errors.New(interceptorCtx, errors.Internal, op, fmt.Sprintf("expected 1 value for %s metadata and got %d", requestInfoMdKey, len(values)))
 		}
 
-		decoded, err := base58.FastBase58Decoding(values[0])
+		requestInfo, err := decodeRequestInfo(interceptorCtx, values[0], ticket)
 		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to decode request info"))
+			return nil, errors.Wrap(interceptorCtx, err, op)
 		}
-		var requestInfo authpb.RequestInfo
-		if err := proto.Unmarshal(decoded, &requestInfo); err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to unmarshal request info"))
-		}
-		switch {
-		case requestInfo.Ticket == "":
-			return nil, errors.New(interceptorCtx, errors.Internal, op, "Invalid context (missing ticket)")
-		case requestInfo.Ticket != ticket:
-			return nil, errors.New(interceptorCtx, errors.Internal, op, "Invalid context (bad ticket)")
-		}
-
-		interceptorCtx = auth.NewVerifierContext(interceptorCtx, iamRepoFn, authTokenRepoFn, serversRepoFn, kms, &requestInfo)
-
-		// Add general request information to the context. The information from
-		// the auth verifier context is pretty specifically curated to
-		// authentication/authorization verification so this is more
-		// general-purpose.
-		//
-		// We could use requests.NewRequestContext but this saves an immediate
-		// lookup.
-		interceptorCtx = context.WithValue(interceptorCtx, requests.ContextRequestInformationKey, &requests.RequestContext{
-			Path:   requestInfo.Path,
-			Method: requestInfo.Method,
-		})
-
-		// This event request info is required by downstream handlers
-		info := &event.RequestInfo{
-			EventId:  requestInfo.EventId,
-			Id:       requestInfo.TraceId,
-			PublicId: requestInfo.PublicId,
-			Method:   requestInfo.Method,
-			Path:     requestInfo.Path,
-			ClientIp: requestInfo.ClientIp,
-		}
-		interceptorCtx, err = event.NewRequestInfoContext(interceptorCtx, info)
-		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with request info"))
-		}
-		interceptorCtx, err = event.NewEventerContext(interceptorCtx, eventer)
+		reqCtx, err := newRequestContext(interceptorCtx, requestInfo, iamRepoFn, authTokenRepoFn, serversRepoFn, kms, eventer)
 		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with eventer"))
+			return nil, errors.Wrap(interceptorCtx, err, op)
 		}
+		interceptorCtx = reqCtx
 
 		// Calls the handler
 		h, err := handler(interceptorCtx, req)
@@ -144,6 +109,75 @@ func requestCtxInterceptor(
 	}, nil
 }
 
+// decodeRequestInfo decodes the RequestInfo marshalled into the
+// requestInfoMdKey header by controller.wrapHandlerWithCommonFuncs and verifies
+// its ticket.
+func decodeRequestInfo(ctx context.Context, encoded, ticket string) (*authpb.RequestInfo, error) {
+	const op = "controller.decodeRequestInfo"
+	decoded, err := base58.FastBase58Decoding(encoded)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to decode request info"))
+	}
+	var requestInfo authpb.RequestInfo
+	if err := proto.Unmarshal(decoded, &requestInfo); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to unmarshal request info"))
+	}
+	switch {
+	case requestInfo.Ticket == "":
+		return nil, errors.New(ctx, errors.Internal, op, "Invalid context (missing ticket)")
+	case requestInfo.Ticket != ticket:
+		return nil, errors.New(ctx, errors.Internal, op, "Invalid context (bad ticket)")
+	}
+	return &requestInfo, nil
+}
+
+// newRequestContext returns a ctx for the request with the auth verifier,
+// request information, event request information and eventer required by
+// downstream handlers.
+func newRequestContext(
+	ctx context.Context,
+	requestInfo *authpb.RequestInfo,
+	iamRepoFn common.IamRepoFactory,
+	authTokenRepoFn common.AuthTokenRepoFactory,
+	serversRepoFn common.ServersRepoFactory,
+	kms *kms.Kms,
+	eventer *event.Eventer,
+) (context.Context, error) {
+	const op = "controller.newRequestContext"
+	ctx = auth.NewVerifierContext(ctx, iamRepoFn, authTokenRepoFn, serversRepoFn, kms, requestInfo)
+
+	// Add general request information to the context. The information from
+	// the auth verifier context is pretty specifically curated to
+	// authentication/authorization verification so this is more
+	// general-purpose.
+	//
+	// We could use requests.NewRequestContext but this saves an immediate
+	// lookup.
+	ctx = context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{
+		Path:   requestInfo.Path,
+		Method: requestInfo.Method,
+	})
+
+	// This event request info is required by downstream handlers
+	info := &event.RequestInfo{
+		EventId:  requestInfo.EventId,
+		Id:       requestInfo.TraceId,
+		PublicId: requestInfo.PublicId,
+		Method:   requestInfo.Method,
+		Path:     requestInfo.Path,
+		ClientIp: requestInfo.ClientIp,
+	}
+	ctx, err := event.NewRequestInfoContext(ctx, info)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with request info"))
+	}
+	ctx, err = event.NewEventerContext(ctx, eventer)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with eventer"))
+	}
+	return ctx, nil
+}
+
 func errorInterceptor(
 	_ context.Context,
 ) grpc.UnaryServerInterceptor {
@@ -231,6 +265,31 @@ func isNil(i interface{}) bool {
 	return false
 }
 
+// replicaReadsInterceptor allows the reads of list and read requests to be
+// routed to read replicas of the database. Every other request reads from the
+// database so it sees its own writes.
+func replicaReadsInterceptor(
+	_ context.Context,
+) grpc.UnaryServerInterceptor {
+	return func(interceptorCtx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error,
+	) {
+		if isReadOnlyMethod(info.FullMethod) {
+			interceptorCtx = db.WithReplicaReads(interceptorCtx)
+		}
+		return handler(interceptorCtx, req)
+	}
+}
+
+// isReadOnlyMethod reports whether the grpc method is a list or read of a
+// resource, for example "/controller.api.services.v1.TargetService/ListTargets".
+func isReadOnlyMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(method, "List") || strings.HasPrefix(method, "Get")
+}
+
 func auditRequestInterceptor(
 	_ context.Context,
 ) grpc.UnaryServerInterceptor {
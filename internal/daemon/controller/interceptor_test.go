@@ -464,6 +464,52 @@ func Test_statusCodeInterceptor(t *testing.T) {
 	}
 }
 
+func Test_isReadOnlyMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		want       bool
+	}{
+		{fullMethod: "/controller.api.services.v1.TargetService/ListTargets", want: true},
+		{fullMethod: "/controller.api.services.v1.TargetService/GetTarget", want: true},
+		{fullMethod: "/controller.api.services.v1.TargetService/CreateTarget"},
+		{fullMethod: "/controller.api.services.v1.TargetService/UpdateTarget"},
+		{fullMethod: "/controller.api.services.v1.TargetService/DeleteTarget"},
+		{fullMethod: "/controller.api.services.v1.TargetService/AuthorizeSession"},
+		{fullMethod: "/controller.api.services.v1.AuthMethodService/Authenticate"},
+		{fullMethod: "ListTargets", want: true},
+		{fullMethod: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			assert.Equal(t, tt.want, isReadOnlyMethod(tt.fullMethod))
+		})
+	}
+}
+
+func Test_replicaReadsInterceptor(t *testing.T) {
+	ctx := context.Background()
+	interceptor := replicaReadsInterceptor(ctx)
+
+	var gotCtx context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotCtx = ctx
+		return req, nil
+	}
+
+	t.Run("read", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/controller.api.services.v1.TargetService/ListTargets"}
+		_, err := interceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.NotEqual(t, ctx, gotCtx)
+	})
+	t.Run("write", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/controller.api.services.v1.TargetService/CreateTarget"}
+		_, err := interceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, ctx, gotCtx)
+	})
+}
+
 func Test_workerRequestInfoInterceptor(t *testing.T) {
 	factoryCtx := context.Background()
 	requestCtx := context.Background()
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/cluster/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/internal/metric"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nodeenrollment/multihop"
 	"github.com/hashicorp/nodeenrollment/protocol"
@@ -112,11 +113,17 @@ func (c *Controller) configureForCluster(ln *base.ServerListener) (func(), error
 		return nil, fmt.Errorf("error fetching worker auth storage: %w", err)
 	}
 
+	fetchCredsFn, err := handlers.NewFetchNodeCredentialsFn(c.ServersRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("error creating fetch node credentials function: %w", err)
+	}
+
 	l, err := protocol.NewInterceptingListener(
 		&protocol.InterceptingListenerConfiguration{
-			Context:      c.baseContext,
-			Storage:      workerAuthStorage,
-			BaseListener: ln.ClusterListener,
+			Context:        c.baseContext,
+			Storage:        workerAuthStorage,
+			BaseListener:   ln.ClusterListener,
+			FetchCredsFunc: fetchCredsFn,
 			BaseTlsConfiguration: &tls.Config{
 				GetConfigForClient: c.validateWorkerTls,
 			},
@@ -134,8 +141,10 @@ func (c *Controller) configureForCluster(ln *base.ServerListener) (func(), error
 		grpc.StatsHandler(metric.InstrumentClusterStatsHandler()),
 		grpc.MaxRecvMsgSize(math.MaxInt32),
 		grpc.MaxSendMsgSize(math.MaxInt32),
+		grpc.StreamInterceptor(tracing.StreamServerInterceptor()),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
+				tracing.UnaryServerInterceptor(), // continue the trace of the worker's request
 				workerReqInterceptor,
 				auditRequestInterceptor(c.baseContext),  // before we get started, audit the request
 				auditResponseInterceptor(c.baseContext), // as we finish, audit the response
@@ -152,6 +161,7 @@ func (c *Controller) configureForCluster(ln *base.ServerListener) (func(), error
 		workerAuthStorage,
 		true,
 		nil,
+		fetchCredsFn,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s: error creating multihop service handler: %w", op, err)
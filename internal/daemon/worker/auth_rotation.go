@@ -12,6 +12,37 @@ import (
 	"github.com/hashicorp/nodeenrollment/types"
 )
 
+// AuthRotationInformation contains the outcome of the most recent check of
+// the worker's PKI credentials, along with the validity period of the
+// credentials in use.
+type AuthRotationInformation struct {
+	// CertificateExpiration is the latest NotAfter time across the
+	// current credentials' certificate bundles
+	CertificateExpiration time.Time
+	// LastRotationTime is the time of the most recent successful rotation
+	LastRotationTime time.Time
+	// LastRotationError contains the error from the most recent rotation
+	// attempt, if it failed; it is cleared on a successful rotation
+	LastRotationError error
+}
+
+// AuthRotationInfo reports the outcome of the most recent PKI auth rotation
+// check. It is nil if no check has run yet or KMS worker auth is in use.
+func (w *Worker) AuthRotationInfo() *AuthRotationInformation {
+	return w.authRotationInfo.Load().(*AuthRotationInformation)
+}
+
+// updateAuthRotationInfo atomically applies fn to a copy of the current auth
+// rotation information and stores the result
+func (w *Worker) updateAuthRotationInfo(fn func(*AuthRotationInformation)) {
+	var info AuthRotationInformation
+	if current := w.AuthRotationInfo(); current != nil {
+		info = *current
+	}
+	fn(&info)
+	w.authRotationInfo.Store(&info)
+}
+
 func (w *Worker) startAuthRotationTicking(cancelCtx context.Context) {
 	const op = "worker.(Worker).startAuthRotationTicking"
 	if w.conf.WorkerAuthKms != nil && !w.conf.DevUsePkiForUpstream {
@@ -35,78 +66,109 @@ func (w *Worker) startAuthRotationTicking(cancelCtx context.Context) {
 		}
 		timer.Reset(resetDuration)
 
+		var forceRotation bool
 		select {
 		case <-cancelCtx.Done():
 			event.WriteSysEvent(cancelCtx, op, "pki auth rotation ticking shutting down")
 			return
 
 		case <-timer.C:
-			resetDuration = defaultResetDuration
-
-			// Check if it's time to rotate and if not don't do anything
-			currentNodeCreds, err := types.LoadNodeCredentials(cancelCtx, w.WorkerAuthStorage, nodeenrollment.CurrentId, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms))
-			if err != nil {
-				if errors.Is(err, nodeenrollment.ErrNotFound) {
-					// Be silent
-					continue
-				}
-				event.WriteError(cancelCtx, op, err)
-				continue
-			}
 
-			if currentNodeCreds == nil {
-				event.WriteSysEvent(cancelCtx, op, "no error loading worker pki auth creds but nil creds, skipping rotation")
-				continue
+		case <-w.authRotationRequests:
+			// A rotation was requested by the controller; stop the timer so it
+			// can be safely reset below
+			forceRotation = true
+			if !timer.Stop() {
+				<-timer.C
 			}
+		}
+
+		resetDuration = defaultResetDuration
 
-			// Check the certificates to see if it's time
-			if len(currentNodeCreds.CertificateBundles) != 2 {
-				// Likely this means we haven't been authorized yet, and
-				// unclear what to do in any other situation
+		// Check if it's time to rotate and if not don't do anything
+		currentNodeCreds, err := types.LoadNodeCredentials(cancelCtx, w.WorkerAuthStorage, nodeenrollment.CurrentId, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms))
+		if err != nil {
+			if errors.Is(err, nodeenrollment.ErrNotFound) {
+				// Be silent
 				continue
 			}
+			event.WriteError(cancelCtx, op, err)
+			continue
+		}
+
+		if currentNodeCreds == nil {
+			event.WriteSysEvent(cancelCtx, op, "no error loading worker pki auth creds but nil creds, skipping rotation")
+			continue
+		}
 
-			var earliestValid, latestValid time.Time
-			// Go through the bundles and find the full range of time that we
-			// have valid certificates
-			for _, bundle := range currentNodeCreds.CertificateBundles {
-				if curr := bundle.CertificateNotBefore.AsTime(); earliestValid.IsZero() || curr.Before(earliestValid) {
-					earliestValid = curr
-				}
-				if curr := bundle.CertificateNotAfter.AsTime(); latestValid.IsZero() || curr.After(latestValid) {
-					latestValid = curr
-				}
+		// Check the certificates to see if it's time
+		if len(currentNodeCreds.CertificateBundles) != 2 {
+			// Likely this means we haven't been authorized yet, and
+			// unclear what to do in any other situation
+			continue
+		}
+
+		var earliestValid, latestValid time.Time
+		// Go through the bundles and find the full range of time that we
+		// have valid certificates
+		for _, bundle := range currentNodeCreds.CertificateBundles {
+			if curr := bundle.CertificateNotBefore.AsTime(); earliestValid.IsZero() || curr.Before(earliestValid) {
+				earliestValid = curr
 			}
-			// Ensure that our time periods aren't somehow quite messed up
-			now := time.Now()
-			if earliestValid.After(now) || latestValid.Before(now) || earliestValid.After(latestValid) {
-				// We basically have no valid creds so we can't rotate.
-				// TODO (maybe): Have this trigger a new set of creds and request?
-				event.WriteSysEvent(cancelCtx, op, "not within worker creds validity period, unsure what to do, not rotating")
-				continue
+			if curr := bundle.CertificateNotAfter.AsTime(); latestValid.IsZero() || curr.After(latestValid) {
+				latestValid = curr
 			}
+		}
+		w.updateAuthRotationInfo(func(info *AuthRotationInformation) {
+			info.CertificateExpiration = latestValid
+		})
 
-			// Figure out the midpoint; if we're after it, try to rotate
-			var shouldRotate bool
-			switch {
-			case w.TestOverrideAuthRotationPeriod != 0:
-				shouldRotate = true
-			default:
-				delta := latestValid.Sub(earliestValid)
-				shouldRotate = now.Before(earliestValid.Add(delta / 2))
-			}
+		// Ensure that our time periods aren't somehow quite messed up
+		now := time.Now()
+		if earliestValid.After(now) || latestValid.Before(now) || earliestValid.After(latestValid) {
+			// We basically have no valid creds so we can't rotate.
+			// TODO (maybe): Have this trigger a new set of creds and request?
+			event.WriteSysEvent(cancelCtx, op, "not within worker creds validity period, unsure what to do, not rotating")
+			continue
+		}
 
-			if !shouldRotate {
-				continue
-			}
+		// Figure out the midpoint; if we're after it, try to rotate
+		var shouldRotate bool
+		switch {
+		case forceRotation, w.TestOverrideAuthRotationPeriod != 0:
+			shouldRotate = true
+		default:
+			delta := latestValid.Sub(earliestValid)
+			shouldRotate = now.Before(earliestValid.Add(delta / 2))
+		}
 
-			if err := rotateWorkerAuth(cancelCtx, w, currentNodeCreds); err != nil {
-				event.WriteError(cancelCtx, op, err)
-				continue
-			}
+		if !shouldRotate {
+			continue
+		}
 
-			// TODO (maybe): Calculate new delta and set a custom retry time on the timer?
+		if err := rotateWorkerAuth(cancelCtx, w, currentNodeCreds); err != nil {
+			w.updateAuthRotationInfo(func(info *AuthRotationInformation) {
+				info.LastRotationError = err
+			})
+			event.WriteError(cancelCtx, op, err)
+			continue
 		}
+		w.updateAuthRotationInfo(func(info *AuthRotationInformation) {
+			info.LastRotationTime = time.Now()
+			info.LastRotationError = nil
+		})
+
+		// TODO (maybe): Calculate new delta and set a custom retry time on the timer?
+	}
+}
+
+// requestAuthRotation asks the auth rotation ticking goroutine to rotate the
+// worker's credentials as soon as possible. It does not block; if a request
+// is already pending this is a no-op.
+func (w *Worker) requestAuthRotation() {
+	select {
+	case w.authRotationRequests <- struct{}{}:
+	default:
 	}
 }
 
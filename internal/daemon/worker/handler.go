@@ -53,6 +53,15 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 	}
 	return func(wr http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		if w.conf.Eventer != nil {
+			// send the connection's events to the worker's eventer sinks
+			var err error
+			if ctx, err = event.NewEventerContext(ctx, w.conf.Eventer); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("unable to add eventer to context"))
+				wr.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
 		if r.TLS == nil {
 			event.WriteError(ctx, op, errors.New("no request tls information found"))
 			wr.WriteHeader(http.StatusInternalServerError)
@@ -163,6 +172,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 				}
 				return
 			}
+			metric.RecordSessionCanceled()
 			if err = conn.Close(websocket.StatusNormalClosure, "session canceled"); err != nil && !errors.Is(err, io.EOF) {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
 			}
@@ -194,6 +204,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 					}
 					return
 				}
+				metric.RecordSessionActivated()
 				event.WriteSysEvent(ctx, op, "session successfully activated", "session_id", sessionId)
 			}
 		}
@@ -235,20 +246,38 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 			}
 			return
 		}
+		metric.RecordConnectionAuthorized()
 		event.WriteSysEvent(ctx, op, "connection successfully authorized", "session_id", sessionId, "connection_id", ci.Id)
-		defer func() {
-			if session.CloseConnections(ctx, sessClient, w.sessionInfoMap, map[string]string{ci.Id: si.Id}) {
-				event.WriteSysEvent(ctx, op, "connection closed", "session_id", sessionId, "connection_id", ci.Id)
-			}
-		}()
 
 		si.Lock()
 		ci.ConnCtx = connCtx
 		ci.ConnCancel = connCancel
+		ci.Connection = &event.Connection{
+			SessionId:     sessionId,
+			ConnectionId:  ci.Id,
+			TargetId:      si.LookupSessionResponse.GetTargetId(),
+			UserId:        si.LookupSessionResponse.GetUserId(),
+			WorkerId:      workerId,
+			Endpoint:      endpoint,
+			ClientAddress: clientAddr.String(),
+			UserClientIp:  userClientIp,
+		}
+		connEvent := *ci.Connection
 		si.ConnInfoMap[ci.Id] = ci
 		si.Status = sessStatus
 		connectionLimit := si.LookupSessionResponse.GetConnectionLimit()
 		si.Unlock()
+		session.WriteConnectionEvent(ctx, op, event.ConnectionAuthorized, connEvent)
+
+		defer func() {
+			if session.CloseConnections(ctx, sessClient, w.sessionInfoMap, map[string]string{ci.Id: si.Id}) {
+				event.WriteSysEvent(ctx, op, "connection closed", "session_id", sessionId, "connection_id", ci.Id)
+			}
+			si.RLock()
+			connEvent := *ci.Connection
+			si.RUnlock()
+			session.WriteConnectionEvent(ctx, op, event.ConnectionClosed, connEvent)
+		}()
 
 		handshakeResult := &proxy.HandshakeResult{
 			Expiration:      expiration,
@@ -286,7 +315,11 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 			proxyOpts = append(proxyOpts, proxyHandlers.WithEgressCredentials(credentials))
 		}
 
-		if err = handleProxyFn(connCtx, conf, proxyOpts...); err != nil {
+		err = handleProxyFn(connCtx, conf, proxyOpts...)
+		si.Lock()
+		ci.Connection.ClosedReason = session.ConnectionClosedReason(connCtx, err).String()
+		si.Unlock()
+		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error handling proxy", "session_id", sessionId, "endpoint", endpoint))
 			if err = conn.Close(websocket.StatusInternalError, "unable to establish proxy"); err != nil {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
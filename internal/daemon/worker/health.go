@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"google.golang.org/grpc/connectivity"
+)
+
+// HealthInformation is the JSON body returned by the worker's health
+// endpoint.
+type HealthInformation struct {
+	// Live is always true when the worker is able to answer the request
+	Live bool `json:"live"`
+	// Ready indicates the worker is able to proxy sessions: it has a recent
+	// successful status report upstream and is not shutting down
+	Ready bool `json:"ready"`
+	// ShuttingDown is set once graceful shutdown has begun
+	ShuttingDown bool `json:"shutting_down"`
+
+	WorkerId                string     `json:"worker_id,omitempty"`
+	UpstreamConnectionState string     `json:"upstream_connection_state"`
+	LastStatusTime          *time.Time `json:"last_status_time,omitempty"`
+	ActiveSessionCount      int        `json:"active_session_count"`
+	ActiveConnectionCount   int        `json:"active_connection_count"`
+
+	AuthRotation *AuthRotationHealth `json:"auth_rotation,omitempty"`
+}
+
+// AuthRotationHealth is the JSON representation of AuthRotationInformation.
+type AuthRotationHealth struct {
+	CertificateExpiration *time.Time `json:"certificate_expiration,omitempty"`
+	LastRotationTime      *time.Time `json:"last_rotation_time,omitempty"`
+	LastRotationError     string     `json:"last_rotation_error,omitempty"`
+}
+
+// StartServiceUnavailableReplies causes the health endpoint to report the
+// worker as not ready, with a 503 status code, so that load balancers stop
+// sending new traffic to it.
+func (w *Worker) StartServiceUnavailableReplies() {
+	w.shuttingDown.Store(true)
+}
+
+// HealthInformation returns a point-in-time snapshot of the worker's health.
+func (w *Worker) HealthInformation() *HealthInformation {
+	h := &HealthInformation{
+		Live:                    true,
+		ShuttingDown:            w.shuttingDown.Load(),
+		UpstreamConnectionState: connectivity.Shutdown.String(),
+	}
+
+	if cc, err := w.GrpcClientConn(); err == nil {
+		h.UpstreamConnectionState = cc.GetState().String()
+	}
+
+	lastStatus := w.LastStatusSuccess()
+	if lastStatus != nil {
+		h.WorkerId = lastStatus.GetWorkerId()
+		statusTime := lastStatus.StatusTime
+		h.LastStatusTime = &statusTime
+	}
+
+	w.sessionInfoMap.Range(func(_, value interface{}) bool {
+		si := value.(*session.Info)
+		si.RLock()
+		defer si.RUnlock()
+		if si.Status == pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE {
+			h.ActiveSessionCount++
+		}
+		for _, ci := range si.ConnInfoMap {
+			if ci.Status == pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED {
+				h.ActiveConnectionCount++
+			}
+		}
+		return true
+	})
+
+	if info := w.AuthRotationInfo(); info != nil {
+		ar := &AuthRotationHealth{}
+		if !info.CertificateExpiration.IsZero() {
+			exp := info.CertificateExpiration
+			ar.CertificateExpiration = &exp
+		}
+		if !info.LastRotationTime.IsZero() {
+			rot := info.LastRotationTime
+			ar.LastRotationTime = &rot
+		}
+		if info.LastRotationError != nil {
+			ar.LastRotationError = info.LastRotationError.Error()
+		}
+		h.AuthRotation = ar
+	}
+
+	isPastGrace, _, _ := w.isPastGrace()
+	h.Ready = w.started.Load() && !h.ShuttingDown && lastStatus != nil && !isPastGrace
+
+	return h
+}
+
+// HealthHandler returns an http.Handler that reports the worker's health as
+// JSON. It responds with a 200 when the worker is ready and a 503 otherwise.
+func (w *Worker) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		h := w.HealthInformation()
+		rw.Header().Set("Content-Type", "application/json")
+		if !h.Ready {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(rw).Encode(h)
+	})
+}
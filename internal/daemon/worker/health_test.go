@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/daemon/worker/session"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ua "go.uber.org/atomic"
+)
+
+func TestWorkerHealthHandler(t *testing.T) {
+	newTestWorker := func() *Worker {
+		// As-needed initialization of a mock worker
+		w := &Worker{
+			started:           ua.NewBool(true),
+			shuttingDown:      ua.NewBool(false),
+			grpcClientConn:    new(atomic.Value),
+			lastStatusSuccess: new(atomic.Value),
+			authRotationInfo:  new(atomic.Value),
+			sessionInfoMap:    new(sync.Map),
+			conf: &Config{
+				Server: &base.Server{
+					StatusGracePeriodDuration: time.Minute,
+				},
+			},
+		}
+		// This is present in New()
+		w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
+		w.authRotationInfo.Store((*AuthRotationInformation)(nil))
+		return w
+	}
+	successfulStatus := func(w *Worker) {
+		w.lastStatusSuccess.Store(&LastStatusInformation{
+			StatusResponse: &pbs.StatusResponse{WorkerId: "w_1234567890"},
+			StatusTime:     time.Now(),
+		})
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(w *Worker)
+		expCode    int
+		assertions func(t *testing.T, h *HealthInformation)
+	}{
+		{
+			name:    "no status yet",
+			expCode: http.StatusServiceUnavailable,
+			assertions: func(t *testing.T, h *HealthInformation) {
+				assert.True(t, h.Live)
+				assert.False(t, h.Ready)
+				assert.Nil(t, h.LastStatusTime)
+				assert.Nil(t, h.AuthRotation)
+			},
+		},
+		{
+			name:    "ready",
+			setup:   successfulStatus,
+			expCode: http.StatusOK,
+			assertions: func(t *testing.T, h *HealthInformation) {
+				assert.True(t, h.Ready)
+				assert.False(t, h.ShuttingDown)
+				assert.Equal(t, "w_1234567890", h.WorkerId)
+				assert.NotNil(t, h.LastStatusTime)
+			},
+		},
+		{
+			name: "past grace period",
+			setup: func(w *Worker) {
+				w.lastStatusSuccess.Store(&LastStatusInformation{
+					StatusResponse: &pbs.StatusResponse{WorkerId: "w_1234567890"},
+					StatusTime:     time.Now().Add(-time.Hour),
+				})
+			},
+			expCode: http.StatusServiceUnavailable,
+			assertions: func(t *testing.T, h *HealthInformation) {
+				assert.False(t, h.Ready)
+			},
+		},
+		{
+			name: "shutting down",
+			setup: func(w *Worker) {
+				successfulStatus(w)
+				w.StartServiceUnavailableReplies()
+			},
+			expCode: http.StatusServiceUnavailable,
+			assertions: func(t *testing.T, h *HealthInformation) {
+				assert.True(t, h.Live)
+				assert.False(t, h.Ready)
+				assert.True(t, h.ShuttingDown)
+			},
+		},
+		{
+			name: "sessions and auth rotation",
+			setup: func(w *Worker) {
+				successfulStatus(w)
+				w.sessionInfoMap.Store("s_1", &session.Info{
+					Id:     "s_1",
+					Status: pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+					ConnInfoMap: map[string]*session.ConnInfo{
+						"sc_1": {Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED},
+						"sc_2": {Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED},
+					},
+				})
+				w.sessionInfoMap.Store("s_2", &session.Info{
+					Id:          "s_2",
+					Status:      pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING,
+					ConnInfoMap: map[string]*session.ConnInfo{},
+				})
+				w.updateAuthRotationInfo(func(info *AuthRotationInformation) {
+					info.CertificateExpiration = time.Now().Add(time.Hour)
+					info.LastRotationError = errors.New("rotation failed")
+				})
+			},
+			expCode: http.StatusOK,
+			assertions: func(t *testing.T, h *HealthInformation) {
+				assert.Equal(t, 1, h.ActiveSessionCount)
+				assert.Equal(t, 1, h.ActiveConnectionCount)
+				require.NotNil(t, h.AuthRotation)
+				assert.NotNil(t, h.AuthRotation.CertificateExpiration)
+				assert.Nil(t, h.AuthRotation.LastRotationTime)
+				assert.Equal(t, "rotation failed", h.AuthRotation.LastRotationError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestWorker()
+			if tt.setup != nil {
+				tt.setup(w)
+			}
+
+			rec := httptest.NewRecorder()
+			w.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+			require.Equal(t, tt.expCode, rec.Code)
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var h HealthInformation
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &h))
+			assert.Equal(t, "SHUTDOWN", h.UpstreamConnectionState)
+			if tt.assertions != nil {
+				tt.assertions(t, &h)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package metric
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	labelClosedReason = "reason"
+	labelTargetId     = "target_id"
+
+	sessionSubsystem = "worker_session"
+	statusSubsystem  = "worker_status"
+)
+
+var (
+	sessionsActivated prometheus.Counter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "activated_total",
+			Help:      "Count of sessions activated through this worker.",
+		},
+	)
+
+	sessionsCanceled prometheus.Counter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "canceled_total",
+			Help:      "Count of sessions canceled through this worker.",
+		},
+	)
+
+	connectionsAuthorized prometheus.Counter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "connections_authorized_total",
+			Help:      "Count of session connections authorized on this worker.",
+		},
+	)
+
+	connectionsClosed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "connections_closed_total",
+			Help:      "Count of session connections closed on this worker, by closed reason.",
+		},
+		[]string{labelClosedReason},
+	)
+
+	bytesUp = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "up_bytes_total",
+			Help:      "Count of bytes proxied from clients to remote endpoints, by target id.",
+		},
+		[]string{labelTargetId},
+	)
+
+	bytesDown = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "down_bytes_total",
+			Help:      "Count of bytes proxied from remote endpoints to clients, by target id.",
+		},
+		[]string{labelTargetId},
+	)
+
+	// dialLatency collects measurements of how long it takes the worker to
+	// dial the remote endpoint of a session.
+	dialLatency prometheus.Histogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: sessionSubsystem,
+			Name:      "endpoint_dial_duration_seconds",
+			Help:      "Histogram of latencies for successfully dialing the remote endpoint of a session.",
+			Buckets:   prometheus.DefBuckets,
+		},
+	)
+
+	// statusLatency collects measurements of how long the status requests
+	// from the worker to its upstream take.
+	statusLatency prometheus.ObserverVec = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: statusSubsystem,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Histogram of latencies for status requests from the worker to its upstream.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{labelGrpcCode},
+	)
+)
+
+var allClosedReasons = []session.ClosedReason{
+	session.UnknownReason, session.ConnectionTimedOut, session.ConnectionClosedByUser,
+	session.ConnectionCanceled, session.ConnectionNetworkError, session.ConnectionSystemError,
+}
+
+// RecordSessionActivated records that a session was activated.
+func RecordSessionActivated() {
+	sessionsActivated.Inc()
+}
+
+// RecordSessionCanceled records that a session was canceled.
+func RecordSessionCanceled() {
+	sessionsCanceled.Inc()
+}
+
+// RecordConnectionAuthorized records that a connection was authorized.
+func RecordConnectionAuthorized() {
+	connectionsAuthorized.Inc()
+}
+
+// RecordConnectionClosed records that a connection was closed for the
+// provided reason.
+func RecordConnectionClosed(reason session.ClosedReason) {
+	connectionsClosed.With(prometheus.Labels{labelClosedReason: reason.String()}).Inc()
+}
+
+// RecordProxiedBytes records the number of bytes proxied for a connection to
+// the provided target. up is the count of bytes sent from the client to the
+// endpoint and down the count of bytes sent from the endpoint to the client.
+func RecordProxiedBytes(targetId string, up, down int64) {
+	l := prometheus.Labels{labelTargetId: targetId}
+	if up > 0 {
+		bytesUp.With(l).Add(float64(up))
+	}
+	if down > 0 {
+		bytesDown.With(l).Add(float64(down))
+	}
+}
+
+// RecordDialLatency records how long successfully dialing a remote endpoint
+// took.
+func RecordDialLatency(d time.Duration) {
+	dialLatency.Observe(d.Seconds())
+}
+
+// RecordStatusLatency records how long a status request to the upstream took
+// and the code of the error it returned, if any.
+func RecordStatusLatency(d time.Duration, err error) {
+	statusLatency.With(prometheus.Labels{
+		labelGrpcCode: statusFromError(err).Code().String(),
+	}).Observe(d.Seconds())
+}
+
+// InitializeSessionCollectors registers the session and status collectors
+// onto `r` and initializes them to 0 for the known label values. It panics
+// upon the first registration that causes an error.
+func InitializeSessionCollectors(r prometheus.Registerer) {
+	if r == nil {
+		return
+	}
+	r.MustRegister(
+		sessionsActivated,
+		sessionsCanceled,
+		connectionsAuthorized,
+		connectionsClosed,
+		bytesUp,
+		bytesDown,
+		dialLatency,
+		statusLatency,
+	)
+
+	for _, cr := range allClosedReasons {
+		connectionsClosed.With(prometheus.Labels{labelClosedReason: cr.String()})
+	}
+	for _, c := range allCodes {
+		statusLatency.With(prometheus.Labels{labelGrpcCode: c.String()})
+	}
+}
@@ -0,0 +1,101 @@
+package metric
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInitializeSessionCollectors(t *testing.T) {
+	require.NotPanics(t, func() { InitializeSessionCollectors(nil) })
+
+	r := prometheus.NewRegistry()
+	require.NotPanics(t, func() { InitializeSessionCollectors(r) })
+
+	f, err := r.Gather()
+	require.NoError(t, err)
+	require.Greater(t, len(f), 0)
+
+	// The closed reasons are initialized to 0.
+	for _, cr := range allClosedReasons {
+		assert.Equal(t, float64(0), testutil.ToFloat64(connectionsClosed.With(prometheus.Labels{labelClosedReason: cr.String()})))
+	}
+}
+
+func TestSessionCounters(t *testing.T) {
+	ogActivated, ogCanceled, ogAuthorized := sessionsActivated, sessionsCanceled, connectionsAuthorized
+	t.Cleanup(func() {
+		sessionsActivated, sessionsCanceled, connectionsAuthorized = ogActivated, ogCanceled, ogAuthorized
+	})
+	sessionsActivated = prometheus.NewCounter(prometheus.CounterOpts{Name: "activated"})
+	sessionsCanceled = prometheus.NewCounter(prometheus.CounterOpts{Name: "canceled"})
+	connectionsAuthorized = prometheus.NewCounter(prometheus.CounterOpts{Name: "authorized"})
+
+	RecordSessionActivated()
+	RecordSessionActivated()
+	RecordSessionCanceled()
+	RecordConnectionAuthorized()
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(sessionsActivated))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sessionsCanceled))
+	assert.Equal(t, float64(1), testutil.ToFloat64(connectionsAuthorized))
+}
+
+func TestRecordConnectionClosed(t *testing.T) {
+	ogClosed := connectionsClosed
+	t.Cleanup(func() { connectionsClosed = ogClosed })
+	connectionsClosed = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "closed"}, []string{labelClosedReason})
+
+	RecordConnectionClosed(session.ConnectionClosedByUser)
+	RecordConnectionClosed(session.ConnectionClosedByUser)
+	RecordConnectionClosed(session.ConnectionTimedOut)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(connectionsClosed.With(prometheus.Labels{labelClosedReason: session.ConnectionClosedByUser.String()})))
+	assert.Equal(t, float64(1), testutil.ToFloat64(connectionsClosed.With(prometheus.Labels{labelClosedReason: session.ConnectionTimedOut.String()})))
+	assert.Equal(t, float64(0), testutil.ToFloat64(connectionsClosed.With(prometheus.Labels{labelClosedReason: session.UnknownReason.String()})))
+}
+
+func TestRecordProxiedBytes(t *testing.T) {
+	ogUp, ogDown := bytesUp, bytesDown
+	t.Cleanup(func() { bytesUp, bytesDown = ogUp, ogDown })
+	bytesUp = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "up"}, []string{labelTargetId})
+	bytesDown = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "down"}, []string{labelTargetId})
+
+	RecordProxiedBytes("ttcp_1234567890", 10, 20)
+	RecordProxiedBytes("ttcp_1234567890", 5, 0)
+	RecordProxiedBytes("ttcp_0987654321", 0, 7)
+
+	assert.Equal(t, float64(15), testutil.ToFloat64(bytesUp.With(prometheus.Labels{labelTargetId: "ttcp_1234567890"})))
+	assert.Equal(t, float64(20), testutil.ToFloat64(bytesDown.With(prometheus.Labels{labelTargetId: "ttcp_1234567890"})))
+	assert.Equal(t, float64(7), testutil.ToFloat64(bytesDown.With(prometheus.Labels{labelTargetId: "ttcp_0987654321"})))
+	assert.Equal(t, 2, testutil.CollectAndCount(bytesDown))
+	assert.Equal(t, 1, testutil.CollectAndCount(bytesUp))
+}
+
+func TestRecordLatencies(t *testing.T) {
+	ogDial, ogStatus := dialLatency, statusLatency
+	t.Cleanup(func() { dialLatency, statusLatency = ogDial, ogStatus })
+
+	dialLatency = prometheus.NewHistogram(prometheus.HistogramOpts{Name: "dial"})
+	RecordDialLatency(time.Second)
+	assert.Equal(t, 1, testutil.CollectAndCount(dialLatency))
+
+	testableLatency := &testableObserverVec{}
+	statusLatency = testableLatency
+	RecordStatusLatency(time.Second, nil)
+	RecordStatusLatency(2*time.Second, fmt.Errorf("wrapped: %w", status.Error(codes.Unavailable, "")))
+
+	require.Len(t, testableLatency.observations, 2)
+	assert.Equal(t, float64(1), testableLatency.observations[0].observation)
+	assert.Equal(t, prometheus.Labels{labelGrpcCode: codes.OK.String()}, testableLatency.observations[0].labels)
+	assert.Equal(t, float64(2), testableLatency.observations[1].observation)
+	assert.Equal(t, prometheus.Labels{labelGrpcCode: codes.Unavailable.String()}, testableLatency.observations[1].labels)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/cluster/handlers"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/go-multierror"
 	nodee "github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/multihop"
@@ -140,11 +141,14 @@ func (w *Worker) configureForWorker(ln *base.ServerListener, logger *log.Logger)
 	downstreamServer := grpc.NewServer(
 		grpc.MaxRecvMsgSize(math.MaxInt32),
 		grpc.MaxSendMsgSize(math.MaxInt32),
+		grpc.UnaryInterceptor(tracing.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(tracing.StreamServerInterceptor()),
 	)
 	multihopService, err := handlers.NewMultihopServiceServer(
 		w.WorkerAuthStorage,
 		false,
 		w.controllerMultihopConn,
+		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s: error creating multihop service handler: %w", op, err)
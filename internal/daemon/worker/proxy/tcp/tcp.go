@@ -7,10 +7,13 @@ import (
 	"net"
 	"net/url"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/daemon/worker/internal/metric"
 	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
 	"github.com/hashicorp/boundary/internal/daemon/worker/session"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"nhooyr.io/websocket"
 )
 
@@ -30,6 +33,7 @@ func init() {
 //
 // All options are ignored.
 func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) error {
+	const op = "tcp.handleProxy"
 	conn := conf.ClientConn
 	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
 	if err != nil {
@@ -38,10 +42,12 @@ func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) erro
 	if sessionUrl.Scheme != "tcp" {
 		return fmt.Errorf("invalid scheme for tcp proxy: %v", sessionUrl.Scheme)
 	}
+	dialStart := time.Now()
 	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
 	if err != nil {
 		return fmt.Errorf("error dialing endpoint: %w", err)
 	}
+	metric.RecordDialLatency(time.Since(dialStart))
 	// Assert this for better Go 1.11 splice support
 	tcpRemoteConn := remoteConn.(*net.TCPConn)
 
@@ -63,26 +69,70 @@ func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) erro
 
 	// Update connection info to set connection status
 	conf.SessionInfo.Lock()
-	conf.SessionInfo.ConnInfoMap[conf.ConnectionId].Status = connStatus
+	ci := conf.SessionInfo.ConnInfoMap[conf.ConnectionId]
+	ci.Status = connStatus
+	if ci.Connection == nil {
+		ci.Connection = &event.Connection{
+			SessionId:     conf.SessionInfo.Id,
+			ConnectionId:  conf.ConnectionId,
+			Endpoint:      conf.RemoteEndpoint,
+			ClientAddress: conf.ClientAddress.String(),
+			UserClientIp:  conf.UserClientIp.String(),
+		}
+	}
+	ci.Connection.EndpointAddress = endpointAddr.String()
+	connEvent := *ci.Connection
 	conf.SessionInfo.Unlock()
+	session.WriteConnectionEvent(ctx, op, event.ConnectionConnected, connEvent)
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
 
+	conf.SessionInfo.RLock()
+	targetId := conf.SessionInfo.LookupSessionResponse.GetTargetId()
+	conf.SessionInfo.RUnlock()
+
+	// The proxied bytes are recorded as they're read so the metrics of long
+	// lived connections don't wait for the connection to close.
+	down := &meteredReader{Reader: tcpRemoteConn, record: func(n int64) { metric.RecordProxiedBytes(targetId, 0, n) }}
+	up := &meteredReader{Reader: netConn, record: func(n int64) { metric.RecordProxiedBytes(targetId, n, 0) }}
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(netConn, tcpRemoteConn)
+		_, _ = io.Copy(netConn, down)
 		_ = netConn.Close()
 		_ = tcpRemoteConn.Close()
 	}()
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(tcpRemoteConn, netConn)
+		_, _ = io.Copy(tcpRemoteConn, up)
 		_ = tcpRemoteConn.Close()
 		_ = netConn.Close()
 	}()
 	connWg.Wait()
+
+	conf.SessionInfo.Lock()
+	ci.Connection.BytesUp = up.total
+	ci.Connection.BytesDown = down.total
+	conf.SessionInfo.Unlock()
 	return nil
 }
+
+// meteredReader records the count of bytes of each read from the underlying
+// reader and keeps their total.
+type meteredReader struct {
+	io.Reader
+	record func(n int64)
+	total  int64
+}
+
+// Read implements io.Reader.
+func (r *meteredReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.total += int64(n)
+		r.record(int64(n))
+	}
+	return n, err
+}
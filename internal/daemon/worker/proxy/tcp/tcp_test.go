@@ -3,8 +3,12 @@ package tcp
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
 	"github.com/hashicorp/boundary/internal/daemon/worker/session"
@@ -80,6 +84,20 @@ func TestHandleTcpProxyV1(t *testing.T) {
 	}()
 	t.Cleanup(func() {
 		require.NoError(<-errChan)
+
+		// the connection's details are updated for its lifecycle events
+		si.RLock()
+		defer si.RUnlock()
+		c := si.ConnInfoMap["mock-connection"].Connection
+		require.NotNil(c)
+		assert.Equal("one", c.SessionId)
+		assert.Equal("mock-connection", c.ConnectionId)
+		assert.Equal(clientAddr.String(), c.ClientAddress)
+		_, endpointPort, err := net.SplitHostPort(c.EndpointAddress)
+		require.NoError(err)
+		assert.Equal(strconv.Itoa(port), endpointPort)
+		assert.Equal(int64(len("client write to endpoint via proxy")), c.BytesUp)
+		assert.Equal(int64(len("endpoint write to client via proxy")), c.BytesDown)
 	})
 
 	// wait for HandleTcpProxyV1 to dial endpoint
@@ -109,3 +127,16 @@ func TestHandleTcpProxyV1(t *testing.T) {
 
 	cancelCtx()
 }
+
+func TestMeteredReader(t *testing.T) {
+	var recorded []int64
+	r := &meteredReader{
+		Reader: iotest.OneByteReader(strings.NewReader("abc")),
+		record: func(n int64) { recorded = append(recorded, n) },
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(b))
+	assert.Equal(t, int64(3), r.total)
+	assert.Equal(t, []int64{1, 1, 1}, recorded)
+}
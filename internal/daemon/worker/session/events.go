@@ -0,0 +1,62 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/session"
+)
+
+// WriteConnectionEvent writes an audit event and an observation of the given
+// type for a stage of a connection's lifecycle.  Errors writing the events are
+// sent as error events, so the connection isn't interrupted by them.
+func WriteConnectionEvent(ctx context.Context, caller event.Op, t event.AuditEventType, c event.Connection) {
+	const op = "session.WriteConnectionEvent"
+	if t == event.ConnectionClosed && c.ClosedReason == "" {
+		c.ClosedReason = session.UnknownReason.String()
+	}
+	if err := event.WriteAudit(ctx, caller, event.WithAuditType(t), event.WithConnection(&c)); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write connection audit event", "type", t, "connection_id", c.ConnectionId))
+	}
+	details := []interface{}{
+		"type", string(t),
+		"session_id", c.SessionId,
+		"connection_id", c.ConnectionId,
+		"target_id", c.TargetId,
+		"user_id", c.UserId,
+		"worker_id", c.WorkerId,
+		"endpoint", c.Endpoint,
+		"client_address", c.ClientAddress,
+		"user_client_ip", c.UserClientIp,
+	}
+	switch t {
+	case event.ConnectionConnected:
+		details = append(details, "endpoint_address", c.EndpointAddress)
+	case event.ConnectionClosed:
+		details = append(details,
+			"endpoint_address", c.EndpointAddress,
+			"bytes_up", c.BytesUp,
+			"bytes_down", c.BytesDown,
+			"closed_reason", c.ClosedReason,
+		)
+	}
+	if err := event.WriteObservation(ctx, caller, event.WithDetails(details...)); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write connection observation", "type", t, "connection_id", c.ConnectionId))
+	}
+}
+
+// ConnectionClosedReason returns the reason a proxied connection was closed,
+// given the connection's context and the error returned by its proxy handler.
+func ConnectionClosedReason(connCtx context.Context, proxyErr error) session.ClosedReason {
+	switch {
+	case errors.Is(connCtx.Err(), context.DeadlineExceeded):
+		return session.ConnectionTimedOut
+	case errors.Is(connCtx.Err(), context.Canceled):
+		return session.ConnectionCanceled
+	case proxyErr != nil:
+		return session.ConnectionNetworkError
+	default:
+		return session.ConnectionClosedByUser
+	}
+}
@@ -0,0 +1,192 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConnectionEvent(t *testing.T) {
+	c := event.TestEventerConfig(t, "TestWriteConnectionEvent", event.TestWithAuditSink(t), event.TestWithObservationSink(t))
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+		Name:  "test",
+	})
+	e, err := event.NewEventer(testLogger, testLock, "TestWriteConnectionEvent", c.EventerConfig)
+	require.NoError(t, err)
+	ctx, err := event.NewEventerContext(context.Background(), e)
+	require.NoError(t, err)
+
+	conn := event.Connection{
+		SessionId:       "s_1234567890",
+		ConnectionId:    "sc_1234567890",
+		TargetId:        "ttcp_1234567890",
+		UserId:          "u_1234567890",
+		WorkerId:        "w_1234567890",
+		Endpoint:        "tcp://127.0.0.1:22",
+		ClientAddress:   "127.0.0.1:54321",
+		UserClientIp:    "127.0.0.1",
+		EndpointAddress: "127.0.0.1:22",
+		BytesUp:         100,
+		BytesDown:       200,
+	}
+	tests := []struct {
+		name            string
+		typ             event.AuditEventType
+		wantAudit       map[string]interface{}
+		wantObservation map[string]interface{}
+	}{
+		{
+			name: "authorized",
+			typ:  event.ConnectionAuthorized,
+			wantObservation: map[string]interface{}{
+				"op":             "TestWriteConnectionEvent",
+				"type":           string(event.ConnectionAuthorized),
+				"session_id":     conn.SessionId,
+				"connection_id":  conn.ConnectionId,
+				"target_id":      conn.TargetId,
+				"user_id":        conn.UserId,
+				"worker_id":      conn.WorkerId,
+				"endpoint":       conn.Endpoint,
+				"client_address": conn.ClientAddress,
+				"user_client_ip": conn.UserClientIp,
+			},
+		},
+		{
+			name: "connected",
+			typ:  event.ConnectionConnected,
+			wantObservation: map[string]interface{}{
+				"op":               "TestWriteConnectionEvent",
+				"type":             string(event.ConnectionConnected),
+				"session_id":       conn.SessionId,
+				"connection_id":    conn.ConnectionId,
+				"target_id":        conn.TargetId,
+				"user_id":          conn.UserId,
+				"worker_id":        conn.WorkerId,
+				"endpoint":         conn.Endpoint,
+				"client_address":   conn.ClientAddress,
+				"user_client_ip":   conn.UserClientIp,
+				"endpoint_address": conn.EndpointAddress,
+			},
+		},
+		{
+			name: "closed",
+			typ:  event.ConnectionClosed,
+			wantObservation: map[string]interface{}{
+				"op":               "TestWriteConnectionEvent",
+				"type":             string(event.ConnectionClosed),
+				"session_id":       conn.SessionId,
+				"connection_id":    conn.ConnectionId,
+				"target_id":        conn.TargetId,
+				"user_id":          conn.UserId,
+				"worker_id":        conn.WorkerId,
+				"endpoint":         conn.Endpoint,
+				"client_address":   conn.ClientAddress,
+				"user_client_ip":   conn.UserClientIp,
+				"endpoint_address": conn.EndpointAddress,
+				"bytes_up":         float64(conn.BytesUp),
+				"bytes_down":       float64(conn.BytesDown),
+				"closed_reason":    session.UnknownReason.String(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			require.NoError(os.Truncate(c.AuditEvents.Name(), 0))
+			require.NoError(os.Truncate(c.ObservationEvents.Name(), 0))
+
+			WriteConnectionEvent(ctx, "TestWriteConnectionEvent", tt.typ, conn)
+
+			audit := readEvent(t, c.AuditEvents.Name())
+			assert.Equal(string(tt.typ), audit["type"])
+			gotConn, ok := audit["connection"].(map[string]interface{})
+			require.True(ok)
+			assert.Equal(conn.SessionId, gotConn["session_id"])
+			assert.Equal(conn.ConnectionId, gotConn["connection_id"])
+			assert.Equal(conn.ClientAddress, gotConn["client_address"])
+			assert.Equal(conn.EndpointAddress, gotConn["endpoint_address"])
+			assert.Equal(float64(conn.BytesUp), gotConn["bytes_up"])
+			assert.Equal(float64(conn.BytesDown), gotConn["bytes_down"])
+			if tt.typ == event.ConnectionClosed {
+				assert.Equal(session.UnknownReason.String(), gotConn["closed_reason"])
+			}
+
+			observation := readEvent(t, c.ObservationEvents.Name())
+			details, ok := observation["details"].([]interface{})
+			require.True(ok)
+			require.Len(details, 1)
+			payload, ok := details[0].(map[string]interface{})["payload"]
+			require.True(ok)
+			assert.Equal(tt.wantObservation, payload)
+		})
+	}
+}
+
+func readEvent(t *testing.T, name string) map[string]interface{} {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 1)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	data, ok := got["data"].(map[string]interface{})
+	require.True(t, ok)
+	return data
+}
+
+func TestConnectionClosedReason(t *testing.T) {
+	t.Parallel()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	timedOut, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		proxyErr error
+		want     session.ClosedReason
+	}{
+		{
+			name: "closed",
+			ctx:  context.Background(),
+			want: session.ConnectionClosedByUser,
+		},
+		{
+			name:     "proxy-error",
+			ctx:      context.Background(),
+			proxyErr: errors.New("error dialing endpoint"),
+			want:     session.ConnectionNetworkError,
+		},
+		{
+			name:     "canceled",
+			ctx:      canceled,
+			proxyErr: errors.New("error dialing endpoint"),
+			want:     session.ConnectionCanceled,
+		},
+		{
+			name: "timed-out",
+			ctx:  timedOut,
+			want: session.ConnectionTimedOut,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ConnectionClosedReason(tt.ctx, tt.proxyErr))
+		})
+	}
+}
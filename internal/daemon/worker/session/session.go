@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/daemon/worker/common"
+	"github.com/hashicorp/boundary/internal/daemon/worker/internal/metric"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/session"
@@ -27,6 +28,9 @@ type ConnInfo struct {
 	ConnCancel context.CancelFunc
 	Status     pbs.CONNECTIONSTATUS
 	CloseTime  time.Time
+	// Connection holds the details written with the connection's lifecycle
+	// events, which are added to as the connection progresses.
+	Connection *event.Connection
 }
 
 // Info defines the information about a session
@@ -139,7 +143,8 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 	// within an adequate period of time.
 	closeConnCtx, closeConnCancel := context.WithTimeout(ctx, common.StatusTimeout)
 	defer closeConnCancel()
-	response, err := closeConnection(closeConnCtx, sessClient, makeCloseConnectionRequest(closeInfo))
+	request := makeCloseConnectionRequest(closeInfo)
+	response, err := closeConnection(closeConnCtx, sessClient, request)
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error marking connections closed",
 			"warning", "error contacting controller, connections will be closed only on worker",
@@ -160,7 +165,8 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 	}
 
 	// Mark connections as closed
-	_, errs := setCloseTimeForResponse(sessionInfo, sessionCloseInfo)
+	closedIds, errs := setCloseTimeForResponse(sessionInfo, sessionCloseInfo)
+	recordClosedConnections(request, closedIds)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error marking connection closed in state"))
@@ -171,6 +177,22 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 	return true
 }
 
+// recordClosedConnections records the reason given in the request for each
+// connection which was closed.
+func recordClosedConnections(request *pbs.CloseConnectionRequest, closedIds []string) {
+	reasons := make(map[string]string, len(request.GetCloseRequestData()))
+	for _, d := range request.GetCloseRequestData() {
+		reasons[d.GetConnectionId()] = d.GetReason()
+	}
+	for _, id := range closedIds {
+		reason := session.ClosedReason(reasons[id])
+		if reason == "" {
+			reason = session.UnknownReason
+		}
+		metric.RecordConnectionClosed(reason)
+	}
+}
+
 // makeCloseConnectionRequest creates a CloseConnectionRequest for
 // use with closing connections.
 //
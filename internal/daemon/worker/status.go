@@ -10,11 +10,14 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/servers"
 
 	"github.com/hashicorp/boundary/internal/daemon/worker/common"
+	"github.com/hashicorp/boundary/internal/daemon/worker/internal/metric"
 	"github.com/hashicorp/boundary/internal/daemon/worker/session"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/version"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/resolver"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LastStatusInformation struct {
@@ -155,17 +158,40 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 			event.WithInfoMsg("error making status request to controller"))
 	}
 
+	workerStatus := &pb.ServerWorkerStatus{
+		Name:        w.conf.RawConfig.Worker.Name,
+		Description: w.conf.RawConfig.Worker.Description,
+		Address:     w.conf.RawConfig.Worker.PublicAddr,
+		Tags:        tags,
+		KeyId:       keyId,
+	}
+	// Like tags, the version and features only change when the worker
+	// restarts, so they are only sent when tags are being updated.
+	if w.updateTags.Load() {
+		workerStatus.ReleaseVersion = version.Get().VersionNumber()
+		for _, f := range version.SupportedFeatures() {
+			workerStatus.Features = append(workerStatus.Features, f.String())
+		}
+	}
+	if info := w.AuthRotationInfo(); info != nil {
+		if !info.CertificateExpiration.IsZero() {
+			workerStatus.CertificateExpiration = timestamppb.New(info.CertificateExpiration)
+		}
+		if !info.LastRotationTime.IsZero() {
+			workerStatus.LastRotationTime = timestamppb.New(info.LastRotationTime)
+		}
+		if info.LastRotationError != nil {
+			workerStatus.LastRotationError = info.LastRotationError.Error()
+		}
+	}
+
+	statusStart := time.Now()
 	result, err := client.Status(statusCtx, &pbs.StatusRequest{
-		Jobs: activeJobs,
-		WorkerStatus: &pb.ServerWorkerStatus{
-			Name:        w.conf.RawConfig.Worker.Name,
-			Description: w.conf.RawConfig.Worker.Description,
-			Address:     w.conf.RawConfig.Worker.PublicAddr,
-			Tags:        tags,
-			KeyId:       keyId,
-		},
-		UpdateTags: w.updateTags.Load(),
+		Jobs:         activeJobs,
+		WorkerStatus: workerStatus,
+		UpdateTags:   w.updateTags.Load(),
 	})
+	metric.RecordStatusLatency(time.Since(statusStart), err)
 	if err != nil {
 		event.WriteError(statusCtx, op, err, event.WithInfoMsg("error making status request to controller"))
 		// Check for last successful status. Ignore nil last status, this probably
@@ -224,6 +250,11 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 		}
 		w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: result, StatusTime: time.Now(), LastCalculatedUpstreams: newUpstreams})
 
+		if result.GetRotateAuth() {
+			event.WriteSysEvent(cancelCtx, op, "controller requested worker auth rotation")
+			w.requestAuthRotation()
+		}
+
 		for _, request := range result.GetJobsRequests() {
 			switch request.GetRequestType() {
 			case pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE:
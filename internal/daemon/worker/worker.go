@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
@@ -66,6 +67,17 @@ type Worker struct {
 
 	controllerMultihopConn *atomic.Value
 
+	// authRotationInfo stores the outcome of the most recent PKI auth
+	// rotation check
+	authRotationInfo *atomic.Value
+	// authRotationRequests is used to ask the auth rotation goroutine to
+	// rotate immediately
+	authRotationRequests chan struct{}
+
+	// shuttingDown indicates that the health endpoint should report the
+	// worker as unavailable
+	shuttingDown *ua.Bool
+
 	proxyListener *base.ServerListener
 
 	// Used to generate a random nonce for Controller connections
@@ -95,6 +107,7 @@ func New(conf *Config) (*Worker, error) {
 	metric.InitializeHttpCollectors(conf.PrometheusRegisterer)
 	metric.InitializeWebsocketCollectors(conf.PrometheusRegisterer)
 	metric.InitializeClusterClientCollectors(conf.PrometheusRegisterer)
+	metric.InitializeSessionCollectors(conf.PrometheusRegisterer)
 
 	w := &Worker{
 		conf:                   conf,
@@ -108,6 +121,9 @@ func New(conf *Config) (*Worker, error) {
 		controllerSessionConn:  new(atomic.Value),
 		sessionInfoMap:         new(sync.Map),
 		controllerMultihopConn: new(atomic.Value),
+		authRotationInfo:       new(atomic.Value),
+		authRotationRequests:   make(chan struct{}, 1),
+		shuttingDown:           ua.NewBool(false),
 		tags:                   new(atomic.Value),
 		updateTags:             ua.NewBool(false),
 		nonceFn:                base62.Random,
@@ -115,6 +131,7 @@ func New(conf *Config) (*Worker, error) {
 	}
 
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
+	w.authRotationInfo.Store((*AuthRotationInformation)(nil))
 	w.controllerResolver.Store((*manual.Resolver)(nil))
 
 	if conf.RawConfig.Worker == nil {
@@ -197,6 +214,14 @@ func (w *Worker) Start() error {
 			return fmt.Errorf("error loading worker auth storage directory: %w", err)
 		}
 
+		var activationTokenNonce []byte
+		if token := w.conf.RawConfig.Worker.ControllerGeneratedActivationToken; token != "" {
+			activationTokenNonce, err = decodeActivationToken(token)
+			if err != nil {
+				return fmt.Errorf("error decoding worker activation token: %w", err)
+			}
+		}
+
 		var createNodeAuthCreds bool
 		var createFetchRequest bool
 		nodeCreds, err := types.LoadNodeCredentials(w.baseContext, w.WorkerAuthStorage, nodeenrollment.CurrentId, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms))
@@ -222,6 +247,12 @@ func (w *Worker) Start() error {
 				// by creating new credentials. However, we will generate and
 				// display a new valid request in case the first was lost.
 				createFetchRequest = true
+				// If an activation token has been configured since these
+				// credentials were generated, regenerate them so the token is
+				// presented.
+				if activationTokenNonce != nil && !bytes.Equal(nodeCreds.RegistrationNonce, activationTokenNonce) {
+					createNodeAuthCreds = true
+				}
 
 			default:
 				now := time.Now()
@@ -258,10 +289,20 @@ func (w *Worker) Start() error {
 				w.WorkerAuthStorage,
 				nodeenrollment.WithRandomReader(w.conf.SecureRandomReader),
 				nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms),
+				nodeenrollment.WithSkipStorage(activationTokenNonce != nil),
 			)
 			if err != nil {
 				return fmt.Errorf("error generating new worker auth creds: %w", err)
 			}
+			if activationTokenNonce != nil {
+				// The activation token is presented as the registration nonce,
+				// which lets the controller authorize the worker when it
+				// fetches its credentials
+				nodeCreds.RegistrationNonce = activationTokenNonce
+				if err := nodeCreds.Store(w.baseContext, w.WorkerAuthStorage, nodeenrollment.WithWrapper(w.conf.WorkerAuthStorageKms)); err != nil {
+					return fmt.Errorf("error storing new worker auth creds: %w", err)
+				}
+			}
 		}
 
 		if createFetchRequest {
@@ -332,6 +373,10 @@ func (w *Worker) Shutdown() error {
 		return nil
 	}
 
+	// Ensure health reports the worker as unavailable from here on out, even
+	// if no graceful shutdown wait was configured
+	w.StartServiceUnavailableReplies()
+
 	// Stop listeners first to prevent new connections to the
 	// controller.
 	defer w.started.Store(false)
@@ -595,3 +640,20 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 
 	return tlsConf, nil
 }
+
+// decodeActivationToken decodes an activation token generated by the
+// controller led node enrollment flow into the registration nonce the worker
+// presents when fetching its credentials.
+func decodeActivationToken(token string) ([]byte, error) {
+	if !strings.HasPrefix(token, globals.ControllerLedActivationTokenPrefix) {
+		return nil, errors.New("activation token has an unknown prefix")
+	}
+	nonce, err := base58.Decode(strings.TrimPrefix(token, globals.ControllerLedActivationTokenPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode activation token: %w", err)
+	}
+	if len(nonce) != nodeenrollment.NonceSize {
+		return nil, errors.New("activation token has an invalid length")
+	}
+	return nonce, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/db"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/nodeenrollment/rotation"
 	nodeefile "github.com/hashicorp/nodeenrollment/storage/file"
 	"github.com/hashicorp/nodeenrollment/types"
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -274,3 +276,36 @@ func TestSetupWorkerAuthStorage(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeActivationToken(t *testing.T) {
+	want := make([]byte, nodeenrollment.NonceSize)
+	_, err := rand.Read(want)
+	require.NoError(t, err)
+	got, err := decodeActivationToken(globals.ControllerLedActivationTokenPrefix + base58.Encode(want))
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "missing-prefix",
+			token: base58.Encode(want),
+		},
+		{
+			name:  "not-base58",
+			token: globals.ControllerLedActivationTokenPrefix + "0OIl",
+		},
+		{
+			name:  "wrong-length",
+			token: globals.ControllerLedActivationTokenPrefix + base58.Encode([]byte("too short")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeActivationToken(tt.token)
+			require.Error(t, err)
+		})
+	}
+}
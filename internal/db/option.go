@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -175,6 +176,8 @@ type Options struct {
 
 	withOnConflict   *OnConflict
 	withRowsAffected *int64
+
+	withMaxReplicaLag time.Duration
 }
 
 type oplogOpts struct {
@@ -194,6 +197,7 @@ func getDefaultOptions() Options {
 		WithNullPaths:      []string{},
 		WithLimit:          0,
 		WithVersion:        nil,
+		withMaxReplicaLag:  DefaultMaxReplicaLag,
 	}
 }
 
@@ -336,3 +340,14 @@ func WithReturnRowsAffected(rowsAffected *int64) Option {
 		o.withRowsAffected = rowsAffected
 	}
 }
+
+// WithMaxReplicaLag specifies the amount a read replica can lag behind the
+// primary database before reads are made against the primary instead. A zero
+// value leaves the default of DefaultMaxReplicaLag.
+func WithMaxReplicaLag(lag time.Duration) Option {
+	return func(o *Options) {
+		if lag > 0 {
+			o.withMaxReplicaLag = lag
+		}
+	}
+}
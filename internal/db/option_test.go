@@ -2,6 +2,7 @@ package db
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/go-hclog"
@@ -231,4 +232,18 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withRowsAffected = &rowsAffected
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithMaxReplicaLag", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(DefaultMaxReplicaLag, testOpts.withMaxReplicaLag)
+		assert.Equal(opts, testOpts)
+		opts = GetOpts(WithMaxReplicaLag(time.Minute))
+		testOpts.withMaxReplicaLag = time.Minute
+		assert.Equal(opts, testOpts)
+		// a zero lag leaves the default
+		opts = GetOpts(WithMaxReplicaLag(0))
+		assert.Equal(DefaultMaxReplicaLag, opts.withMaxReplicaLag)
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/oplog/store"
 	"github.com/hashicorp/go-dbw"
@@ -215,8 +216,10 @@ func (rw *Db) UnderlyingDB() *dbw.DB {
 
 // Exec will execute the sql with the values as parameters. The int returned
 // is the number of rows affected by the sql. WithDebug is supported.
-func (rw *Db) Exec(ctx context.Context, sql string, values []interface{}, opt ...Option) (int, error) {
+func (rw *Db) Exec(ctx context.Context, sql string, values []interface{}, opt ...Option) (_ int, retErr error) {
 	const op = "db.Exec"
+	ctx, span := startSqlSpan(ctx, op, sql)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if sql == "" {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing sql")
 	}
@@ -232,8 +235,10 @@ func (rw *Db) Exec(ctx context.Context, sql string, values []interface{}, opt ..
 // operate within the context of any ongoing transaction for the db.Reader.  The
 // caller must close the returned *sql.Rows. Query can/should be used in
 // combination with ScanRows.
-func (rw *Db) Query(ctx context.Context, sql string, values []interface{}, opt ...Option) (*sql.Rows, error) {
+func (rw *Db) Query(ctx context.Context, sql string, values []interface{}, opt ...Option) (_ *sql.Rows, retErr error) {
 	const op = "db.Query"
+	ctx, span := startSqlSpan(ctx, op, sql)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if sql == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing sql")
 	}
@@ -275,8 +280,10 @@ func (rw *Db) ScanRows(ctx context.Context, rows *sql.Rows, result interface{})
 // valid value for the WithVersion option and will return an error. WithWhere
 // allows specifying an additional constraint on the on conflict operation in
 // addition to the on conflict target policy (columns or constraint).
-func (rw *Db) Create(ctx context.Context, i interface{}, opt ...Option) error {
+func (rw *Db) Create(ctx context.Context, i interface{}, opt ...Option) (retErr error) {
 	const op = "db.Create"
+	ctx, span := startSpan(ctx, op, i)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -294,8 +301,10 @@ func (rw *Db) Create(ctx context.Context, i interface{}, opt ...Option) error {
 // WithDebug, WithOplog, WithOplogMsgs, WithReturnRowsAffected, OnConflict,
 // WithVersion, and WithWhere  WithOplog and WithOplogMsgs may not be used
 // together.  WithLookup is not a supported option.
-func (rw *Db) CreateItems(ctx context.Context, createItems []interface{}, opt ...Option) error {
+func (rw *Db) CreateItems(ctx context.Context, createItems []interface{}, opt ...Option) (retErr error) {
 	const op = "db.CreateItems"
+	ctx, span := startSpan(ctx, op, createItems)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -329,8 +338,10 @@ func (rw *Db) CreateItems(ctx context.Context, createItems []interface{}, opt ..
 // WithVersion option and will return an error. WithWhere allows specifying an
 // additional constraint on the operation in addition to the PKs. WithDebug will
 // turn on debugging for the update call.
-func (rw *Db) Update(ctx context.Context, i interface{}, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (int, error) {
+func (rw *Db) Update(ctx context.Context, i interface{}, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (_ int, retErr error) {
 	const op = "db.Update"
+	ctx, span := startSpan(ctx, op, i)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -353,8 +364,10 @@ func (rw *Db) Update(ctx context.Context, i interface{}, fieldMaskPaths []string
 // in-memory oplog message. WithOplog and NewOplogMsg cannot be used together.
 // WithWhere allows specifying an additional constraint on the operation in
 // addition to the PKs. Delete returns the number of rows deleted and any errors.
-func (rw *Db) Delete(ctx context.Context, i interface{}, opt ...Option) (int, error) {
+func (rw *Db) Delete(ctx context.Context, i interface{}, opt ...Option) (_ int, retErr error) {
 	const op = "db.Delete"
+	ctx, span := startSpan(ctx, op, i)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -372,8 +385,10 @@ func (rw *Db) Delete(ctx context.Context, i interface{}, opt ...Option) (int, er
 // DeleteItems will delete multiple items of the same type. Supported options:
 // WithOplog and WithOplogMsgs.  WithOplog and WithOplogMsgs may not be used
 // together.
-func (rw *Db) DeleteItems(ctx context.Context, deleteItems []interface{}, opt ...Option) (int, error) {
+func (rw *Db) DeleteItems(ctx context.Context, deleteItems []interface{}, opt ...Option) (_ int, retErr error) {
 	const op = "db.DeleteItems"
+	ctx, span := startSpan(ctx, op, deleteItems)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -392,8 +407,10 @@ func (rw *Db) DeleteItems(ctx context.Context, deleteItems []interface{}, opt ..
 // you should ensure that any objects written to the db in your TxHandler are retryable, which
 // means that the object may be sent to the db several times (retried), so things like the primary key must
 // be reset before retry
-func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler TxHandler) (RetryInfo, error) {
+func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler TxHandler) (_ RetryInfo, retErr error) {
 	const op = "db.DoTx"
+	ctx, span := startSpan(ctx, op, nil)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if w.underlying == nil {
 		return RetryInfo{}, errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -442,8 +459,10 @@ func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, handler Tx
 
 // LookupByPublicId will lookup resource by its public_id or private_id, which
 // must be unique. WithDebug is the only valid option, all other options are ignored.
-func (rw *Db) LookupById(ctx context.Context, resourceWithIder interface{}, opt ...Option) error {
+func (rw *Db) LookupById(ctx context.Context, resourceWithIder interface{}, opt ...Option) (retErr error) {
 	const op = "db.LookupById"
+	ctx, span := startSpan(ctx, op, resourceWithIder)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -468,8 +487,10 @@ func (rw *Db) LookupByPublicId(ctx context.Context, resource ResourcePublicIder,
 
 // LookupWhere will lookup the first resource using a where clause with
 // parameters (it only returns the first one). WithDebug is supported.
-func (rw *Db) LookupWhere(ctx context.Context, resource interface{}, where string, args []interface{}, opt ...Option) error {
+func (rw *Db) LookupWhere(ctx context.Context, resource interface{}, where string, args []interface{}, opt ...Option) (retErr error) {
 	const op = "db.LookupWhere"
+	ctx, span := startSpan(ctx, op, resource)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
@@ -493,8 +514,10 @@ func (rw *Db) LookupWhere(ctx context.Context, resource interface{}, where strin
 // Supports the WithLimit option.  If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
 // Supports the WithOrder and WithDebug options.
-func (rw *Db) SearchWhere(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+func (rw *Db) SearchWhere(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) (retErr error) {
 	const op = "db.SearchWhere"
+	ctx, span := startSpan(ctx, op, resources)
+	defer func() { tracing.EndSpan(span, retErr) }()
 	if rw.underlying == nil {
 		return errors.New(ctx, errors.InvalidParameter, op, "missing underlying db")
 	}
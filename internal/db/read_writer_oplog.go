@@ -96,7 +96,7 @@ func (rw *Db) generateOplogBeforeAfterOpts(ctx context.Context, i interface{}, o
 			switch {
 			case onConflictDoNothing && rowsAffected == 0:
 			default:
-				if err := rw.addOplog(ctx, CreateOp, opts, ticket, i); err != nil {
+				if err := rw.addOplog(ctx, opType, opts, ticket, i); err != nil {
 					return errors.Wrap(ctx, err, op)
 				}
 			}
@@ -120,7 +120,7 @@ func (rw *Db) generateOplogBeforeAfterOpts(ctx context.Context, i interface{}, o
 			switch {
 			case onConflictDoNothing && rowsAffected == 0:
 			default:
-				msg, err := rw.newOplogMessage(ctx, CreateOp, i)
+				msg, err := rw.newOplogMessage(ctx, opType, i, WithFieldMaskPaths(opts.WithFieldMaskPaths), WithNullPaths(opts.WithNullPaths))
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("returning oplog failed"))
 				}
@@ -133,7 +133,7 @@ func (rw *Db) generateOplogBeforeAfterOpts(ctx context.Context, i interface{}, o
 		afterFn = func(i interface{}, rowsAffected int) error {
 			const op = "db.afterFnNewOplogMsgs"
 			if rowsAffected > 0 {
-				msgs, err := rw.oplogMsgsForItems(ctx, CreateOp, opts, items)
+				msgs, err := rw.oplogMsgsForItems(ctx, opType, opts, items)
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("returning oplog msgs failed"))
 				}
@@ -476,6 +476,9 @@ func TestDb_Update(t *testing.T) {
 		rowsUpdated, err := w.Update(context.Background(), user, []string{"Name"}, nil, NewOplogMsg(&updateMsg))
 		require.NoError(err)
 		assert.Equal(1, rowsUpdated)
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE, createMsg.OpType)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, updateMsg.OpType)
+		assert.Equal([]string{"Name"}, updateMsg.FieldMaskPaths)
 
 		foundUser, err := db_test.NewTestUser()
 		require.NoError(err)
@@ -1551,6 +1554,8 @@ func TestDb_Delete(t *testing.T) {
 			rowsDeleted, err := w.Delete(context.Background(), user, NewOplogMsg(&deleteMsg))
 			require.NoError(err)
 			assert.Equal(1, rowsDeleted)
+			assert.Equal(oplog.OpType_OP_TYPE_CREATE, createMsg.OpType)
+			assert.Equal(oplog.OpType_OP_TYPE_DELETE, deleteMsg.OpType)
 
 			foundUser, err := db_test.NewTestUser()
 			require.NoError(err)
@@ -1843,7 +1848,7 @@ func TestDb_CreateItems(t *testing.T) {
 			if tt.wantOplogMsgs {
 				assert.Equal(len(tt.args.createItems), len(returnedMsgs))
 				for _, m := range returnedMsgs {
-					assert.Equal(m.OpType, oplog.OpType_OP_TYPE_CREATE)
+					assert.Equal(m.OpType, oplog.OpType_OP_TYPE_CREATE_ITEMS)
 				}
 			}
 		})
@@ -2036,7 +2041,7 @@ func TestDb_DeleteItems(t *testing.T) {
 			if tt.wantOplogMsgs {
 				assert.Equal(len(tt.args.deleteItems), len(returnedMsgs))
 				for _, m := range returnedMsgs {
-					assert.Equal(m.OpType, oplog.OpType_OP_TYPE_DELETE)
+					assert.Equal(m.OpType, oplog.OpType_OP_TYPE_DELETE_ITEMS)
 				}
 			}
 		})
@@ -2966,6 +2971,142 @@ func TestDb_WriteOplogEntryWith(t *testing.T) {
 // 	}
 // }
 
+func TestDb_oplogOperations(t *testing.T) {
+	conn, _ := TestSetup(t, "postgres")
+	TestCreateTables(t, conn)
+	testCtx := context.Background()
+	w := New(conn)
+	wrapper := TestWrapper(t)
+	types, err := oplog.NewTypeCatalog(testCtx, oplog.Type{Interface: new(db_test.TestUser), Name: "db_test_user"})
+	require.NoError(t, err)
+
+	// entryMessages returns the messages of the oplog entry with the op-type
+	// written for the resource.
+	entryMessages := func(t *testing.T, resourceId string, opType oplog.OpType) []oplog.Message {
+		t.Helper()
+		require := require.New(t)
+		var metadata store.Metadata
+		err := w.LookupWhere(testCtx, &metadata, `key = 'resource-public-id' and value = ? and entry_id in (
+  select entry_id
+    from oplog_metadata
+   where key = 'op-type'
+     and value = ?
+)`, []interface{}{resourceId, opType.String()})
+		require.NoError(err)
+		var entry store.Entry
+		require.NoError(w.LookupWhere(testCtx, &entry, "id = ?", []interface{}{metadata.EntryId}))
+		e := &oplog.Entry{Entry: &entry, Cipherer: wrapper}
+		require.NoError(e.DecryptData(testCtx))
+		msgs, err := e.UnmarshalData(testCtx, types)
+		require.NoError(err)
+		return msgs
+	}
+
+	t.Run("WithOplog", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		user := testUser(t, conn, "", "", "")
+		md := func(opType oplog.OpType) Option {
+			return WithOplog(wrapper, oplog.Metadata{
+				"resource-public-id": []string{user.PublicId},
+				"op-type":            []string{opType.String()},
+			})
+		}
+
+		user.Name = "updated-" + user.PublicId
+		user.Email = ""
+		rowsUpdated, err := w.Update(testCtx, user, []string{"Name"}, []string{"Email"}, md(oplog.OpType_OP_TYPE_UPDATE))
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		msgs := entryMessages(t, user.PublicId, oplog.OpType_OP_TYPE_UPDATE)
+		require.Len(msgs, 1)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, msgs[0].OpType)
+		assert.Equal([]string{"Name"}, msgs[0].FieldMaskPaths)
+		assert.Equal([]string{"Email"}, msgs[0].SetToNullPaths)
+
+		rowsDeleted, err := w.Delete(testCtx, user, md(oplog.OpType_OP_TYPE_DELETE))
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+		msgs = entryMessages(t, user.PublicId, oplog.OpType_OP_TYPE_DELETE)
+		require.Len(msgs, 1)
+		assert.Equal(oplog.OpType_OP_TYPE_DELETE, msgs[0].OpType)
+		assert.Equal(user.PublicId, msgs[0].Message.(*db_test.TestUser).PublicId)
+	})
+
+	t.Run("items", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		users := []interface{}{}
+		for i := 0; i < 3; i++ {
+			u, err := db_test.NewTestUser()
+			require.NoError(err)
+			users = append(users, u)
+		}
+		resourceId := users[0].(*db_test.TestUser).PublicId
+		md := func(opType oplog.OpType) Option {
+			return WithOplog(wrapper, oplog.Metadata{
+				"resource-public-id": []string{resourceId},
+				"op-type":            []string{opType.String()},
+			})
+		}
+
+		require.NoError(w.CreateItems(testCtx, users, md(oplog.OpType_OP_TYPE_CREATE_ITEMS)))
+		msgs := entryMessages(t, resourceId, oplog.OpType_OP_TYPE_CREATE_ITEMS)
+		require.Len(msgs, len(users))
+		for _, m := range msgs {
+			assert.Equal(oplog.OpType_OP_TYPE_CREATE_ITEMS, m.OpType)
+		}
+
+		rowsDeleted, err := w.DeleteItems(testCtx, users, md(oplog.OpType_OP_TYPE_DELETE_ITEMS))
+		require.NoError(err)
+		assert.Equal(len(users), rowsDeleted)
+		msgs = entryMessages(t, resourceId, oplog.OpType_OP_TYPE_DELETE_ITEMS)
+		require.Len(msgs, len(users))
+		for _, m := range msgs {
+			assert.Equal(oplog.OpType_OP_TYPE_DELETE_ITEMS, m.OpType)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		createMsg, updateMsg, deleteMsg := oplog.Message{}, oplog.Message{}, oplog.Message{}
+		user, err := db_test.NewTestUser()
+		require.NoError(err)
+		user.Name = "replay-" + user.PublicId
+		require.NoError(w.Create(testCtx, user, NewOplogMsg(&createMsg)))
+		user.Name = "updated-" + user.PublicId
+		_, err = w.Update(testCtx, user, []string{"Name"}, nil, NewOplogMsg(&updateMsg))
+		require.NoError(err)
+		_, err = w.Delete(testCtx, user, NewOplogMsg(&deleteMsg))
+		require.NoError(err)
+
+		ticket, err := w.GetTicket(testCtx, user)
+		require.NoError(err)
+		md := oplog.Metadata{"resource-public-id": []string{user.PublicId}}
+		require.NoError(w.WriteOplogEntryWith(testCtx, wrapper, ticket, md, []*oplog.Message{&createMsg, &updateMsg}))
+
+		var metadata store.Metadata
+		require.NoError(w.LookupWhere(testCtx, &metadata, "key = 'resource-public-id' and value = ?", []interface{}{user.PublicId}))
+		var entry store.Entry
+		require.NoError(w.LookupWhere(testCtx, &entry, "id = ?", []interface{}{metadata.EntryId}))
+		e := &oplog.Entry{Entry: &entry, Cipherer: wrapper}
+		require.NoError(e.DecryptData(testCtx))
+
+		// replaying the create and then the update into the replay table
+		// leaves the updated row.
+		tableSuffix := "_oplog_operations_replay"
+		defer func() {
+			_, err := w.Exec(testCtx, "drop table if exists "+user.TableName()+tableSuffix, nil)
+			require.NoError(err)
+		}()
+		require.NoError(e.Replay(testCtx, &oplog.Writer{DB: conn.wrapped}, types, tableSuffix))
+		found, err := db_test.NewTestUser()
+		require.NoError(err)
+		found.PublicId = user.PublicId
+		found.SetTableName(user.TableName() + tableSuffix)
+		require.NoError(w.LookupByPublicId(testCtx, found))
+		assert.Equal(user.Name, found.Name)
+	})
+}
+
 func TestDb_oplogMsgsForItems(t *testing.T) {
 	t.Parallel()
 
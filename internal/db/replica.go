@@ -0,0 +1,207 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// DefaultMaxReplicaLag is the default amount a read replica can lag behind the
+// primary database before reads are made against the primary instead.
+const DefaultMaxReplicaLag = 5 * time.Second
+
+const (
+	// replicaLagCheckInterval is how long the lag of a read replica is cached
+	// for before it's checked again.
+	replicaLagCheckInterval = time.Second
+
+	// replicaLagCheckTimeout bounds how long checking the lag of a read
+	// replica can take, so an unreachable replica doesn't hold up reads.
+	replicaLagCheckTimeout = time.Second
+)
+
+// replicaLagQuery returns the number of seconds a read replica is lagging
+// behind the primary. A replica which is streaming WAL from the primary and has
+// replayed all of the WAL it has received is not lagging, even if nothing has
+// been written to the primary in a while. A replica whose WAL receiver isn't
+// streaming may be arbitrarily far behind the primary, so its lag is the age of
+// the last transaction it replayed. Null is returned when the lag can't be
+// determined, because the replica hasn't replayed any transactions.
+const replicaLagQuery = `
+select case
+         when not pg_is_in_recovery() then 0
+         when not exists (select from pg_stat_wal_receiver where status = 'streaming')
+           then extract(epoch from now() - pg_last_xact_replay_timestamp())
+         when pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+         else extract(epoch from now() - pg_last_xact_replay_timestamp())
+       end
+;`
+
+type replicaReadsCtxKey struct{}
+
+// WithReplicaReads returns a context which allows a ReplicaReader to route
+// reads made with it to a read replica. It should only be used for operations
+// which don't need to read their own writes.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsCtxKey{}, true)
+}
+
+// WithoutReplicaReads returns a context which makes a ReplicaReader route the
+// reads made with it to the primary, even when ctx was created with
+// WithReplicaReads. It's used for reads which must not be stale, such as the
+// lookups deciding whether a request is authorized.
+func WithoutReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsCtxKey{}, false)
+}
+
+// replicaReadsAllowed reports whether the context was created with
+// WithReplicaReads.
+func replicaReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaReadsCtxKey{}).(bool)
+	return allowed
+}
+
+// readReplica is a read replica along with the most recent check of its lag.
+type readReplica struct {
+	rw *Db
+
+	mu      sync.Mutex
+	checked time.Time
+	lag     time.Duration
+	err     error
+}
+
+// currentLag returns the lag of the replica, checking it if the last check is
+// older than the replicaLagCheckInterval.
+func (r *readReplica) currentLag(ctx context.Context) (time.Duration, error) {
+	const op = "db.(readReplica).currentLag"
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) < replicaLagCheckInterval {
+		return r.lag, r.err
+	}
+	r.checked = time.Now()
+	r.lag, r.err = 0, nil
+
+	ctx, cancel := context.WithTimeout(ctx, replicaLagCheckTimeout)
+	defer cancel()
+	rows, err := r.rw.Query(ctx, replicaLagQuery, nil)
+	if err != nil {
+		r.err = errors.Wrap(ctx, err, op)
+		return r.lag, r.err
+	}
+	defer rows.Close()
+	var seconds sql.NullFloat64
+	for rows.Next() {
+		if err := rows.Scan(&seconds); err != nil {
+			r.err = errors.Wrap(ctx, err, op)
+			return r.lag, r.err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		r.err = errors.Wrap(ctx, err, op)
+		return r.lag, r.err
+	}
+	if !seconds.Valid {
+		r.err = errors.New(ctx, errors.Internal, op, "unable to determine replica lag")
+		return r.lag, r.err
+	}
+	r.lag = time.Duration(seconds.Float64 * float64(time.Second))
+	return r.lag, r.err
+}
+
+// ReplicaReader is a Reader which routes reads to read replicas of the primary
+// database. A read is only routed to a replica if its context was created with
+// WithReplicaReads and a replica is lagging behind the primary by no more than
+// the maximum replica lag, otherwise it's made against the primary. Replicas
+// are used in turn.
+type ReplicaReader struct {
+	primary  *Db
+	replicas []*readReplica
+	maxLag   time.Duration
+	next     uint32
+}
+
+// ensure that ReplicaReader implements the interface of: Reader
+var _ Reader = (*ReplicaReader)(nil)
+
+// NewReplicaReader creates a ReplicaReader which routes reads to the given
+// replicas of the primary. If there are no replicas, all reads are made
+// against the primary. The WithMaxReplicaLag option is supported.
+func NewReplicaReader(ctx context.Context, primary *DB, replicas []*DB, opt ...Option) (*ReplicaReader, error) {
+	const op = "db.NewReplicaReader"
+	if primary == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing primary db")
+	}
+	opts := GetOpts(opt...)
+	r := &ReplicaReader{
+		primary:  New(primary),
+		replicas: make([]*readReplica, 0, len(replicas)),
+		maxLag:   opts.withMaxReplicaLag,
+	}
+	for _, replica := range replicas {
+		if replica == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing replica db")
+		}
+		r.replicas = append(r.replicas, &readReplica{rw: New(replica)})
+	}
+	return r, nil
+}
+
+// reader returns the reader to use with the context: the next replica whose
+// lag is within the maximum replica lag if the context allows it, otherwise
+// the primary.
+func (r *ReplicaReader) reader(ctx context.Context) *Db {
+	if len(r.replicas) == 0 || !replicaReadsAllowed(ctx) {
+		return r.primary
+	}
+	start := atomic.AddUint32(&r.next, 1)
+	for i := 0; i < len(r.replicas); i++ {
+		replica := r.replicas[(int(start)+i)%len(r.replicas)]
+		lag, err := replica.currentLag(ctx)
+		if err == nil && lag <= r.maxLag {
+			return replica.rw
+		}
+	}
+	return r.primary
+}
+
+// LookupById will lookup a resource by its primary key id, which must be
+// unique. WithDebug is supported.
+func (r *ReplicaReader) LookupById(ctx context.Context, resource interface{}, opt ...Option) error {
+	return r.reader(ctx).LookupById(ctx, resource, opt...)
+}
+
+// LookupByPublicId will lookup resource by its public_id, which must be unique.
+// WithDebug is supported.
+func (r *ReplicaReader) LookupByPublicId(ctx context.Context, resource ResourcePublicIder, opt ...Option) error {
+	return r.reader(ctx).LookupByPublicId(ctx, resource, opt...)
+}
+
+// LookupWhere will lookup the first resource using a where clause with
+// parameters (it only returns the first one). WithDebug is supported.
+func (r *ReplicaReader) LookupWhere(ctx context.Context, resource interface{}, where string, args []interface{}, opt ...Option) error {
+	return r.reader(ctx).LookupWhere(ctx, resource, where, args, opt...)
+}
+
+// SearchWhere will search for all the resources it can find using a where
+// clause with parameters. Supports the WithLimit, WithOrder and WithDebug
+// options.
+func (r *ReplicaReader) SearchWhere(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+	return r.reader(ctx).SearchWhere(ctx, resources, where, args, opt...)
+}
+
+// Query will run the raw query and return the *sql.Rows results. The caller
+// must close the returned *sql.Rows.
+func (r *ReplicaReader) Query(ctx context.Context, sql string, values []interface{}, opt ...Option) (*sql.Rows, error) {
+	return r.reader(ctx).Query(ctx, sql, values, opt...)
+}
+
+// ScanRows will scan the rows into the interface
+func (r *ReplicaReader) ScanRows(ctx context.Context, rows *sql.Rows, result interface{}) error {
+	return r.primary.ScanRows(ctx, rows, result)
+}
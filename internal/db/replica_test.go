@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/db_test"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplicaReader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := TestSetup(t, "postgres")
+
+	t.Run("missing-primary", func(t *testing.T) {
+		_, err := NewReplicaReader(ctx, nil, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-replica", func(t *testing.T) {
+		_, err := NewReplicaReader(ctx, conn, []*DB{nil})
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("defaults", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, conn, []*DB{conn})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultMaxReplicaLag, r.maxLag)
+		assert.Len(t, r.replicas, 1)
+	})
+	t.Run("with-max-replica-lag", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, conn, nil, WithMaxReplicaLag(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, r.maxLag)
+	})
+}
+
+func TestReplicaReader_routing(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// the primary and the "replica" are separate databases, so which one a
+	// read was made against can be told by what it finds.
+	primary, _ := TestSetup(t, "postgres")
+	TestCreateTables(t, primary)
+	replica, _ := TestSetup(t, "postgres")
+	TestCreateTables(t, replica)
+
+	user, err := db_test.NewTestUser()
+	require.NoError(t, err)
+	require.NoError(t, New(primary).Create(ctx, user))
+
+	lookup := func(ctx context.Context, r Reader) error {
+		var found db_test.TestUser
+		return r.LookupWhere(ctx, &found, "public_id = ?", []interface{}{user.PublicId})
+	}
+
+	t.Run("no-replicas", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, nil)
+		require.NoError(t, err)
+		assert.NoError(t, lookup(WithReplicaReads(ctx), r))
+	})
+	t.Run("replica-reads-not-allowed", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, []*DB{replica})
+		require.NoError(t, err)
+		assert.NoError(t, lookup(ctx, r))
+	})
+	t.Run("replica-reads-allowed", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, []*DB{replica})
+		require.NoError(t, err)
+		err = lookup(WithReplicaReads(ctx), r)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("replica-lagging", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, []*DB{replica})
+		require.NoError(t, err)
+		r.replicas[0].checked = time.Now().Add(time.Hour)
+		r.replicas[0].lag = DefaultMaxReplicaLag + time.Second
+		assert.NoError(t, lookup(WithReplicaReads(ctx), r))
+	})
+	t.Run("replica-unavailable", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, []*DB{replica})
+		require.NoError(t, err)
+		r.replicas[0].checked = time.Now().Add(time.Hour)
+		r.replicas[0].err = errors.New(ctx, errors.Unknown, "test", "unavailable")
+		assert.NoError(t, lookup(WithReplicaReads(ctx), r))
+	})
+	t.Run("replica-lag-checked", func(t *testing.T) {
+		r, err := NewReplicaReader(ctx, primary, []*DB{replica})
+		require.NoError(t, err)
+		lag, err := r.replicas[0].currentLag(ctx)
+		require.NoError(t, err)
+		// the test database is not a replica, so it is never lagging
+		assert.Equal(t, time.Duration(0), lag)
+		assert.False(t, r.replicas[0].checked.IsZero())
+	})
+}
+
+func Test_replicaReadsAllowed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.False(t, replicaReadsAllowed(ctx))
+	assert.True(t, replicaReadsAllowed(WithReplicaReads(ctx)))
+	assert.False(t, replicaReadsAllowed(WithoutReplicaReads(WithReplicaReads(ctx))))
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Migration identifies a migration of an edition which has not yet been
+// applied to the database.
+type Migration struct {
+	// Edition is the name of the edition the migration belongs to.
+	Edition string
+	// Version is the schema version the edition is at once the migration is
+	// applied.
+	Version int
+	// Filename is the name of the file containing the migration, relative to
+	// its major version's parent directory.
+	Filename string
+}
+
+// MigrationResult is the outcome of running a migration.
+type MigrationResult struct {
+	Migration
+	// Duration is how long the migration took to run.
+	Duration time.Duration
+	// Error is the error returned when running the migration, if any.
+	Error error
+}
+
+// DryRunResult reports the outcome of running the migrations inside of a
+// transaction which is rolled back.
+type DryRunResult struct {
+	// State is the state of the schema before the dry run.
+	State *State
+	// Results contains the outcome of each migration that was run, in the
+	// order they were run. Migrations after one which fails are not run.
+	Results []MigrationResult
+	// Duration is how long it took to run all of the migrations.
+	Duration time.Duration
+}
+
+// Failed returns the result of the migration which failed during the dry run,
+// or nil if all of the migrations succeeded.
+func (r *DryRunResult) Failed() *MigrationResult {
+	for i := range r.Results {
+		if r.Results[i].Error != nil {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// PendingMigrations returns the migrations that ApplyMigrations would apply,
+// in the order they would be applied.
+func (b *Manager) PendingMigrations(ctx context.Context) ([]Migration, error) {
+	const op = "schema.(Manager).PendingMigrations"
+
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var migrations []Migration
+	p := provider.New(state.databaseState(), b.editions)
+	for p.Next() {
+		migrations = append(migrations, Migration{
+			Edition:  p.Edition(),
+			Version:  p.Version(),
+			Filename: p.Filename(),
+		})
+	}
+	return migrations, nil
+}
+
+// DryRunMigrations runs the migrations that ApplyMigrations would apply inside
+// of a transaction which is then rolled back, leaving the database schema
+// unchanged. A migration which fails is reported in the returned result rather
+// than as an error.
+func (b *Manager) DryRunMigrations(ctx context.Context) (*DryRunResult, error) {
+	const op = "schema.(Manager).DryRunMigrations"
+
+	// Capturing a lock that this session to the db already possesses is okay.
+	if err := b.driver.Lock(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer func() {
+		if err := b.driver.Unlock(ctx); err != nil {
+			panic(errors.Wrap(ctx, err, op))
+		}
+	}()
+
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	start := time.Now()
+	results, err := b.runMigrations(ctx, provider.New(state.databaseState(), b.editions), true)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &DryRunResult{
+		State:    state,
+		Results:  results,
+		Duration: time.Since(start),
+	}, nil
+}
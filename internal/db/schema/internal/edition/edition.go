@@ -36,6 +36,10 @@ type Edition struct {
 	// This is a map of schema versions to sql.
 	Migrations map[int][]byte
 
+	// The names of the migration files, relative to their major version's
+	// parent directory. This is a map of schema versions to file names.
+	Files map[int]string
+
 	// Priority is used to determine the order that multiple Editions should be applied.
 	Priority int
 }
@@ -75,6 +79,7 @@ func (e Editions) Sort() {
 func New(name string, dialect Dialect, m embed.FS, priority int) Edition {
 	var largestSchemaVersion int
 	migrations := make(map[int][]byte)
+	files := make(map[int]string)
 
 	fs.WalkDir(m, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -125,6 +130,7 @@ func New(name string, dialect Dialect, m embed.FS, priority int) Edition {
 			panic(fmt.Sprintf("migration file for version %d already exists", fullV))
 		}
 		migrations[fullV] = []byte(contents)
+		files[fullV] = filepath.Join(verMajorDir, file)
 
 		return nil
 	})
@@ -134,6 +140,7 @@ func New(name string, dialect Dialect, m embed.FS, priority int) Edition {
 		Dialect:       dialect,
 		LatestVersion: largestSchemaVersion,
 		Migrations:    migrations,
+		Files:         files,
 		Priority:      priority,
 	}
 }
@@ -27,6 +27,7 @@ func TestNew(t *testing.T) {
 		fs                     embed.FS
 		expectedVersion        int
 		expectedMigrationCount int
+		expectedFiles          map[int]string
 	}{
 		{
 			"one",
@@ -34,6 +35,9 @@ func TestNew(t *testing.T) {
 			one,
 			1,
 			1,
+			map[int]string{
+				1: "0/01_initial.up.sql",
+			},
 		},
 		{
 			"two",
@@ -41,6 +45,10 @@ func TestNew(t *testing.T) {
 			two,
 			2,
 			2,
+			map[int]string{
+				1: "0/01_add_table.up.sql",
+				2: "0/02_initial.up.sql",
+			},
 		},
 		{
 			"three",
@@ -48,6 +56,10 @@ func TestNew(t *testing.T) {
 			three,
 			1001,
 			2,
+			map[int]string{
+				1:    "0/01_initial.up.sql",
+				1001: "1/01_add_table.up.sql",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -58,6 +70,7 @@ func TestNew(t *testing.T) {
 			assert.Equal(t, e.LatestVersion, tt.expectedVersion, "Version")
 			assert.Equal(t, e.Priority, tt.priority, "Priority")
 			assert.Equal(t, len(e.Migrations), tt.expectedMigrationCount, "Number of migrations")
+			assert.Equal(t, e.Files, tt.expectedFiles, "Files")
 		})
 	}
 }
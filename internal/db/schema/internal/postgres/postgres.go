@@ -175,6 +175,22 @@ func (p *Postgres) CommitRun(ctx context.Context) error {
 	return nil
 }
 
+// RollbackRun rolls back a transaction, discarding any migrations that were
+// run since StartRun.
+func (p *Postgres) RollbackRun(ctx context.Context) error {
+	const op = "postgres.(Postgres).RollbackRun"
+	defer func() {
+		p.tx = nil
+	}()
+	if p.tx == nil {
+		return errors.New(ctx, errors.MigrationIntegrity, op, "no pending transaction")
+	}
+	if err := p.tx.Rollback(); err != nil && err != sql.ErrTxDone {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
 // Run will apply a migration. The io.Reader should provide the SQL
 // statements to execute, and the int is the version for that set of
 // statements. This should always be wrapped by StartRun and CommitRun.
@@ -137,3 +137,104 @@ comment on function log_migration_version() is
 'log_migration_version will set the log_migration entries to the current migration version';
 `
 )
+
+// Queries for describing the objects in a schema
+const (
+	createScratchSchema = `create schema boundary_schema_verify;`
+
+	// objects are created in the scratch schema, while extensions are still
+	// resolved from the current schema.
+	useScratchSchema = `
+select set_config('search_path', 'boundary_schema_verify, ' || quote_ident(current_schema()), true);
+`
+
+	selectCurrentSchema = `select current_schema();`
+
+	selectTables = `
+select c.relname,
+       case c.relkind
+         when 'r' then 'table'
+         when 'p' then 'partitioned table'
+         when 'v' then 'view'
+         when 'm' then 'materialized view'
+       end
+       || case when c.relkind in ('v', 'm') then ' ' || pg_get_viewdef(c.oid) else '' end
+  from pg_class c
+  join pg_namespace n on n.oid = c.relnamespace
+ where n.nspname = current_schema()
+   and c.relkind in ('r', 'p', 'v', 'm')
+   and not exists (
+         select 1 from pg_depend d
+          where d.classid = 'pg_class'::regclass
+            and d.objid   = c.oid
+            and d.deptype = 'e'
+       )
+;`
+
+	selectColumns = `
+select c.relname || '.' || a.attname,
+       format_type(a.atttypid, a.atttypmod)
+       || case when a.attnotnull then ' not null' else '' end
+       || case a.attidentity
+            when 'a' then ' generated always as identity'
+            when 'd' then ' generated by default as identity'
+            else ''
+          end
+       || coalesce(' default ' || pg_get_expr(ad.adbin, ad.adrelid), '')
+  from pg_attribute a
+  join pg_class c on c.oid = a.attrelid
+  join pg_namespace n on n.oid = c.relnamespace
+  left join pg_attrdef ad on ad.adrelid = a.attrelid and ad.adnum = a.attnum
+ where n.nspname = current_schema()
+   and c.relkind in ('r', 'p', 'v', 'm')
+   and a.attnum > 0
+   and not a.attisdropped
+;`
+
+	selectConstraints = `
+select c.relname || '.' || con.conname,
+       pg_get_constraintdef(con.oid)
+  from pg_constraint con
+  join pg_class c on c.oid = con.conrelid
+  join pg_namespace n on n.oid = c.relnamespace
+ where n.nspname = current_schema()
+;`
+
+	selectIndexes = `
+select i.relname,
+       pg_get_indexdef(i.oid)
+  from pg_index x
+  join pg_class i on i.oid = x.indexrelid
+  join pg_namespace n on n.oid = i.relnamespace
+ where n.nspname = current_schema()
+;`
+
+	selectTriggers = `
+select c.relname || '.' || t.tgname,
+       pg_get_triggerdef(t.oid)
+  from pg_trigger t
+  join pg_class c on c.oid = t.tgrelid
+  join pg_namespace n on n.oid = c.relnamespace
+ where n.nspname = current_schema()
+   and not t.tgisinternal
+;`
+
+	// the source of a function is compared by its hash to keep the
+	// definition short.
+	selectFunctions = `
+select p.proname || '(' || pg_get_function_identity_arguments(p.oid) || ')',
+       coalesce('returns ' || pg_get_function_result(p.oid) || ' ', '')
+       || 'language ' || l.lanname
+       || ' source ' || md5(p.prosrc)
+  from pg_proc p
+  join pg_namespace n on n.oid = p.pronamespace
+  join pg_language l on l.oid = p.prolang
+ where n.nspname = current_schema()
+   and not exists (
+         select 1 from pg_depend d
+          where d.classid = 'pg_proc'::regclass
+            and d.objid   = p.oid
+            and d.deptype = 'e'
+       )
+;`
+)
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/snapshot"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// UseScratchSchema creates an empty schema and makes it the current schema for
+// the remainder of the transaction, so that migrations which are run create
+// their objects in it. This should always be wrapped by StartRun and
+// RollbackRun.
+func (p *Postgres) UseScratchSchema(ctx context.Context) error {
+	const op = "postgres.(Postgres).UseScratchSchema"
+
+	if p.tx == nil {
+		return errors.New(ctx, errors.MigrationIntegrity, op, "no pending transaction")
+	}
+	if _, err := p.tx.ExecContext(ctx, createScratchSchema); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if _, err := p.tx.ExecContext(ctx, useScratchSchema); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// Snapshot describes the tables, columns, constraints, indexes, triggers and
+// functions in the current schema. Objects which belong to an extension are
+// not included. References to the current schema are removed from the
+// definitions, so snapshots of different schemas can be compared.
+func (p *Postgres) Snapshot(ctx context.Context) ([]snapshot.Object, error) {
+	const op = "postgres.(Postgres).Snapshot"
+
+	var current string
+	if err := p.conn.QueryRowContext(ctx, selectCurrentSchema).Scan(&current); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	prefixes := []string{current + ".", `"` + current + `".`}
+
+	queries := []struct {
+		kind  snapshot.Kind
+		query string
+	}{
+		{snapshot.Table, selectTables},
+		{snapshot.Column, selectColumns},
+		{snapshot.Constraint, selectConstraints},
+		{snapshot.Index, selectIndexes},
+		{snapshot.Trigger, selectTriggers},
+		{snapshot.Function, selectFunctions},
+	}
+
+	var objects []snapshot.Object
+	for _, q := range queries {
+		rows, err := p.conn.QueryContext(ctx, q.query)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(string(q.kind)))
+		}
+		for rows.Next() {
+			o := snapshot.Object{Kind: q.kind}
+			if err := rows.Scan(&o.Name, &o.Definition); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg(string(q.kind)))
+			}
+			for _, prefix := range prefixes {
+				o.Definition = strings.ReplaceAll(o.Definition, prefix, "")
+			}
+			objects = append(objects, o)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(string(q.kind)))
+		}
+		rows.Close()
+	}
+	return objects, nil
+}
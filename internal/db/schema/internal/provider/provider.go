@@ -14,6 +14,7 @@ const nilVersion = -1
 type migration struct {
 	version    int
 	edition    string
+	filename   string
 	statements []byte
 }
 
@@ -51,6 +52,7 @@ func New(dbState DatabaseState, editions edition.Editions) *Provider {
 				migrations = append(migrations, migration{
 					version:    ver,
 					edition:    e.Name,
+					filename:   e.Files[ver],
 					statements: statements,
 				})
 			}
@@ -91,6 +93,15 @@ func (p *Provider) Edition() string {
 	return p.migrations[p.pos].edition
 }
 
+// Filename returns the name of the file for the current migration. It is
+// empty if the edition was not created from migration files.
+func (p *Provider) Filename() string {
+	if p.pos < 0 || p.pos >= len(p.migrations) {
+		return ""
+	}
+	return p.migrations[p.pos].filename
+}
+
 // Statements returns the sql statements name for the current migration.
 func (p *Provider) Statements() []byte {
 	if p.pos < 0 || p.pos >= len(p.migrations) {
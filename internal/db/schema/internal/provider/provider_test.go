@@ -156,3 +156,43 @@ func TestProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderFilename(t *testing.T) {
+	editions := edition.Editions{
+		edition.Edition{
+			Name:          "one",
+			LatestVersion: 2,
+			Migrations: map[int][]byte{
+				1: []byte(`migration one`),
+				2: []byte(`migration two`),
+			},
+			Files: map[int]string{
+				1: "0/01_one.up.sql",
+				2: "0/02_two.up.sql",
+			},
+			Priority: 0,
+		},
+		edition.Edition{
+			Name:          "two",
+			LatestVersion: 1,
+			Migrations: map[int][]byte{
+				1: []byte(`migration one`),
+			},
+			Priority: 1,
+		},
+	}
+
+	p := provider.New(provider.DatabaseState{"one": 1}, editions)
+	assert.Equal(t, "", p.Filename())
+
+	require.True(t, p.Next())
+	assert.Equal(t, "one", p.Edition())
+	assert.Equal(t, "0/02_two.up.sql", p.Filename())
+
+	require.True(t, p.Next())
+	assert.Equal(t, "two", p.Edition())
+	assert.Equal(t, "", p.Filename())
+
+	assert.False(t, p.Next())
+	assert.Equal(t, "", p.Filename())
+}
@@ -0,0 +1,3 @@
+// Package snapshot provides internal structs for the schema package for
+// describing the objects in a database schema.
+package snapshot
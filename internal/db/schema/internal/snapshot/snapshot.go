@@ -0,0 +1,24 @@
+package snapshot
+
+// Kind is the kind of a database object.
+type Kind string
+
+const (
+	Table      Kind = "table"
+	Column     Kind = "column"
+	Constraint Kind = "constraint"
+	Index      Kind = "index"
+	Trigger    Kind = "trigger"
+	Function   Kind = "function"
+)
+
+// Object represents an object in a database schema.
+type Object struct {
+	Kind Kind
+	// Name identifies the object among the objects of its kind. The names of
+	// objects which belong to a table are prefixed with the table's name.
+	Name string
+	// Definition describes the object. Two objects of the same kind and name
+	// are the same if their definitions are equal.
+	Definition string
+}
@@ -6,11 +6,13 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db/schema/internal/edition"
 	"github.com/hashicorp/boundary/internal/db/schema/internal/log"
 	"github.com/hashicorp/boundary/internal/db/schema/internal/postgres"
 	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/snapshot"
 	"github.com/hashicorp/boundary/internal/errors"
 )
 
@@ -25,6 +27,9 @@ type driver interface {
 	StartRun(context.Context) error
 	// CommitRun commits a transaction, if there is an error it should rollback the transaction.
 	CommitRun(context.Context) error
+	// RollbackRun rolls back a transaction, discarding any migrations run
+	// since StartRun.
+	RollbackRun(context.Context) error
 	// Run will apply a migration. The io.Reader should provide the SQL
 	// statements to execute, and the int is the version for that set of
 	// statements. This should always be wrapped by StartRun and CommitRun.
@@ -45,6 +50,13 @@ type driver interface {
 	//  The WithDeleteLog option is supported and will remove all log entries,
 	// after reading the entries, when provided.
 	GetMigrationLog(ctx context.Context, opt ...log.Option) ([]*log.Entry, error)
+	// UseScratchSchema creates an empty schema which migrations that are run
+	// create their objects in for the remainder of the transaction. This
+	// should always be wrapped by StartRun and RollbackRun.
+	UseScratchSchema(ctx context.Context) error
+	// Snapshot describes the objects in the schema that migrations create
+	// their objects in.
+	Snapshot(ctx context.Context) ([]snapshot.Object, error)
 }
 
 // Manager provides a way to run operations and retrieve information regarding
@@ -176,7 +188,7 @@ func (b *Manager) ApplyMigrations(ctx context.Context) error {
 		return errors.Wrap(ctx, err, op)
 	}
 
-	if err = b.runMigrations(ctx, provider.New(state.databaseState(), b.editions)); err != nil {
+	if _, err = b.runMigrations(ctx, provider.New(state.databaseState(), b.editions), false); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
 	return nil
@@ -184,16 +196,25 @@ func (b *Manager) ApplyMigrations(ctx context.Context) error {
 
 // runMigrations passes migration queries to a database driver and manages
 // the version and dirty bit. Cancellation or deadline/timeout is managed
-// through the passed in context.
-func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider) (err error) {
+// through the passed in context. The outcome of each migration that was run
+// is returned. If dryRun is set the transaction the migrations are run in is
+// always rolled back and a failing migration is reported in its result
+// instead of as an error.
+func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider, dryRun bool) (results []MigrationResult, err error) {
 	const op = "schema.(Manager).runMigrations"
 
 	if startErr := b.driver.StartRun(ctx); startErr != nil {
 		err = errors.Wrap(ctx, startErr, op)
-		return err
+		return nil, err
 	}
 
 	defer func() {
+		if dryRun {
+			if rollbackErr := b.driver.RollbackRun(ctx); rollbackErr != nil {
+				err = errors.Wrap(ctx, rollbackErr, op)
+			}
+			return
+		}
 		if commitErr := b.driver.CommitRun(ctx); commitErr != nil {
 			err = errors.Wrap(ctx, commitErr, op)
 		}
@@ -201,27 +222,44 @@ func (b *Manager) runMigrations(ctx context.Context, p *provider.Provider) (err
 
 	if ensureErr := b.driver.EnsureVersionTable(ctx); ensureErr != nil {
 		err = errors.Wrap(ctx, ensureErr, op)
-		return err
+		return nil, err
 	}
 
 	if ensureErr := b.driver.EnsureMigrationLogTable(ctx); ensureErr != nil {
 		err = errors.Wrap(ctx, ensureErr, op)
-		return err
+		return nil, err
 	}
 
 	for p.Next() {
 		select {
 		case <-ctx.Done():
 			err = errors.Wrap(ctx, ctx.Err(), op)
-			return err
+			return results, err
 		default:
 			// context is not done yet. Continue on to the next query to execute.
 		}
-		if runErr := b.driver.Run(ctx, bytes.NewReader(p.Statements()), p.Version(), p.Edition()); err != nil {
+		r := MigrationResult{
+			Migration: Migration{
+				Edition:  p.Edition(),
+				Version:  p.Version(),
+				Filename: p.Filename(),
+			},
+		}
+		start := time.Now()
+		runErr := b.driver.Run(ctx, bytes.NewReader(p.Statements()), p.Version(), p.Edition())
+		r.Duration = time.Since(start)
+		if runErr != nil {
+			r.Error = runErr
+			results = append(results, r)
+			if dryRun {
+				// the transaction is aborted, so no further migrations can run.
+				return results, nil
+			}
 			err = errors.Wrap(ctx, runErr, op)
-			return err
+			return results, err
 		}
+		results = append(results, r)
 	}
 
-	return nil
+	return results, nil
 }
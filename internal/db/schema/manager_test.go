@@ -342,6 +342,258 @@ func TestApplyMigrations_BadSQL(t *testing.T) {
 	assert.False(t, state.MigrationsApplied())
 }
 
+func TestPendingMigrations(t *testing.T) {
+	dialect := dbtest.Postgres
+
+	c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+	d, err := common.SqlOpen(dialect, u)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	m, err := schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(
+		edition.Editions{
+			{
+				Name:          "oss",
+				Dialect:       schema.Postgres,
+				LatestVersion: 2,
+				Migrations: map[int][]byte{
+					1: []byte(`create table foo (id bigint primary key);`),
+					2: []byte(`alter table foo add column bar text;`),
+				},
+				Files: map[int]string{
+					1: "0/01_foo.up.sql",
+					2: "0/02_foo_bar.up.sql",
+				},
+				Priority: 0,
+			},
+		},
+	))
+	require.NoError(t, err)
+
+	got, err := m.PendingMigrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Migration{
+		{Edition: "oss", Version: 1, Filename: "0/01_foo.up.sql"},
+		{Edition: "oss", Version: 2, Filename: "0/02_foo_bar.up.sql"},
+	}, got)
+
+	require.NoError(t, m.ApplyMigrations(ctx))
+	got, err = m.PendingMigrations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDryRunMigrations(t *testing.T) {
+	tests := []struct {
+		name        string
+		migrations  map[int][]byte
+		wantResults int
+		wantFailed  int
+	}{
+		{
+			name: "success",
+			migrations: map[int][]byte{
+				1: []byte(`create table foo (id bigint primary key);`),
+				2: []byte(`alter table foo add column bar text;`),
+			},
+			wantResults: 2,
+		},
+		{
+			name: "failure",
+			migrations: map[int][]byte{
+				1: []byte(`create table foo (id bigint primary key);`),
+				2: []byte(`select 1 from nonexistanttable;`),
+				3: []byte(`alter table foo add column bar text;`),
+			},
+			wantResults: 2,
+			wantFailed:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			dialect := dbtest.Postgres
+
+			c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+			require.NoError(err)
+			t.Cleanup(func() {
+				require.NoError(c())
+			})
+			d, err := common.SqlOpen(dialect, u)
+			require.NoError(err)
+
+			ctx := context.Background()
+			m, err := schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(
+				edition.Editions{
+					{
+						Name:          "oss",
+						Dialect:       schema.Postgres,
+						LatestVersion: len(tt.migrations),
+						Migrations:    tt.migrations,
+						Priority:      0,
+					},
+				},
+			))
+			require.NoError(err)
+
+			res, err := m.DryRunMigrations(ctx)
+			require.NoError(err)
+			require.NotNil(res)
+			assert.Equal(&schema.State{
+				Editions: []schema.EditionState{
+					{
+						Name:                  "oss",
+						BinarySchemaVersion:   len(tt.migrations),
+						DatabaseSchemaVersion: schema.NilVersion,
+						DatabaseSchemaState:   schema.Behind,
+					},
+				},
+			}, res.State)
+			assert.Len(res.Results, tt.wantResults)
+			for i, r := range res.Results {
+				assert.Equal("oss", r.Edition)
+				assert.Equal(i+1, r.Version)
+			}
+			if tt.wantFailed == 0 {
+				assert.Nil(res.Failed())
+			} else {
+				failed := res.Failed()
+				require.NotNil(failed)
+				assert.Equal(tt.wantFailed, failed.Version)
+				assert.Error(failed.Error)
+			}
+
+			// the dry run must not have changed the schema
+			state, err := m.CurrentState(ctx)
+			require.NoError(err)
+			assert.Equal(res.State, state)
+			assert.False(state.MigrationsApplied())
+		})
+	}
+}
+
+func TestVerifySchema(t *testing.T) {
+	tests := []struct {
+		name  string
+		drift string
+		want  []schema.Difference
+	}{
+		{
+			name: "no-drift",
+		},
+		{
+			name:  "unexpected-index",
+			drift: `create index foo_bar_ix on foo (bar);`,
+			want: []schema.Difference{
+				{
+					Type:   schema.Unexpected,
+					Kind:   "index",
+					Name:   "foo_bar_ix",
+					Actual: "CREATE INDEX foo_bar_ix ON foo USING btree (bar)",
+				},
+			},
+		},
+		{
+			name:  "missing-column",
+			drift: `alter table foo drop column bar;`,
+			want: []schema.Difference{
+				{
+					Type:     schema.Missing,
+					Kind:     "column",
+					Name:     "foo.bar",
+					Expected: "text",
+				},
+			},
+		},
+		{
+			name:  "changed-column",
+			drift: `alter table foo alter column bar set not null;`,
+			want: []schema.Difference{
+				{
+					Type:     schema.Changed,
+					Kind:     "column",
+					Name:     "foo.bar",
+					Expected: "text",
+					Actual:   "text not null",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			dialect := dbtest.Postgres
+
+			c, u, _, err := dbtest.StartUsingTemplate(dialect, dbtest.WithTemplate(dbtest.Template1))
+			require.NoError(err)
+			t.Cleanup(func() {
+				require.NoError(c())
+			})
+			d, err := common.SqlOpen(dialect, u)
+			require.NoError(err)
+
+			ctx := context.Background()
+			m, err := schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(
+				edition.Editions{
+					{
+						Name:          "oss",
+						Dialect:       schema.Postgres,
+						LatestVersion: 2,
+						Migrations: map[int][]byte{
+							1: []byte(`create table foo (id bigint primary key);`),
+							2: []byte(`alter table foo add column bar text;`),
+						},
+						Priority: 0,
+					},
+				},
+			))
+			require.NoError(err)
+
+			_, err = m.VerifySchema(ctx)
+			require.Error(err, "uninitialized database")
+
+			require.NoError(m.ApplyMigrations(ctx))
+			if tt.drift != "" {
+				_, err = d.ExecContext(ctx, tt.drift)
+				require.NoError(err)
+			}
+
+			// a newer binary's migrations which have not been applied are not
+			// part of the expected schema
+			m, err = schema.NewManager(ctx, schema.Dialect(dialect), d, schema.WithEditions(
+				edition.Editions{
+					{
+						Name:          "oss",
+						Dialect:       schema.Postgres,
+						LatestVersion: 3,
+						Migrations: map[int][]byte{
+							1: []byte(`create table foo (id bigint primary key);`),
+							2: []byte(`alter table foo add column bar text;`),
+							3: []byte(`create table baz (id bigint primary key);`),
+						},
+						Priority: 0,
+					},
+				},
+			))
+			require.NoError(err)
+
+			res, err := m.VerifySchema(ctx)
+			require.NoError(err)
+			assert.Equal(tt.want, res.Differences)
+			assert.Equal(len(tt.want) > 0, res.Drifted())
+
+			// verifying must not leave the scratch schema behind
+			var exists bool
+			require.NoError(d.QueryRowContext(ctx, `select exists (select 1 from pg_namespace where nspname = 'boundary_schema_verify')`).Scan(&exists))
+			assert.False(exists)
+		})
+	}
+}
+
 func TestManager_ExclusiveLock(t *testing.T) {
 	ctx := context.Background()
 	dialect := dbtest.Postgres
@@ -0,0 +1,72 @@
+begin;
+
+-- Adds the credential rotation status reported by pki workers along with a
+-- flag an operator can set to have a worker rotate its credentials on its
+-- next status update.
+alter table server_worker
+  add column certificate_expiration timestamp with time zone,
+  add column last_rotation_time timestamp with time zone,
+  add column last_rotation_error text
+    constraint last_rotation_error_must_not_be_empty
+      check(length(trim(last_rotation_error)) > 0),
+  add column rotate_auth_requested boolean not null default false;
+
+comment on column server_worker.certificate_expiration is
+  'certificate_expiration is the expiration time of the worker''s current credentials as last reported by the worker.';
+comment on column server_worker.last_rotation_time is
+  'last_rotation_time is the last time the worker reported successfully rotating its credentials.';
+comment on column server_worker.last_rotation_error is
+  'last_rotation_error is the error from the worker''s most recent failed credential rotation, if any.';
+comment on column server_worker.rotate_auth_requested is
+  'rotate_auth_requested is true when the worker should rotate its credentials on its next status update.';
+
+-- Replaces the view created in 34/04 to add the credential rotation columns.
+drop view server_worker_aggregate;
+create view server_worker_aggregate as
+with worker_config_tags(worker_id, source, tags) as (
+  select
+    ct.worker_id,
+    ct.source,
+    -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+    string_agg(distinct concat_ws('Y', ct.key, ct.value), 'Z') as tags
+  from server_worker_tag ct
+  group by ct.worker_id, ct.source
+),
+ connection_count (worker_id, count) as (
+   select
+     worker_id,
+     count(1) as count
+   from session_connection
+   where closed_reason is null
+   group by worker_id
+ )
+select
+  w.public_id,
+  w.scope_id,
+  w.description,
+  w.name,
+  w.address,
+  w.create_time,
+  w.update_time,
+  w.version,
+  w.last_status_time,
+  w.type,
+  w.certificate_expiration,
+  w.last_rotation_time,
+  w.last_rotation_error,
+  w.rotate_auth_requested,
+  cc.count as active_connection_count,
+  -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+  wt.tags as api_tags,
+  ct.tags as worker_config_tags
+from server_worker w
+  left join worker_config_tags wt on
+      w.public_id = wt.worker_id and wt.source = 'api'
+  left join worker_config_tags ct on
+      w.public_id = ct.worker_id and ct.source = 'configuration'
+  left join connection_count as cc on
+      w.public_id = cc.worker_id;
+comment on view server_worker_aggregate is
+  'server_worker_aggregate contains the worker resource with its worker provided config values and its configuration and api provided tags.';
+
+commit;
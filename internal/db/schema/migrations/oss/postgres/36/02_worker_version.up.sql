@@ -0,0 +1,88 @@
+begin;
+
+-- Adds the Boundary version reported by workers.
+alter table server_worker
+  add column release_version text
+    constraint release_version_must_not_be_empty
+      check(length(trim(release_version)) > 0);
+
+comment on column server_worker.release_version is
+  'release_version is the version of Boundary the worker last reported running.';
+
+create table server_worker_feature (
+  worker_id wt_public_id
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  feature text not null
+    constraint feature_must_not_be_empty
+      check(length(trim(feature)) > 0),
+  primary key (worker_id, feature)
+);
+comment on table server_worker_feature is
+  'server_worker_feature is a table where each row represents a protocol feature supported by a worker, as reported by the worker.';
+
+-- Replaces the view created in 36/01 to add the version and features.
+drop view server_worker_aggregate;
+create view server_worker_aggregate as
+with worker_config_tags(worker_id, source, tags) as (
+  select
+    ct.worker_id,
+    ct.source,
+    -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+    string_agg(distinct concat_ws('Y', ct.key, ct.value), 'Z') as tags
+  from server_worker_tag ct
+  group by ct.worker_id, ct.source
+),
+ worker_features(worker_id, features) as (
+   select
+     wf.worker_id,
+     -- features are reported by the worker so use a delimiter which can't be
+     -- part of a feature name.
+     string_agg(distinct wf.feature, ',') as features
+   from server_worker_feature wf
+   group by wf.worker_id
+ ),
+ connection_count (worker_id, count) as (
+   select
+     worker_id,
+     count(1) as count
+   from session_connection
+   where closed_reason is null
+   group by worker_id
+ )
+select
+  w.public_id,
+  w.scope_id,
+  w.description,
+  w.name,
+  w.address,
+  w.create_time,
+  w.update_time,
+  w.version,
+  w.last_status_time,
+  w.type,
+  w.certificate_expiration,
+  w.last_rotation_time,
+  w.last_rotation_error,
+  w.rotate_auth_requested,
+  w.release_version,
+  wf.features,
+  cc.count as active_connection_count,
+  -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+  wt.tags as api_tags,
+  ct.tags as worker_config_tags
+from server_worker w
+  left join worker_config_tags wt on
+      w.public_id = wt.worker_id and wt.source = 'api'
+  left join worker_config_tags ct on
+      w.public_id = ct.worker_id and ct.source = 'configuration'
+  left join worker_features wf on
+      w.public_id = wf.worker_id
+  left join connection_count as cc on
+      w.public_id = cc.worker_id;
+comment on view server_worker_aggregate is
+  'server_worker_aggregate contains the worker resource with its worker provided config values and its configuration and api provided tags.';
+
+commit;
@@ -0,0 +1,42 @@
+begin;
+
+create table server_worker_activation_token (
+  private_id wt_private_id primary key,
+  worker_id wt_public_id not null
+    constraint server_worker_fkey
+      references server_worker (public_id)
+      on delete cascade
+      on update cascade,
+  scope_id wt_scope_id not null
+    constraint iam_scope_fkey
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+  token_hash bytea not null
+    constraint token_hash_must_not_be_empty
+      check(length(token_hash) > 0)
+    constraint server_worker_activation_token_token_hash_uq
+      unique,
+  create_time wt_timestamp,
+  expiration_time timestamp with time zone not null
+    constraint expiration_time_must_be_after_create_time
+      check(expiration_time > create_time),
+  redeemed_time timestamp with time zone
+    constraint redeemed_time_must_not_be_before_create_time
+      check(redeemed_time >= create_time),
+  revoked_time timestamp with time zone
+    constraint revoked_time_must_not_be_before_create_time
+      check(revoked_time >= create_time),
+  constraint token_cannot_be_both_redeemed_and_revoked
+    check(redeemed_time is null or revoked_time is null)
+);
+comment on table server_worker_activation_token is
+  'server_worker_activation_token is a table where each row represents a single use token, generated by the controller, which a worker can present to be authorized.';
+
+create trigger immutable_columns before update on server_worker_activation_token
+  for each row execute procedure immutable_columns('private_id', 'worker_id', 'scope_id', 'token_hash', 'create_time', 'expiration_time');
+
+create trigger default_create_time_column before insert on server_worker_activation_token
+  for each row execute procedure default_create_time();
+
+commit;
@@ -0,0 +1,38 @@
+begin;
+
+-- Rotating a scope's keys re-encrypts existing rows under the new data key
+-- version, so the encrypted token columns can no longer be immutable.
+
+-- Replaces the function created in 0/11 to allow the token to be updated.
+create or replace function immutable_auth_token_columns() returns trigger
+as $$
+begin
+  if new.auth_account_id is distinct from old.auth_account_id then
+    raise exception 'auth_account_id is read-only';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+-- Replaces the trigger created in 10/04 to allow the token to be updated.
+drop trigger immutable_columns on credential_vault_token;
+create trigger immutable_columns before update on credential_vault_token
+  for each row execute procedure immutable_columns('token_hmac', 'store_id', 'create_time');
+
+-- Re-encrypting a row is the only update which changes its key_id, and it
+-- must not bump the version of the resource, so it doesn't race with updates
+-- made by an admin.
+
+-- Replaces the trigger created in 2/04.
+drop trigger update_version_column on auth_oidc_method;
+create trigger update_version_column after update on auth_oidc_method
+  for each row when (new.key_id is not distinct from old.key_id)
+  execute procedure update_version_column();
+
+-- Replaces the trigger created in 33/01.
+drop trigger update_version_column on credential_static_username_password_credential;
+create trigger update_version_column after update on credential_static_username_password_credential
+  for each row when (new.key_id is not distinct from old.key_id)
+  execute procedure update_version_column();
+
+commit;
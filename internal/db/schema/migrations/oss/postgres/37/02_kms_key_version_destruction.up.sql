@@ -0,0 +1,33 @@
+begin;
+
+-- Destroying a root key version re-encrypts the data key versions it encrypts
+-- with the current root key version, so the key and the root key version of a
+-- data key version can no longer be immutable.
+
+-- Replaces the trigger created in 30/04 to allow the key to be rewrapped.
+drop trigger kms_immutable_columns on kms_data_key_version;
+create trigger kms_immutable_columns before update on kms_data_key_version
+  for each row execute procedure immutable_columns('private_id', 'data_key_id', 'version', 'create_time');
+
+create table kms_data_key_version_destruction_job (
+  key_id kms_private_id primary key
+    constraint kms_data_key_version_fkey
+      references kms_data_key_version (private_id)
+      on delete cascade
+      on update cascade,
+  total_count bigint not null
+    constraint total_count_must_be_positive
+      check(total_count > 0),
+  create_time wt_timestamp
+);
+comment on table kms_data_key_version_destruction_job is
+  'kms_data_key_version_destruction_job is a table where each row represents a data key version '
+  'which is destroyed once all of the rows it encrypts have been re-encrypted.';
+
+create trigger immutable_columns before update on kms_data_key_version_destruction_job
+  for each row execute procedure immutable_columns('key_id', 'total_count', 'create_time');
+
+create trigger default_create_time_column before insert on kms_data_key_version_destruction_job
+  for each row execute procedure default_create_time();
+
+commit;
@@ -0,0 +1,38 @@
+begin;
+
+-- Moving a scope to another external root wrapper re-encrypts its root key
+-- versions with the new wrapper, so the key of a root key version can no
+-- longer be immutable.
+
+-- Replaces the trigger created in 30/04 to allow the key to be rewrapped.
+drop trigger kms_immutable_columns on kms_root_key_version;
+create trigger kms_immutable_columns before update on kms_root_key_version
+  for each row execute procedure kms_immutable_columns('private_id', 'root_key_id', 'version', 'create_time');
+
+create table kms_scope_root_wrapper (
+  scope_id wt_scope_id primary key
+    constraint iam_scope_fkey
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+  name text not null
+    constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+comment on table kms_scope_root_wrapper is
+  'kms_scope_root_wrapper is a table where each row names the external wrapper, '
+  'configured by a scope-root kms block, which wraps the root key of a scope. '
+  'Scopes without a row use the wrapper of the root kms block.';
+
+create trigger immutable_columns before update on kms_scope_root_wrapper
+  for each row execute procedure immutable_columns('scope_id', 'create_time');
+
+create trigger default_create_time_column before insert on kms_scope_root_wrapper
+  for each row execute procedure default_create_time();
+
+create trigger update_time_column before update on kms_scope_root_wrapper
+  for each row execute procedure update_time_column();
+
+commit;
@@ -0,0 +1,21 @@
+begin;
+
+-- A job can run on a cron schedule rather than computing its next run time
+-- itself. The schedule is stored with the job so every controller computes
+-- the same next run time when it completes a run.
+alter table job
+  add column schedule text
+    constraint schedule_must_not_be_empty
+      check(length(trim(schedule)) > 0),
+  add column time_zone text
+    constraint time_zone_must_not_be_empty
+      check(length(trim(time_zone)) > 0),
+  add constraint time_zone_requires_schedule
+    check(time_zone is null or schedule is not null);
+
+comment on column job.schedule is
+  'schedule is the cron expression used to compute the next run time of the job, or null if the job computes its own next run time.';
+comment on column job.time_zone is
+  'time_zone is the name of the time zone the schedule is evaluated in.';
+
+commit;
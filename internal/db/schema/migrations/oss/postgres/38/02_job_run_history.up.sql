@@ -0,0 +1,51 @@
+begin;
+
+-- Runs which failed record why, so the history of runs can be used to
+-- diagnose failing jobs rather than only log lines.
+alter table job_run
+  add column error_message text
+    constraint error_message_must_not_be_empty
+      check(length(trim(error_message)) > 0);
+
+-- Ended runs are pruned by end_time once they are older than the retention.
+create index job_run_end_time_ix
+  on job_run (end_time)
+  where status != 'running';
+
+alter table job
+  add column consecutive_failures int not null
+    default 0
+    constraint consecutive_failures_can_not_be_negative
+      check(consecutive_failures >= 0);
+
+comment on column job.consecutive_failures is
+  'consecutive_failures is the number of runs of the job which have failed or been interrupted since the job last completed a run.';
+
+create function update_job_consecutive_failures() returns trigger
+as $$
+begin
+  if new.status = old.status then
+    return null;
+  end if;
+  if new.status = 'completed' then
+    update job
+       set consecutive_failures = 0
+     where plugin_id = new.job_plugin_id
+       and name      = new.job_name;
+  elsif new.status in ('failed', 'interrupted') then
+    update job
+       set consecutive_failures = consecutive_failures + 1
+     where plugin_id = new.job_plugin_id
+       and name      = new.job_name;
+  end if;
+  return null;
+end;
+$$ language plpgsql;
+
+comment on function update_job_consecutive_failures is
+  'update_job_consecutive_failures is an after update trigger function which resets or increments the consecutive_failures of a job when one of its runs ends.';
+
+create trigger update_job_consecutive_failures after update of status on job_run
+  for each row execute procedure update_job_consecutive_failures();
+
+commit;
@@ -0,0 +1,191 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/edition"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/provider"
+	"github.com/hashicorp/boundary/internal/db/schema/internal/snapshot"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// DifferenceType describes how an object in the database schema differs from
+// the expected schema.
+type DifferenceType string
+
+const (
+	// Missing is an object in the expected schema which is not in the
+	// database schema.
+	Missing DifferenceType = "missing"
+	// Unexpected is an object in the database schema which is not in the
+	// expected schema.
+	Unexpected DifferenceType = "unexpected"
+	// Changed is an object whose definition in the database schema differs
+	// from its definition in the expected schema.
+	Changed DifferenceType = "changed"
+)
+
+// Difference is a difference between the database schema and the schema
+// created by the migrations up to the version recorded in the database.
+type Difference struct {
+	Type DifferenceType
+	// Kind is the kind of the object, one of "table", "column", "constraint",
+	// "index", "trigger" or "function".
+	Kind string
+	// Name identifies the object among the objects of its kind. The names of
+	// objects which belong to a table are prefixed with the table's name.
+	Name string
+	// Expected is the definition of the object in the expected schema. It is
+	// empty for an Unexpected object.
+	Expected string
+	// Actual is the definition of the object in the database schema. It is
+	// empty for a Missing object.
+	Actual string
+}
+
+// VerifyResult reports the differences between the database schema and the
+// expected schema.
+type VerifyResult struct {
+	// State is the state of the schema that was verified.
+	State *State
+	// Differences are sorted by kind and then name.
+	Differences []Difference
+}
+
+// Drifted reports whether the database schema differs from the expected
+// schema.
+func (r *VerifyResult) Drifted() bool {
+	return len(r.Differences) > 0
+}
+
+// VerifySchema compares the tables, columns, constraints, indexes, triggers and
+// functions in the database schema against the schema created by running the
+// migrations of each edition up to the version recorded in the database. The
+// expected schema is created by running the migrations in an empty schema
+// inside of a transaction which is rolled back, leaving the database unchanged.
+func (b *Manager) VerifySchema(ctx context.Context) (*VerifyResult, error) {
+	const op = "schema.(Manager).VerifySchema"
+
+	state, err := b.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !state.Initialized {
+		return nil, errors.New(ctx, errors.MigrationIntegrity, op, "database schema is not initialized")
+	}
+
+	actual, err := b.driver.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	expected, err := b.expectedSnapshot(ctx, state)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &VerifyResult{
+		State:       state,
+		Differences: diffSnapshots(expected, actual),
+	}, nil
+}
+
+// expectedSnapshot runs the migrations of each edition up to the version
+// recorded in the database in an empty schema and describes the objects they
+// create. The transaction the migrations are run in is always rolled back.
+func (b *Manager) expectedSnapshot(ctx context.Context, state *State) (objects []snapshot.Object, err error) {
+	const op = "schema.(Manager).expectedSnapshot"
+
+	dbState := state.databaseState()
+	editions := make(edition.Editions, 0, len(b.editions))
+	for _, e := range b.editions {
+		dbVer, ok := dbState[e.Name]
+		if !ok || dbVer == nilVersion {
+			continue
+		}
+		applied := e
+		applied.Migrations = make(map[int][]byte, len(e.Migrations))
+		for ver, statements := range e.Migrations {
+			if ver <= dbVer {
+				applied.Migrations[ver] = statements
+			}
+		}
+		editions = append(editions, applied)
+	}
+
+	if startErr := b.driver.StartRun(ctx); startErr != nil {
+		return nil, errors.Wrap(ctx, startErr, op)
+	}
+	defer func() {
+		if rollbackErr := b.driver.RollbackRun(ctx); rollbackErr != nil {
+			err = errors.Wrap(ctx, rollbackErr, op)
+		}
+	}()
+
+	if err := b.driver.UseScratchSchema(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := b.driver.EnsureVersionTable(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := b.driver.EnsureMigrationLogTable(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	p := provider.New(provider.DatabaseState{}, editions)
+	for p.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx, ctx.Err(), op)
+		default:
+			// context is not done yet. Continue on to the next query to execute.
+		}
+		if err := b.driver.Run(ctx, bytes.NewReader(p.Statements()), p.Version(), p.Edition()); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	objects, err = b.driver.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return objects, nil
+}
+
+// diffSnapshots returns the differences between the expected and actual
+// objects, sorted by kind and then name.
+func diffSnapshots(expected, actual []snapshot.Object) []Difference {
+	type key struct {
+		kind snapshot.Kind
+		name string
+	}
+	actualByKey := make(map[key]string, len(actual))
+	for _, o := range actual {
+		actualByKey[key{o.Kind, o.Name}] = o.Definition
+	}
+
+	var diffs []Difference
+	for _, o := range expected {
+		k := key{o.Kind, o.Name}
+		def, ok := actualByKey[k]
+		delete(actualByKey, k)
+		switch {
+		case !ok:
+			diffs = append(diffs, Difference{Type: Missing, Kind: string(o.Kind), Name: o.Name, Expected: o.Definition})
+		case def != o.Definition:
+			diffs = append(diffs, Difference{Type: Changed, Kind: string(o.Kind), Name: o.Name, Expected: o.Definition, Actual: def})
+		}
+	}
+	for k, def := range actualByKey {
+		diffs = append(diffs, Difference{Type: Unexpected, Kind: string(k.kind), Name: k.name, Actual: def})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
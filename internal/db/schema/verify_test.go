@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/schema/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffSnapshots(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []snapshot.Object
+		actual   []snapshot.Object
+		want     []Difference
+	}{
+		{
+			name: "equal",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+				{Kind: snapshot.Column, Name: "foo.id", Definition: "bigint not null"},
+			},
+			actual: []snapshot.Object{
+				{Kind: snapshot.Column, Name: "foo.id", Definition: "bigint not null"},
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+			},
+		},
+		{
+			name: "missing",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+				{Kind: snapshot.Column, Name: "foo.id", Definition: "bigint not null"},
+			},
+			actual: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+			},
+			want: []Difference{
+				{Type: Missing, Kind: "column", Name: "foo.id", Expected: "bigint not null"},
+			},
+		},
+		{
+			name: "unexpected",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+			},
+			actual: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+				{Kind: snapshot.Index, Name: "foo_ix", Definition: "CREATE INDEX foo_ix ON foo USING btree (id)"},
+			},
+			want: []Difference{
+				{Type: Unexpected, Kind: "index", Name: "foo_ix", Actual: "CREATE INDEX foo_ix ON foo USING btree (id)"},
+			},
+		},
+		{
+			name: "changed",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Column, Name: "foo.name", Definition: "text"},
+			},
+			actual: []snapshot.Object{
+				{Kind: snapshot.Column, Name: "foo.name", Definition: "text not null"},
+			},
+			want: []Difference{
+				{Type: Changed, Kind: "column", Name: "foo.name", Expected: "text", Actual: "text not null"},
+			},
+		},
+		{
+			name: "same-name-different-kind",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+			},
+			actual: []snapshot.Object{
+				{Kind: snapshot.Table, Name: "foo", Definition: "table"},
+				{Kind: snapshot.Index, Name: "foo", Definition: "CREATE INDEX foo ON bar USING btree (id)"},
+			},
+			want: []Difference{
+				{Type: Unexpected, Kind: "index", Name: "foo", Actual: "CREATE INDEX foo ON bar USING btree (id)"},
+			},
+		},
+		{
+			name: "sorted",
+			expected: []snapshot.Object{
+				{Kind: snapshot.Trigger, Name: "foo.b", Definition: "b"},
+				{Kind: snapshot.Column, Name: "foo.b", Definition: "b"},
+				{Kind: snapshot.Column, Name: "foo.a", Definition: "a"},
+			},
+			want: []Difference{
+				{Type: Missing, Kind: "column", Name: "foo.a", Expected: "a"},
+				{Type: Missing, Kind: "column", Name: "foo.b", Expected: "b"},
+				{Type: Missing, Kind: "trigger", Name: "foo.b", Expected: "b"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffSnapshots(tt.expected, tt.actual))
+		})
+	}
+}
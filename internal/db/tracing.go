@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/observability/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a span for a repository call to the database.  When the
+// resource (or the first of a slice of resources) has a table name, it's
+// recorded with the span.
+func startSpan(ctx context.Context, op string, resource interface{}) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{semconv.DBSystemPostgreSQL}
+	if table := spanTableName(resource); table != "" {
+		attrs = append(attrs, semconv.DBSQLTableKey.String(table))
+	}
+	return tracing.StartSpan(ctx, op, attrs...)
+}
+
+// startSqlSpan starts a span for raw sql run against the database.  The sql is
+// recorded with the span, but not the values of its parameters.
+func startSqlSpan(ctx context.Context, op string, sql string) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, op, semconv.DBSystemPostgreSQL, semconv.DBStatementKey.String(sql))
+}
+
+// searchTableNames caches the table name of the elements of the slice types
+// that are searched, so the reflection needed to find it only happens the
+// first time a type is searched.
+var searchTableNames sync.Map // reflect.Type -> string
+
+func spanTableName(resource interface{}) string {
+	switch r := resource.(type) {
+	case nil:
+		return ""
+	case interface{ TableName() string }:
+		if isNil(r) {
+			return ""
+		}
+		return r.TableName()
+	case []interface{}:
+		// create and delete items are all the same type
+		if len(r) == 0 {
+			return ""
+		}
+		return spanTableName(r[0])
+	}
+	t := reflect.TypeOf(resource)
+	if name, ok := searchTableNames.Load(t); ok {
+		return name.(string)
+	}
+	name := searchTableName(t)
+	searchTableNames.Store(t, name)
+	return name
+}
+
+// searchTableName returns the table name of the elements of a slice (or a
+// pointer to a slice) of resources.  A new element is used, since a slice of
+// search results is empty until it's been searched.
+func searchTableName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice {
+		return ""
+	}
+	elem := t.Elem()
+	if elem.Kind() != reflect.Ptr || elem.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	if tn, ok := reflect.New(elem.Elem()).Interface().(interface{ TableName() string }); ok {
+		return tn.TableName()
+	}
+	return ""
+}
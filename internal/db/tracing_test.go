@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/db_test"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+func Test_spanTableName(t *testing.T) {
+	t.Parallel()
+	user := &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{}}
+	tests := []struct {
+		name     string
+		resource interface{}
+		want     string
+	}{
+		{name: "nil", resource: nil},
+		{name: "nil-resource", resource: (*db_test.TestUser)(nil)},
+		{name: "no-table-name", resource: &struct{}{}},
+		{name: "resource", resource: user, want: "db_test_user"},
+		{name: "search-results", resource: &[]*db_test.TestUser{}, want: "db_test_user"},
+		{name: "items", resource: []interface{}{user}, want: "db_test_user"},
+		{name: "empty-items", resource: []interface{}{}},
+		{name: "nil-items", resource: []interface{}{nil}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, spanTableName(tt.resource))
+		})
+	}
+}
+
+func Test_startSpan(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := tracing.NewTestCollector(t)
+	ctx := context.Background()
+
+	_, span := startSpan(ctx, "db.Create", &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{}})
+	span.End()
+	_, span = startSqlSpan(ctx, "db.Exec", "delete from db_test_user where public_id = ?")
+	span.End()
+
+	spans := c.Spans()
+	require.Len(spans, 2)
+	assert.Equal("db.Create", spans[0].Name)
+	assert.Contains(spans[0].Attributes, semconv.DBSystemPostgreSQL)
+	assert.Contains(spans[0].Attributes, semconv.DBSQLTableKey.String("db_test_user"))
+	assert.Equal("db.Exec", spans[1].Name)
+	assert.Contains(spans[1].Attributes, semconv.DBStatementKey.String("delete from db_test_user where public_id = ?"))
+}
+
+func Test_spanTableName_cached(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	resources := &[]*db_test.TestUser{}
+	assert.Equal("db_test_user", spanTableName(resources))
+	name, ok := searchTableNames.Load(reflect.TypeOf(resources))
+	assert.True(ok)
+	assert.Equal("db_test_user", name)
+}
+
+func TestDb_spanError(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := tracing.NewTestCollector(t)
+	rw := &Db{}
+
+	err := rw.Create(context.Background(), &db_test.TestUser{StoreTestUser: &db_test.StoreTestUser{}})
+	require.Error(err)
+
+	spans := c.Spans()
+	require.Len(spans, 1)
+	assert.Equal("db.Create", spans[0].Name)
+	assert.Equal(codes.Error, spans[0].Status.Code)
+	assert.Equal(err.Error(), spans[0].Status.Description)
+	require.Len(spans[0].Events, 1)
+	assert.Equal("exception", spans[0].Events[0].Name)
+}
@@ -0,0 +1,107 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package services
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OplogServiceClient is the client API for OplogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OplogServiceClient interface {
+	// ListOplogEntries returns the entries of the oplog, newest first, with
+	// their writes decrypted.  The oplog only exists in the global scope, so an
+	// error is returned if the request's scope ID is not "global".
+	ListOplogEntries(ctx context.Context, in *ListOplogEntriesRequest, opts ...grpc.CallOption) (*ListOplogEntriesResponse, error)
+}
+
+type oplogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOplogServiceClient(cc grpc.ClientConnInterface) OplogServiceClient {
+	return &oplogServiceClient{cc}
+}
+
+func (c *oplogServiceClient) ListOplogEntries(ctx context.Context, in *ListOplogEntriesRequest, opts ...grpc.CallOption) (*ListOplogEntriesResponse, error) {
+	out := new(ListOplogEntriesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.OplogService/ListOplogEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OplogServiceServer is the server API for OplogService service.
+// All implementations must embed UnimplementedOplogServiceServer
+// for forward compatibility
+type OplogServiceServer interface {
+	// ListOplogEntries returns the entries of the oplog, newest first, with
+	// their writes decrypted.  The oplog only exists in the global scope, so an
+	// error is returned if the request's scope ID is not "global".
+	ListOplogEntries(context.Context, *ListOplogEntriesRequest) (*ListOplogEntriesResponse, error)
+	mustEmbedUnimplementedOplogServiceServer()
+}
+
+// UnimplementedOplogServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOplogServiceServer struct {
+}
+
+func (UnimplementedOplogServiceServer) ListOplogEntries(context.Context, *ListOplogEntriesRequest) (*ListOplogEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOplogEntries not implemented")
+}
+func (UnimplementedOplogServiceServer) mustEmbedUnimplementedOplogServiceServer() {}
+
+// UnsafeOplogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OplogServiceServer will
+// result in compilation errors.
+type UnsafeOplogServiceServer interface {
+	mustEmbedUnimplementedOplogServiceServer()
+}
+
+func RegisterOplogServiceServer(s grpc.ServiceRegistrar, srv OplogServiceServer) {
+	s.RegisterService(&OplogService_ServiceDesc, srv)
+}
+
+func _OplogService_ListOplogEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOplogEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OplogServiceServer).ListOplogEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.OplogService/ListOplogEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OplogServiceServer).ListOplogEntries(ctx, req.(*ListOplogEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OplogService_ServiceDesc is the grpc.ServiceDesc for OplogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OplogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controller.api.services.v1.OplogService",
+	HandlerType: (*OplogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOplogEntries",
+			Handler:    _OplogService_ListOplogEntries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controller/api/services/v1/oplog_service.proto",
+}
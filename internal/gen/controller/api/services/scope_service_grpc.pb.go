@@ -44,6 +44,37 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// RotateScopeKeys rotates the root key and the data encryption keys of a
+	// Scope.  Data is encrypted with the new key versions from then on, and a
+	// background job re-encrypts existing data with them.  Other controllers
+	// start using the new versions within 10 seconds.  An error is returned if
+	// the Scope does not exist.
+	RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error)
+	// SetScopeKms moves a Scope's root key to the "scope-root" KMS with the
+	// given name, re-encrypting all of the root key's versions with it.  An
+	// empty name moves the root key back to the "root" KMS.  An error is
+	// returned if the Scope does not exist or if no KMS with the name is
+	// configured.
+	SetScopeKms(ctx context.Context, in *SetScopeKmsRequest, opts ...grpc.CallOption) (*SetScopeKmsResponse, error)
+	// ListKeys lists the root key and the data encryption keys of a Scope along
+	// with all of their versions.  An error is returned if the Scope does not
+	// exist.
+	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
+	// DestroyKeyVersion destroys a version of one of a Scope's keys.  Data
+	// encrypted by the version is re-encrypted with the current version of the
+	// key before the version is destroyed.  If the version can be destroyed
+	// immediately the returned state is "completed", otherwise it is "pending"
+	// and the progress of the destruction can be followed with
+	// ListKeyVersionDestructionJobs.  The current version of a key cannot be
+	// destroyed; rotate the Scope's keys first.  Versions of the oplog and
+	// audit keys, and versions still encrypting data which cannot be
+	// re-encrypted, such as password credentials and sessions, cannot be
+	// destroyed either.
+	DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error)
+	// ListKeyVersionDestructionJobs lists the pending destructions of the
+	// versions of a Scope's keys.  An error is returned if the Scope does not
+	// exist.
+	ListKeyVersionDestructionJobs(ctx context.Context, in *ListKeyVersionDestructionJobsRequest, opts ...grpc.CallOption) (*ListKeyVersionDestructionJobsResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -99,6 +130,51 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error) {
+	out := new(RotateScopeKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RotateScopeKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) SetScopeKms(ctx context.Context, in *SetScopeKmsRequest, opts ...grpc.CallOption) (*SetScopeKmsResponse, error) {
+	out := new(SetScopeKmsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/SetScopeKms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
+	out := new(ListKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error) {
+	out := new(DestroyKeyVersionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/DestroyKeyVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListKeyVersionDestructionJobs(ctx context.Context, in *ListKeyVersionDestructionJobsRequest, opts ...grpc.CallOption) (*ListKeyVersionDestructionJobsResponse, error) {
+	out := new(ListKeyVersionDestructionJobsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListKeyVersionDestructionJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -129,6 +205,37 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// RotateScopeKeys rotates the root key and the data encryption keys of a
+	// Scope.  Data is encrypted with the new key versions from then on, and a
+	// background job re-encrypts existing data with them.  Other controllers
+	// start using the new versions within 10 seconds.  An error is returned if
+	// the Scope does not exist.
+	RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error)
+	// SetScopeKms moves a Scope's root key to the "scope-root" KMS with the
+	// given name, re-encrypting all of the root key's versions with it.  An
+	// empty name moves the root key back to the "root" KMS.  An error is
+	// returned if the Scope does not exist or if no KMS with the name is
+	// configured.
+	SetScopeKms(context.Context, *SetScopeKmsRequest) (*SetScopeKmsResponse, error)
+	// ListKeys lists the root key and the data encryption keys of a Scope along
+	// with all of their versions.  An error is returned if the Scope does not
+	// exist.
+	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
+	// DestroyKeyVersion destroys a version of one of a Scope's keys.  Data
+	// encrypted by the version is re-encrypted with the current version of the
+	// key before the version is destroyed.  If the version can be destroyed
+	// immediately the returned state is "completed", otherwise it is "pending"
+	// and the progress of the destruction can be followed with
+	// ListKeyVersionDestructionJobs.  The current version of a key cannot be
+	// destroyed; rotate the Scope's keys first.  Versions of the oplog and
+	// audit keys, and versions still encrypting data which cannot be
+	// re-encrypted, such as password credentials and sessions, cannot be
+	// destroyed either.
+	DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error)
+	// ListKeyVersionDestructionJobs lists the pending destructions of the
+	// versions of a Scope's keys.  An error is returned if the Scope does not
+	// exist.
+	ListKeyVersionDestructionJobs(context.Context, *ListKeyVersionDestructionJobsRequest) (*ListKeyVersionDestructionJobsResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -151,6 +258,21 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateScopeKeys not implemented")
+}
+func (UnimplementedScopeServiceServer) SetScopeKms(context.Context, *SetScopeKmsRequest) (*SetScopeKmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScopeKms not implemented")
+}
+func (UnimplementedScopeServiceServer) ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeys not implemented")
+}
+func (UnimplementedScopeServiceServer) DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyKeyVersion not implemented")
+}
+func (UnimplementedScopeServiceServer) ListKeyVersionDestructionJobs(context.Context, *ListKeyVersionDestructionJobsRequest) (*ListKeyVersionDestructionJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeyVersionDestructionJobs not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +376,96 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_RotateScopeKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateScopeKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RotateScopeKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, req.(*RotateScopeKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_SetScopeKms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScopeKmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).SetScopeKms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/SetScopeKms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).SetScopeKms(ctx, req.(*SetScopeKmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListKeys(ctx, req.(*ListKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_DestroyKeyVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyKeyVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).DestroyKeyVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/DestroyKeyVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).DestroyKeyVersion(ctx, req.(*DestroyKeyVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListKeyVersionDestructionJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeyVersionDestructionJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListKeyVersionDestructionJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListKeyVersionDestructionJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListKeyVersionDestructionJobs(ctx, req.(*ListKeyVersionDestructionJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScopeService_ServiceDesc is the grpc.ServiceDesc for ScopeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +493,26 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "RotateScopeKeys",
+			Handler:    _ScopeService_RotateScopeKeys_Handler,
+		},
+		{
+			MethodName: "SetScopeKms",
+			Handler:    _ScopeService_SetScopeKms_Handler,
+		},
+		{
+			MethodName: "ListKeys",
+			Handler:    _ScopeService_ListKeys_Handler,
+		},
+		{
+			MethodName: "DestroyKeyVersion",
+			Handler:    _ScopeService_DestroyKeyVersion_Handler,
+		},
+		{
+			MethodName: "ListKeyVersionDestructionJobs",
+			Handler:    _ScopeService_ListKeyVersionDestructionJobs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
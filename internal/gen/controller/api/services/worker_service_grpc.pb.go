@@ -34,6 +34,14 @@ type WorkerServiceClient interface {
 	// resource, an error is returned.  If a name is provided that is in
 	// use in another Worker in the same scope, an error is returned.
 	CreateWorkerLed(ctx context.Context, in *CreateWorkerLedRequest, opts ...grpc.CallOption) (*CreateWorkerLedResponse, error)
+	// CreateControllerLed creates and stores a Worker in boundary and returns a
+	// single use activation token which the worker can use to authorize itself
+	// before the token expires. The provided request must include the Scope id
+	// in which the Worker will be created. If the Scope id is missing,
+	// malformed or references a non existing resource, an error is returned.
+	// If a name is provided that is in use in another Worker in the same
+	// scope, an error is returned.
+	CreateControllerLed(ctx context.Context, in *CreateControllerLedRequest, opts ...grpc.CallOption) (*CreateControllerLedResponse, error)
 	// UpdateWorker updates an existing Worker in boundary.  The provided
 	// Worker must not have any read only fields set.  The update mask must be
 	// included in the request and contain at least 1 mutable field.  To unset
@@ -46,6 +54,16 @@ type WorkerServiceClient interface {
 	// DeleteWorker removes a Worker from Boundary. If the provided Worker ID
 	// is malformed or not provided an error is returned.
 	DeleteWorker(ctx context.Context, in *DeleteWorkerRequest, opts ...grpc.CallOption) (*DeleteWorkerResponse, error)
+	// RotateWorkerAuth requests that a `pki`-type Worker rotate its
+	// credentials the next time it reports its status, rather than waiting for
+	// its next scheduled rotation. An error is returned if the Worker does not
+	// exist or is not a `pki`-type Worker.
+	RotateWorkerAuth(ctx context.Context, in *RotateWorkerAuthRequest, opts ...grpc.CallOption) (*RotateWorkerAuthResponse, error)
+	// RevokeWorkerActivationToken revokes any activation token generated for a
+	// Worker by CreateControllerLed which has not yet been redeemed. An error
+	// is returned if the Worker does not exist or has no activation token
+	// which can be revoked.
+	RevokeWorkerActivationToken(ctx context.Context, in *RevokeWorkerActivationTokenRequest, opts ...grpc.CallOption) (*RevokeWorkerActivationTokenResponse, error)
 }
 
 type workerServiceClient struct {
@@ -83,6 +101,15 @@ func (c *workerServiceClient) CreateWorkerLed(ctx context.Context, in *CreateWor
 	return out, nil
 }
 
+func (c *workerServiceClient) CreateControllerLed(ctx context.Context, in *CreateControllerLedRequest, opts ...grpc.CallOption) (*CreateControllerLedResponse, error) {
+	out := new(CreateControllerLedResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.WorkerService/CreateControllerLed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workerServiceClient) UpdateWorker(ctx context.Context, in *UpdateWorkerRequest, opts ...grpc.CallOption) (*UpdateWorkerResponse, error) {
 	out := new(UpdateWorkerResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.WorkerService/UpdateWorker", in, out, opts...)
@@ -101,6 +128,24 @@ func (c *workerServiceClient) DeleteWorker(ctx context.Context, in *DeleteWorker
 	return out, nil
 }
 
+func (c *workerServiceClient) RotateWorkerAuth(ctx context.Context, in *RotateWorkerAuthRequest, opts ...grpc.CallOption) (*RotateWorkerAuthResponse, error) {
+	out := new(RotateWorkerAuthResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.WorkerService/RotateWorkerAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) RevokeWorkerActivationToken(ctx context.Context, in *RevokeWorkerActivationTokenRequest, opts ...grpc.CallOption) (*RevokeWorkerActivationTokenResponse, error) {
+	out := new(RevokeWorkerActivationTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.WorkerService/RevokeWorkerActivationToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerServiceServer is the server API for WorkerService service.
 // All implementations must embed UnimplementedWorkerServiceServer
 // for forward compatibility
@@ -121,6 +166,14 @@ type WorkerServiceServer interface {
 	// resource, an error is returned.  If a name is provided that is in
 	// use in another Worker in the same scope, an error is returned.
 	CreateWorkerLed(context.Context, *CreateWorkerLedRequest) (*CreateWorkerLedResponse, error)
+	// CreateControllerLed creates and stores a Worker in boundary and returns a
+	// single use activation token which the worker can use to authorize itself
+	// before the token expires. The provided request must include the Scope id
+	// in which the Worker will be created. If the Scope id is missing,
+	// malformed or references a non existing resource, an error is returned.
+	// If a name is provided that is in use in another Worker in the same
+	// scope, an error is returned.
+	CreateControllerLed(context.Context, *CreateControllerLedRequest) (*CreateControllerLedResponse, error)
 	// UpdateWorker updates an existing Worker in boundary.  The provided
 	// Worker must not have any read only fields set.  The update mask must be
 	// included in the request and contain at least 1 mutable field.  To unset
@@ -133,6 +186,16 @@ type WorkerServiceServer interface {
 	// DeleteWorker removes a Worker from Boundary. If the provided Worker ID
 	// is malformed or not provided an error is returned.
 	DeleteWorker(context.Context, *DeleteWorkerRequest) (*DeleteWorkerResponse, error)
+	// RotateWorkerAuth requests that a `pki`-type Worker rotate its
+	// credentials the next time it reports its status, rather than waiting for
+	// its next scheduled rotation. An error is returned if the Worker does not
+	// exist or is not a `pki`-type Worker.
+	RotateWorkerAuth(context.Context, *RotateWorkerAuthRequest) (*RotateWorkerAuthResponse, error)
+	// RevokeWorkerActivationToken revokes any activation token generated for a
+	// Worker by CreateControllerLed which has not yet been redeemed. An error
+	// is returned if the Worker does not exist or has no activation token
+	// which can be revoked.
+	RevokeWorkerActivationToken(context.Context, *RevokeWorkerActivationTokenRequest) (*RevokeWorkerActivationTokenResponse, error)
 	mustEmbedUnimplementedWorkerServiceServer()
 }
 
@@ -149,12 +212,21 @@ func (UnimplementedWorkerServiceServer) ListWorkers(context.Context, *ListWorker
 func (UnimplementedWorkerServiceServer) CreateWorkerLed(context.Context, *CreateWorkerLedRequest) (*CreateWorkerLedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkerLed not implemented")
 }
+func (UnimplementedWorkerServiceServer) CreateControllerLed(context.Context, *CreateControllerLedRequest) (*CreateControllerLedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateControllerLed not implemented")
+}
 func (UnimplementedWorkerServiceServer) UpdateWorker(context.Context, *UpdateWorkerRequest) (*UpdateWorkerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorker not implemented")
 }
 func (UnimplementedWorkerServiceServer) DeleteWorker(context.Context, *DeleteWorkerRequest) (*DeleteWorkerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorker not implemented")
 }
+func (UnimplementedWorkerServiceServer) RotateWorkerAuth(context.Context, *RotateWorkerAuthRequest) (*RotateWorkerAuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateWorkerAuth not implemented")
+}
+func (UnimplementedWorkerServiceServer) RevokeWorkerActivationToken(context.Context, *RevokeWorkerActivationTokenRequest) (*RevokeWorkerActivationTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeWorkerActivationToken not implemented")
+}
 func (UnimplementedWorkerServiceServer) mustEmbedUnimplementedWorkerServiceServer() {}
 
 // UnsafeWorkerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -222,6 +294,24 @@ func _WorkerService_CreateWorkerLed_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerService_CreateControllerLed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateControllerLedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).CreateControllerLed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.WorkerService/CreateControllerLed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).CreateControllerLed(ctx, req.(*CreateControllerLedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkerService_UpdateWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateWorkerRequest)
 	if err := dec(in); err != nil {
@@ -258,6 +348,42 @@ func _WorkerService_DeleteWorker_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerService_RotateWorkerAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateWorkerAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).RotateWorkerAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.WorkerService/RotateWorkerAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).RotateWorkerAuth(ctx, req.(*RotateWorkerAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_RevokeWorkerActivationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeWorkerActivationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).RevokeWorkerActivationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.WorkerService/RevokeWorkerActivationToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).RevokeWorkerActivationToken(ctx, req.(*RevokeWorkerActivationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkerService_ServiceDesc is the grpc.ServiceDesc for WorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -277,6 +403,10 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateWorkerLed",
 			Handler:    _WorkerService_CreateWorkerLed_Handler,
 		},
+		{
+			MethodName: "CreateControllerLed",
+			Handler:    _WorkerService_CreateControllerLed_Handler,
+		},
 		{
 			MethodName: "UpdateWorker",
 			Handler:    _WorkerService_UpdateWorker_Handler,
@@ -285,6 +415,14 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteWorker",
 			Handler:    _WorkerService_DeleteWorker_Handler,
 		},
+		{
+			MethodName: "RotateWorkerAuth",
+			Handler:    _WorkerService_RotateWorkerAuth_Handler,
+		},
+		{
+			MethodName: "RevokeWorkerActivationToken",
+			Handler:    _WorkerService_RevokeWorkerActivationToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/worker_service.proto",
@@ -176,7 +176,8 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("error creating transaction's kms"))
 			}
-			if err := txnKms.AddExternalWrappers(ctx, kms.WithRootWrapper(r.kms.GetExternalWrappers(ctx).Root())); err != nil {
+			externalWrappers := r.kms.GetExternalWrappers(ctx)
+			if err := txnKms.AddExternalWrappers(ctx, kms.WithRootWrapper(externalWrappers.Root()), kms.WithScopeRootWrappers(externalWrappers.ScopeRoots())); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("error adding external root wrapper to transaction's kms"))
 			}
 			if err := txnKms.CreateKeys(ctx, s.PublicId, kms.WithRandomReader(reader), kms.WithReaderWriter(dbr, w)); err != nil {
@@ -435,6 +436,97 @@ func (r *Repository) LookupScope(ctx context.Context, withPublicId string, _ ...
 	return &scope, nil
 }
 
+// RotateScopeKeys will rotate the root key and DEKs of the scope.  New data is
+// encrypted with the new key versions, while existing data keeps using the
+// previous versions until it's re-encrypted.
+func (r *Repository) RotateScopeKeys(ctx context.Context, withPublicId string, _ ...Option) error {
+	const op = "iam.(Repository).RotateScopeKeys"
+	if err := r.lookupScopeForKeys(ctx, withPublicId); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := r.kms.RotateKeys(ctx, withPublicId); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rotate keys for %s", withPublicId)))
+	}
+	return nil
+}
+
+// SetScopeKms moves the root key of the scope to the scope-root kms with the
+// given name, re-encrypting all of its versions with that kms.  An empty name
+// moves the root key back to the root kms.
+func (r *Repository) SetScopeKms(ctx context.Context, withPublicId, kmsName string, _ ...Option) error {
+	const op = "iam.(Repository).SetScopeKms"
+	if err := r.lookupScopeForKeys(ctx, withPublicId); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := r.kms.SetScopeRootWrapper(ctx, withPublicId, kmsName); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to set kms for %s", withPublicId)))
+	}
+	return nil
+}
+
+// ListScopeKeys returns the root key and the data keys of the scope along with
+// all of their versions.
+func (r *Repository) ListScopeKeys(ctx context.Context, withPublicId string, _ ...Option) ([]*kms.Key, error) {
+	const op = "iam.(Repository).ListScopeKeys"
+	if err := r.lookupScopeForKeys(ctx, withPublicId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	keys, err := r.kms.ListKeys(ctx, withPublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to list keys for %s", withPublicId)))
+	}
+	return keys, nil
+}
+
+// DestroyScopeKeyVersion destroys a version of one of the scope's keys once
+// the data it encrypts has been re-encrypted with the current version of the
+// key. The returned bool reports whether the version was destroyed
+// immediately; if not, its progress is reported by
+// ListScopeKeyVersionDestructionJobs.
+func (r *Repository) DestroyScopeKeyVersion(ctx context.Context, withPublicId, keyVersionId string, _ ...Option) (bool, error) {
+	const op = "iam.(Repository).DestroyScopeKeyVersion"
+	if keyVersionId == "" {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing key version id")
+	}
+	if err := r.lookupScopeForKeys(ctx, withPublicId); err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	destroyed, err := r.kms.DestroyKeyVersion(ctx, withPublicId, keyVersionId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to destroy key version %s", keyVersionId)))
+	}
+	return destroyed, nil
+}
+
+// ListScopeKeyVersionDestructionJobs returns the pending destructions of the
+// versions of the scope's keys.
+func (r *Repository) ListScopeKeyVersionDestructionJobs(ctx context.Context, withPublicId string, _ ...Option) ([]*kms.KeyVersionDestructionJob, error) {
+	const op = "iam.(Repository).ListScopeKeyVersionDestructionJobs"
+	if err := r.lookupScopeForKeys(ctx, withPublicId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	jobs, err := r.kms.ListKeyVersionDestructionJobs(ctx, withPublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to list key version destruction jobs for %s", withPublicId)))
+	}
+	return jobs, nil
+}
+
+func (r *Repository) lookupScopeForKeys(ctx context.Context, withPublicId string) error {
+	const op = "iam.(Repository).lookupScopeForKeys"
+	if withPublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	s, err := r.LookupScope(ctx, withPublicId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if s == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("scope %s not found", withPublicId))
+	}
+	return nil
+}
+
 // DeleteScope will delete a scope from the repository
 func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteScope"
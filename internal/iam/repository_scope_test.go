@@ -9,6 +9,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
 	iam_store "github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -261,6 +262,82 @@ func Test_Repository_Scope_Delete(t *testing.T) {
 	})
 }
 
+func Test_Repository_Scope_RotateKeys(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	t.Run("valid-with-public-id", func(t *testing.T) {
+		require := require.New(t)
+		org, proj := TestScopes(t, repo)
+		require.NoError(repo.RotateScopeKeys(context.Background(), org.PublicId))
+		require.NoError(repo.RotateScopeKeys(context.Background(), proj.PublicId))
+		require.NoError(repo.RotateScopeKeys(context.Background(), scope.Global.String()))
+	})
+	t.Run("missing-public-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := repo.RotateScopeKeys(context.Background(), "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("valid-with-bad-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := repo.RotateScopeKeys(context.Background(), testId(t))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+}
+
+func Test_Repository_Scope_Keys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+
+	keys, err := repo.ListScopeKeys(ctx, org.PublicId)
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+	rootVersion := keys[0].Versions[0].Id
+
+	require.NoError(t, repo.RotateScopeKeys(ctx, org.PublicId))
+	destroyed, err := repo.DestroyScopeKeyVersion(ctx, org.PublicId, rootVersion)
+	require.NoError(t, err)
+	assert.True(t, destroyed)
+
+	jobs, err := repo.ListScopeKeyVersionDestructionJobs(ctx, org.PublicId)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	require.NoError(t, repo.SetScopeKms(ctx, org.PublicId, ""))
+
+	t.Run("unknown-kms", func(t *testing.T) {
+		err := repo.SetScopeKms(ctx, org.PublicId, "unknown")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.KeyNotFound), err))
+	})
+	t.Run("missing-key-version-id", func(t *testing.T) {
+		_, err := repo.DestroyScopeKeyVersion(ctx, org.PublicId, "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("valid-with-bad-id", func(t *testing.T) {
+		_, err := repo.ListScopeKeys(ctx, testId(t))
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+		_, err = repo.DestroyScopeKeyVersion(ctx, testId(t), rootVersion)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+		_, err = repo.ListScopeKeyVersionDestructionJobs(ctx, testId(t))
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+		err = repo.SetScopeKms(ctx, testId(t), "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+}
+
 func TestRepository_UpdateScope(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	now := &timestamp.Timestamp{Timestamp: ptypes.TimestampNow()}
@@ -8,6 +8,7 @@ import (
 // configuration file.
 type ExternalWrappers struct {
 	root       wrapping.Wrapper
+	scopeRoots map[string]wrapping.Wrapper
 	workerAuth wrapping.Wrapper
 	recovery   wrapping.Wrapper
 }
@@ -17,6 +18,12 @@ func (e *ExternalWrappers) Root() wrapping.Wrapper {
 	return e.root
 }
 
+// ScopeRoots returns the named wrappers which can be used instead of the root
+// wrapper for the root keys of scopes
+func (e *ExternalWrappers) ScopeRoots() map[string]wrapping.Wrapper {
+	return e.scopeRoots
+}
+
 // WorkerAuth returns the wrapper for worker authentication
 func (e *ExternalWrappers) WorkerAuth() wrapping.Wrapper {
 	return e.workerAuth
@@ -0,0 +1,111 @@
+package kmsjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+const (
+	// RewrapJobName is the name of the job which re-encrypts data after a
+	// scope's keys are rotated.  Rotating keys schedules it to run immediately.
+	RewrapJobName = "kms_rewrap"
+
+	rewrapFrequency = time.Hour
+)
+
+// rewrapJob defines a periodic job that re-encrypts the rows of every table
+// with a registered rewrap function which are still encrypted with a data key
+// version that was superseded by rotating the scope's keys. The rewrapJob is
+// not thread safe.
+type rewrapJob struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+
+	completed, total int
+}
+
+// newRewrapJob instantiates the rewrap job.
+func newRewrapJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms) (*rewrapJob, error) {
+	const op = "kmsjob.newRewrapJob"
+	switch {
+	case isNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case isNil(w):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+
+	return &rewrapJob{
+		reader: r,
+		writer: w,
+		kms:    kms,
+	}, nil
+}
+
+// Name returns a short, unique name for the job.
+func (j *rewrapJob) Name() string { return RewrapJobName }
+
+// Description returns the description for the job.
+func (j *rewrapJob) Description() string {
+	return "Re-encrypt data with the current version of rotated kms keys"
+}
+
+// NextRunIn returns the next run time after a job is completed.
+func (j *rewrapJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return rewrapFrequency, nil
+}
+
+// Status returns the status of the running job, where Total is the number of
+// rows found to be encrypted with a superseded key version when the run
+// started and Completed is the number of those rows re-encrypted so far.
+func (j *rewrapJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.completed,
+		Total:     j.total,
+	}
+}
+
+// Run re-encrypts the rows which are still encrypted with superseded key
+// versions. The rows encrypted with each key version are re-encrypted in their
+// own transaction, so progress made before an error or the job being
+// cancelled is kept. Key versions marked for destruction are destroyed once
+// they no longer encrypt any rows.
+func (j *rewrapJob) Run(ctx context.Context) error {
+	const op = "kmsjob.(rewrapJob).Run"
+	j.completed, j.total = 0, 0
+
+	pending, err := j.kms.ListPendingRewraps(ctx, j.reader)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, p := range pending {
+		j.total += p.Rows
+	}
+
+	for _, p := range pending {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx, ctx.Err(), op)
+		default:
+		}
+		_, err := j.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+			return j.kms.Rewrap(ctx, p, r, w)
+		})
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		j.completed += p.Rows
+	}
+
+	if err := j.kms.DestroyPendingKeyVersions(ctx, j.reader, j.writer); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
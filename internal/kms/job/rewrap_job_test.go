@@ -0,0 +1,245 @@
+package kmsjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRewrapJob(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	tests := []struct {
+		name    string
+		r       db.Reader
+		w       db.Writer
+		kms     *kms.Kms
+		wantErr bool
+	}{
+		{
+			name:    "nil reader",
+			w:       rw,
+			kms:     kmsCache,
+			wantErr: true,
+		},
+		{
+			name:    "nil writer",
+			r:       rw,
+			kms:     kmsCache,
+			wantErr: true,
+		},
+		{
+			name:    "nil kms",
+			r:       rw,
+			w:       rw,
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			r:    rw,
+			w:    rw,
+			kms:  kmsCache,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newRewrapJob(ctx, tt.r, tt.w, tt.kms)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(RewrapJobName, got.Name())
+			next, err := got.NextRunIn(ctx)
+			require.NoError(err)
+			assert.Equal(rewrapFrequency, next)
+		})
+	}
+}
+
+func TestRewrapJob_Run(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	// Nothing to do before the keys are rotated
+	require.NoError(job.Run(ctx))
+	assert.Equal(0, job.Status().Total)
+
+	require.NoError(kmsCache.RotateKeys(ctx, org.GetPublicId()))
+	pending, err := kmsCache.ListPendingRewraps(ctx, rw)
+	require.NoError(err)
+	require.Len(pending, 1)
+	assert.Equal(at.GetKeyId(), pending[0].DataKeyVersionId)
+	assert.Equal(org.GetPublicId(), pending[0].ScopeId)
+
+	require.NoError(job.Run(ctx))
+	assert.Equal(1, job.Status().Total)
+	assert.Equal(1, job.Status().Completed)
+
+	pending, err = kmsCache.ListPendingRewraps(ctx, rw)
+	require.NoError(err)
+	assert.Empty(pending)
+
+	repo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	got, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+	require.NoError(err)
+	assert.NotEqual(at.GetKeyId(), got.GetKeyId())
+
+	// The rewrapped token must still validate
+	validated, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+	require.NoError(err)
+	require.NotNil(validated)
+}
+
+func TestRewrapJob_DestroyKeyVersion(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+
+	require.NoError(kmsCache.RotateKeys(ctx, org.GetPublicId()))
+
+	// The token still references the previous version, so it's destroyed by
+	// the job after the token is rewrapped
+	destroyed, err := kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), at.GetKeyId())
+	require.NoError(err)
+	assert.False(destroyed)
+
+	jobs, err := kmsCache.ListKeyVersionDestructionJobs(ctx, org.GetPublicId())
+	require.NoError(err)
+	require.Len(jobs, 1)
+	assert.Equal(at.GetKeyId(), jobs[0].KeyVersionId)
+	assert.Equal(int64(1), jobs[0].TotalCount)
+	assert.Equal(int64(0), jobs[0].CompletedCount)
+
+	// Requesting the destruction again doesn't create another job
+	destroyed, err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), at.GetKeyId())
+	require.NoError(err)
+	assert.False(destroyed)
+
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+
+	jobs, err = kmsCache.ListKeyVersionDestructionJobs(ctx, org.GetPublicId())
+	require.NoError(err)
+	assert.Empty(jobs)
+
+	keys, err := kmsCache.ListKeys(ctx, org.GetPublicId())
+	require.NoError(err)
+	for _, k := range keys {
+		for _, v := range k.Versions {
+			assert.NotEqual(at.GetKeyId(), v.Id)
+		}
+	}
+}
+
+func TestRewrapJob_RotateRewrapDestroy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+	oldKeyId := at.GetKeyId()
+
+	keyVersionCount := func() int {
+		rows, err := rw.Query(ctx, "select count(*) from kms_data_key_version where private_id = ?", []interface{}{oldKeyId})
+		require.NoError(err)
+		defer rows.Close()
+		require.True(rows.Next())
+		var cnt int
+		require.NoError(rows.Scan(&cnt))
+		return cnt
+	}
+	require.Equal(1, keyVersionCount())
+
+	require.NoError(kmsCache.RotateKeys(ctx, org.GetPublicId()))
+	destroyed, err := kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldKeyId)
+	require.NoError(err)
+	assert.False(destroyed)
+
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+
+	// The token is re-encrypted with the new version, which leaves nothing
+	// referencing the old version, so it's destroyed.
+	repo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	got, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+	require.NoError(err)
+	assert.NotEqual(oldKeyId, got.GetKeyId())
+
+	pending, err := kmsCache.ListPendingRewraps(ctx, rw)
+	require.NoError(err)
+	assert.Empty(pending)
+	jobs, err := kmsCache.ListKeyVersionDestructionJobs(ctx, org.GetPublicId())
+	require.NoError(err)
+	assert.Empty(jobs)
+	assert.Equal(0, keyVersionCount())
+
+	validated, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+	require.NoError(err)
+	require.NotNil(validated)
+}
+
+func TestRewrapJob_Run_keepsVersion(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	am := oidc.TestAuthMethod(t, conn, databaseWrapper, org.GetPublicId(), oidc.InactiveState, "alice-rp", "fido")
+
+	require.NoError(kmsCache.RotateKeys(ctx, org.GetPublicId()))
+	job, err := newRewrapJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+
+	repo, err := oidc.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	got, err := repo.LookupAuthMethod(ctx, am.GetPublicId())
+	require.NoError(err)
+	assert.NotEqual(am.GetKeyId(), got.GetKeyId())
+	assert.Equal("fido", got.GetClientSecret())
+	// re-encrypting the client secret doesn't change the auth method, so an
+	// update made with the version read before the rewrap still succeeds
+	assert.Equal(am.GetVersion(), got.GetVersion())
+}
@@ -0,0 +1,614 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+)
+
+// KeyType is the type of one of a scope's keys.
+type KeyType string
+
+const (
+	// KeyTypeKek is the type of a scope's root key, which encrypts the scope's
+	// data keys.
+	KeyTypeKek KeyType = "kek"
+
+	// KeyTypeDek is the type of a scope's data keys, which encrypt data.
+	KeyTypeDek KeyType = "dek"
+)
+
+// Key is one of a scope's keys along with all of its versions.
+type Key struct {
+	Id         string
+	ScopeId    string
+	Type       KeyType
+	Purpose    KeyPurpose
+	CreateTime time.Time
+	// WrapperName is the name of the scope root wrapper which wraps a root
+	// key, or empty when the root key is wrapped by the external root
+	// wrapper.
+	WrapperName string
+	// Versions are ordered newest first, so the first version is the one
+	// used to encrypt new data.
+	Versions []*KeyVersion
+}
+
+// KeyVersion is a single version of a Key.
+type KeyVersion struct {
+	Id         string
+	Version    uint32
+	CreateTime time.Time
+	// References is the number of data key versions encrypted by a root key
+	// version, or the number of rows encrypted by a data key version.
+	References int64
+}
+
+// KeyVersionDestructionJob reports the progress of destroying a data key
+// version whose rows are being re-encrypted.
+type KeyVersionDestructionJob struct {
+	KeyVersionId   string
+	ScopeId        string
+	CreateTime     time.Time
+	CompletedCount int64
+	TotalCount     int64
+}
+
+// ListKeys returns the root key and the data keys of the scope, along with all
+// of their versions.
+func (k *Kms) ListKeys(ctx context.Context, scopeId string) ([]*Key, error) {
+	const op = "kms.(Kms).ListKeys"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	rk, err := lookupRootKey(ctx, k.reader, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var rootVersions []*rootKeyVersion
+	if err := k.reader.SearchWhere(ctx, &rootVersions, "root_key_id = ?", []interface{}{rk.PrivateId}, db.WithOrder("version desc"), db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search root key versions"))
+	}
+	var dataKeys []*dataKey
+	if err := k.reader.SearchWhere(ctx, &dataKeys, "root_key_id = ?", []interface{}{rk.PrivateId}, db.WithOrder("purpose asc"), db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search data keys"))
+	}
+	var dataVersions []*dataKeyVersion
+	if err := k.reader.SearchWhere(ctx, &dataVersions, "data_key_id in (select private_id from kms_data_key where root_key_id = ?)", []interface{}{rk.PrivateId}, db.WithOrder("version desc"), db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search data key versions"))
+	}
+	tables, err := keyReferenceTables(ctx, k.reader)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	dataRefs, err := scopeKeyReferences(ctx, k.reader, tables, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	rootRefs := make(map[string]int64, len(rootVersions))
+	versionsByKey := make(map[string][]*KeyVersion, len(dataKeys))
+	for _, dkv := range dataVersions {
+		rootRefs[dkv.RootKeyVersionId]++
+		versionsByKey[dkv.DataKeyId] = append(versionsByKey[dkv.DataKeyId], &KeyVersion{
+			Id:         dkv.PrivateId,
+			Version:    dkv.Version,
+			CreateTime: dkv.CreateTime,
+			References: dataRefs[dkv.PrivateId],
+		})
+	}
+
+	wrapperName, err := lookupScopeRootWrapperName(ctx, k.reader, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	root := &Key{
+		Id:          rk.PrivateId,
+		ScopeId:     scopeId,
+		Type:        KeyTypeKek,
+		Purpose:     KeyPurposeRootKey,
+		CreateTime:  rk.CreateTime,
+		WrapperName: wrapperName,
+	}
+	for _, rkv := range rootVersions {
+		root.Versions = append(root.Versions, &KeyVersion{
+			Id:         rkv.PrivateId,
+			Version:    rkv.Version,
+			CreateTime: rkv.CreateTime,
+			References: rootRefs[rkv.PrivateId],
+		})
+	}
+	keys := []*Key{root}
+	for _, dk := range dataKeys {
+		keys = append(keys, &Key{
+			Id:         dk.PrivateId,
+			ScopeId:    scopeId,
+			Type:       KeyTypeDek,
+			Purpose:    purposeFromString(dk.Purpose),
+			CreateTime: dk.CreateTime,
+			Versions:   versionsByKey[dk.PrivateId],
+		})
+	}
+	return keys, nil
+}
+
+// DestroyKeyVersion destroys a version of the scope's root key or of one of
+// its data keys.  A root key version is destroyed immediately, after the data
+// key versions it encrypts are re-encrypted with the current root key version.
+// A data key version which still encrypts rows of tables with a registered
+// rewrap function is marked for destruction, and is destroyed by
+// DestroyPendingKeyVersions once the rows have been re-encrypted.  The returned
+// bool reports whether the version was destroyed immediately.  The current
+// version of a key can't be destroyed, nor can a data key version which
+// encrypts rows that can't be re-encrypted, such as password credentials and
+// sessions, or a version of the oplog and audit keys, whose encrypted data
+// can't be enumerated.
+func (k *Kms) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string) (bool, error) {
+	const op = "kms.(Kms).DestroyKeyVersion"
+	switch {
+	case scopeId == "":
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case keyVersionId == "":
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing key version id")
+	}
+	rk, err := lookupRootKey(ctx, k.reader, scopeId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+
+	var rootVersions []*rootKeyVersion
+	if err := k.reader.SearchWhere(ctx, &rootVersions, "private_id = ? and root_key_id = ?", []interface{}{keyVersionId, rk.PrivateId}, db.WithLimit(1)); err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up root key version"))
+	}
+	if len(rootVersions) > 0 {
+		if err := k.destroyRootKeyVersion(ctx, rk, keyVersionId); err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		return true, nil
+	}
+	destroyed, err := k.destroyDataKeyVersion(ctx, rk, keyVersionId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return destroyed, nil
+}
+
+func (k *Kms) destroyRootKeyVersion(ctx context.Context, rk *rootKey, keyVersionId string) error {
+	const op = "kms.(Kms).destroyRootKeyVersion"
+	rootWrapper, err := k.underlying.GetExternalRootWrapper()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get external root wrapper"))
+	}
+
+	destroyFn := func(r db.Reader, w db.Writer) error {
+		var versions []*rootKeyVersion
+		if err := r.SearchWhere(ctx, &versions, "root_key_id = ?", []interface{}{rk.PrivateId}, db.WithOrder("version desc"), db.WithLimit(-1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search root key versions"))
+		}
+		var old *rootKeyVersion
+		for i, v := range versions {
+			if v.PrivateId != keyVersionId {
+				continue
+			}
+			if i == 0 {
+				return errors.New(ctx, errors.InvalidParameter, op, "cannot destroy the current version of a key")
+			}
+			old = v
+		}
+		if old == nil {
+			return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("root key version %s not found", keyVersionId))
+		}
+		current := versions[0]
+		if err := structwrapping.UnwrapStruct(ctx, rootWrapper, current, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt current root key version"))
+		}
+		if err := structwrapping.UnwrapStruct(ctx, rootWrapper, old, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt root key version"))
+		}
+		currentWrapper, err := newKeyVersionWrapper(ctx, current.PrivateId, current.Key)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		oldWrapper, err := newKeyVersionWrapper(ctx, old.PrivateId, old.Key)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+
+		var dataVersions []*dataKeyVersion
+		if err := r.SearchWhere(ctx, &dataVersions, "root_key_version_id = ?", []interface{}{old.PrivateId}, db.WithLimit(-1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search data key versions"))
+		}
+		for _, dkv := range dataVersions {
+			if err := structwrapping.UnwrapStruct(ctx, oldWrapper, dkv, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt data key version"))
+			}
+			dkv.RootKeyVersionId = current.PrivateId
+			if err := structwrapping.WrapStruct(ctx, currentWrapper, dkv, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to encrypt data key version"))
+			}
+			rowsUpdated, err := w.Update(ctx, dkv, []string{"CtKey", "RootKeyVersionId"}, nil)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update data key version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated data key version and %d rows updated", rowsUpdated))
+			}
+		}
+
+		rowsDeleted, err := w.Delete(ctx, old)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete root key version"))
+		}
+		if rowsDeleted != 1 {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("deleted root key version and %d rows deleted", rowsDeleted))
+		}
+		return nil
+	}
+	if _, err := k.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, destroyFn); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := k.ClearCache(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (k *Kms) destroyDataKeyVersion(ctx context.Context, rk *rootKey, keyVersionId string) (bool, error) {
+	const op = "kms.(Kms).destroyDataKeyVersion"
+	var versions []*dataKeyVersion
+	if err := k.reader.SearchWhere(ctx, &versions, "private_id = ?", []interface{}{keyVersionId}, db.WithLimit(1)); err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up data key version"))
+	}
+	if len(versions) == 0 {
+		return false, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("key version %s not found", keyVersionId))
+	}
+	dkv := versions[0]
+	var dataKeys []*dataKey
+	if err := k.reader.SearchWhere(ctx, &dataKeys, "private_id = ? and root_key_id = ?", []interface{}{dkv.DataKeyId, rk.PrivateId}, db.WithLimit(1)); err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up data key"))
+	}
+	if len(dataKeys) == 0 {
+		return false, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("key version %s not found", keyVersionId))
+	}
+	if p := purposeFromString(dataKeys[0].Purpose); unenumerableKeyPurposes[p] {
+		return false, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("cannot destroy a version of the %s key: its encrypted data cannot be enumerated", p))
+	}
+	var current []*dataKeyVersion
+	if err := k.reader.SearchWhere(ctx, &current, "data_key_id = ?", []interface{}{dkv.DataKeyId}, db.WithOrder("version desc"), db.WithLimit(1)); err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up current data key version"))
+	}
+	if len(current) > 0 && current[0].PrivateId == dkv.PrivateId {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "cannot destroy the current version of a key")
+	}
+
+	tables, err := keyReferenceTables(ctx, k.reader)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	pending, blocked, err := keyVersionReferences(ctx, k.reader, tables, keyVersionId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if blocked > 0 {
+		return false, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("key version encrypts %d rows which cannot be re-encrypted", blocked))
+	}
+	if pending == 0 {
+		if err := k.deleteDataKeyVersion(ctx, k.writer, dkv); err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		return true, nil
+	}
+
+	job := &keyVersionDestructionJob{
+		KeyId:      keyVersionId,
+		TotalCount: pending,
+	}
+	if err := k.writer.Create(ctx, job); err != nil {
+		if errors.IsUniqueError(err) {
+			// the destruction of the version was already requested
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create key version destruction job"))
+	}
+	return false, nil
+}
+
+// deleteDataKeyVersion deletes the data key version, along with its
+// destruction job if there is one, and clears the cache so wrappers for the
+// version are no longer used.
+func (k *Kms) deleteDataKeyVersion(ctx context.Context, writer db.Writer, dkv *dataKeyVersion) error {
+	const op = "kms.(Kms).deleteDataKeyVersion"
+	_, err := writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(_ db.Reader, w db.Writer) error {
+		rowsDeleted, err := w.Delete(ctx, dkv)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete data key version"))
+		}
+		if rowsDeleted != 1 {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("deleted data key version and %d rows deleted", rowsDeleted))
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := k.ClearCache(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// ListKeyVersionDestructionJobs returns the pending destructions of the
+// scope's data key versions.
+func (k *Kms) ListKeyVersionDestructionJobs(ctx context.Context, scopeId string) ([]*KeyVersionDestructionJob, error) {
+	const op = "kms.(Kms).ListKeyVersionDestructionJobs"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if _, err := lookupRootKey(ctx, k.reader, scopeId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	rows, err := k.reader.Query(ctx, scopeKeyVersionDestructionJobsQuery, []interface{}{scopeId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to query key version destruction jobs"))
+	}
+	var jobs []*KeyVersionDestructionJob
+	for rows.Next() {
+		j := &KeyVersionDestructionJob{
+			ScopeId: scopeId,
+		}
+		if err := rows.Scan(&j.KeyVersionId, &j.TotalCount, &j.CreateTime); err != nil {
+			_ = rows.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan key version destruction job"))
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read key version destruction jobs"))
+	}
+	_ = rows.Close()
+
+	tables, err := keyReferenceTables(ctx, k.reader)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, j := range jobs {
+		pending, _, err := keyVersionReferences(ctx, k.reader, tables, j.KeyVersionId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if j.CompletedCount = j.TotalCount - pending; j.CompletedCount < 0 {
+			j.CompletedCount = 0
+		}
+	}
+	return jobs, nil
+}
+
+// DestroyPendingKeyVersions destroys the data key versions marked for
+// destruction by DestroyKeyVersion which no longer encrypt any rows.  It's
+// intended to be called after the rows encrypted by previous key versions have
+// been re-encrypted.
+func (k *Kms) DestroyPendingKeyVersions(ctx context.Context, reader db.Reader, writer db.Writer) error {
+	const op = "kms.(Kms).DestroyPendingKeyVersions"
+	switch {
+	case isNil(reader):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case isNil(writer):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	var jobs []*keyVersionDestructionJob
+	if err := reader.SearchWhere(ctx, &jobs, "1=1", nil, db.WithOrder("create_time asc"), db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search key version destruction jobs"))
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	tables, err := keyReferenceTables(ctx, reader)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, j := range jobs {
+		pending, blocked, err := keyVersionReferences(ctx, reader, tables, j.KeyId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if pending > 0 || blocked > 0 {
+			continue
+		}
+		if err := k.deleteDataKeyVersion(ctx, writer, &dataKeyVersion{PrivateId: j.KeyId}); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+// LookupKeyVersionScopeId returns the ID of the scope whose data key has the
+// version, for finding the scope of the key which encrypted a value when it
+// isn't otherwise known.
+func (k *Kms) LookupKeyVersionScopeId(ctx context.Context, keyVersionId string) (string, error) {
+	const op = "kms.(Kms).LookupKeyVersionScopeId"
+	if keyVersionId == "" {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing key version id")
+	}
+	const where = `
+private_id in (
+  select dk.root_key_id
+    from kms_data_key dk
+    join kms_data_key_version dkv
+      on dkv.data_key_id = dk.private_id
+   where dkv.private_id = ?
+)`
+	var rootKeys []*rootKey
+	if err := k.reader.SearchWhere(ctx, &rootKeys, where, []interface{}{keyVersionId}, db.WithLimit(1)); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up root key"))
+	}
+	if len(rootKeys) == 0 {
+		return "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("no data key version %s found", keyVersionId))
+	}
+	return rootKeys[0].ScopeId, nil
+}
+
+func lookupRootKey(ctx context.Context, reader db.Reader, scopeId string) (*rootKey, error) {
+	const op = "kms.lookupRootKey"
+	var rootKeys []*rootKey
+	if err := reader.SearchWhere(ctx, &rootKeys, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up root key"))
+	}
+	if len(rootKeys) == 0 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("no root key found for scope %s", scopeId))
+	}
+	return rootKeys[0], nil
+}
+
+func purposeFromString(s string) KeyPurpose {
+	for _, p := range append(ValidDekPurposes(), KeyPurposeRecovery, KeyPurposeWorkerAuth, KeyPurposeWorkerAuthStorage, KeyPurposeRootKey) {
+		if p.String() == s {
+			return p
+		}
+	}
+	return KeyPurposeUnknown
+}
+
+// unenumerableKeyPurposes are the purposes of data keys whose encrypted data
+// does not record the key version used, such as oplog entries which hold it
+// inside their ciphertext. Their versions are never destroyed.
+var unenumerableKeyPurposes = map[KeyPurpose]bool{
+	KeyPurposeOplog: true,
+	KeyPurposeAudit: true,
+}
+
+// unconstrainedKeyReferenceTables are tables referencing the data key version
+// without a foreign key to kms_data_key_version.
+var unconstrainedKeyReferenceTables = []keyReferenceTable{
+	{name: "auth_password_argon2_cred", column: "key_id"},
+	{name: "session", column: "key_id"},
+}
+
+// keyReferenceTable is a table with a column referencing the data key version
+// used to encrypt each of its rows.
+type keyReferenceTable struct {
+	name   string
+	column string
+	// rewrappable is true when the table has a registered rewrap function.
+	rewrappable bool
+}
+
+// keyReferenceTables returns the tables with registered rewrap functions, the
+// tables with a foreign key referencing kms_data_key_version and the
+// unconstrainedKeyReferenceTables.
+func keyReferenceTables(ctx context.Context, reader db.Reader) ([]keyReferenceTable, error) {
+	const op = "kms.keyReferenceTables"
+	tables := map[string]keyReferenceTable{}
+	tableRewrapFnsLock.RLock()
+	for name := range tableRewrapFns {
+		tables[name] = keyReferenceTable{name: name, column: "key_id", rewrappable: true}
+	}
+	tableRewrapFnsLock.RUnlock()
+	for _, t := range unconstrainedKeyReferenceTables {
+		if _, ok := tables[t.name]; !ok {
+			tables[t.name] = t
+		}
+	}
+
+	rows, err := reader.Query(ctx, keyReferenceTablesQuery, nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to query key reference tables"))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t keyReferenceTable
+		if err := rows.Scan(&t.name, &t.column); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan key reference table"))
+		}
+		if _, ok := tables[t.name]; ok || t.name == (&keyVersionDestructionJob{}).TableName() {
+			continue
+		}
+		tables[t.name] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read key reference tables"))
+	}
+
+	ret := make([]keyReferenceTable, 0, len(tables))
+	for _, t := range tables {
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].name < ret[j].name })
+	return ret, nil
+}
+
+// scopeKeyReferences returns the number of rows of the tables encrypted by
+// each data key version of the scope.
+func scopeKeyReferences(ctx context.Context, reader db.Reader, tables []keyReferenceTable, scopeId string) (map[string]int64, error) {
+	const op = "kms.scopeKeyReferences"
+	refs := map[string]int64{}
+	for _, t := range tables {
+		rows, err := reader.Query(ctx, fmt.Sprintf(scopeKeyReferencesQuery, t.name, t.column), []interface{}{scopeId})
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to query key references of %s", t.name)))
+		}
+		for rows.Next() {
+			var id string
+			var count int64
+			if err := rows.Scan(&id, &count); err != nil {
+				_ = rows.Close()
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to scan key references of %s", t.name)))
+			}
+			refs[id] += count
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to read key references of %s", t.name)))
+		}
+		_ = rows.Close()
+	}
+	return refs, nil
+}
+
+// keyVersionReferences returns the number of rows encrypted by the data key
+// version in tables which can be re-encrypted, and in tables which can't.
+func keyVersionReferences(ctx context.Context, reader db.Reader, tables []keyReferenceTable, keyVersionId string) (rewrappable, other int64, _ error) {
+	const op = "kms.keyVersionReferences"
+	for _, t := range tables {
+		rows, err := reader.Query(ctx, fmt.Sprintf(keyVersionReferencesQuery, t.name, t.column), []interface{}{keyVersionId})
+		if err != nil {
+			return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to query key references of %s", t.name)))
+		}
+		var count int64
+		for rows.Next() {
+			if err := rows.Scan(&count); err != nil {
+				_ = rows.Close()
+				return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to scan key references of %s", t.name)))
+			}
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return 0, 0, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to read key references of %s", t.name)))
+		}
+		_ = rows.Close()
+		if t.rewrappable {
+			rewrappable += count
+		} else {
+			other += count
+		}
+	}
+	return rewrappable, other, nil
+}
+
+// keyVersionDestructionJob marks a data key version for destruction once the
+// rows it encrypts have been re-encrypted.
+type keyVersionDestructionJob struct {
+	KeyId      string    `gorm:"primary_key"`
+	TotalCount int64     `gorm:"default:null"`
+	CreateTime time.Time `gorm:"default:current_timestamp"`
+}
+
+func (*keyVersionDestructionJob) TableName() string { return "kms_data_key_version_destruction_job" }
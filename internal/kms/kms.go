@@ -2,6 +2,7 @@ package kms
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"reflect"
@@ -14,15 +15,34 @@ import (
 	"github.com/hashicorp/go-dbw"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/aead"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"github.com/hashicorp/go-uuid"
 )
 
+// DefaultVersionCheckInterval is how often a Kms checks that the cached
+// wrapper of a scope's key uses the current version of the key, so keys
+// rotated by another controller are used within this interval.
+const DefaultVersionCheckInterval = 10 * time.Second
+
 // Kms is a way to access wrappers for a given scope and purpose. Since keys can
 // never change, only be added or (eventually) removed, it opportunistically
 // caches, going to the database as needed.
 type Kms struct {
 	underlying          *wrappingKms.Kms
 	reader              db.Reader
+	writer              db.Writer
 	derivedPurposeCache sync.Map
+
+	// versionChecks holds the time the current version of each scope's key
+	// was last checked, keyed by scope id and purpose.
+	versionChecks        sync.Map
+	versionCheckInterval time.Duration
+
+	// externalRootWrapper and externalScopeRootWrappers are the wrappers
+	// combined into the external root wrapper of the underlying kms.
+	externalRootWrapper       wrapping.Wrapper
+	externalScopeRootWrappers map[string]wrapping.Wrapper
 }
 
 // New creates a Kms using the provided reader and writer.  No options are
@@ -44,8 +64,10 @@ func New(ctx context.Context, reader *db.Db, writer *db.Db, _ ...Option) (*Kms,
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error creating new in-memory kms"))
 	}
 	return &Kms{
-		underlying: k,
-		reader:     reader,
+		underlying:           k,
+		reader:               reader,
+		writer:               writer,
+		versionCheckInterval: DefaultVersionCheckInterval,
 	}, nil
 }
 
@@ -74,8 +96,10 @@ func NewUsingReaderWriter(ctx context.Context, reader db.Reader, writer db.Write
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error creating new in-memory kms"))
 	}
 	return &Kms{
-		underlying: k,
-		reader:     reader,
+		underlying:           k,
+		reader:               reader,
+		writer:               writer,
+		versionCheckInterval: DefaultVersionCheckInterval,
 	}, nil
 }
 
@@ -93,10 +117,27 @@ func (k *Kms) AddExternalWrappers(ctx context.Context, opt ...Option) error {
 	const op = "kms.(Kms).AddExternalWrappers"
 
 	opts := getOpts(opt...)
-	if opts.withRootWrapper != nil {
-		if err := k.underlying.AddExternalWrapper(ctx, wrappingKms.KeyPurpose(KeyPurposeRootKey.String()), opts.withRootWrapper); err != nil {
+	if opts.withRootWrapper != nil || len(opts.withScopeRootWrappers) > 0 {
+		root := k.externalRootWrapper
+		if opts.withRootWrapper != nil {
+			root = opts.withRootWrapper
+		}
+		scopeRoots := make(map[string]wrapping.Wrapper, len(k.externalScopeRootWrappers)+len(opts.withScopeRootWrappers))
+		for name, w := range k.externalScopeRootWrappers {
+			scopeRoots[name] = w
+		}
+		for name, w := range opts.withScopeRootWrappers {
+			scopeRoots[name] = w
+		}
+		rootWrapper, err := newPooledRootWrapper(ctx, root, scopeRoots)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := k.underlying.AddExternalWrapper(ctx, wrappingKms.KeyPurpose(KeyPurposeRootKey.String()), rootWrapper); err != nil {
 			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add root wrapper"))
 		}
+		k.externalRootWrapper = root
+		k.externalScopeRootWrappers = scopeRoots
 	}
 	if opts.withWorkerAuthWrapper != nil {
 		if err := k.underlying.AddExternalWrapper(ctx, wrappingKms.KeyPurpose(KeyPurposeWorkerAuth.String()), opts.withWorkerAuthWrapper); err != nil {
@@ -128,15 +169,62 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get wrapper"))
 	}
+	if opts.withKeyId == "" {
+		if w, err = k.currentWrapper(ctx, scopeId, purpose, w); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
 	return w, err
 }
 
+// currentWrapper returns the cached wrapper w if it uses the current version
+// of the scope's key.  Otherwise the key was rotated by another controller, so
+// the cache is cleared and the wrapper is loaded again.  The current version is
+// checked at most once per versionCheckInterval.
+func (k *Kms) currentWrapper(ctx context.Context, scopeId string, purpose KeyPurpose, w wrapping.Wrapper) (wrapping.Wrapper, error) {
+	const op = "kms.(Kms).currentWrapper"
+	checkId := scopeId + "/" + purpose.String()
+	if last, ok := k.versionChecks.Load(checkId); ok && time.Since(last.(time.Time)) < k.versionCheckInterval {
+		return w, nil
+	}
+	keyId, err := w.KeyId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get wrapper key id"))
+	}
+	rows, err := k.reader.Query(ctx, currentDataKeyVersionQuery, []interface{}{scopeId, purpose.String()})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up current key version"))
+	}
+	defer rows.Close()
+	var currentId string
+	for rows.Next() {
+		if err := rows.Scan(&currentId); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan current key version"))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read current key version"))
+	}
+	k.versionChecks.Store(checkId, time.Now())
+	if currentId == "" || currentId == keyId {
+		return w, nil
+	}
+	if err := k.ClearCache(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	w, err = k.underlying.GetWrapper(ctx, scopeId, wrappingKms.KeyPurpose(purpose.String()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get wrapper"))
+	}
+	return w, nil
+}
+
 // GetExternalWrappers returns the Kms' ExternalWrappers
 func (k *Kms) GetExternalWrappers(ctx context.Context) *ExternalWrappers {
 	const op = "kms.(Kms).GetExternalWrappers"
-	ret := &ExternalWrappers{}
-	if root, err := k.underlying.GetExternalRootWrapper(); err == nil {
-		ret.root = root
+	ret := &ExternalWrappers{
+		root:       k.externalRootWrapper,
+		scopeRoots: k.externalScopeRootWrappers,
 	}
 	if workerAuth, err := k.underlying.GetExternalWrapper(ctx, wrappingKms.KeyPurpose(KeyPurposeWorkerAuth.String())); err == nil {
 		ret.workerAuth = workerAuth
@@ -228,6 +316,107 @@ func (k *Kms) CreateKeys(ctx context.Context, scopeId string, opt ...Option) err
 	return nil
 }
 
+// RotateKeys rotates the root key and DEKs for the scope by creating a new
+// version of each, which become the versions used for all new encryption.
+// Existing versions are kept so previously encrypted data can still be
+// decrypted until it's re-encrypted by the registered rewrap functions.  Only
+// this Kms's cache is cleared; other controllers use the new versions once
+// their next version check, within DefaultVersionCheckInterval, finds them.
+// Supports the WithRandomReader(...) and WithReaderWriter(...) options. When
+// WithReaderWriter(...) is used the caller is responsible for managing the
+// transaction and for calling ClearCache(...) once it's committed.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) error {
+	const op = "kms.(Kms).RotateKeys"
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	if isNil(opts.withRandomReader) {
+		opts.withRandomReader = rand.Reader
+	}
+	rotateFn := func(r db.Reader, w db.Writer) error {
+		rootWrapper, err := k.scopeExternalRootWrapper(ctx, r, scopeId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+
+		var rootKeys []*rootKey
+		if err := r.SearchWhere(ctx, &rootKeys, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up root key"))
+		}
+		if len(rootKeys) == 0 {
+			return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("no root key found for scope %s", scopeId))
+		}
+		rk := rootKeys[0]
+
+		rkv := &rootKeyVersion{
+			RootKeyId: rk.PrivateId,
+		}
+		if rkv.PrivateId, err = dbw.NewId(rootKeyVersionPrefix); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if rkv.Key, err = generateKey(ctx, opts.withRandomReader); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := structwrapping.WrapStruct(ctx, rootWrapper, rkv, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to encrypt root key version"))
+		}
+		if err := w.Create(ctx, rkv); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create root key version"))
+		}
+
+		rkvWrapper, err := newKeyVersionWrapper(ctx, rkv.PrivateId, rkv.Key)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+
+		var dataKeys []*dataKey
+		if err := r.SearchWhere(ctx, &dataKeys, "root_key_id = ?", []interface{}{rk.PrivateId}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up data keys"))
+		}
+		for _, dk := range dataKeys {
+			dkv := &dataKeyVersion{
+				DataKeyId:        dk.PrivateId,
+				RootKeyVersionId: rkv.PrivateId,
+			}
+			if dkv.PrivateId, err = dbw.NewId(dataKeyVersionPrefix); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if dkv.Key, err = generateKey(ctx, opts.withRandomReader); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := structwrapping.WrapStruct(ctx, rkvWrapper, dkv, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg(fmt.Sprintf("unable to encrypt %s key version", dk.Purpose)))
+			}
+			if err := w.Create(ctx, dkv); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to create %s key version", dk.Purpose)))
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case !isNil(opts.withReader) && isNil(opts.withWriter):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	case isNil(opts.withReader) && !isNil(opts.withWriter):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case !isNil(opts.withReader) && !isNil(opts.withWriter):
+		if err := rotateFn(opts.withReader, opts.withWriter); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		return nil
+	}
+	if _, err := k.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, rotateFn); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	// the cached wrappers for the scope don't include the new versions, so
+	// drop them and let the next request load the rotated keys.
+	if err := k.ClearCache(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
 // ClearCache will clear the kms's cache which is useful after a scope has been
 // deleted.
 func (k *Kms) ClearCache(ctx context.Context) error {
@@ -281,3 +470,67 @@ type rootKey struct {
 }
 
 func (*rootKey) TableName() string { return "kms_root_key" }
+
+type dataKey struct {
+	PrivateId  string    `gorm:"primary_key"`
+	RootKeyId  string    `gorm:"default:null"`
+	Purpose    string    `gorm:"default:null"`
+	CreateTime time.Time `gorm:"default:current_timestamp"`
+}
+
+func (*dataKey) TableName() string { return "kms_data_key" }
+
+const (
+	rootKeyVersionPrefix = "krkv"
+	dataKeyVersionPrefix = "kdkv"
+)
+
+// rootKeyVersion and dataKeyVersion mirror the key version tables managed by
+// the underlying kms, so new versions can be written when keys are rotated.
+// The version of the underlying kms in use has no support for rotating keys;
+// RotateKeys should use it once it does.
+type rootKeyVersion struct {
+	PrivateId  string    `gorm:"primary_key"`
+	RootKeyId  string    `gorm:"default:null"`
+	Key        []byte    `gorm:"-" wrapping:"pt,key_data"`
+	CtKey      []byte    `gorm:"column:key;not_null" wrapping:"ct,key_data"`
+	Version    uint32    `gorm:"default:null"`
+	CreateTime time.Time `gorm:"default:current_timestamp"`
+}
+
+func (*rootKeyVersion) TableName() string { return "kms_root_key_version" }
+
+type dataKeyVersion struct {
+	PrivateId        string    `gorm:"primary_key"`
+	DataKeyId        string    `gorm:"default:null"`
+	RootKeyVersionId string    `gorm:"default:null"`
+	Key              []byte    `gorm:"-" wrapping:"pt,key_data"`
+	CtKey            []byte    `gorm:"column:key;not_null" wrapping:"ct,key_data"`
+	Version          uint32    `gorm:"default:null"`
+	CreateTime       time.Time `gorm:"default:current_timestamp"`
+}
+
+func (*dataKeyVersion) TableName() string { return "kms_data_key_version" }
+
+// newKeyVersionWrapper returns a wrapper which encrypts with the plaintext key
+// of a key version and identifies itself with the key version's id.
+func newKeyVersionWrapper(ctx context.Context, keyVersionId string, key []byte) (wrapping.Wrapper, error) {
+	const op = "kms.newKeyVersionWrapper"
+	w := aead.NewWrapper()
+	if _, err := w.SetConfig(ctx, wrapping.WithKeyId(keyVersionId)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to configure key version wrapper"))
+	}
+	if err := w.SetAesGcmKeyBytes(key); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to set key version bytes"))
+	}
+	return w, nil
+}
+
+func generateKey(ctx context.Context, randomReader io.Reader) ([]byte, error) {
+	const op = "kms.generateKey"
+	k, err := uuid.GenerateRandomBytesWithReader(32, randomReader)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	return k, nil
+}
@@ -9,11 +9,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	aead "github.com/hashicorp/go-kms-wrapping/v2/aead"
 	"github.com/hashicorp/go-kms-wrapping/v2/extras/multi"
 	"github.com/stretchr/testify/assert"
@@ -367,3 +369,326 @@ func TestKms_CreateKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+
+	t.Run("missing-scope-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.RotateKeys(testCtx, "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unknown-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.RotateKeys(testCtx, "o_1234567890")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		before, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+		beforeKeyId, err := before.KeyId(testCtx)
+		require.NoError(err)
+		blob, err := before.Encrypt(testCtx, []byte("secret"))
+		require.NoError(err)
+
+		require.NoError(kmsCache.RotateKeys(testCtx, org.GetPublicId()))
+
+		after, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+		afterKeyId, err := after.KeyId(testCtx)
+		require.NoError(err)
+		assert.NotEqual(beforeKeyId, afterKeyId)
+
+		// Data encrypted with the previous version must remain decryptable
+		old, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(beforeKeyId))
+		require.NoError(err)
+		pt, err := old.Decrypt(testCtx, blob)
+		require.NoError(err)
+		assert.Equal([]byte("secret"), pt)
+	})
+}
+
+func TestKms_SetScopeRootWrapper(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rootWrapper := db.TestWrapper(t)
+	tenantWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	require.NoError(t, kmsCache.AddExternalWrappers(testCtx, kms.WithScopeRootWrappers(map[string]wrapping.Wrapper{"tenant": tenantWrapper})))
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+
+	t.Run("duplicate-key-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		k := kms.TestKms(t, conn, rootWrapper)
+		err := k.AddExternalWrappers(testCtx, kms.WithScopeRootWrappers(map[string]wrapping.Wrapper{"tenant": rootWrapper}))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-scope-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.SetScopeRootWrapper(testCtx, "", "tenant")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unknown-wrapper", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.SetScopeRootWrapper(testCtx, org.GetPublicId(), "unknown")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.KeyNotFound), err))
+	})
+	t.Run("unknown-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.SetScopeRootWrapper(testCtx, "o_1234567890", "tenant")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		before, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+		blob, err := before.Encrypt(testCtx, []byte("secret"))
+		require.NoError(err)
+
+		require.NoError(kmsCache.SetScopeRootWrapper(testCtx, org.GetPublicId(), "tenant"))
+		name, err := kmsCache.ScopeRootWrapperName(testCtx, org.GetPublicId())
+		require.NoError(err)
+		assert.Equal("tenant", name)
+
+		// Without the tenant wrapper the root key can no longer be decrypted
+		_, err = kms.TestKms(t, conn, rootWrapper).GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.Error(err)
+
+		after, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+		pt, err := after.Decrypt(testCtx, blob)
+		require.NoError(err)
+		assert.Equal([]byte("secret"), pt)
+
+		// New root key versions are encrypted with the tenant wrapper too
+		require.NoError(kmsCache.RotateKeys(testCtx, org.GetPublicId()))
+		_, err = kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+		keys, err := kmsCache.ListKeys(testCtx, org.GetPublicId())
+		require.NoError(err)
+		assert.Equal("tenant", keys[0].WrapperName)
+
+		require.NoError(kmsCache.SetScopeRootWrapper(testCtx, org.GetPublicId(), ""))
+		name, err = kmsCache.ScopeRootWrapperName(testCtx, org.GetPublicId())
+		require.NoError(err)
+		assert.Empty(name)
+		_, err = kms.TestKms(t, conn, rootWrapper).GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+		require.NoError(err)
+	})
+}
+
+func TestKms_ListKeys(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+
+	t.Run("missing-scope-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.ListKeys(testCtx, "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unknown-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.ListKeys(testCtx, "o_1234567890")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(kmsCache.RotateKeys(testCtx, org.GetPublicId()))
+
+		keys, err := kmsCache.ListKeys(testCtx, org.GetPublicId())
+		require.NoError(err)
+		require.Len(keys, len(kms.ValidDekPurposes())+1)
+
+		root := keys[0]
+		assert.Equal(kms.KeyTypeKek, root.Type)
+		assert.Equal(kms.KeyPurposeRootKey, root.Purpose)
+		require.Len(root.Versions, 2)
+		assert.Equal(uint32(2), root.Versions[0].Version)
+		// each root key version encrypts one version of every data key
+		assert.Equal(int64(len(kms.ValidDekPurposes())), root.Versions[0].References)
+		assert.Equal(int64(len(kms.ValidDekPurposes())), root.Versions[1].References)
+
+		for _, k := range keys[1:] {
+			assert.Equal(kms.KeyTypeDek, k.Type)
+			assert.Equal(org.GetPublicId(), k.ScopeId)
+			assert.Len(k.Versions, 2)
+		}
+	})
+}
+
+func TestKms_LookupKeyVersionScopeId(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+
+	t.Run("missing-key-version-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.LookupKeyVersionScopeId(testCtx, "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unknown-key-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.LookupKeyVersionScopeId(testCtx, "kdkv_1234567890")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeOplog)
+		require.NoError(err)
+		keyId, err := w.KeyId(testCtx)
+		require.NoError(err)
+
+		scopeId, err := kmsCache.LookupKeyVersionScopeId(testCtx, keyId)
+		require.NoError(err)
+		assert.Equal(org.GetPublicId(), scopeId)
+	})
+}
+
+func TestKms_DestroyKeyVersion(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+
+	keyVersions := func(t *testing.T, purpose kms.KeyPurpose) []*kms.KeyVersion {
+		t.Helper()
+		keys, err := kmsCache.ListKeys(testCtx, org.GetPublicId())
+		require.NoError(t, err)
+		for _, k := range keys {
+			if k.Purpose == purpose {
+				return k.Versions
+			}
+		}
+		require.FailNow(t, "key not found", purpose.String())
+		return nil
+	}
+
+	before, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	blob, err := before.Encrypt(testCtx, []byte("secret"))
+	require.NoError(t, err)
+
+	t.Run("current-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for _, p := range []kms.KeyPurpose{kms.KeyPurposeRootKey, kms.KeyPurposeDatabase} {
+			_, err := kmsCache.DestroyKeyVersion(testCtx, org.GetPublicId(), keyVersions(t, p)[0].Id)
+			require.Error(err)
+			assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		}
+	})
+	t.Run("unknown-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := kmsCache.DestroyKeyVersion(testCtx, org.GetPublicId(), "kdkv_1234567890")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+
+	require.NoError(t, kmsCache.RotateKeys(testCtx, org.GetPublicId()))
+
+	t.Run("root-key-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		versions := keyVersions(t, kms.KeyPurposeRootKey)
+		require.Len(versions, 2)
+		destroyed, err := kmsCache.DestroyKeyVersion(testCtx, org.GetPublicId(), versions[1].Id)
+		require.NoError(err)
+		assert.True(destroyed)
+
+		versions = keyVersions(t, kms.KeyPurposeRootKey)
+		require.Len(versions, 1)
+		assert.Equal(int64(2*len(kms.ValidDekPurposes())), versions[0].References)
+
+		// data encrypted with the previous data key version must remain
+		// decryptable after its root key version is destroyed
+		keyId, err := before.KeyId(testCtx)
+		require.NoError(err)
+		w, err := kmsCache.GetWrapper(testCtx, org.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(keyId))
+		require.NoError(err)
+		pt, err := w.Decrypt(testCtx, blob)
+		require.NoError(err)
+		assert.Equal([]byte("secret"), pt)
+	})
+	t.Run("unreferenced-data-key-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		versions := keyVersions(t, kms.KeyPurposeDatabase)
+		require.Len(versions, 2)
+		destroyed, err := kmsCache.DestroyKeyVersion(testCtx, org.GetPublicId(), versions[1].Id)
+		require.NoError(err)
+		assert.True(destroyed)
+		assert.Len(keyVersions(t, kms.KeyPurposeDatabase), 1)
+
+		jobs, err := kmsCache.ListKeyVersionDestructionJobs(testCtx, org.GetPublicId())
+		require.NoError(err)
+		assert.Empty(jobs)
+	})
+	t.Run("unenumerable-data-key-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for _, p := range []kms.KeyPurpose{kms.KeyPurposeOplog, kms.KeyPurposeAudit} {
+			versions := keyVersions(t, p)
+			require.Len(versions, 2)
+			_, err := kmsCache.DestroyKeyVersion(testCtx, org.GetPublicId(), versions[1].Id)
+			require.Error(err)
+			assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+			assert.Len(keyVersions(t, p), 2)
+		}
+	})
+	t.Run("password-credential-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rw := db.New(conn)
+		pwOrg, _ := iam.TestScopes(t, iam.TestRepo(t, conn, rootWrapper))
+		am := password.TestAuthMethod(t, conn, pwOrg.GetPublicId())
+		pwRepo, err := password.NewRepository(rw, rw, kmsCache)
+		require.NoError(err)
+		acct, err := password.NewAccount(am.GetPublicId(), password.WithLoginName("kms-destroy"))
+		require.NoError(err)
+		_, err = pwRepo.CreateAccount(testCtx, pwOrg.GetPublicId(), acct, password.WithPassword("kms-destroy-password"))
+		require.NoError(err)
+		require.NoError(kmsCache.RotateKeys(testCtx, pwOrg.GetPublicId()))
+
+		keys, err := kmsCache.ListKeys(testCtx, pwOrg.GetPublicId())
+		require.NoError(err)
+		var versions []*kms.KeyVersion
+		for _, k := range keys {
+			if k.Purpose == kms.KeyPurposeDatabase {
+				versions = k.Versions
+			}
+		}
+		require.Len(versions, 2)
+		_, err = kmsCache.DestroyKeyVersion(testCtx, pwOrg.GetPublicId(), versions[1].Id)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		keys, err = kmsCache.ListKeys(testCtx, pwOrg.GetPublicId())
+		require.NoError(err)
+		for _, k := range keys {
+			if k.Purpose == kms.KeyPurposeDatabase {
+				assert.Len(k.Versions, 2)
+			}
+		}
+	})
+}
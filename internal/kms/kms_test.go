@@ -3,9 +3,11 @@ package kms
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +49,7 @@ func Test_New(t *testing.T) {
 			w:    rw,
 			want: &Kms{
 				reader: rw,
+				writer: rw,
 				underlying: func() *wrappingKms.Kms {
 					purposes := make([]wrappingKms.KeyPurpose, 0, len(ValidDekPurposes()))
 					for _, p := range ValidDekPurposes() {
@@ -136,6 +139,7 @@ func Test_NewUsingReaderWriter(t *testing.T) {
 			w:    rw,
 			want: &Kms{
 				reader: rw,
+				writer: rw,
 				underlying: func() *wrappingKms.Kms {
 					purposes := stdNewKmsPurposes()
 					r := dbw.New(rw.UnderlyingDB())
@@ -172,6 +176,37 @@ type invalidReader struct {
 	db.Reader
 }
 
+func TestKms_GetWrapper_rotatedElsewhere(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	rotating := TestKms(t, conn, wrapper)
+	require.NoError(rotating.CreateKeys(testCtx, scope.Global.String()))
+	// other is another controller's kms, which caches the wrappers it uses
+	other := TestKms(t, conn, wrapper)
+	other.versionCheckInterval = time.Hour
+
+	keyId := func(k *Kms) string {
+		t.Helper()
+		w, err := k.GetWrapper(testCtx, scope.Global.String(), KeyPurposeDatabase)
+		require.NoError(err)
+		id, err := w.KeyId(testCtx)
+		require.NoError(err)
+		return id
+	}
+	before := keyId(other)
+	require.NoError(rotating.RotateKeys(testCtx, scope.Global.String()))
+	rotated := keyId(rotating)
+	assert.NotEqual(before, rotated)
+
+	// the cached wrapper is used until the version is checked again
+	assert.Equal(before, keyId(other))
+	other.versionCheckInterval = 0
+	assert.Equal(rotated, keyId(other))
+}
+
 type invalidWriter struct {
 	db.Writer
 }
@@ -23,6 +23,7 @@ type Option func(*options)
 type options struct {
 	withLimit                    int
 	withRootWrapper              wrapping.Wrapper
+	withScopeRootWrappers        map[string]wrapping.Wrapper
 	withWorkerAuthWrapper        wrapping.Wrapper
 	withWorkerAuthStorageWrapper wrapping.Wrapper
 	withRecoveryWrapper          wrapping.Wrapper
@@ -54,6 +55,14 @@ func WithRootWrapper(w wrapping.Wrapper) Option {
 	}
 }
 
+// WithScopeRootWrappers sets the named external wrappers which can be used,
+// instead of the external root wrapper, to wrap the root keys of scopes
+func WithScopeRootWrappers(w map[string]wrapping.Wrapper) Option {
+	return func(o *options) {
+		o.withScopeRootWrappers = w
+	}
+}
+
 // WithWorkerAuthWrapper sets the external worker authentication wrapper for a
 // given scope
 func WithWorkerAuthWrapper(w wrapping.Wrapper) Option {
@@ -0,0 +1,101 @@
+package kms
+
+const (
+	// pendingRewrapQuery is formatted with the name of a table which has a
+	// key_id column referencing kms_data_key_version and returns the number of
+	// the table's rows encrypted by each data key version which is no longer
+	// the current version of its key.
+	pendingRewrapQuery = `
+   select t.key_id,
+          krk.scope_id,
+          count(*)
+     from %s as t
+     join kms_data_key_version as kdkv
+       on kdkv.private_id = t.key_id
+     join kms_data_key as kdk
+       on kdk.private_id = kdkv.data_key_id
+     join kms_root_key as krk
+       on krk.private_id = kdk.root_key_id
+    where kdkv.version < (
+          select max(cur.version)
+            from kms_data_key_version as cur
+           where cur.data_key_id = kdkv.data_key_id
+          )
+ group by t.key_id, krk.scope_id
+ order by t.key_id;
+`
+
+	// currentDataKeyVersionQuery returns the id of the current version of the
+	// scope's data key for a purpose.
+	currentDataKeyVersionQuery = `
+   select kdkv.private_id
+     from kms_data_key_version as kdkv
+     join kms_data_key as kdk
+       on kdk.private_id = kdkv.data_key_id
+     join kms_root_key as krk
+       on krk.private_id = kdk.root_key_id
+    where krk.scope_id = ?
+      and kdk.purpose = ?
+ order by kdkv.version desc
+    limit 1;
+`
+
+	// keyReferenceTablesQuery returns the tables, and their columns, which have
+	// a foreign key referencing kms_data_key_version.
+	keyReferenceTablesQuery = `
+   select cl.relname,
+          a.attname
+     from pg_constraint as c
+     join pg_class as cl
+       on cl.oid = c.conrelid
+     join pg_attribute as a
+       on a.attrelid = c.conrelid
+      and a.attnum = c.conkey[1]
+    where c.contype = 'f'
+      and c.confrelid = 'kms_data_key_version'::regclass
+ order by cl.relname;
+`
+
+	// scopeKeyReferencesQuery is formatted with the name of a table and of its
+	// column referencing kms_data_key_version and returns the number of the
+	// table's rows encrypted by each data key version of a scope.
+	scopeKeyReferencesQuery = `
+   select t.%[2]s,
+          count(*)
+     from %[1]s as t
+     join kms_data_key_version as kdkv
+       on kdkv.private_id = t.%[2]s
+     join kms_data_key as kdk
+       on kdk.private_id = kdkv.data_key_id
+     join kms_root_key as krk
+       on krk.private_id = kdk.root_key_id
+    where krk.scope_id = ?
+ group by t.%[2]s;
+`
+
+	// keyVersionReferencesQuery is formatted with the name of a table and of
+	// its column referencing kms_data_key_version and returns the number of
+	// the table's rows encrypted by a data key version.
+	keyVersionReferencesQuery = `
+   select count(*)
+     from %[1]s
+    where %[2]s = ?;
+`
+
+	// scopeKeyVersionDestructionJobsQuery returns the pending destructions of
+	// the data key versions of a scope.
+	scopeKeyVersionDestructionJobsQuery = `
+   select j.key_id,
+          j.total_count,
+          j.create_time
+     from kms_data_key_version_destruction_job as j
+     join kms_data_key_version as kdkv
+       on kdkv.private_id = j.key_id
+     join kms_data_key as kdk
+       on kdk.private_id = kdkv.data_key_id
+     join kms_root_key as krk
+       on krk.private_id = kdk.root_key_id
+    where krk.scope_id = ?
+ order by j.create_time;
+`
+)
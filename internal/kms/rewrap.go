@@ -0,0 +1,112 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// RewrapFn re-encrypts every row of a table that was encrypted with the data
+// key version dataKeyVersionId, using the scope's current key version.
+type RewrapFn func(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kms *Kms) error
+
+var (
+	tableRewrapFnsLock sync.RWMutex
+	tableRewrapFns     = map[string]RewrapFn{}
+)
+
+// RegisterTableRewrapFn registers the function used to re-encrypt rows of the
+// table after the scope's keys have been rotated. The table must have a key_id
+// column which references the data key version used to encrypt each row.  It's
+// intended to be called from the init() of the package which owns the table
+// and panics if the table already has a registered function.
+func RegisterTableRewrapFn(tableName string, fn RewrapFn) {
+	tableRewrapFnsLock.Lock()
+	defer tableRewrapFnsLock.Unlock()
+	if _, ok := tableRewrapFns[tableName]; ok {
+		panic(fmt.Sprintf("rewrap function already registered for table %q", tableName))
+	}
+	tableRewrapFns[tableName] = fn
+}
+
+// PendingRewrap describes the rows of a table which are still encrypted with a
+// data key version that's no longer the current version of its key.
+type PendingRewrap struct {
+	TableName        string
+	DataKeyVersionId string
+	ScopeId          string
+	Rows             int
+}
+
+// ListPendingRewraps returns the rows of the tables with registered rewrap
+// functions that are still encrypted with a data key version which has been
+// superseded by rotating the scope's keys.
+func (k *Kms) ListPendingRewraps(ctx context.Context, reader db.Reader) ([]*PendingRewrap, error) {
+	const op = "kms.(Kms).ListPendingRewraps"
+	if isNil(reader) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	tableRewrapFnsLock.RLock()
+	tableNames := make([]string, 0, len(tableRewrapFns))
+	for name := range tableRewrapFns {
+		tableNames = append(tableNames, name)
+	}
+	tableRewrapFnsLock.RUnlock()
+	sort.Strings(tableNames)
+
+	var pending []*PendingRewrap
+	for _, tableName := range tableNames {
+		rows, err := reader.Query(ctx, fmt.Sprintf(pendingRewrapQuery, tableName), nil)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to query pending rewraps for %s", tableName)))
+		}
+		for rows.Next() {
+			p := &PendingRewrap{
+				TableName: tableName,
+			}
+			if err := rows.Scan(&p.DataKeyVersionId, &p.ScopeId, &p.Rows); err != nil {
+				_ = rows.Close()
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to scan pending rewraps for %s", tableName)))
+			}
+			pending = append(pending, p)
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to read pending rewraps for %s", tableName)))
+		}
+		_ = rows.Close()
+	}
+	return pending, nil
+}
+
+// Rewrap re-encrypts the rows described by the pending rewrap with the
+// function registered for its table.
+func (k *Kms) Rewrap(ctx context.Context, p *PendingRewrap, reader db.Reader, writer db.Writer) error {
+	const op = "kms.(Kms).Rewrap"
+	switch {
+	case p == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing pending rewrap")
+	case p.DataKeyVersionId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing data key version id")
+	case p.ScopeId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case isNil(reader):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case isNil(writer):
+		return errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	tableRewrapFnsLock.RLock()
+	fn, ok := tableRewrapFns[p.TableName]
+	tableRewrapFnsLock.RUnlock()
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("no rewrap function registered for table %q", p.TableName))
+	}
+	if err := fn(ctx, p.DataKeyVersionId, p.ScopeId, reader, writer, k); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to rewrap %s", p.TableName)))
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/multi"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+)
+
+// scopeRootWrapper names the external wrapper which wraps the root key of a
+// scope.  Scopes without one have their root key wrapped by the external root
+// wrapper.
+type scopeRootWrapper struct {
+	ScopeId    string    `gorm:"primary_key"`
+	Name       string    `gorm:"default:null"`
+	CreateTime time.Time `gorm:"default:current_timestamp"`
+	UpdateTime time.Time `gorm:"default:current_timestamp"`
+}
+
+func (*scopeRootWrapper) TableName() string { return "kms_scope_root_wrapper" }
+
+// ScopeRootWrapperName returns the name of the external wrapper which wraps the
+// root key of the scope, or an empty string when it's wrapped by the external
+// root wrapper.
+func (k *Kms) ScopeRootWrapperName(ctx context.Context, scopeId string) (string, error) {
+	const op = "kms.(Kms).ScopeRootWrapperName"
+	if scopeId == "" {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	name, err := lookupScopeRootWrapperName(ctx, k.reader, scopeId)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return name, nil
+}
+
+// SetScopeRootWrapper moves the root key of the scope to the named external
+// wrapper, re-encrypting all of its versions with that wrapper.  An empty name
+// moves the root key back to the external root wrapper.  The scope's data keys
+// and the data they encrypt are unaffected.
+func (k *Kms) SetScopeRootWrapper(ctx context.Context, scopeId, name string) error {
+	const op = "kms.(Kms).SetScopeRootWrapper"
+	if scopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	target, err := k.namedExternalRootWrapper(ctx, name)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	rootWrapper, err := k.underlying.GetExternalRootWrapper()
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get external root wrapper"))
+	}
+
+	setFn := func(r db.Reader, w db.Writer) error {
+		rk, err := lookupRootKey(ctx, r, scopeId)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		var versions []*rootKeyVersion
+		if err := r.SearchWhere(ctx, &versions, "root_key_id = ?", []interface{}{rk.PrivateId}, db.WithLimit(-1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to search root key versions"))
+		}
+		for _, rkv := range versions {
+			if err := structwrapping.UnwrapStruct(ctx, rootWrapper, rkv, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt root key version"))
+			}
+			if err := structwrapping.WrapStruct(ctx, target, rkv, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to encrypt root key version"))
+			}
+			rowsUpdated, err := w.Update(ctx, rkv, []string{"CtKey"}, nil)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update root key version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated root key version and %d rows updated", rowsUpdated))
+			}
+		}
+
+		var current []*scopeRootWrapper
+		if err := r.SearchWhere(ctx, &current, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(1)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up scope root wrapper"))
+		}
+		switch {
+		case len(current) > 0 && name == "":
+			if _, err := w.Delete(ctx, current[0]); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete scope root wrapper"))
+			}
+		case len(current) > 0:
+			current[0].Name = name
+			if _, err := w.Update(ctx, current[0], []string{"Name"}, nil); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update scope root wrapper"))
+			}
+		case name != "":
+			if err := w.Create(ctx, &scopeRootWrapper{ScopeId: scopeId, Name: name}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create scope root wrapper"))
+			}
+		}
+		return nil
+	}
+	if _, err := k.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, setFn); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := k.ClearCache(ctx); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// newPooledRootWrapper returns the wrapper given to the underlying kms as its
+// external root wrapper.  It encrypts with the external root wrapper, and
+// decrypts with whichever of the external root wrapper and the scope root
+// wrappers encrypted the value, based on its key id.
+func newPooledRootWrapper(ctx context.Context, root wrapping.Wrapper, scopeRoots map[string]wrapping.Wrapper) (wrapping.Wrapper, error) {
+	const op = "kms.newPooledRootWrapper"
+	if isNil(root) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "scope root wrappers require a root wrapper")
+	}
+	if len(scopeRoots) == 0 {
+		return root, nil
+	}
+	pooled, err := multi.NewPooledWrapper(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create pooled root wrapper"))
+	}
+	names := make([]string, 0, len(scopeRoots))
+	for name := range scopeRoots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		added, err := pooled.AddWrapper(ctx, scopeRoots[name])
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to add scope root wrapper %q", name)))
+		}
+		if !added {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("scope root wrapper %q has the same key id as another root wrapper", name))
+		}
+	}
+	return pooled, nil
+}
+
+// namedExternalRootWrapper returns the scope root wrapper with the given name,
+// or the external root wrapper when the name is empty.
+func (k *Kms) namedExternalRootWrapper(ctx context.Context, name string) (wrapping.Wrapper, error) {
+	const op = "kms.(Kms).namedExternalRootWrapper"
+	if name == "" {
+		if isNil(k.externalRootWrapper) {
+			return nil, errors.New(ctx, errors.KeyNotFound, op, "missing external root wrapper")
+		}
+		return k.externalRootWrapper, nil
+	}
+	w, ok := k.externalScopeRootWrappers[name]
+	if !ok {
+		return nil, errors.New(ctx, errors.KeyNotFound, op, fmt.Sprintf("no scope root wrapper named %q is configured", name))
+	}
+	return w, nil
+}
+
+// scopeExternalRootWrapper returns the external wrapper used to encrypt new
+// versions of the root key of the scope.
+func (k *Kms) scopeExternalRootWrapper(ctx context.Context, reader db.Reader, scopeId string) (wrapping.Wrapper, error) {
+	const op = "kms.(Kms).scopeExternalRootWrapper"
+	name, err := lookupScopeRootWrapperName(ctx, reader, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	w, err := k.namedExternalRootWrapper(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return w, nil
+}
+
+func lookupScopeRootWrapperName(ctx context.Context, reader db.Reader, scopeId string) (string, error) {
+	const op = "kms.lookupScopeRootWrapperName"
+	var wrappers []*scopeRootWrapper
+	if err := reader.SearchWhere(ctx, &wrappers, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(1)); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up scope root wrapper"))
+	}
+	if len(wrappers) == 0 {
+		return "", nil
+	}
+	return wrappers[0].Name, nil
+}
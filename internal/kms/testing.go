@@ -33,7 +33,3 @@ func TestKmsDeleteKeyPurpose(t testing.TB, conn *db.DB, purpose KeyPurpose) {
 func TestKmsDeleteAllKeys(t testing.TB, conn *db.DB) {
 	db.TestDeleteWhere(t, conn, func() interface{} { i := rootKey{}; return &i }(), "1=1")
 }
-
-type dataKey struct{}
-
-func (*dataKey) TableName() string { return "kms_data_key" }
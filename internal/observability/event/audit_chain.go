@@ -0,0 +1,351 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+)
+
+const (
+	// DefaultAuditChainCheckpointInterval is the default interval between
+	// signed checkpoints of an audit event chain.
+	DefaultAuditChainCheckpointInterval = time.Minute
+
+	auditChainField           = "boundary_chain"
+	auditChainCheckpointField = "boundary_chain_checkpoint"
+	auditChainInfo            = "boundary-audit-chain"
+)
+
+var (
+	auditChainMarker           = []byte(`"` + auditChainField + `":`)
+	auditChainCheckpointPrefix = []byte(`{"` + auditChainCheckpointField + `":`)
+)
+
+// auditChainLink is appended to every chained audit event.  Its hmac covers
+// the link's other fields and the event as it was formatted, so the previous
+// event's hmac (prev) ties each event to the one before it.  The first event
+// of a chain which continues the chain a sink wrote before it was restarted
+// is tied to the head of that chain, which is identified by prev_chain_id and
+// prev_seq.
+type auditChainLink struct {
+	ChainId     string `json:"chain_id"`
+	Seq         uint64 `json:"seq"`
+	KeyId       string `json:"key_id"`
+	Prev        string `json:"prev"`
+	PrevChainId string `json:"prev_chain_id,omitempty"`
+	PrevSeq     uint64 `json:"prev_seq,omitempty"`
+	Hmac        string `json:"hmac,omitempty"`
+}
+
+// auditChainCheckpoint is a signed record of the head of an audit event chain.
+type auditChainCheckpoint struct {
+	ChainId   string    `json:"chain_id"`
+	Seq       uint64    `json:"seq"`
+	KeyId     string    `json:"key_id"`
+	Head      string    `json:"head"`
+	CreatedAt time.Time `json:"created_at"`
+	Hmac      string    `json:"hmac,omitempty"`
+}
+
+// auditChainFilter is an eventlogger.Node which chains the formatted audit
+// events of a sink.  Every event is given a sequence number and the hmac of
+// the event before it, and a signed checkpoint of the chain is written along
+// with the first event and then with the first event after each checkpoint
+// interval.  The hmacs are keyed by the audit wrapper, so events can't be
+// processed until a wrapper is available.
+type auditChainFilter struct {
+	format             SinkFormat
+	checkpointInterval time.Duration
+	now                func() time.Time
+
+	// prevChainId and prevSeq identify the head of the chain the sink wrote
+	// before the filter was created, which the first event is linked to
+	prevChainId string
+	prevSeq     uint64
+
+	l              sync.Mutex
+	wrapper        wrapping.Wrapper
+	keyId          string
+	chainId        string
+	seq            uint64
+	prev           string
+	lastCheckpoint time.Time
+}
+
+var _ eventlogger.Node = &auditChainFilter{}
+
+// newAuditChainFilter creates an auditChainFilter for a sink with the given
+// format, which must be a json format.  A zero checkpoint interval disables
+// periodic checkpoints.  The head is the last link of the chain written by
+// the sink before it was restarted, if known, which the new chain continues.
+func newAuditChainFilter(format SinkFormat, checkpointInterval time.Duration, w wrapping.Wrapper, head *auditChainLink) (*auditChainFilter, error) {
+	const op = "event.newAuditChainFilter"
+	switch format {
+	case JSONSinkFormat, JSONHclogSinkFormat:
+	default:
+		return nil, fmt.Errorf("%s: audit event chaining is not supported for the %q format: %w", op, format, ErrInvalidParameter)
+	}
+	if checkpointInterval < 0 {
+		return nil, fmt.Errorf("%s: checkpoint interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	chainId, err := NewId("chain")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	f := &auditChainFilter{
+		format:             format,
+		checkpointInterval: checkpointInterval,
+		now:                time.Now,
+		wrapper:            w,
+		chainId:            chainId,
+	}
+	if head != nil {
+		f.prevChainId = head.ChainId
+		f.prevSeq = head.Seq
+		f.prev = head.Hmac
+	}
+	return f, nil
+}
+
+// Type describes the type of the node as a FormatterFilter, since it replaces
+// the formatted data of the events it processes.
+func (f *auditChainFilter) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeFormatterFilter
+}
+
+// Reopen does nothing for this type of Node.
+func (f *auditChainFilter) Reopen() error { return nil }
+
+// Rotate the wrapper used to key the chain.  The chain continues with the new
+// wrapper and a checkpoint is written with the next event.
+func (f *auditChainFilter) Rotate(w wrapping.Wrapper) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.wrapper = w
+	f.keyId = ""
+	f.lastCheckpoint = time.Time{}
+}
+
+// Process returns a new event whose formatted data is chained to the
+// previously processed event.
+func (f *auditChainFilter) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(auditChainFilter).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(string(f.format))
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled", op)
+	}
+
+	f.l.Lock()
+	defer f.l.Unlock()
+	if f.wrapper == nil {
+		return nil, fmt.Errorf("%s: audit hash chaining requires an audit wrapper: %w", op, ErrInvalidParameter)
+	}
+	if f.keyId == "" {
+		keyId, err := f.wrapper.KeyId(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to get wrapper key id: %w", op, err)
+		}
+		f.keyId = keyId
+	}
+
+	body, err := auditChainBody(val)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	link := auditChainLink{
+		ChainId: f.chainId,
+		Seq:     f.seq + 1,
+		KeyId:   f.keyId,
+		Prev:    f.prev,
+	}
+	if link.Seq == 1 {
+		link.PrevChainId = f.prevChainId
+		link.PrevSeq = f.prevSeq
+	}
+	link.Hmac, err = signAuditChainLink(ctx, f.wrapper, link, body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	line, err := appendAuditChainLink(body, link)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	line = append(line, '\n')
+
+	now := f.now()
+	if f.lastCheckpoint.IsZero() || (f.checkpointInterval > 0 && now.Sub(f.lastCheckpoint) >= f.checkpointInterval) {
+		cp := auditChainCheckpoint{
+			ChainId:   f.chainId,
+			Seq:       link.Seq,
+			KeyId:     f.keyId,
+			Head:      link.Hmac,
+			CreatedAt: now.UTC(),
+		}
+		cp.Hmac, err = signAuditChainCheckpoint(ctx, f.wrapper, cp)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		cpLine, err := json.Marshal(map[string]auditChainCheckpoint{auditChainCheckpointField: cp})
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to marshal checkpoint: %w", op, err)
+		}
+		line = append(line, cpLine...)
+		line = append(line, '\n')
+		f.lastCheckpoint = now
+	}
+	f.seq = link.Seq
+	f.prev = link.Hmac
+
+	// the event is shared with the pipelines of other sinks, so the chained
+	// data is set on a new event.
+	chained := &eventlogger.Event{
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Payload:   e.Payload,
+	}
+	chained.FormattedAs(string(f.format), line)
+	return chained, nil
+}
+
+// auditChainBody returns a copy of the formatted json object without any
+// whitespace before its closing brace, which is the form of the event that is
+// signed.
+func auditChainBody(val []byte) ([]byte, error) {
+	const op = "event.auditChainBody"
+	val = bytes.TrimRight(val, " \t\r\n")
+	if len(val) == 0 || val[0] != '{' || val[len(val)-1] != '}' {
+		return nil, fmt.Errorf("%s: event is not formatted as a json object: %w", op, ErrInvalidParameter)
+	}
+	obj := bytes.TrimRight(val[:len(val)-1], " \t\r\n")
+	body := make([]byte, 0, len(obj)+1)
+	body = append(body, obj...)
+	return append(body, '}'), nil
+}
+
+// appendAuditChainLink appends the link as the last field of the json object
+// in body.
+func appendAuditChainLink(body []byte, link auditChainLink) ([]byte, error) {
+	const op = "event.appendAuditChainLink"
+	linkJson, err := json.Marshal(link)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to marshal chain link: %w", op, err)
+	}
+	obj := body[:len(body)-1]
+	line := make([]byte, 0, len(obj)+len(auditChainMarker)+len(linkJson)+2)
+	line = append(line, obj...)
+	if len(obj) > 0 && obj[len(obj)-1] != '{' {
+		line = append(line, ',')
+	}
+	line = append(line, auditChainMarker...)
+	line = append(line, linkJson...)
+	line = append(line, '}')
+	return line, nil
+}
+
+// lastAuditChainLink returns the link of the last chained event in the first
+// of the files which has one, or nil when none of them do.  Files which don't
+// exist are skipped, and files with a ".gz" extension are decompressed.
+func lastAuditChainLink(files ...string) (*auditChainLink, error) {
+	const op = "event.lastAuditChainLink"
+	for _, name := range files {
+		link, err := readLastAuditChainLink(name)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", op, err)
+		case link != nil:
+			return link, nil
+		}
+	}
+	return nil, nil
+}
+
+func readLastAuditChainLink(name string) (*auditChainLink, error) {
+	const op = "event.readLastAuditChainLink"
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, compressedFileExt) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to read %s: %w", op, name, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	var last *auditChainLink
+	br := bufio.NewReader(r)
+	for {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, fmt.Errorf("%s: unable to read %s: %w", op, name, readErr)
+		}
+		line = bytes.TrimSpace(line)
+		if idx := bytes.LastIndex(line, auditChainMarker); idx >= 0 && line[len(line)-1] == '}' {
+			var link auditChainLink
+			// a malformed link can't be continued, so it's skipped
+			if err := json.Unmarshal(line[idx+len(auditChainMarker):len(line)-1], &link); err == nil && link.Hmac != "" {
+				last = &link
+			}
+		}
+		if readErr != nil {
+			return last, nil
+		}
+	}
+}
+
+func signAuditChainLink(ctx context.Context, w wrapping.Wrapper, link auditChainLink, body []byte) (string, error) {
+	const op = "event.signAuditChainLink"
+	link.Hmac = ""
+	linkJson, err := json.Marshal(link)
+	if err != nil {
+		return "", fmt.Errorf("%s: unable to marshal chain link: %w", op, err)
+	}
+	data := make([]byte, 0, len(linkJson)+len(body)+1)
+	data = append(data, linkJson...)
+	data = append(data, '\n')
+	data = append(data, body...)
+	return signAuditChainData(ctx, w, data)
+}
+
+func signAuditChainCheckpoint(ctx context.Context, w wrapping.Wrapper, cp auditChainCheckpoint) (string, error) {
+	const op = "event.signAuditChainCheckpoint"
+	cp.Hmac = ""
+	cpJson, err := json.Marshal(cp)
+	if err != nil {
+		return "", fmt.Errorf("%s: unable to marshal checkpoint: %w", op, err)
+	}
+	return signAuditChainData(ctx, w, append([]byte(auditChainCheckpointField+"\n"), cpJson...))
+}
+
+func signAuditChainData(ctx context.Context, w wrapping.Wrapper, data []byte) (string, error) {
+	const op = "event.signAuditChainData"
+	s, err := newSigner(ctx, w, nil, []byte(auditChainInfo))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	mac, err := s(ctx, data)
+	if err != nil {
+		return "", fmt.Errorf("%s: unable to hmac chain data: %w", op, err)
+	}
+	return mac, nil
+}
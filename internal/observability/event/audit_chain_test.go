@@ -0,0 +1,629 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditChainFilter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		format          SinkFormat
+		interval        time.Duration
+		wantErrContains string
+	}{
+		{
+			name:            "text-format",
+			format:          TextSinkFormat,
+			wantErrContains: `audit event chaining is not supported for the "cloudevents-text" format`,
+		},
+		{
+			name:            "negative-interval",
+			format:          JSONSinkFormat,
+			interval:        -time.Second,
+			wantErrContains: "checkpoint interval must not be negative",
+		},
+		{
+			name:   "valid-cloudevents",
+			format: JSONSinkFormat,
+		},
+		{
+			name:     "valid-hclog",
+			format:   JSONHclogSinkFormat,
+			interval: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newAuditChainFilter(tt.format, tt.interval, nil, nil)
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.format, got.format)
+			assert.Equal(tt.interval, got.checkpointInterval)
+			assert.True(strings.HasPrefix(got.chainId, "chain_"))
+			assert.Equal(eventlogger.NodeTypeFormatterFilter, got.Type())
+		})
+	}
+}
+
+func TestAuditChainFilter_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("no-wrapper", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Minute, nil, nil)
+		require.NoError(err)
+		got, err := f.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.Error(err)
+		assert.Nil(got)
+		assert.ErrorIs(err, ErrInvalidParameter)
+		assert.Contains(err.Error(), "requires an audit wrapper")
+	})
+
+	t.Run("not-json", func(t *testing.T) {
+		require := require.New(t)
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Minute, testWrapper(t), nil)
+		require.NoError(err)
+		_, err = f.Process(ctx, testHttpEvent(t, "not json"))
+		require.Error(err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("missing-event", func(t *testing.T) {
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Minute, testWrapper(t), nil)
+		require.NoError(t, err)
+		_, err = f.Process(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+
+	t.Run("chained", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := testWrapper(t)
+		keyId, err := w.KeyId(ctx)
+		require.NoError(err)
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Minute, w, nil)
+		require.NoError(err)
+		now := time.Now()
+		f.now = func() time.Time { return now }
+
+		e := testHttpEvent(t, `{"id":"1"}`)
+		got, err := f.Process(ctx, e)
+		require.NoError(err)
+		// the original event is not modified
+		orig, _ := e.Format(string(JSONSinkFormat))
+		assert.Equal("{\"id\":\"1\"}\n", string(orig))
+
+		// the first event is written with a checkpoint
+		lines := testAuditChainLines(t, got)
+		require.Len(lines, 2)
+		first := testAuditChainLink(t, lines[0])
+		assert.Equal(auditChainLink{ChainId: f.chainId, Seq: 1, KeyId: keyId, Hmac: first.Hmac}, first)
+		assert.True(strings.HasPrefix(first.Hmac, "hmac-sha256:"))
+		assert.True(strings.HasPrefix(lines[0], `{"id":"1","boundary_chain":`))
+		cp := testAuditChainCheckpoint(t, lines[1])
+		assert.Equal(uint64(1), cp.Seq)
+		assert.Equal(first.Hmac, cp.Head)
+
+		got, err = f.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		lines = testAuditChainLines(t, got)
+		require.Len(lines, 1)
+		second := testAuditChainLink(t, lines[0])
+		assert.Equal(uint64(2), second.Seq)
+		assert.Equal(first.Hmac, second.Prev)
+
+		// a checkpoint is written once the interval has passed
+		now = now.Add(time.Minute)
+		got, err = f.Process(ctx, testHttpEvent(t, `{}`))
+		require.NoError(err)
+		lines = testAuditChainLines(t, got)
+		require.Len(lines, 2)
+		third := testAuditChainLink(t, lines[0])
+		assert.True(strings.HasPrefix(lines[0], `{"boundary_chain":`))
+		assert.Equal(second.Hmac, third.Prev)
+		assert.Equal(third.Hmac, testAuditChainCheckpoint(t, lines[1]).Head)
+	})
+
+	t.Run("continue-previous-chain", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := testWrapper(t)
+		head := &auditChainLink{ChainId: "chain_prev", Seq: 7, Hmac: "hmac-sha256:head"}
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Minute, w, head)
+		require.NoError(err)
+
+		// only the first event is linked to the previous chain's head
+		got, err := f.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		first := testAuditChainLink(t, testAuditChainLines(t, got)[0])
+		assert.Equal(uint64(1), first.Seq)
+		assert.Equal(head.Hmac, first.Prev)
+		assert.Equal(head.ChainId, first.PrevChainId)
+		assert.Equal(head.Seq, first.PrevSeq)
+
+		got, err = f.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		second := testAuditChainLink(t, testAuditChainLines(t, got)[0])
+		assert.Equal(first.Hmac, second.Prev)
+		assert.Empty(second.PrevChainId)
+		assert.Zero(second.PrevSeq)
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f, err := newAuditChainFilter(JSONSinkFormat, time.Hour, testWrapper(t), nil)
+		require.NoError(err)
+		got, err := f.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		first := testAuditChainLink(t, testAuditChainLines(t, got)[0])
+
+		w := testWrapper(t)
+		keyId, err := w.KeyId(ctx)
+		require.NoError(err)
+		f.Rotate(w)
+		got, err = f.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		lines := testAuditChainLines(t, got)
+		require.Len(lines, 2)
+		second := testAuditChainLink(t, lines[0])
+		assert.Equal(keyId, second.KeyId)
+		assert.Equal(uint64(2), second.Seq)
+		assert.Equal(first.Hmac, second.Prev)
+		assert.Equal(keyId, testAuditChainCheckpoint(t, lines[1]).KeyId)
+	})
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	w := testWrapper(t)
+	rotated := testWrapper(t)
+	keyFn := testAuditChainKeyFunc(t, w, rotated)
+
+	// chain ten events, with checkpoints at seq 1 and 8, and rotate the key
+	// before seq 6
+	f, err := newAuditChainFilter(JSONSinkFormat, time.Hour, w, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	f.now = func() time.Time { return now }
+	var lines []string
+	for i := 1; i <= 10; i++ {
+		switch i {
+		case 6:
+			f.Rotate(rotated)
+			f.lastCheckpoint = now
+		case 8:
+			now = now.Add(time.Hour)
+		}
+		got, err := f.Process(ctx, testHttpEvent(t, fmt.Sprintf(`{"id":"%d"}`, i)))
+		require.NoError(t, err)
+		lines = append(lines, testAuditChainLines(t, got)...)
+	}
+	// lines: 0 seq 1, 1 checkpoint 1, 2-8 seq 2-8, 9 checkpoint 8, 10-11 seq 9-10
+	require.Len(t, lines, 12)
+	chainId := f.chainId
+
+	without := func(idx ...int) []string {
+		var l []string
+	next:
+		for i := range lines {
+			for _, skip := range idx {
+				if i == skip {
+					continue next
+				}
+			}
+			l = append(l, lines[i])
+		}
+		return l
+	}
+	replace := func(idx int, line string) []string {
+		l := append([]string(nil), lines...)
+		l[idx] = line
+		return l
+	}
+
+	tests := []struct {
+		name          string
+		files         [][]string
+		keyFn         AuditChainKeyFunc
+		wantErrIs     error
+		wantChains    []*AuditChainSummary
+		wantUnchained int
+		wantProblems  []AuditChainProblemType
+	}{
+		{
+			name:      "missing-key-func",
+			files:     [][]string{lines},
+			wantErrIs: ErrInvalidParameter,
+		},
+		{
+			name:      "missing-files",
+			keyFn:     keyFn,
+			wantErrIs: ErrInvalidParameter,
+		},
+		{
+			name:  "unknown-key",
+			files: [][]string{lines},
+			keyFn: testAuditChainKeyFunc(t, w),
+		},
+		{
+			name:       "valid",
+			files:      [][]string{lines},
+			keyFn:      keyFn,
+			wantChains: []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+		},
+		{
+			name:          "unchained-events",
+			files:         [][]string{append([]string{`{"type":"audit"}`}, lines...)},
+			keyFn:         keyFn,
+			wantChains:    []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantUnchained: 1,
+			wantProblems:  []AuditChainProblemType{AuditChainUnchained},
+		},
+		{
+			name:          "valid-unchained-file",
+			files:         [][]string{{`{"type":"audit"}`}, lines},
+			keyFn:         keyFn,
+			wantChains:    []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantUnchained: 1,
+		},
+		{
+			name:       "valid-out-of-order",
+			files:      [][]string{replace(3, lines[4])[:4], append([]string{lines[3]}, lines[5:]...)},
+			keyFn:      keyFn,
+			wantChains: []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+		},
+		{
+			name:       "valid-rotated-files",
+			files:      [][]string{lines[:5], lines[5:]},
+			keyFn:      keyFn,
+			wantChains: []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+		},
+		{
+			name:       "valid-later-rotated-file",
+			files:      [][]string{lines[5:]},
+			keyFn:      keyFn,
+			wantChains: []*AuditChainSummary{{ChainId: chainId, FirstSeq: 5, LastSeq: 10, Events: 6, Checkpoints: 1}},
+		},
+		{
+			name:         "modified",
+			files:        [][]string{replace(3, strings.Replace(lines[3], `"id":"3"`, `"id":"x"`, 1))},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainModified},
+		},
+		{
+			name:         "modified-checkpoint",
+			files:        [][]string{replace(9, strings.Replace(lines[9], `"seq":8`, `"seq":9`, 1))},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainModified},
+		},
+		{
+			name:         "malformed",
+			files:        [][]string{replace(3, strings.Replace(lines[3], `"seq":3`, `"seq":"3"`, 1))},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 9, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainMalformed, AuditChainGap},
+		},
+		{
+			name:         "deleted",
+			files:        [][]string{without(3, 4)},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 8, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainGap},
+		},
+		{
+			name:         "duplicate",
+			files:        [][]string{append(append([]string(nil), lines...), lines[3])},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainDuplicate},
+		},
+		{
+			name:         "truncated",
+			files:        [][]string{without(8, 10, 11)},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 7, Events: 7, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainTruncated},
+		},
+		{
+			name:         "relinked",
+			files:        [][]string{replace(3, testAuditChainRelink(t, w, lines[3]))},
+			keyFn:        keyFn,
+			wantChains:   []*AuditChainSummary{{ChainId: chainId, FirstSeq: 1, LastSeq: 10, Events: 10, Checkpoints: 2}},
+			wantProblems: []AuditChainProblemType{AuditChainBrokenLink, AuditChainBrokenLink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			files := testAuditChainFiles(t, tt.files...)
+			got, err := VerifyAuditChain(ctx, tt.keyFn, files...)
+			switch {
+			case tt.wantErrIs != nil:
+				require.Error(err)
+				assert.ErrorIs(err, tt.wantErrIs)
+				return
+			case tt.wantChains == nil:
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantChains, got.Chains)
+			assert.Equal(tt.wantUnchained, got.Unchained)
+			var gotProblems []AuditChainProblemType
+			for _, p := range got.Problems {
+				gotProblems = append(gotProblems, p.Type)
+				assert.NotEmpty(p.String())
+			}
+			assert.Equal(tt.wantProblems, gotProblems)
+			assert.Equal(len(tt.wantProblems) == 0, got.Valid())
+		})
+	}
+}
+
+func TestVerifyAuditChain_continued(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	w := testWrapper(t)
+	keyFn := testAuditChainKeyFunc(t, w)
+
+	// chainLines returns the lines of a chain of n events which continues the
+	// chain whose last link is head
+	chainLines := func(t *testing.T, head *auditChainLink, n int) ([]string, *auditChainLink) {
+		t.Helper()
+		f, err := newAuditChainFilter(JSONSinkFormat, 0, w, head)
+		require.NoError(t, err)
+		var lines []string
+		var last auditChainLink
+		for i := 1; i <= n; i++ {
+			got, err := f.Process(ctx, testHttpEvent(t, fmt.Sprintf(`{"id":"%d"}`, i)))
+			require.NoError(t, err)
+			l := testAuditChainLines(t, got)
+			last = testAuditChainLink(t, l[0])
+			lines = append(lines, l...)
+		}
+		return lines, &last
+	}
+	first, firstHead := chainLines(t, nil, 3)
+	second, _ := chainLines(t, firstHead, 2)
+	unlinked, _ := chainLines(t, nil, 2)
+	// the first chain's checkpoint follows its first event, so its second
+	// event is on the third line
+	forked, _ := chainLines(t, &auditChainLink{ChainId: firstHead.ChainId, Seq: 2, Hmac: testAuditChainLink(t, first[2]).Hmac}, 2)
+
+	tests := []struct {
+		name         string
+		files        [][]string
+		wantProblems []AuditChainProblemType
+	}{
+		{
+			name:  "valid",
+			files: [][]string{first, second},
+		},
+		{
+			name:  "valid-previous-chain-not-included",
+			files: [][]string{second},
+		},
+		{
+			name:         "previous-chain-truncated",
+			files:        [][]string{first[:len(first)-1], second},
+			wantProblems: []AuditChainProblemType{AuditChainTruncated},
+		},
+		{
+			name:         "previous-chain-forked",
+			files:        [][]string{first, forked},
+			wantProblems: []AuditChainProblemType{AuditChainBrokenLink},
+		},
+		{
+			name:         "unlinked-chain",
+			files:        [][]string{first, unlinked},
+			wantProblems: []AuditChainProblemType{AuditChainBrokenLink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := VerifyAuditChain(ctx, keyFn, testAuditChainFiles(t, tt.files...)...)
+			require.NoError(err)
+			var gotProblems []AuditChainProblemType
+			for _, p := range got.Problems {
+				gotProblems = append(gotProblems, p.Type)
+			}
+			assert.Equal(tt.wantProblems, gotProblems)
+		})
+	}
+}
+
+func Test_lastAuditChainLink(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	f, err := newAuditChainFilter(JSONSinkFormat, time.Hour, testWrapper(t), nil)
+	require.NoError(err)
+	var lines []string
+	for i := 1; i <= 2; i++ {
+		got, err := f.Process(ctx, testHttpEvent(t, fmt.Sprintf(`{"id":"%d"}`, i)))
+		require.NoError(err)
+		lines = append(lines, testAuditChainLines(t, got)...)
+	}
+	files := testAuditChainFiles(t, []string{`{"id":"unchained"}`}, lines)
+	// compressed rotated files are read too
+	require.NoError(compressFile(files[1]))
+	compressed := files[1] + compressedFileExt
+
+	missing := filepath.Join(t.TempDir(), "missing.log")
+	got, err := lastAuditChainLink(missing, files[0], compressed)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(testAuditChainLink(t, lines[2]), *got)
+
+	got, err = lastAuditChainLink(missing, files[0])
+	require.NoError(err)
+	assert.Nil(got)
+}
+
+func testAuditChainFiles(t *testing.T, lines ...[]string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	var files []string
+	for i, l := range lines {
+		name := filepath.Join(dir, fmt.Sprintf("audit-%d.log", i))
+		require.NoError(t, os.WriteFile(name, []byte(strings.Join(l, "\n")+"\n"), 0o600))
+		files = append(files, name)
+	}
+	return files
+}
+
+func TestEventer_auditHashChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for _, format := range []SinkFormat{JSONSinkFormat, JSONHclogSinkFormat} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			require := require.New(t)
+			w := testWrapper(t)
+			dir := t.TempDir()
+			c := EventerConfig{
+				AuditEnabled: true,
+				Sinks: []*SinkConfig{
+					{
+						Name:       "audit-file-sink",
+						Type:       FileSink,
+						EventTypes: []Type{AuditType},
+						Format:     format,
+						FileConfig: &FileSinkTypeConfig{
+							Path:     dir,
+							FileName: "audit.log",
+						},
+						AuditConfig: &AuditConfig{HashChain: true},
+					},
+				},
+			}
+			testLock := &sync.Mutex{}
+			testLogger := hclog.New(&hclog.LoggerOptions{Mutex: testLock, Name: "test"})
+			eventer, err := NewEventer(testLogger, testLock, "TestEventer_auditHashChain", c, WithAuditWrapper(w))
+			require.NoError(err)
+
+			for i := 0; i < 3; i++ {
+				a, err := newAudit(
+					"TestEventer_auditHashChain",
+					WithRequestInfo(TestRequestInfo(t)),
+					WithAuth(testAuth(t)),
+					WithRequest(testRequest(t)),
+					WithResponse(testResponse(t)),
+					WithFlush())
+				require.NoError(err)
+				require.NoError(eventer.writeAudit(ctx, a))
+			}
+
+			report, err := VerifyAuditChain(ctx, testAuditChainKeyFunc(t, w), filepath.Join(dir, "audit.log"))
+			require.NoError(err)
+			require.Len(report.Chains, 1)
+			assert.Equal(t, 3, report.Chains[0].Events)
+			assert.Equal(t, 1, report.Chains[0].Checkpoints)
+			assert.Zero(t, report.Unchained)
+			assert.True(t, report.Valid(), report.Problems)
+
+			// a restarted eventer continues the chain written to the file
+			eventer, err = NewEventer(testLogger, testLock, "TestEventer_auditHashChain", c, WithAuditWrapper(w))
+			require.NoError(err)
+			a, err := newAudit(
+				"TestEventer_auditHashChain",
+				WithRequestInfo(TestRequestInfo(t)),
+				WithAuth(testAuth(t)),
+				WithRequest(testRequest(t)),
+				WithResponse(testResponse(t)),
+				WithFlush())
+			require.NoError(err)
+			require.NoError(eventer.writeAudit(ctx, a))
+			report, err = VerifyAuditChain(ctx, testAuditChainKeyFunc(t, w), filepath.Join(dir, "audit.log"))
+			require.NoError(err)
+			require.Len(report.Chains, 2)
+			assert.Equal(t, 1, report.Chains[1].Events)
+			assert.True(t, report.Valid(), report.Problems)
+		})
+	}
+}
+
+func testAuditChainLines(t *testing.T, e *eventlogger.Event) []string {
+	t.Helper()
+	val, ok := e.Format(string(JSONSinkFormat))
+	require.True(t, ok)
+	require.True(t, strings.HasSuffix(string(val), "\n"))
+	return strings.Split(strings.TrimSuffix(string(val), "\n"), "\n")
+}
+
+func testAuditChainLink(t *testing.T, line string) auditChainLink {
+	t.Helper()
+	var got struct {
+		Link auditChainLink `json:"boundary_chain"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(line), &got))
+	return got.Link
+}
+
+func testAuditChainCheckpoint(t *testing.T, line string) auditChainCheckpoint {
+	t.Helper()
+	var got map[string]auditChainCheckpoint
+	require.NoError(t, json.Unmarshal([]byte(line), &got))
+	cp, ok := got[auditChainCheckpointField]
+	require.True(t, ok)
+	return cp
+}
+
+// testAuditChainRelink re-signs a chained event with a different previous
+// hmac, as if it had been moved to another place in the chain by someone with
+// the key.
+func testAuditChainRelink(t *testing.T, w wrapping.Wrapper, line string) string {
+	t.Helper()
+	ctx := context.Background()
+	link := testAuditChainLink(t, line)
+	link.Prev = "hmac-sha256:moved"
+	body := line[:strings.LastIndex(line, `,"boundary_chain":`)] + "}"
+	var err error
+	link.Hmac, err = signAuditChainLink(ctx, w, link, []byte(body))
+	require.NoError(t, err)
+	relinked, err := appendAuditChainLink([]byte(body), link)
+	require.NoError(t, err)
+	return string(relinked)
+}
+
+func testAuditChainKeyFunc(t *testing.T, w ...wrapping.Wrapper) AuditChainKeyFunc {
+	t.Helper()
+	keys := map[string]wrapping.Wrapper{}
+	for _, k := range w {
+		keyId, err := k.KeyId(context.Background())
+		require.NoError(t, err)
+		keys[keyId] = k
+	}
+	return func(_ context.Context, keyId string) (wrapping.Wrapper, error) {
+		k, ok := keys[keyId]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", keyId)
+		}
+		return k, nil
+	}
+}
@@ -0,0 +1,357 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+)
+
+// AuditChainKeyFunc returns the audit wrapper for the given key id, which is
+// used to verify the hmacs of chained audit events.
+type AuditChainKeyFunc func(ctx context.Context, keyId string) (wrapping.Wrapper, error)
+
+// AuditChainProblemType defines the type of problem found when verifying
+// chained audit events.
+type AuditChainProblemType string
+
+const (
+	AuditChainMalformed  AuditChainProblemType = "malformed"   // AuditChainMalformed means chain data could not be parsed
+	AuditChainModified   AuditChainProblemType = "modified"    // AuditChainModified means an event or checkpoint doesn't match its hmac
+	AuditChainGap        AuditChainProblemType = "gap"         // AuditChainGap means events are missing from the chain
+	AuditChainDuplicate  AuditChainProblemType = "duplicate"   // AuditChainDuplicate means an event appears more than once
+	AuditChainBrokenLink AuditChainProblemType = "broken-link" // AuditChainBrokenLink means an event isn't linked to the event before it
+	AuditChainTruncated  AuditChainProblemType = "truncated"   // AuditChainTruncated means events checkpointed by the chain are missing
+	AuditChainUnchained  AuditChainProblemType = "unchained"   // AuditChainUnchained means a line of a file of chained events isn't part of a chain
+)
+
+// AuditChainProblem describes a problem found when verifying chained audit
+// events.
+type AuditChainProblem struct {
+	Type    AuditChainProblemType `json:"type"`
+	File    string                `json:"file,omitempty"`
+	Line    int                   `json:"line,omitempty"`
+	ChainId string                `json:"chain_id,omitempty"`
+	Seq     uint64                `json:"seq,omitempty"`
+	Detail  string                `json:"detail"`
+}
+
+// String returns a description of the problem including where it was found.
+func (p *AuditChainProblem) String() string {
+	switch {
+	case p.File != "":
+		return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Type, p.Detail)
+	default:
+		return fmt.Sprintf("%s: %s", p.Type, p.Detail)
+	}
+}
+
+// AuditChainSummary summarizes a verified audit event chain.
+type AuditChainSummary struct {
+	ChainId     string `json:"chain_id"`
+	FirstSeq    uint64 `json:"first_seq"`
+	LastSeq     uint64 `json:"last_seq"`
+	Events      int    `json:"events"`
+	Checkpoints int    `json:"checkpoints"`
+}
+
+// AuditChainReport is the result of verifying chained audit events.
+type AuditChainReport struct {
+	// Chains are the chains found, in the order they first appear.
+	Chains []*AuditChainSummary `json:"chains"`
+	// Unchained is the number of lines which are not part of a chain.  Those
+	// in files which have chained events are also reported as problems.
+	Unchained int `json:"unchained"`
+	// Problems found while verifying the chains.
+	Problems []*AuditChainProblem `json:"problems"`
+}
+
+// Valid returns true when no problems were found.
+func (r *AuditChainReport) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+type auditChainRecord struct {
+	file  string
+	line  int
+	link  auditChainLink
+	valid bool
+}
+
+type auditChainCheckpointRecord struct {
+	file  string
+	line  int
+	cp    auditChainCheckpoint
+	valid bool
+}
+
+// VerifyAuditChain verifies the chained audit events in the files, which
+// should be given in the order they were written when a rotated log is
+// verified.  Events are ordered by their sequence number, so the events of a
+// chain may appear in any order within the files.  A chain may begin part way
+// through when the files written before it aren't included, but events
+// missing from within a chain or after its last checkpoint are reported as
+// problems, as are events which have been modified and unchained lines in
+// files of chained events.  A chain which continues a previous chain is
+// checked against the previous chain's last event, when it's included.
+func VerifyAuditChain(ctx context.Context, keyFn AuditChainKeyFunc, files ...string) (*AuditChainReport, error) {
+	const op = "event.VerifyAuditChain"
+	if keyFn == nil {
+		return nil, fmt.Errorf("%s: missing key func: %w", op, ErrInvalidParameter)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: missing files: %w", op, ErrInvalidParameter)
+	}
+	v := &auditChainVerifier{
+		keyFn:       keyFn,
+		wrappers:    map[string]wrapping.Wrapper{},
+		records:     map[string][]*auditChainRecord{},
+		checkpoints: map[string][]*auditChainCheckpointRecord{},
+		report:      &AuditChainReport{},
+	}
+	for _, name := range files {
+		if err := v.readFile(ctx, name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	for _, chainId := range v.chainIds {
+		v.verifyChain(chainId)
+	}
+	for i, chainId := range v.chainIds {
+		v.verifyPrevChain(chainId, i == 0)
+	}
+	return v.report, nil
+}
+
+type auditChainVerifier struct {
+	keyFn       AuditChainKeyFunc
+	wrappers    map[string]wrapping.Wrapper
+	chainIds    []string
+	records     map[string][]*auditChainRecord
+	checkpoints map[string][]*auditChainCheckpointRecord
+	report      *AuditChainReport
+}
+
+func (v *auditChainVerifier) readFile(ctx context.Context, name string) error {
+	const op = "event.(auditChainVerifier).readFile"
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("%s: unable to open %s: %w", op, name, err)
+	}
+	defer f.Close()
+	// unchained lines are only problems in files with chained events, so
+	// they're reported once the whole file has been read
+	var unchained []int
+	chained := false
+	r := bufio.NewReader(f)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := r.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return fmt.Errorf("%s: unable to read %s: %w", op, name, readErr)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			isChained, err := v.readLine(ctx, name, lineNum, line)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			switch {
+			case isChained:
+				chained = true
+			default:
+				v.report.Unchained++
+				unchained = append(unchained, lineNum)
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	if chained {
+		for _, lineNum := range unchained {
+			v.problem(AuditChainUnchained, name, lineNum, "", 0, "line is not part of a chain")
+		}
+	}
+	return nil
+}
+
+// readLine reads a line of a file, returning false if it isn't part of a
+// chain.
+func (v *auditChainVerifier) readLine(ctx context.Context, file string, lineNum int, line []byte) (bool, error) {
+	const op = "event.(auditChainVerifier).readLine"
+	if bytes.HasPrefix(line, auditChainCheckpointPrefix) {
+		var cpLine map[string]auditChainCheckpoint
+		if err := json.Unmarshal(line, &cpLine); err != nil {
+			v.problem(AuditChainMalformed, file, lineNum, "", 0, fmt.Sprintf("unable to parse checkpoint: %s", err))
+			return true, nil
+		}
+		rec := &auditChainCheckpointRecord{file: file, line: lineNum, cp: cpLine[auditChainCheckpointField]}
+		w, err := v.wrapper(ctx, rec.cp.KeyId)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+		mac, err := signAuditChainCheckpoint(ctx, w, rec.cp)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+		rec.valid = mac == rec.cp.Hmac
+		if !rec.valid {
+			v.problem(AuditChainModified, file, lineNum, rec.cp.ChainId, rec.cp.Seq, fmt.Sprintf("checkpoint at seq %d does not match its hmac", rec.cp.Seq))
+		}
+		v.addChain(rec.cp.ChainId)
+		v.checkpoints[rec.cp.ChainId] = append(v.checkpoints[rec.cp.ChainId], rec)
+		return true, nil
+	}
+
+	idx := bytes.LastIndex(line, auditChainMarker)
+	if idx < 0 {
+		return false, nil
+	}
+	if line[len(line)-1] != '}' {
+		v.problem(AuditChainMalformed, file, lineNum, "", 0, "chained event is not a json object")
+		return true, nil
+	}
+	rec := &auditChainRecord{file: file, line: lineNum}
+	if err := json.Unmarshal(line[idx+len(auditChainMarker):len(line)-1], &rec.link); err != nil {
+		v.problem(AuditChainMalformed, file, lineNum, "", 0, fmt.Sprintf("unable to parse chain link: %s", err))
+		return true, nil
+	}
+	obj := bytes.TrimSuffix(line[:idx], []byte(","))
+	body := make([]byte, 0, len(obj)+1)
+	body = append(body, obj...)
+	body = append(body, '}')
+
+	w, err := v.wrapper(ctx, rec.link.KeyId)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	mac, err := signAuditChainLink(ctx, w, rec.link, body)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	rec.valid = mac == rec.link.Hmac
+	if !rec.valid {
+		v.problem(AuditChainModified, file, lineNum, rec.link.ChainId, rec.link.Seq, fmt.Sprintf("event at seq %d does not match its hmac", rec.link.Seq))
+	}
+	v.addChain(rec.link.ChainId)
+	v.records[rec.link.ChainId] = append(v.records[rec.link.ChainId], rec)
+	return true, nil
+}
+
+func (v *auditChainVerifier) verifyChain(chainId string) {
+	summary := &AuditChainSummary{ChainId: chainId}
+	v.report.Chains = append(v.report.Chains, summary)
+
+	records := v.records[chainId]
+	sort.SliceStable(records, func(i, j int) bool { return records[i].link.Seq < records[j].link.Seq })
+	bySeq := make(map[uint64]*auditChainRecord, len(records))
+	var prev *auditChainRecord
+	for _, rec := range records {
+		switch {
+		case prev == nil:
+			summary.FirstSeq = rec.link.Seq
+			if rec.link.Seq == 1 && rec.link.Prev != "" && rec.link.PrevChainId == "" {
+				v.problem(AuditChainBrokenLink, rec.file, rec.line, chainId, rec.link.Seq, "first event of the chain is linked to a previous event")
+			}
+		case rec.link.Seq == prev.link.Seq:
+			v.problem(AuditChainDuplicate, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("event at seq %d was already seen at %s:%d", rec.link.Seq, prev.file, prev.line))
+			continue
+		case rec.link.Seq != prev.link.Seq+1:
+			v.problem(AuditChainGap, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("events at seq %d to %d are missing", prev.link.Seq+1, rec.link.Seq-1))
+		case rec.valid && prev.valid && rec.link.Prev != prev.link.Hmac:
+			v.problem(AuditChainBrokenLink, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("event at seq %d is not linked to the event at seq %d", rec.link.Seq, prev.link.Seq))
+		}
+		bySeq[rec.link.Seq] = rec
+		summary.Events++
+		summary.LastSeq = rec.link.Seq
+		prev = rec
+	}
+
+	for _, cp := range v.checkpoints[chainId] {
+		summary.Checkpoints++
+		if !cp.valid {
+			continue
+		}
+		switch rec, ok := bySeq[cp.cp.Seq]; {
+		case cp.cp.Seq > summary.LastSeq:
+			v.problem(AuditChainTruncated, cp.file, cp.line, chainId, cp.cp.Seq, fmt.Sprintf("checkpoint is at seq %d but the last event is at seq %d", cp.cp.Seq, summary.LastSeq))
+		case ok && rec.valid && rec.link.Hmac != cp.cp.Head:
+			v.problem(AuditChainBrokenLink, cp.file, cp.line, chainId, cp.cp.Seq, fmt.Sprintf("checkpoint does not match the event at seq %d", cp.cp.Seq))
+		}
+	}
+}
+
+// verifyPrevChain checks the first event of the chain against the last event
+// of the chain it continues.  Chains which begin in the files, other than the
+// first chain, must continue a previous chain.
+func (v *auditChainVerifier) verifyPrevChain(chainId string, first bool) {
+	records := v.records[chainId]
+	if len(records) == 0 || records[0].link.Seq != 1 || !records[0].valid {
+		return
+	}
+	rec := records[0]
+	if rec.link.PrevChainId == "" {
+		if !first {
+			v.problem(AuditChainBrokenLink, rec.file, rec.line, chainId, rec.link.Seq, "chain does not continue the chain before it")
+		}
+		return
+	}
+	prevRecords := v.records[rec.link.PrevChainId]
+	if len(prevRecords) == 0 {
+		// the previous chain was written to files which aren't included
+		return
+	}
+	last := prevRecords[len(prevRecords)-1]
+	switch {
+	case last.link.Seq < rec.link.PrevSeq:
+		v.problem(AuditChainTruncated, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("chain continues chain %s at seq %d but its last event is at seq %d", rec.link.PrevChainId, rec.link.PrevSeq, last.link.Seq))
+	case last.link.Seq > rec.link.PrevSeq:
+		v.problem(AuditChainBrokenLink, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("chain continues chain %s at seq %d but it has events up to seq %d", rec.link.PrevChainId, rec.link.PrevSeq, last.link.Seq))
+	case last.valid && last.link.Hmac != rec.link.Prev:
+		v.problem(AuditChainBrokenLink, rec.file, rec.line, chainId, rec.link.Seq, fmt.Sprintf("chain is not linked to the event at seq %d of chain %s", rec.link.PrevSeq, rec.link.PrevChainId))
+	}
+}
+
+func (v *auditChainVerifier) wrapper(ctx context.Context, keyId string) (wrapping.Wrapper, error) {
+	const op = "event.(auditChainVerifier).wrapper"
+	if w, ok := v.wrappers[keyId]; ok {
+		return w, nil
+	}
+	w, err := v.keyFn(ctx, keyId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to get wrapper for key %q: %w", op, keyId, err)
+	}
+	if w == nil {
+		return nil, fmt.Errorf("%s: missing wrapper for key %q: %w", op, keyId, ErrInvalidParameter)
+	}
+	v.wrappers[keyId] = w
+	return w, nil
+}
+
+func (v *auditChainVerifier) addChain(chainId string) {
+	if _, ok := v.records[chainId]; ok {
+		return
+	}
+	if _, ok := v.checkpoints[chainId]; ok {
+		return
+	}
+	v.chainIds = append(v.chainIds, chainId)
+}
+
+func (v *auditChainVerifier) problem(t AuditChainProblemType, file string, line int, chainId string, seq uint64, detail string) {
+	v.report.Problems = append(v.report.Problems, &AuditChainProblem{
+		Type:    t,
+		File:    file,
+		Line:    line,
+		ChainId: chainId,
+		Seq:     seq,
+		Detail:  detail,
+	})
+}
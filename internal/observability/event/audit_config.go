@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"time"
 
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 )
@@ -13,13 +14,28 @@ type AuditConfig struct {
 	FilterOverrides    AuditFilterOperations `hcl:"-"`
 	FilterOverridesHCL map[string]string     `hcl:"audit_filter_overrides"`
 
+	// FieldOverrides provide an optional set of overrides for the
+	// DataClassification of specific proto message fields.
+	FieldOverrides    AuditFieldOverrides `hcl:"-"`
+	FieldOverridesHCL map[string]string   `hcl:"audit_field_overrides"`
+
+	// HashChain enables chaining of the sink's audit events, so the deletion,
+	// reordering or modification of events can be detected. It's only
+	// supported for json sink formats.
+	HashChain bool `hcl:"hash_chain"`
+
+	// HashChainCheckpointInterval is the interval between signed checkpoints of
+	// the chain. Defaults to DefaultAuditChainCheckpointInterval.
+	HashChainCheckpointInterval    time.Duration `hcl:"-"`
+	HashChainCheckpointIntervalHCL string        `hcl:"hash_chain_checkpoint_interval"`
+
 	// wrapper to use for audit event crypto operations.
 	wrapper wrapping.Wrapper
 }
 
 // NewAuditConfig creates a new config starting with the DefaultAuditConfig()
-// and applying options. Supported options are: WithWrapper and
-// WithFilterOperations.
+// and applying options. Supported options are: WithWrapper,
+// WithFilterOperations, WithFieldOverrides and WithHashChain.
 func NewAuditConfig(opt ...Option) (*AuditConfig, error) {
 	const op = "event.NewAuditConfig"
 	opts := getOpts(opt...)
@@ -30,6 +46,13 @@ func NewAuditConfig(opt ...Option) (*AuditConfig, error) {
 	if opts.withFilterOperations != nil {
 		c.FilterOverrides = opts.withFilterOperations
 	}
+	if opts.withFieldOverrides != nil {
+		c.FieldOverrides = opts.withFieldOverrides
+	}
+	if opts.withHashChain {
+		c.HashChain = true
+		c.HashChainCheckpointInterval = opts.withHashChainCheckpointInterval
+	}
 	if err := c.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: invalid configuration: %w", op, err)
 	}
@@ -45,6 +68,13 @@ func (ac *AuditConfig) Validate() error {
 	if err := ac.FilterOverrides.Validate(); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	if err := ac.FieldOverrides.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ac.HashChainCheckpointInterval < 0 {
+		return fmt.Errorf("%s: hash chain checkpoint interval must not be negative: %w", op, ErrInvalidParameter)
+	}
 
 	// Note: we don't validate the wrapper here because it may not be set yet.
 
@@ -2,6 +2,7 @@ package event
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,10 +44,37 @@ func TestAuditConfig_Validate(t *testing.T) {
 			wantIsError:     ErrInvalidParameter,
 			wantErrContains: "invalid filter override operation (invalid-operation)",
 		},
+		{
+			name: "invalid-field-override",
+			ac: &AuditConfig{
+				FieldOverrides: AuditFieldOverrides{
+					"Target.not_a_field": PublicClassification,
+				},
+			},
+			wantIsError:     ErrInvalidParameter,
+			wantErrContains: `unknown field "not_a_field"`,
+		},
+		{
+			name: "negative-hash-chain-checkpoint-interval",
+			ac: &AuditConfig{
+				HashChain:                   true,
+				HashChainCheckpointInterval: -time.Second,
+			},
+			wantIsError:     ErrInvalidParameter,
+			wantErrContains: "hash chain checkpoint interval must not be negative",
+		},
 		{
 			name: "valid-default",
 			ac:   DefaultAuditConfig(),
 		},
+		{
+			name: "valid-field-overrides",
+			ac: &AuditConfig{
+				FieldOverrides: AuditFieldOverrides{
+					"Target.description": PublicClassification,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -83,10 +111,12 @@ func TestNewAuditConfig(t *testing.T) {
 		},
 		{
 			name: "valid-with-all-opts",
-			opts: []Option{WithAuditWrapper(wrapper), WithFilterOperations(filterOps)},
+			opts: []Option{WithAuditWrapper(wrapper), WithFilterOperations(filterOps), WithHashChain(time.Hour)},
 			want: &AuditConfig{
-				FilterOverrides: filterOps,
-				wrapper:         wrapper,
+				FilterOverrides:             filterOps,
+				HashChain:                   true,
+				HashChainCheckpointInterval: time.Hour,
+				wrapper:                     wrapper,
 			},
 		},
 	}
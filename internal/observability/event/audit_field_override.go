@@ -0,0 +1,584 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// fieldOverridePathDelimiter separates the message and field names of an
+	// AuditFieldOverrides path.
+	fieldOverridePathDelimiter = "."
+
+	// apiPackagePrefix is the proto package prefix of the api messages, which
+	// are preferred when resolving a message by its short name.
+	apiPackagePrefix = "controller.api."
+
+	structFullName      protoreflect.FullName = "google.protobuf.Struct"
+	stringValueFullName protoreflect.FullName = "google.protobuf.StringValue"
+	bytesValueFullName  protoreflect.FullName = "google.protobuf.BytesValue"
+)
+
+// AuditFieldOverrides defines a map between proto message field paths and the
+// DataClassification to apply to them, which overrides the classification
+// declared by the field's "class" tag.
+//
+// A path starts with a message name (either fully qualified or its short name)
+// followed by one or more field names (either the proto or json name of the
+// field), for example: "Account.attributes.email".  Naming a member of a oneof
+// matches whichever member of the oneof is set, and a google.protobuf.Struct
+// field may be followed by a single key within the struct.
+type AuditFieldOverrides map[string]DataClassification
+
+// Validate the AuditFieldOverrides, which includes resolving every path against
+// the registered proto messages.
+func (fo AuditFieldOverrides) Validate() error {
+	const op = "event.(AuditFieldOverrides).Validate"
+	if _, err := fo.compile(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// fieldOverride is a validated AuditFieldOverrides path.
+type fieldOverride struct {
+	path           string
+	fields         []string
+	classification DataClassification
+}
+
+// compile validates the overrides and returns them indexed by the full name of
+// the message each path starts with.
+func (fo AuditFieldOverrides) compile() (map[protoreflect.FullName][]*fieldOverride, error) {
+	const op = "event.(AuditFieldOverrides).compile"
+	compiled := make(map[protoreflect.FullName][]*fieldOverride, len(fo))
+	for path, classification := range fo {
+		if err := classification.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: invalid field override classification for %q: %w", op, path, err)
+		}
+		md, fields, err := resolveOverrideMessage(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := validateOverrideFields(md, fields); err != nil {
+			return nil, fmt.Errorf("%s: invalid field override %q: %w", op, path, err)
+		}
+		compiled[md.FullName()] = append(compiled[md.FullName()], &fieldOverride{
+			path:           path,
+			fields:         fields,
+			classification: classification,
+		})
+	}
+	// sort, so events are processed consistently when more than one path
+	// references the same field.
+	for _, overrides := range compiled {
+		sort.Slice(overrides, func(i, j int) bool { return overrides[i].path < overrides[j].path })
+	}
+	return compiled, nil
+}
+
+// resolveOverrideMessage returns the message that the override path starts with and the
+// remaining field names of the path.  The longest fully qualified message name
+// is preferred, before falling back to the path's first segment as a short
+// message name.
+func resolveOverrideMessage(overridePath string) (protoreflect.MessageDescriptor, []string, error) {
+	const op = "event.resolveOverrideMessage"
+	segs := strings.Split(overridePath, fieldOverridePathDelimiter)
+	if len(segs) < 2 {
+		return nil, nil, fmt.Errorf("%s: field override %q must include a message and field name: %w", op, overridePath, ErrInvalidParameter)
+	}
+	for _, s := range segs {
+		if s == "" {
+			return nil, nil, fmt.Errorf("%s: field override %q has an empty name: %w", op, overridePath, ErrInvalidParameter)
+		}
+	}
+	for i := len(segs) - 1; i > 1; i-- {
+		name := protoreflect.FullName(strings.Join(segs[:i], fieldOverridePathDelimiter))
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+		if err != nil {
+			continue
+		}
+		if md, ok := d.(protoreflect.MessageDescriptor); ok {
+			return md, segs[i:], nil
+		}
+	}
+
+	var apiMatches, otherMatches []protoreflect.MessageDescriptor
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		rangeMessages(fd.Messages(), func(md protoreflect.MessageDescriptor) {
+			if string(md.Name()) != segs[0] {
+				return
+			}
+			if strings.HasPrefix(string(md.FullName()), apiPackagePrefix) {
+				apiMatches = append(apiMatches, md)
+				return
+			}
+			otherMatches = append(otherMatches, md)
+		})
+		return true
+	})
+	matches := apiMatches
+	if len(apiMatches) > 1 {
+		// an api resource is defined in a file named after it, which is
+		// preferred over other api messages that share its name.
+		var resourceMatches []protoreflect.MessageDescriptor
+		for _, md := range apiMatches {
+			fileName := strings.TrimSuffix(path.Base(md.ParentFile().Path()), ".proto")
+			if strings.EqualFold(strings.ReplaceAll(fileName, "_", ""), string(md.Name())) {
+				resourceMatches = append(resourceMatches, md)
+			}
+		}
+		if len(resourceMatches) > 0 {
+			matches = resourceMatches
+		}
+	}
+	if len(matches) == 0 {
+		matches = otherMatches
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil, fmt.Errorf("%s: unknown message %q in field override %q: %w", op, segs[0], overridePath, ErrInvalidParameter)
+	case 1:
+		return matches[0], segs[1:], nil
+	default:
+		return nil, nil, fmt.Errorf("%s: ambiguous message %q in field override %q (use its fully qualified name): %w", op, segs[0], overridePath, ErrInvalidParameter)
+	}
+}
+
+// rangeMessages calls fn for every message and nested message.
+func rangeMessages(mds protoreflect.MessageDescriptors, fn func(protoreflect.MessageDescriptor)) {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		fn(md)
+		rangeMessages(md.Messages(), fn)
+	}
+}
+
+// validateOverrideFields validates that the field names resolve to a field
+// which can be filtered.
+func validateOverrideFields(md protoreflect.MessageDescriptor, fields []string) error {
+	const op = "event.validateOverrideFields"
+	fd := findOverrideField(md, fields[0])
+	if fd == nil {
+		return fmt.Errorf("%s: unknown field %q in message %s: %w", op, fields[0], md.FullName(), ErrInvalidParameter)
+	}
+	// a oneof member matches whichever member is set, so the other members are
+	// candidates too.  A struct member accepts any key, so it's only a
+	// candidate when it's named.
+	candidates := []protoreflect.FieldDescriptor{fd}
+	if oo := fd.ContainingOneof(); oo != nil && !oo.IsSynthetic() {
+		for i := 0; i < oo.Fields().Len(); i++ {
+			f := oo.Fields().Get(i)
+			if f == fd || (f.Message() != nil && f.Message().FullName() == structFullName) {
+				continue
+			}
+			candidates = append(candidates, f)
+		}
+	}
+	var firstErr error
+	for _, c := range candidates {
+		err := validateOverrideField(c, fields[1:])
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func validateOverrideField(fd protoreflect.FieldDescriptor, remaining []string) error {
+	const op = "event.validateOverrideField"
+	switch {
+	case len(remaining) == 0:
+		if !isOverridableField(fd) {
+			return fmt.Errorf("%s: field %s is not a string or bytes field: %w", op, fd.FullName(), ErrInvalidParameter)
+		}
+		return nil
+	case fd.IsMap():
+		return fmt.Errorf("%s: map field %s is not supported: %w", op, fd.FullName(), ErrInvalidParameter)
+	case fd.Message() == nil:
+		return fmt.Errorf("%s: field %s is not a message: %w", op, fd.FullName(), ErrInvalidParameter)
+	case fd.Message().FullName() == structFullName:
+		if len(remaining) != 1 || fd.IsList() {
+			return fmt.Errorf("%s: struct field %s only supports a single key: %w", op, fd.FullName(), ErrInvalidParameter)
+		}
+		return nil
+	default:
+		return validateOverrideFields(fd.Message(), remaining)
+	}
+}
+
+// isOverridableField returns true for the field types that the audit encrypt
+// filter will filter.
+func isOverridableField(fd protoreflect.FieldDescriptor) bool {
+	switch {
+	case fd.IsMap():
+		return false
+	case fd.Kind() == protoreflect.StringKind, fd.Kind() == protoreflect.BytesKind:
+		return true
+	case fd.Message() != nil && !fd.IsList():
+		switch fd.Message().FullName() {
+		case stringValueFullName, bytesValueFullName:
+			return true
+		}
+	}
+	return false
+}
+
+// findOverrideField finds a field by either its proto or json name.
+func findOverrideField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(string(fd.Name()), name) || strings.EqualFold(fd.JSONName(), name) {
+			return fd
+		}
+	}
+	return nil
+}
+
+// auditFieldOverrideFilter is an eventlogger Filter Node which wraps the audit
+// encrypt filter, and applies AuditFieldOverrides to the proto message details
+// of the audit events it filters.
+type auditFieldOverrideFilter struct {
+	filter    *encrypt.Filter
+	overrides map[protoreflect.FullName][]*fieldOverride
+}
+
+var _ eventlogger.Node = &auditFieldOverrideFilter{}
+
+func newAuditFieldOverrideFilter(filter *encrypt.Filter, fo AuditFieldOverrides) (*auditFieldOverrideFilter, error) {
+	const op = "event.newAuditFieldOverrideFilter"
+	if filter == nil {
+		return nil, fmt.Errorf("%s: missing encrypt filter: %w", op, ErrInvalidParameter)
+	}
+	overrides, err := fo.compile()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &auditFieldOverrideFilter{
+		filter:    filter,
+		overrides: overrides,
+	}, nil
+}
+
+// Reopen is a no op for Filters.
+func (f *auditFieldOverrideFilter) Reopen() error {
+	return nil
+}
+
+// Type describes the type of the node as a Filter.
+func (f *auditFieldOverrideFilter) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeFilter
+}
+
+// Process will filter the event with the encrypt filter and then apply the
+// field overrides to the filtered event.  Fields overridden as public are
+// restored to their original value, and fields overridden as sensitive or
+// secret are filtered along with the event, using the encrypt filter's
+// operation for that classification.
+func (f *auditFieldOverrideFilter) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(auditFieldOverrideFilter).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	a, ok := auditPayload(e.Payload)
+	if !ok {
+		return f.filter.Process(ctx, e)
+	}
+	origRefs := f.fieldRefs(a)
+	if len(origRefs) == 0 {
+		return f.filter.Process(ctx, e)
+	}
+
+	values := make(fieldOverrideValues, len(origRefs))
+	for i, r := range origRefs {
+		if r.classification == PublicClassification {
+			continue
+		}
+		values[strconv.Itoa(i)] = classifiedValue{
+			Classification: r.classification,
+			Value:          overrideString(r.value),
+		}
+	}
+	filtered, err := f.filter.Process(ctx, &eventlogger.Event{
+		Type:      e.Type,
+		CreatedAt: e.CreatedAt,
+		Formatted: e.Formatted,
+		Payload: &overriddenAudit{
+			Audit:     a,
+			Overrides: values,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if filtered == nil {
+		return nil, nil
+	}
+	oa, ok := filtered.Payload.(*overriddenAudit)
+	if !ok || oa.Audit == nil {
+		return nil, fmt.Errorf("%s: filtered payload is not an audit event: %w", op, ErrInvalidParameter)
+	}
+	filteredRefs := f.fieldRefs(oa.Audit)
+	if len(filteredRefs) != len(origRefs) {
+		return nil, fmt.Errorf("%s: filtered event fields do not match the original event: %w", op, ErrInvalidParameter)
+	}
+	for i, r := range origRefs {
+		if r.classification == PublicClassification {
+			filteredRefs[i].set(r.value)
+		}
+	}
+	for k, v := range oa.Overrides {
+		i, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		filteredRefs[i].set(overrideString(v))
+	}
+	if _, isPtr := e.Payload.(*audit); isPtr {
+		filtered.Payload = oa.Audit
+	} else {
+		filtered.Payload = *oa.Audit
+	}
+	return filtered, nil
+}
+
+// overriddenAudit is the payload filtered by the encrypt filter, so the audit
+// event and its overridden field values are filtered in a single pass.
+type overriddenAudit struct {
+	Audit     *audit
+	Overrides fieldOverrideValues
+}
+
+// auditPayload returns the audit event from the payload, which is a value
+// rather than a pointer once gated audit events have been composed.
+func auditPayload(payload interface{}) (*audit, bool) {
+	switch a := payload.(type) {
+	case *audit:
+		return a, true
+	case audit:
+		return &a, true
+	default:
+		return nil, false
+	}
+}
+
+// fieldRefs returns references to all the overridden fields within the audit
+// event's request and response details.
+func (f *auditFieldOverrideFilter) fieldRefs(a *audit) []*fieldRef {
+	var refs []*fieldRef
+	var details []proto.Message
+	if a.Request != nil && a.Request.Details != nil {
+		details = append(details, a.Request.Details)
+	}
+	if a.Response != nil && a.Response.Details != nil {
+		details = append(details, a.Response.Details)
+	}
+	for _, d := range details {
+		if m := d.ProtoReflect(); m.IsValid() {
+			f.walk(m, &refs)
+		}
+	}
+	return refs
+}
+
+// walk will find the overridden fields of the message and all of its nested
+// messages.  Fields are visited in a consistent order, so walking a message
+// and its filtered copy returns matching references.
+func (f *auditFieldOverrideFilter) walk(m protoreflect.Message, refs *[]*fieldRef) {
+	for _, o := range f.overrides[m.Descriptor().FullName()] {
+		resolveFieldRefs(m, o.fields, o.classification, refs)
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			mv := m.Get(fd).Map()
+			keys := make([]protoreflect.MapKey, 0, mv.Len())
+			mv.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			for _, k := range keys {
+				f.walk(mv.Get(k).Message(), refs)
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			l := m.Get(fd).List()
+			for j := 0; j < l.Len(); j++ {
+				f.walk(l.Get(j).Message(), refs)
+			}
+		default:
+			f.walk(m.Get(fd).Message(), refs)
+		}
+	}
+}
+
+// fieldRef references a single overridden field value.
+type fieldRef struct {
+	classification DataClassification
+	// value is either a string, []byte or *structpb.Value
+	value interface{}
+	set   func(interface{})
+}
+
+func resolveFieldRefs(m protoreflect.Message, fields []string, classification DataClassification, refs *[]*fieldRef) {
+	fd := findOverrideField(m.Descriptor(), fields[0])
+	if fd == nil {
+		return
+	}
+	if oo := fd.ContainingOneof(); oo != nil && !oo.IsSynthetic() {
+		if fd = m.WhichOneof(oo); fd == nil {
+			return
+		}
+	}
+	if !m.Has(fd) {
+		return
+	}
+	remaining := fields[1:]
+	switch {
+	case len(remaining) == 0:
+		leafFieldRefs(m, fd, classification, refs)
+	case fd.IsMap() || fd.Message() == nil:
+	case fd.Message().FullName() == structFullName:
+		s, ok := m.Get(fd).Message().Interface().(*structpb.Struct)
+		if !ok || len(remaining) != 1 || fd.IsList() {
+			return
+		}
+		key := remaining[0]
+		v, ok := s.GetFields()[key]
+		if !ok {
+			return
+		}
+		*refs = append(*refs, &fieldRef{
+			classification: classification,
+			value:          v,
+			set: func(nv interface{}) {
+				if sv, ok := nv.(*structpb.Value); ok {
+					s.Fields[key] = proto.Clone(sv).(*structpb.Value)
+					return
+				}
+				s.Fields[key] = structpb.NewStringValue(overrideString(nv))
+			},
+		})
+	case fd.IsList():
+		l := m.Get(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			resolveFieldRefs(l.Get(i).Message(), remaining, classification, refs)
+		}
+	default:
+		resolveFieldRefs(m.Get(fd).Message(), remaining, classification, refs)
+	}
+}
+
+func leafFieldRefs(m protoreflect.Message, fd protoreflect.FieldDescriptor, classification DataClassification, refs *[]*fieldRef) {
+	switch {
+	case !isOverridableField(fd):
+	case fd.Message() != nil:
+		// a StringValue or BytesValue wrapper
+		w := m.Get(fd).Message()
+		if vfd := w.Descriptor().Fields().ByName("value"); vfd != nil && w.Has(vfd) {
+			leafFieldRefs(w, vfd, classification, refs)
+		}
+	case fd.IsList():
+		l := m.Get(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			i := i
+			*refs = append(*refs, &fieldRef{
+				classification: classification,
+				value:          l.Get(i).Interface(),
+				set:            func(nv interface{}) { l.Set(i, overrideValue(fd, nv)) },
+			})
+		}
+	default:
+		*refs = append(*refs, &fieldRef{
+			classification: classification,
+			value:          m.Get(fd).Interface(),
+			set:            func(nv interface{}) { m.Set(fd, overrideValue(fd, nv)) },
+		})
+	}
+}
+
+// overrideValue converts the value to the field's kind.
+func overrideValue(fd protoreflect.FieldDescriptor, v interface{}) protoreflect.Value {
+	if fd.Kind() == protoreflect.BytesKind {
+		if b, ok := v.([]byte); ok {
+			return protoreflect.ValueOfBytes(b)
+		}
+		return protoreflect.ValueOfBytes([]byte(overrideString(v)))
+	}
+	return protoreflect.ValueOfString(overrideString(v))
+}
+
+func overrideString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case classifiedValue:
+		return t.Value
+	case *structpb.Value:
+		if s, ok := t.GetKind().(*structpb.Value_StringValue); ok {
+			return s.StringValue
+		}
+		return fmt.Sprint(t.AsInterface())
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// fieldOverrideValues is a taggable map of overridden field values, which is
+// used to filter the values using the encrypt filter.
+type fieldOverrideValues map[string]interface{}
+
+// Tags implements the encrypt.Taggable interface.
+func (v fieldOverrideValues) Tags() ([]encrypt.PointerTag, error) {
+	tags := make([]encrypt.PointerTag, 0, len(v))
+	for k, value := range v {
+		cv, ok := value.(classifiedValue)
+		if !ok {
+			continue
+		}
+		tags = append(tags, encrypt.PointerTag{
+			Pointer:        "/" + k,
+			Classification: encrypt.DataClassification(cv.Classification),
+		})
+	}
+	return tags, nil
+}
+
+// classifiedValue is an overridden field value along with its classification.
+// The encrypt filter replaces it with the filtered string value, unless no
+// filter operation applies to its classification.
+type classifiedValue struct {
+	Classification DataClassification
+	Value          string
+}
+
+// String returns the value, which is what the encrypt filter will filter.
+func (cv classifiedValue) String() string {
+	return cv.Value
+}
@@ -0,0 +1,267 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestAuditFieldOverrides_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		fo              AuditFieldOverrides
+		wantIsErr       error
+		wantErrContains string
+	}{
+		{name: "nil"},
+		{name: "short-name", fo: AuditFieldOverrides{"Target.name": SensitiveClassification}},
+		{name: "fully-qualified", fo: AuditFieldOverrides{"controller.api.resources.targets.v1.Target.name": SensitiveClassification}},
+		{name: "json-name", fo: AuditFieldOverrides{"Account.auth_method_id": SecretClassification}},
+		{name: "wrapper", fo: AuditFieldOverrides{"Target.description": PublicClassification}},
+		{name: "oneof-member", fo: AuditFieldOverrides{"Account.attributes.email": SensitiveClassification}},
+		{name: "named-oneof-member", fo: AuditFieldOverrides{"Account.oidc_account_attributes.email": SensitiveClassification}},
+		{name: "repeated", fo: AuditFieldOverrides{"Account.managed_group_ids": SensitiveClassification}},
+		{name: "nested", fo: AuditFieldOverrides{"ListAccountsResponse.items.attributes.email": SensitiveClassification}},
+		{
+			name:            "invalid-classification",
+			fo:              AuditFieldOverrides{"Target.name": UnknownClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: "invalid data classification 'unknown'",
+		},
+		{
+			name:            "missing-field",
+			fo:              AuditFieldOverrides{"Target": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: "must include a message and field name",
+		},
+		{
+			name:            "empty-name",
+			fo:              AuditFieldOverrides{"Target..name": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: "has an empty name",
+		},
+		{
+			name:            "unknown-message",
+			fo:              AuditFieldOverrides{"NotAMessage.name": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: `unknown message "NotAMessage"`,
+		},
+		{
+			name:            "unknown-field",
+			fo:              AuditFieldOverrides{"Target.not_a_field": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: `unknown field "not_a_field" in message controller.api.resources.targets.v1.Target`,
+		},
+		{
+			name:            "unknown-nested-field",
+			fo:              AuditFieldOverrides{"Account.oidc_account_attributes.not_a_field": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: `unknown field "not_a_field" in message controller.api.resources.accounts.v1.OidcAccountAttributes`,
+		},
+		{
+			name:            "not-a-string",
+			fo:              AuditFieldOverrides{"Target.session_max_seconds": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: "is not a string or bytes field",
+		},
+		{
+			name:            "not-a-message",
+			fo:              AuditFieldOverrides{"Account.id.value": PublicClassification},
+			wantIsErr:       ErrInvalidParameter,
+			wantErrContains: "is not a message",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := tt.fo.Validate()
+			if tt.wantIsErr != nil {
+				require.Error(err)
+				assert.ErrorIs(err, tt.wantIsErr)
+				if tt.wantErrContains != "" {
+					assert.Contains(err.Error(), tt.wantErrContains)
+				}
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func Test_newAuditFieldOverrideFilter(t *testing.T) {
+	t.Parallel()
+	t.Run("missing-filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f, err := newAuditFieldOverrideFilter(nil, AuditFieldOverrides{"Target.name": PublicClassification})
+		require.Error(err)
+		assert.Nil(f)
+		assert.ErrorIs(err, ErrInvalidParameter)
+		assert.Contains(err.Error(), "missing encrypt filter")
+	})
+	t.Run("invalid-overrides", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f, err := newAuditFieldOverrideFilter(&encrypt.Filter{}, AuditFieldOverrides{"Target.not_a_field": PublicClassification})
+		require.Error(err)
+		assert.Nil(f)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+}
+
+func Test_auditFieldOverrideFilter_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newFilter := func(t *testing.T, fo AuditFieldOverrides) *auditFieldOverrideFilter {
+		t.Helper()
+		encryptFilter, err := NewAuditEncryptFilter(WithAuditWrapper(testWrapper(t)))
+		require.NoError(t, err)
+		encryptFilter.FilterOperationOverrides = map[encrypt.DataClassification]encrypt.FilterOperation{
+			encrypt.SensitiveClassification: encrypt.RedactOperation,
+			encrypt.SecretClassification:    encrypt.RedactOperation,
+		}
+		f, err := newAuditFieldOverrideFilter(encryptFilter, fo)
+		require.NoError(t, err)
+		return f
+	}
+
+	newAuditEvent := func() *eventlogger.Event {
+		attrs, err := structpb.NewStruct(map[string]interface{}{"login_name": "carol"})
+		if err != nil {
+			panic(err)
+		}
+		return &eventlogger.Event{
+			Type: eventlogger.EventType(AuditType),
+			Payload: &audit{
+				Id:      "audit-id",
+				Version: auditVersion,
+				Type:    string(ApiRequest),
+				Request: &Request{
+					Operation: "POST",
+					Details: &pbs.CreateTargetRequest{
+						Item: &targets.Target{
+							Name:        wrapperspb.String("target-name"),
+							Description: wrapperspb.String("target-description"),
+						},
+					},
+				},
+				Response: &Response{
+					StatusCode: 200,
+					Details: &pbs.ListAccountsResponse{
+						Items: []*accounts.Account{
+							{
+								Id: "acct-oidc",
+								Attrs: &accounts.Account_OidcAccountAttributes{
+									OidcAccountAttributes: &accounts.OidcAccountAttributes{
+										Email:    "alice@example.com",
+										FullName: "alice",
+									},
+								},
+							},
+							{
+								Id: "acct-password",
+								Attrs: &accounts.Account_PasswordAccountAttributes{
+									PasswordAccountAttributes: &accounts.PasswordAccountAttributes{
+										LoginName: "bob",
+										Password:  wrapperspb.String("bob-password"),
+									},
+								},
+								ManagedGroupIds: []string{"mg-1", "mg-2"},
+							},
+							{
+								Id:    "acct-struct",
+								Attrs: &accounts.Account_Attributes{Attributes: attrs},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("overrides", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, AuditFieldOverrides{
+			"Account.attributes.email":      SensitiveClassification,
+			"Account.attributes.login_name": PublicClassification,
+			"Account.managed_group_ids":     SecretClassification,
+			"Target.description":            SecretClassification,
+		})
+		e := newAuditEvent()
+		got, err := f.Process(ctx, e)
+		require.NoError(err)
+		require.NotNil(got)
+
+		a := got.Payload.(*audit)
+		target := a.Request.Details.(*pbs.CreateTargetRequest).GetItem()
+		assert.Equal("target-name", target.GetName().GetValue())
+		assert.Equal(encrypt.RedactedData, target.GetDescription().GetValue())
+
+		items := a.Response.Details.(*pbs.ListAccountsResponse).GetItems()
+		require.Len(items, 3)
+		assert.Equal(encrypt.RedactedData, items[0].GetOidcAccountAttributes().GetEmail())
+		assert.Equal("alice", items[0].GetOidcAccountAttributes().GetFullName())
+		assert.Equal("bob", items[1].GetPasswordAccountAttributes().GetLoginName())
+		assert.Equal(encrypt.RedactedData, items[1].GetPasswordAccountAttributes().GetPassword().GetValue())
+		assert.Equal([]string{encrypt.RedactedData, encrypt.RedactedData}, items[1].GetManagedGroupIds())
+		assert.Equal("carol", items[2].GetAttributes().GetFields()["login_name"].GetStringValue())
+
+		// the original event must not be modified
+		orig := e.Payload.(*audit)
+		assert.Equal("target-description", orig.Request.Details.(*pbs.CreateTargetRequest).GetItem().GetDescription().GetValue())
+		origItems := orig.Response.Details.(*pbs.ListAccountsResponse).GetItems()
+		assert.Equal("alice@example.com", origItems[0].GetOidcAccountAttributes().GetEmail())
+		assert.Equal([]string{"mg-1", "mg-2"}, origItems[1].GetManagedGroupIds())
+	})
+	t.Run("composed-audit-value", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, AuditFieldOverrides{"Target.description": SecretClassification})
+		e := newAuditEvent()
+		// gated audit events are composed into an audit value
+		e.Payload = *e.Payload.(*audit)
+		got, err := f.Process(ctx, e)
+		require.NoError(err)
+		require.NotNil(got)
+		a, ok := auditPayload(got.Payload)
+		require.True(ok)
+		target := a.Request.Details.(*pbs.CreateTargetRequest).GetItem()
+		assert.Equal(encrypt.RedactedData, target.GetDescription().GetValue())
+	})
+	t.Run("no-matching-fields", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, AuditFieldOverrides{"Group.name": PublicClassification})
+		got, err := f.Process(ctx, newAuditEvent())
+		require.NoError(err)
+		require.NotNil(got)
+		items := got.Payload.(*audit).Response.Details.(*pbs.ListAccountsResponse).GetItems()
+		assert.Equal(encrypt.RedactedData, items[1].GetPasswordAccountAttributes().GetLoginName())
+		assert.Equal("alice@example.com", items[0].GetOidcAccountAttributes().GetEmail())
+	})
+	t.Run("not-an-audit-event", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, AuditFieldOverrides{"Target.name": PublicClassification})
+		got, err := f.Process(ctx, &eventlogger.Event{
+			Type:    eventlogger.EventType(AuditType),
+			Payload: encrypt.TestTaggedMap{encrypt.TestMapField: "secret"},
+		})
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(encrypt.RedactedData, got.Payload.(encrypt.TestTaggedMap)[encrypt.TestMapField])
+	})
+	t.Run("missing-event", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, AuditFieldOverrides{"Target.name": PublicClassification})
+		got, err := f.Process(ctx, nil)
+		require.Error(err)
+		assert.Nil(got)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+}
@@ -50,6 +50,23 @@ type Request struct {
 	Details   proto.Message `json:"details,omitempty"`                  // boundary field
 }
 
+// Connection defines the fields captured about a connection proxied by a
+// worker for a session.
+type Connection struct {
+	SessionId       string `json:"session_id,omitempty" class:"public"`
+	ConnectionId    string `json:"connection_id,omitempty" class:"public"`
+	TargetId        string `json:"target_id,omitempty" class:"public"`
+	UserId          string `json:"user_id,omitempty" class:"public"`
+	WorkerId        string `json:"worker_id,omitempty" class:"public"`
+	Endpoint        string `json:"endpoint,omitempty" class:"public"`
+	ClientAddress   string `json:"client_address,omitempty" class:"public"`
+	UserClientIp    string `json:"user_client_ip,omitempty" class:"public"`
+	EndpointAddress string `json:"endpoint_address,omitempty" class:"public"`
+	BytesUp         int64  `json:"bytes_up,omitempty"`
+	BytesDown       int64  `json:"bytes_down,omitempty"`
+	ClosedReason    string `json:"closed_reason,omitempty" class:"public"`
+}
+
 type Response struct {
 	StatusCode int           `json:"status_code,omitempty"` // std audit
 	Details    proto.Message `json:"details,omitempty"`     // boundary field
@@ -10,13 +10,24 @@ import (
 // auditVersion defines the version of audit events
 const auditVersion = "v0.1"
 
-// auditEventType defines the type of audit event
-type auditEventType string
+// AuditEventType defines the type of audit event
+type AuditEventType string
 
 const (
-	ApiRequest auditEventType = "APIRequest" // ApiRequest defines an API request audit event type
+	ApiRequest           AuditEventType = "APIRequest"           // ApiRequest defines an API request audit event type
+	ConnectionAuthorized AuditEventType = "ConnectionAuthorized" // ConnectionAuthorized defines a worker connection authorized audit event type
+	ConnectionConnected  AuditEventType = "ConnectionConnected"  // ConnectionConnected defines a worker connection connected audit event type
+	ConnectionClosed     AuditEventType = "ConnectionClosed"     // ConnectionClosed defines a worker connection closed audit event type
 )
 
+func (t AuditEventType) valid() bool {
+	switch t {
+	case ApiRequest, ConnectionAuthorized, ConnectionConnected, ConnectionClosed:
+		return true
+	}
+	return false
+}
+
 // audit defines the data of audit events
 type audit struct {
 	Id          string       `json:"id"`                     // std audit/boundary field
@@ -27,6 +38,7 @@ type audit struct {
 	Auth        *Auth        `json:"auth,omitempty"`         // std audit field
 	Request     *Request     `json:"request,omitempty"`      // std audit field
 	Response    *Response    `json:"response,omitempty"`     // std audit field
+	Connection  *Connection  `json:"connection,omitempty"`   // boundary field
 	Flush       bool         `json:"-"`
 }
 
@@ -43,6 +55,12 @@ func newAudit(fromOperation Op, opt ...Option) (*audit, error) {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 	}
+	switch {
+	case opts.withAuditType == "":
+		opts.withAuditType = ApiRequest
+	case !opts.withAuditType.valid():
+		return nil, fmt.Errorf("%s: %q is not a valid audit event type: %w", op, opts.withAuditType, ErrInvalidParameter)
+	}
 	var dtm time.Time
 	switch opts.withNow.IsZero() {
 	case false:
@@ -54,12 +72,13 @@ func newAudit(fromOperation Op, opt ...Option) (*audit, error) {
 	a := &audit{
 		Id:          opts.withId,
 		Version:     auditVersion,
-		Type:        string(ApiRequest),
+		Type:        string(opts.withAuditType),
 		Timestamp:   dtm,
 		RequestInfo: opts.withRequestInfo,
 		Auth:        opts.withAuth,
 		Request:     opts.withRequest,
 		Response:    opts.withResponse,
+		Connection:  opts.withConnection,
 		Flush:       opts.withFlush,
 	}
 	if err := a.validate(); err != nil {
@@ -98,7 +117,7 @@ func (a *audit) ComposeFrom(events []*eventlogger.Event) (eventlogger.EventType,
 	if len(events) == 0 {
 		return "", nil, fmt.Errorf("%s: missing events: %w", op, ErrInvalidParameter)
 	}
-	var validId string
+	var validId, validType string
 	payload := audit{}
 	for i, v := range events {
 		gated, ok := v.Payload.(*audit)
@@ -119,8 +138,14 @@ func (a *audit) ComposeFrom(events []*eventlogger.Event) (eventlogger.EventType,
 		if gated.Version != auditVersion {
 			return "", nil, fmt.Errorf("%s: event %d has an invalid version: %s != %s: %w", op, i, gated.Version, auditVersion, ErrInvalidParameter)
 		}
-		if gated.Type != string(ApiRequest) {
-			return "", nil, fmt.Errorf("%s: event %d has an invalid type: %s != %s: %w", op, i, gated.Type, string(AuditType), ErrInvalidParameter)
+		if !AuditEventType(gated.Type).valid() {
+			return "", nil, fmt.Errorf("%s: event %d has an invalid type: %s: %w", op, i, gated.Type, ErrInvalidParameter)
+		}
+		if validType == "" {
+			validType = gated.Type
+		}
+		if gated.Type != validType {
+			return "", nil, fmt.Errorf("%s: event %d has an invalid type: %s != %s: %w", op, i, gated.Type, validType, ErrInvalidParameter)
 		}
 		if gated.RequestInfo != nil {
 			payload.RequestInfo = gated.RequestInfo
@@ -131,6 +156,9 @@ func (a *audit) ComposeFrom(events []*eventlogger.Event) (eventlogger.EventType,
 		if gated.Request != nil {
 			payload.Request = gated.Request
 		}
+		if gated.Connection != nil {
+			payload.Connection = gated.Connection
+		}
 		if gated.Response != nil {
 			if payload.Response == nil {
 				payload.Response = &Response{}
@@ -149,6 +177,6 @@ func (a *audit) ComposeFrom(events []*eventlogger.Event) (eventlogger.EventType,
 	}
 	payload.Id = validId
 	payload.Version = auditVersion
-	payload.Type = string(ApiRequest)
+	payload.Type = validType
 	return eventlogger.EventType(a.EventType()), payload, nil
 }
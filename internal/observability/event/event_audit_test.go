@@ -56,6 +56,31 @@ func Test_newAudit(t *testing.T) {
 				Flush:       true,
 			},
 		},
+		{
+			name:   "connection",
+			fromOp: "connection",
+			opts: []Option{
+				WithId("connection"),
+				WithNow(testNow),
+				WithAuditType(ConnectionClosed),
+				WithConnection(testConnection(t)),
+				WithFlush(),
+			},
+			want: &audit{
+				Id:         "connection",
+				Version:    auditVersion,
+				Type:       string(ConnectionClosed),
+				Timestamp:  testNow,
+				Connection: testConnection(t),
+				Flush:      true,
+			},
+		},
+		{
+			name:      "invalid-audit-type",
+			fromOp:    "invalid-audit-type",
+			opts:      []Option{WithAuditType("invalid")},
+			wantErrIs: ErrInvalidParameter,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -209,6 +234,48 @@ func TestAudit_ComposeFrom(t *testing.T) {
 			wantErrIs:       ErrInvalidParameter,
 			wantErrContains: "invalid id",
 		},
+		{
+			name: "mismatched-type",
+			events: []*eventlogger.Event{
+				{
+					Payload: &audit{
+						Id:      "test-id",
+						Version: auditVersion,
+						Type:    string(ConnectionAuthorized),
+					},
+				},
+				{
+					Payload: &audit{
+						Id:      "test-id",
+						Version: auditVersion,
+						Type:    string(ConnectionClosed),
+					},
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "invalid type",
+		},
+		{
+			name: "valid-connection",
+			events: []*eventlogger.Event{
+				{
+					Payload: &audit{
+						Id:         "valid",
+						Version:    auditVersion,
+						Type:       string(ConnectionConnected),
+						Timestamp:  testNow,
+						Connection: testConnection(t),
+					},
+				},
+			},
+			want: audit{
+				Id:         "valid",
+				Version:    auditVersion,
+				Type:       string(ConnectionConnected),
+				Timestamp:  testNow,
+				Connection: testConnection(t),
+			},
+		},
 		{
 			name: "valid",
 			events: []*eventlogger.Event{
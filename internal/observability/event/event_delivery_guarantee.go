@@ -2,6 +2,8 @@ package event
 
 import (
 	"fmt"
+
+	"github.com/segmentio/kafka-go"
 )
 
 const (
@@ -21,3 +23,20 @@ func (g DeliveryGuarantee) validate() error {
 		return fmt.Errorf("%s: %s is not a valid delivery guarantee: %w", op, g, ErrInvalidParameter)
 	}
 }
+
+// enforced returns true when delivery of each event must be acknowledged
+// before it's considered sent, so failures are reported to the caller.
+func (g DeliveryGuarantee) enforced() bool {
+	return g == Enforced
+}
+
+// kafkaRequiredAcks returns the acknowledgement a kafka sink requires from
+// the brokers before an event is considered delivered.  Enforced delivery
+// requires every in-sync replica to acknowledge the event, while a best
+// effort only requires the partition's leader to.
+func (g DeliveryGuarantee) kafkaRequiredAcks() kafka.RequiredAcks {
+	if g.enforced() {
+		return kafka.RequireAll
+	}
+	return kafka.RequireOne
+}
@@ -3,6 +3,7 @@ package event
 import (
 	"testing"
 
+	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,3 +51,37 @@ func TestDeliveryGuarantee_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestDeliveryGuarantee_kafkaRequiredAcks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		g            DeliveryGuarantee
+		wantEnforced bool
+		wantAcks     kafka.RequiredAcks
+	}{
+		{
+			name:     "Default",
+			g:        DefaultDeliveryGuarantee,
+			wantAcks: kafka.RequireOne,
+		},
+		{
+			name:     "BestEffort",
+			g:        BestEffort,
+			wantAcks: kafka.RequireOne,
+		},
+		{
+			name:         "Enforced",
+			g:            Enforced,
+			wantEnforced: true,
+			wantAcks:     kafka.RequireAll,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.wantEnforced, tt.g.enforced())
+			assert.Equal(tt.wantAcks, tt.g.kafkaRequiredAcks())
+		})
+	}
+}
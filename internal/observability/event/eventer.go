@@ -49,6 +49,12 @@ type flushable interface {
 	FlushAll(ctx context.Context) error
 }
 
+// closable defines an interface for nodes which hold resources, such as
+// connections, that must be released once the eventer is no longer used.
+type closable interface {
+	Close(ctx context.Context) error
+}
+
 // broker defines an interface for an eventlogger Broker... which will allow us
 // to substitute our testing broker when needed to write tests for things
 // like event send retrying.
@@ -59,6 +65,7 @@ type broker interface {
 	RegisterNode(id eventlogger.NodeID, node eventlogger.Node) error
 	SetSuccessThreshold(t eventlogger.EventType, successThreshold int) error
 	RegisterPipeline(def eventlogger.Pipeline) error
+	RemovePipeline(t eventlogger.EventType, id eventlogger.PipelineID) error
 }
 
 // queuedEvent stores an event and the context that was associated with it when
@@ -72,12 +79,19 @@ type queuedEvent struct {
 type Eventer struct {
 	broker               broker
 	flushableNodes       []flushable
+	closableNodes        []closable
 	conf                 EventerConfig
 	logger               hclog.Logger
 	auditPipelines       []pipeline
 	observationPipelines []pipeline
 	errPipelines         []pipeline
 	auditWrapperNodes    []interface{}
+	serverName           string
+
+	// tail holds the tail subscribers, and is created when the tail
+	// nodes are registered the first time the eventer is tailed.
+	tail     *tailSubscribers
+	tailLock *sync.Mutex
 
 	// Gating is used to delay output of events until after we have a chance to
 	// render startup info, similar to what was done for hclog before eventing
@@ -93,6 +107,8 @@ type pipeline struct {
 	sinkId          eventlogger.NodeID
 	gateId          eventlogger.NodeID
 	encryptFilterId eventlogger.NodeID
+	chainFilterId   eventlogger.NodeID
+	samplingId      eventlogger.NodeID
 	sinkConfig      *SinkConfig
 }
 
@@ -208,10 +224,12 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 
 	e := &Eventer{
 		gatedQueueLock:    new(sync.Mutex),
+		tailLock:          new(sync.Mutex),
 		logger:            log,
 		conf:              c,
 		broker:            b,
 		auditWrapperNodes: []interface{}{},
+		serverName:        serverName,
 	}
 
 	if !opts.withNow.IsZero() {
@@ -233,6 +251,12 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 	// reused.
 	allSinkFilenames := map[string]bool{}
 
+	// flushableSinks are the sinks which buffer events and need flushing
+	var flushableSinks []flushable
+	// closableSinks are the sinks which hold connections that are closed
+	// with the eventer
+	var closableSinks []closable
+
 	for _, s := range c.Sinks {
 		fmtId, fmtNode, err := newFmtFilterNode(serverName, *s, opt...)
 		e.auditWrapperNodes = append(e.auditWrapperNodes, fmtNode)
@@ -246,6 +270,9 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 
 		var sinkId eventlogger.NodeID
 		var sinkNode eventlogger.Node
+		// fileNode is set for file sinks, whose chained audit events can be
+		// read back when the sink is created
+		var fileNode *fileSink
 		switch s.Type {
 		case StderrSink:
 			sinkNode = &writer.Sink{
@@ -263,14 +290,11 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				return nil, fmt.Errorf("%s: duplicate file sink: %s %s: %w", op, fsc.Path, fsc.FileName, ErrInvalidParameter)
 			}
 			allSinkFilenames[fsc.Path+fsc.FileName] = true
-			sinkNode = &eventlogger.FileSink{
-				Format:      string(s.Format),
-				Path:        fsc.Path,
-				FileName:    fsc.FileName,
-				MaxBytes:    fsc.RotateBytes,
-				MaxDuration: fsc.RotateDuration,
-				MaxFiles:    fsc.RotateMaxFiles,
+			fileNode, err = newFileSink(fsc, s.Format)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
 			}
+			sinkNode = fileNode
 			id, err := NewId(fmt.Sprintf("file_%s_%s_", fsc.Path, fsc.FileName))
 			if err != nil {
 				return nil, fmt.Errorf("%s: %w", op, err)
@@ -287,6 +311,45 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
 			sinkId = eventlogger.NodeID(id)
+		case SyslogSink:
+			sinkNode, err = newSyslogSink(s.SyslogConfig, s.Format)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			id, err := NewId("syslog")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
+		case HttpSink:
+			httpNode, err := newHttpSink(s.HttpConfig, s.Format)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			// http sinks buffer events, so they're flushed after the gated
+			// nodes which feed them
+			flushableSinks = append(flushableSinks, httpNode)
+			sinkNode = httpNode
+			id, err := NewId("http")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
+		case KafkaSink:
+			kafkaNode, err := newKafkaSink(s.KafkaConfig, s.Format)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			// kafka sinks buffer events unless delivery is enforced, so
+			// they're flushed after the gated nodes which feed them
+			flushableSinks = append(flushableSinks, kafkaNode)
+			closableSinks = append(closableSinks, kafkaNode)
+			sinkNode = kafkaNode
+			id, err := NewId("kafka")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
 		default:
 			return nil, fmt.Errorf("%s: unknown sink type %s", op, s.Type)
 		}
@@ -294,6 +357,23 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to register sink node %s: %w", op, sinkId, err)
 		}
+		var samplingId eventlogger.NodeID
+		if s.SamplingConfig != nil && len(s.SamplingConfig.Rules) > 0 {
+			samplingNode, err := newSamplingFilter(s.Name, s.SamplingConfig, e.writeSamplingSummary)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			// sampling filters report the events they've dropped when flushed
+			flushableSinks = append(flushableSinks, samplingNode)
+			id, err := NewId("sampling")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			samplingId = eventlogger.NodeID(id)
+			if err := e.broker.RegisterNode(samplingId, samplingNode); err != nil {
+				return nil, fmt.Errorf("%s: unable to register sampling filter node: %w", op, err)
+			}
+		}
 		var addToAudit, addToObservation, addToErr, addToSys bool
 		for _, t := range s.EventTypes {
 			switch t {
@@ -314,10 +394,16 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		}
 		if addToAudit {
 			var fop AuditFilterOperations
+			auditOpts := []Option{WithAuditWrapper(opts.withAuditWrapper)}
 			if s.AuditConfig != nil {
 				fop = s.AuditConfig.FilterOverrides
+				auditOpts = append(auditOpts, WithFieldOverrides(s.AuditConfig.FieldOverrides))
+				if s.AuditConfig.HashChain {
+					auditOpts = append(auditOpts, WithHashChain(s.AuditConfig.HashChainCheckpointInterval))
+				}
 			}
-			s.AuditConfig, err = NewAuditConfig(WithAuditWrapper(opts.withAuditWrapper), WithFilterOperations(fop))
+			auditOpts = append(auditOpts, WithFilterOperations(fop))
+			s.AuditConfig, err = NewAuditConfig(auditOpts...)
 			if err != nil {
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
@@ -333,19 +419,59 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				}
 				encryptFilter.FilterOperationOverrides = overrides
 			}
+			var encryptNode eventlogger.Node = encryptFilter
+			if len(s.AuditConfig.FieldOverrides) > 0 {
+				encryptNode, err = newAuditFieldOverrideFilter(encryptFilter, s.AuditConfig.FieldOverrides)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+			}
 			id, err := NewId("encrypt-audit")
 			if err != nil {
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
 			encryptFilterId := eventlogger.NodeID(id)
-			if err := b.RegisterNode(encryptFilterId, encryptFilter); err != nil {
+			if err := b.RegisterNode(encryptFilterId, encryptNode); err != nil {
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
+			var chainFilterId eventlogger.NodeID
+			if s.AuditConfig.HashChain {
+				interval := s.AuditConfig.HashChainCheckpointInterval
+				if interval == 0 {
+					interval = DefaultAuditChainCheckpointInterval
+				}
+				// a file sink's new chain continues the chain it last wrote
+				var head *auditChainLink
+				if fileNode != nil {
+					files, err := fileNode.writtenFiles()
+					if err != nil {
+						return nil, fmt.Errorf("%s: %w", op, err)
+					}
+					head, err = lastAuditChainLink(files...)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %w", op, err)
+					}
+				}
+				chainFilter, err := newAuditChainFilter(s.Format, interval, opts.withAuditWrapper, head)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				e.auditWrapperNodes = append(e.auditWrapperNodes, chainFilter)
+				id, err := NewId("chain-audit")
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				chainFilterId = eventlogger.NodeID(id)
+				if err := b.RegisterNode(chainFilterId, chainFilter); err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+			}
 			auditPipelines = append(auditPipelines, pipeline{
 				eventType:       AuditType,
 				fmtId:           fmtId,
 				sinkId:          sinkId,
 				encryptFilterId: encryptFilterId,
+				chainFilterId:   chainFilterId,
 				sinkConfig:      s,
 			})
 		}
@@ -354,6 +480,7 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				eventType:  ObservationType,
 				fmtId:      fmtId,
 				sinkId:     sinkId,
+				samplingId: samplingId,
 				sinkConfig: s,
 			})
 		}
@@ -402,11 +529,16 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
+		// order of nodes is important!  gate (aggregate), then encrypt, then
+		// filter/format, then chain (if enabled), then write to sink
+		nodeIds := []eventlogger.NodeID{p.gateId, p.encryptFilterId, p.fmtId}
+		if p.chainFilterId != "" {
+			nodeIds = append(nodeIds, p.chainFilterId)
+		}
 		err = e.broker.RegisterPipeline(eventlogger.Pipeline{
 			EventType:  eventlogger.EventType(p.eventType),
 			PipelineID: eventlogger.PipelineID(pipeId),
-			// order of nodes is important!  gate (aggregate), then filter/format, then encrypt, then write to sink
-			NodeIDs: []eventlogger.NodeID{p.gateId, p.encryptFilterId, p.fmtId, p.sinkId},
+			NodeIDs:    append(nodeIds, p.sinkId),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to register audit pipeline: %w", op, err)
@@ -433,11 +565,16 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
+		// order of nodes is important!  gate (aggregate), then sample (if
+		// enabled), then filter/format, then write to sink
+		nodeIds := []eventlogger.NodeID{p.gateId}
+		if p.samplingId != "" {
+			nodeIds = append(nodeIds, p.samplingId)
+		}
 		err = e.broker.RegisterPipeline(eventlogger.Pipeline{
 			EventType:  eventlogger.EventType(p.eventType),
 			PipelineID: eventlogger.PipelineID(pipeId),
-			// order of nodes is important!  gate (aggregate), then filter/format, then write to sink
-			NodeIDs: []eventlogger.NodeID{p.gateId, p.fmtId, p.sinkId},
+			NodeIDs:    append(nodeIds, p.fmtId, p.sinkId),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to register observation pipeline: %w", op, err)
@@ -496,6 +633,9 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		return nil, fmt.Errorf("%s: failed to set success threshold for sysevents: %w", op, err)
 	}
 
+	e.flushableNodes = append(e.flushableNodes, flushableSinks...)
+	e.closableNodes = append(e.closableNodes, closableSinks...)
+
 	e.auditPipelines = append(e.auditPipelines, auditPipelines...)
 	e.errPipelines = append(e.errPipelines, errPipelines...)
 	e.observationPipelines = append(e.observationPipelines, observationPipelines...)
@@ -584,6 +724,8 @@ func (e *Eventer) RotateAuditWrapper(ctx context.Context, newWrapper wrapping.Wr
 			w.Rotate(newWrapper)
 		case *encrypt.Filter:
 			w.Rotate(encrypt.WithWrapper(newWrapper))
+		case *auditChainFilter:
+			w.Rotate(newWrapper)
 		default:
 			return fmt.Errorf("%s: unsupported node type (%s): %w", op, reflect.TypeOf(w), ErrInvalidParameter)
 		}
@@ -673,6 +815,20 @@ func (e *Eventer) writeSysEvent(ctx context.Context, event *sysEvent, opt ...Opt
 	return nil
 }
 
+// writeSamplingSummary writes/sends a sysEvent which summarizes the events
+// dropped by a sink's sampling filter
+func (e *Eventer) writeSamplingSummary(ctx context.Context, data map[string]interface{}) error {
+	const op = "event.(Eventer).writeSamplingSummary"
+	id, err := NewId(string(SystemType))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := e.writeSysEvent(ctx, &sysEvent{Id: Id(id), Version: sysVersion, Op: samplingSummaryOp, Data: data}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // writeAudit writes/send an audit event
 func (e *Eventer) writeAudit(ctx context.Context, event *audit, _ ...Option) error {
 	const op = "event.(Eventer).writeAudit"
@@ -713,6 +869,26 @@ func (e *Eventer) FlushNodes(ctx context.Context) error {
 	return nil
 }
 
+// Close flushes the eventer's flushable nodes and then closes any of its
+// nodes which hold connections.  It needs to be called once Boundary has
+// stopped and the eventer will no longer be used.
+func (e *Eventer) Close(ctx context.Context) error {
+	const op = "event.(Eventer).Close"
+	var errs error
+	if err := e.FlushNodes(ctx); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	for _, n := range e.closableNodes {
+		if err := n.Close(ctx); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("%s: %w", op, errs)
+	}
+	return nil
+}
+
 // ReleaseGate releases queued events. If any event isn't successfully written,
 // it remains in the queue and we could try a flush later.
 func (e *Eventer) ReleaseGate() error {
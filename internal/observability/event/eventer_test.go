@@ -99,6 +99,8 @@ func Test_InitSysEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "success-with-config",
 				conf:           testConfig.EventerConfig,
 			},
 		},
@@ -111,6 +113,8 @@ func Test_InitSysEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "success-with-default-config",
 				conf: EventerConfig{
 					Sinks: []*SinkConfig{
 						{
@@ -412,6 +416,8 @@ func Test_NewEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "valid-audit-config",
 				conf: EventerConfig{
 					AuditEnabled: true,
 					Sinks: []*SinkConfig{
@@ -499,6 +505,8 @@ func Test_NewEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "success-with-default-config",
 				conf: EventerConfig{
 					Sinks: []*SinkConfig{
 						{
@@ -540,6 +548,8 @@ func Test_NewEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "testSetup",
 				conf:           testSetup.EventerConfig,
 			},
 			wantRegistered: []string{
@@ -583,6 +593,8 @@ func Test_NewEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "testSetup-with-all-opts",
 				conf:           testSetupWithOpts.EventerConfig,
 			},
 			wantRegistered: []string{
@@ -638,6 +650,8 @@ func Test_NewEventer(t *testing.T) {
 			want: &Eventer{
 				logger:         testLogger,
 				gatedQueueLock: new(sync.Mutex),
+				tailLock:       new(sync.Mutex),
+				serverName:     "testSetup",
 				conf:           testHclogSetup.EventerConfig,
 			},
 			wantRegistered: []string{
@@ -1175,6 +1189,9 @@ func TestEventer_RotateAuditWrapper(t *testing.T) {
 
 	cloudEventsConfig := TestEventerConfig(t, "TestEventer_RotateAuditWrapper")
 	hclogConfig := TestEventerConfig(t, "TestEventer_RotateAuditWrapper", testWithSinkFormat(t, JSONHclogSinkFormat))
+	hashChainConfig := TestEventerConfig(t, "TestEventer_RotateAuditWrapper")
+	hashChainConfig.EventerConfig.Sinks[0].AuditConfig.HashChain = true
+	hashChainConfig.EventerConfig.Sinks[0].EventTypes = []Type{AuditType}
 
 	testLock := &sync.Mutex{}
 	testLogger := hclog.New(&hclog.LoggerOptions{
@@ -1206,6 +1223,11 @@ func TestEventer_RotateAuditWrapper(t *testing.T) {
 			w:      testWrapper(t),
 			config: hclogConfig,
 		},
+		{
+			name:   "valid-hash-chain",
+			w:      testWrapper(t),
+			config: hashChainConfig,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1229,6 +1251,8 @@ func TestEventer_RotateAuditWrapper(t *testing.T) {
 					assert.NotNil(w.Signer)
 				case *encrypt.Filter:
 					assert.NotNil(w.Wrapper)
+				case *auditChainFilter:
+					assert.Equal(tt.w, w.wrapper)
 				}
 			}
 		})
@@ -26,25 +26,30 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withId               string
-	withDetails          map[string]interface{}
-	withHeader           map[string]interface{}
-	withFlush            bool
-	withInfo             map[string]interface{}
-	withRequestInfo      *RequestInfo
-	withNow              time.Time
-	withRequest          *Request
-	withResponse         *Response
-	withAuth             *Auth
-	withEventer          *Eventer
-	withEventerConfig    *EventerConfig
-	withAllow            []string
-	withDeny             []string
-	withSchema           *url.URL
-	withAuditWrapper     wrapping.Wrapper
-	withFilterOperations AuditFilterOperations
-	withGating           bool
-	withNoGateLocking    bool
+	withId                          string
+	withDetails                     map[string]interface{}
+	withHeader                      map[string]interface{}
+	withFlush                       bool
+	withInfo                        map[string]interface{}
+	withRequestInfo                 *RequestInfo
+	withNow                         time.Time
+	withRequest                     *Request
+	withResponse                    *Response
+	withAuth                        *Auth
+	withAuditType                   AuditEventType
+	withConnection                  *Connection
+	withEventer                     *Eventer
+	withEventerConfig               *EventerConfig
+	withAllow                       []string
+	withDeny                        []string
+	withSchema                      *url.URL
+	withAuditWrapper                wrapping.Wrapper
+	withFilterOperations            AuditFilterOperations
+	withFieldOverrides              AuditFieldOverrides
+	withHashChain                   bool
+	withHashChainCheckpointInterval time.Duration
+	withGating                      bool
+	withNoGateLocking               bool
 
 	// These options are related to the hclog adapter
 	withHclogLevel hclog.Level
@@ -155,6 +160,21 @@ func WithAuth(a *Auth) Option {
 	}
 }
 
+// WithAuditType allows an optional audit event type, which defaults to
+// ApiRequest
+func WithAuditType(t AuditEventType) Option {
+	return func(o *options) {
+		o.withAuditType = t
+	}
+}
+
+// WithConnection allows an optional worker connection
+func WithConnection(c *Connection) Option {
+	return func(o *options) {
+		o.withConnection = c
+	}
+}
+
 // WithEventer allows an optional eventer
 func WithEventer(e *Eventer) Option {
 	return func(o *options) {
@@ -204,6 +224,22 @@ func WithFilterOperations(fop AuditFilterOperations) Option {
 	}
 }
 
+// WithFieldOverrides is an optional set of per field classification overrides
+func WithFieldOverrides(fo AuditFieldOverrides) Option {
+	return func(o *options) {
+		o.withFieldOverrides = fo
+	}
+}
+
+// WithHashChain provides an option to enable chaining of audit events, with
+// signed checkpoints of the chain written at the given interval.
+func WithHashChain(checkpointInterval time.Duration) Option {
+	return func(o *options) {
+		o.withHashChain = true
+		o.withHashChainCheckpointInterval = checkpointInterval
+	}
+}
+
 // WithHclogLevel is an option to specify a log level if using the adapter
 func WithHclogLevel(with hclog.Level) Option {
 	return func(o *options) {
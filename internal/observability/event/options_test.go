@@ -115,6 +115,21 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withAuth = auth
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAuditType", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAuditType(ConnectionAuthorized))
+		testOpts := getDefaultOptions()
+		testOpts.withAuditType = ConnectionAuthorized
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithConnection", func(t *testing.T) {
+		assert := assert.New(t)
+		c := testConnection(t)
+		opts := getOpts(WithConnection(c))
+		testOpts := getDefaultOptions()
+		testOpts.withConnection = c
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithEventer", func(t *testing.T) {
 		assert := assert.New(t)
 		eventer := Eventer{}
@@ -172,6 +187,22 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withFilterOperations = overrides
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithFieldOverrides", func(t *testing.T) {
+		assert := assert.New(t)
+		overrides := AuditFieldOverrides{"Group.name": SensitiveClassification}
+		opts := getOpts(WithFieldOverrides(overrides))
+		testOpts := getDefaultOptions()
+		testOpts.withFieldOverrides = overrides
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHashChain", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithHashChain(time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withHashChain = true
+		testOpts.withHashChainCheckpointInterval = time.Hour
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithHclogLevel", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithHclogLevel(hclog.Info))
@@ -0,0 +1,135 @@
+package event
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// DefaultSamplingSummaryInterval is how often the number of events dropped by
+// a sink's sampling rules is reported, when no summary interval is configured
+const DefaultSamplingSummaryInterval = time.Minute
+
+// SamplingConfig defines optional sampling and rate limiting of the events
+// sent to a sink. Events which are dropped are counted, and a system event
+// summarizing the counts is sent at most once per SummaryInterval.
+type SamplingConfig struct {
+	// Rules are the sampling rules of the sink. The first rule which
+	// matches an event is applied to it, and events which don't match any
+	// rule are always sent. In HCL, each rule is a "rule" block within the
+	// "sampling_config" block.
+	Rules []*SamplingRule `hcl:"-"`
+
+	// SummaryInterval is how often the number of dropped events is reported.
+	// Defaults to DefaultSamplingSummaryInterval.
+	SummaryInterval    time.Duration `hcl:"-"`
+	SummaryIntervalHCL string        `hcl:"summary_interval"`
+}
+
+// SamplingRule defines the sampling rate and rate limit of events of the
+// given types and operation. Only observations can be sampled.
+type SamplingRule struct {
+	// EventTypes are the types of events the rule applies to, which may only
+	// be ObservationType or EveryType.
+	EventTypes []Type `hcl:"event_types"`
+
+	// Operation is a pattern matched against the event's operation, using
+	// the syntax of path.Match. For observations with request info, the
+	// operation is the request's method and path (e.g. "GET /v1/targets"),
+	// and for other observations it's their op. If empty, every operation
+	// matches.
+	Operation string `hcl:"operation"`
+
+	// SampleRate is the fraction of matching events which are sent, between
+	// 0 and 1, so zero drops every matching event. If unset, every event is
+	// sent (subject to the rate limit).
+	SampleRate *float64 `hcl:"sample_rate"`
+
+	// RateLimit is the maximum number of events per second which are sent
+	// for each event type and operation matching the rule, as a token
+	// bucket.  If zero, the events aren't rate limited.
+	RateLimit float64 `hcl:"rate_limit"`
+
+	// RateLimitBurst is the size of the token bucket. Defaults to the rate
+	// limit, rounded up.
+	RateLimitBurst int `hcl:"rate_limit_burst"`
+}
+
+// Validate the SamplingConfig
+func (c *SamplingConfig) Validate() error {
+	const op = "event.(SamplingConfig).Validate"
+	if c.SummaryInterval < 0 {
+		return fmt.Errorf("%s: summary interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	for i, r := range c.Rules {
+		if r == nil {
+			return fmt.Errorf("%s: rule %d is missing: %w", op, i, ErrInvalidParameter)
+		}
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("%s: invalid rule %d: %w", op, i, err)
+		}
+	}
+	return nil
+}
+
+// Validate the SamplingRule
+func (r *SamplingRule) Validate() error {
+	const op = "event.(SamplingRule).Validate"
+	if len(r.EventTypes) == 0 {
+		return fmt.Errorf("%s: missing event types: %w", op, ErrInvalidParameter)
+	}
+	for _, t := range r.EventTypes {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		switch t {
+		case AuditType:
+			return fmt.Errorf("%s: audit events can't be sampled: %w", op, ErrInvalidParameter)
+		case ObservationType, EveryType:
+		default:
+			return fmt.Errorf("%s: only observation events can be sampled: %w", op, ErrInvalidParameter)
+		}
+	}
+	if _, err := path.Match(r.Operation, ""); err != nil {
+		return fmt.Errorf("%s: invalid operation pattern %q: %w", op, r.Operation, ErrInvalidParameter)
+	}
+	if r.SampleRate != nil && (*r.SampleRate < 0 || *r.SampleRate > 1) {
+		return fmt.Errorf("%s: sample rate must be between 0 and 1: %w", op, ErrInvalidParameter)
+	}
+	if r.RateLimit < 0 {
+		return fmt.Errorf("%s: rate limit must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if r.RateLimitBurst < 0 {
+		return fmt.Errorf("%s: rate limit burst must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if r.RateLimitBurst > 0 && r.RateLimit == 0 {
+		return fmt.Errorf("%s: rate limit burst requires a rate limit: %w", op, ErrInvalidParameter)
+	}
+	if r.SampleRate == nil && r.RateLimit == 0 {
+		return fmt.Errorf("%s: missing sample rate or rate limit: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// matches returns true if the rule applies to events of the type and
+// operation
+func (r *SamplingRule) matches(t Type, operation string) bool {
+	if t == AuditType {
+		return false
+	}
+	var typeMatched bool
+	for _, rt := range r.EventTypes {
+		if rt == EveryType || rt == t {
+			typeMatched = true
+			break
+		}
+	}
+	if !typeMatched {
+		return false
+	}
+	if r.Operation == "" {
+		return true
+	}
+	matched, _ := path.Match(r.Operation, operation)
+	return matched
+}
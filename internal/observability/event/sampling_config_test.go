@@ -0,0 +1,172 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamplingConfig_Validate(t *testing.T) {
+	t.Parallel()
+	rate := func(r float64) *float64 { return &r }
+	tests := []struct {
+		name            string
+		c               SamplingConfig
+		wantErrContains string
+	}{
+		{
+			name:            "negative-summary-interval",
+			c:               SamplingConfig{SummaryInterval: -time.Second},
+			wantErrContains: "summary interval must not be negative",
+		},
+		{
+			name:            "nil-rule",
+			c:               SamplingConfig{Rules: []*SamplingRule{nil}},
+			wantErrContains: "rule 0 is missing",
+		},
+		{
+			name:            "missing-event-types",
+			c:               SamplingConfig{Rules: []*SamplingRule{{SampleRate: rate(0.5)}}},
+			wantErrContains: "missing event types",
+		},
+		{
+			name:            "invalid-event-type",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{"bad"}, SampleRate: rate(0.5)}}},
+			wantErrContains: "'bad' is not a valid event type",
+		},
+		{
+			name:            "audit",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{AuditType}, SampleRate: rate(0.5)}}},
+			wantErrContains: "audit events can't be sampled",
+		},
+		{
+			name:            "error",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType, ErrorType}, SampleRate: rate(0.5)}}},
+			wantErrContains: "only observation events can be sampled",
+		},
+		{
+			name:            "invalid-operation",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, Operation: "GET /v1/[", SampleRate: rate(0.5)}}},
+			wantErrContains: "invalid operation pattern",
+		},
+		{
+			name:            "sample-rate-too-large",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(1.5)}}},
+			wantErrContains: "sample rate must be between 0 and 1",
+		},
+		{
+			name:            "negative-sample-rate",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(-0.5)}}},
+			wantErrContains: "sample rate must be between 0 and 1",
+		},
+		{
+			name:            "negative-rate-limit",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, RateLimit: -1}}},
+			wantErrContains: "rate limit must not be negative",
+		},
+		{
+			name:            "negative-burst",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, RateLimit: 1, RateLimitBurst: -1}}},
+			wantErrContains: "rate limit burst must not be negative",
+		},
+		{
+			name:            "burst-without-rate-limit",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5), RateLimitBurst: 1}}},
+			wantErrContains: "rate limit burst requires a rate limit",
+		},
+		{
+			name:            "missing-rate",
+			c:               SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}}}},
+			wantErrContains: "missing sample rate or rate limit",
+		},
+		{
+			name: "drop-all",
+			c:    SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(0)}}},
+		},
+		{
+			name: "valid",
+			c: SamplingConfig{
+				SummaryInterval: time.Minute,
+				Rules: []*SamplingRule{
+					{EventTypes: []Type{ObservationType}, Operation: "GET /v1/*", SampleRate: rate(0.1)},
+					{EventTypes: []Type{EveryType}, RateLimit: 10, RateLimitBurst: 20},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := tt.c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSamplingRule_matches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		r         SamplingRule
+		eventType Type
+		operation string
+		want      bool
+	}{
+		{
+			name:      "type-and-operation",
+			r:         SamplingRule{EventTypes: []Type{ObservationType}, Operation: "GET /v1/targets"},
+			eventType: ObservationType,
+			operation: "GET /v1/targets",
+			want:      true,
+		},
+		{
+			name:      "pattern",
+			r:         SamplingRule{EventTypes: []Type{ObservationType}, Operation: "GET /v1/*"},
+			eventType: ObservationType,
+			operation: "GET /v1/targets",
+			want:      true,
+		},
+		{
+			name:      "pattern-not-matched",
+			r:         SamplingRule{EventTypes: []Type{ObservationType}, Operation: "GET /v1/*"},
+			eventType: ObservationType,
+			operation: "GET /v1/targets/ttcp_1234567890",
+		},
+		{
+			name:      "any-operation",
+			r:         SamplingRule{EventTypes: []Type{ErrorType}},
+			eventType: ErrorType,
+			operation: "session.(Repository).CancelSession",
+			want:      true,
+		},
+		{
+			name:      "other-type",
+			r:         SamplingRule{EventTypes: []Type{ObservationType}},
+			eventType: SystemType,
+		},
+		{
+			name:      "every-type",
+			r:         SamplingRule{EventTypes: []Type{EveryType}},
+			eventType: SystemType,
+			want:      true,
+		},
+		{
+			name:      "every-type-not-audit",
+			r:         SamplingRule{EventTypes: []Type{EveryType}},
+			eventType: AuditType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.matches(tt.eventType, tt.operation))
+		})
+	}
+}
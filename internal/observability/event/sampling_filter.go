@@ -0,0 +1,300 @@
+package event
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/gated"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// samplingSummaryOp is the op of the system events which summarize the
+	// events dropped by a sink's sampling rules.
+	samplingSummaryOp Op = "event.(samplingFilter).summarize"
+
+	// maxSamplingLimiters is the maximum number of rate limiters kept by a
+	// sampling filter.  Operations may include ids, so the least recently
+	// used limiter is evicted when there are more than this, rather than
+	// growing without bound.
+	maxSamplingLimiters = 10000
+
+	// maxSamplingDropped is the maximum number of operations whose drops are
+	// counted separately between summaries.  Drops of any further operations
+	// are counted under samplingOtherOperation.
+	maxSamplingDropped = 1000
+
+	// samplingOtherOperation is the operation under which drops are counted
+	// once maxSamplingDropped operations have been counted.
+	samplingOtherOperation = "(other)"
+)
+
+// samplingSummaryFunc sends a summary of the events dropped by a sampling
+// filter
+type samplingSummaryFunc func(ctx context.Context, data map[string]interface{}) error
+
+// samplingKey identifies the events which share a rate limiter and whose
+// drops are counted together
+type samplingKey struct {
+	eventType Type
+	operation string
+}
+
+// samplingCounts are the number of events dropped for a samplingKey
+type samplingCounts struct {
+	sampled     uint64
+	rateLimited uint64
+}
+
+// samplingLimiter is a rate limiter in the sampling filter's list of
+// limiters, which is ordered from the most to the least recently used.
+type samplingLimiter struct {
+	key     samplingKey
+	limiter *rate.Limiter
+}
+
+// samplingFilter is an eventlogger filter node which drops events according
+// to a sink's sampling rules.  The dropped events are counted, and the counts
+// are summarized once the summary interval has passed since the first drop.
+type samplingFilter struct {
+	sinkName        string
+	rules           []*SamplingRule
+	summaryInterval time.Duration
+	summarize       samplingSummaryFunc
+
+	// random and now are replaced when testing
+	random func() float64
+	now    func() time.Time
+
+	// l protects the limiters, counts and summary timer
+	l            sync.Mutex
+	limiters     map[samplingKey]*list.Element
+	limiterOrder *list.List
+	dropped      map[samplingKey]*samplingCounts
+	timer        *time.Timer
+}
+
+var (
+	_ eventlogger.Node = &samplingFilter{}
+	_ flushable        = &samplingFilter{}
+)
+
+func newSamplingFilter(sinkName string, c *SamplingConfig, summarize samplingSummaryFunc) (*samplingFilter, error) {
+	const op = "event.newSamplingFilter"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing sampling config: %w", op, ErrInvalidParameter)
+	}
+	if summarize == nil {
+		return nil, fmt.Errorf("%s: missing summary func: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	f := &samplingFilter{
+		sinkName:        sinkName,
+		rules:           c.Rules,
+		summaryInterval: c.SummaryInterval,
+		summarize:       summarize,
+		random:          rand.Float64,
+		now:             time.Now,
+		limiters:        map[samplingKey]*list.Element{},
+		limiterOrder:    list.New(),
+		dropped:         map[samplingKey]*samplingCounts{},
+	}
+	if f.summaryInterval == 0 {
+		f.summaryInterval = DefaultSamplingSummaryInterval
+	}
+	return f, nil
+}
+
+// Type describes the type of the node as a Filter.
+func (f *samplingFilter) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeFilter
+}
+
+// Reopen is a no op for sampling filters.
+func (f *samplingFilter) Reopen() error {
+	return nil
+}
+
+// Process applies the first matching sampling rule to the event, returning
+// nil when the event is dropped.
+func (f *samplingFilter) Process(_ context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(samplingFilter).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	operation := samplingOperation(e.Payload)
+	key := samplingKey{eventType: Type(e.Type), operation: operation}
+	var rule *SamplingRule
+	for _, r := range f.rules {
+		if r.matches(key.eventType, key.operation) {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		return e, nil
+	}
+
+	f.l.Lock()
+	defer f.l.Unlock()
+	if rule.SampleRate != nil && f.random() >= *rule.SampleRate {
+		f.drop(key).sampled++
+		return nil, nil
+	}
+	if rule.RateLimit > 0 && !f.limiter(key, rule).AllowN(f.now(), 1) {
+		f.drop(key).rateLimited++
+		return nil, nil
+	}
+	return e, nil
+}
+
+// FlushAll sends a summary of the events dropped since the last summary, if
+// any were dropped.
+func (f *samplingFilter) FlushAll(ctx context.Context) error {
+	const op = "event.(samplingFilter).FlushAll"
+	f.l.Lock()
+	if f.timer != nil {
+		f.timer.Stop()
+		f.timer = nil
+	}
+	dropped := f.dropped
+	f.dropped = map[samplingKey]*samplingCounts{}
+	f.l.Unlock()
+
+	if len(dropped) == 0 {
+		return nil
+	}
+	keys := make([]samplingKey, 0, len(dropped))
+	for k := range dropped {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].eventType != keys[j].eventType {
+			return keys[i].eventType < keys[j].eventType
+		}
+		return keys[i].operation < keys[j].operation
+	})
+	var total uint64
+	summary := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		c := dropped[k]
+		total += c.sampled + c.rateLimited
+		summary = append(summary, map[string]interface{}{
+			"event_type":   string(k.eventType),
+			"operation":    k.operation,
+			"sampled":      c.sampled,
+			"rate_limited": c.rateLimited,
+		})
+	}
+	err := f.summarize(ctx, map[string]interface{}{
+		msgField:  "events dropped by sampling",
+		"sink":    f.sinkName,
+		"total":   total,
+		"dropped": summary,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// drop returns the counts for the key, starting the summary timer if this is
+// the first drop since the last summary.  Once maxSamplingDropped keys are
+// counted, the drops of other keys are counted under samplingOtherOperation.
+// The caller must hold the lock.
+func (f *samplingFilter) drop(key samplingKey) *samplingCounts {
+	if f.timer == nil {
+		f.timer = time.AfterFunc(f.summaryInterval, f.flushSummary)
+	}
+	c, ok := f.dropped[key]
+	if !ok && len(f.dropped) >= maxSamplingDropped {
+		key = samplingKey{eventType: key.eventType, operation: samplingOtherOperation}
+		c, ok = f.dropped[key]
+	}
+	if !ok {
+		c = &samplingCounts{}
+		f.dropped[key] = c
+	}
+	return c
+}
+
+// flushSummary sends the summary once the summary interval has passed,
+// reporting a summary which can't be sent with an error event.
+func (f *samplingFilter) flushSummary() {
+	const op = "event.(samplingFilter).flushSummary"
+	if err := f.FlushAll(context.Background()); err != nil {
+		WriteError(context.Background(), op, err, WithInfoMsg("unable to send summary of events dropped by sampling", "sink", f.sinkName))
+	}
+}
+
+// limiter returns the rate limiter for the key, evicting the least recently
+// used limiter when there are maxSamplingLimiters.  The caller must hold the
+// lock.
+func (f *samplingFilter) limiter(key samplingKey, rule *SamplingRule) *rate.Limiter {
+	if el, ok := f.limiters[key]; ok {
+		f.limiterOrder.MoveToFront(el)
+		return el.Value.(*samplingLimiter).limiter
+	}
+	if f.limiterOrder.Len() >= maxSamplingLimiters {
+		oldest := f.limiterOrder.Back()
+		f.limiterOrder.Remove(oldest)
+		delete(f.limiters, oldest.Value.(*samplingLimiter).key)
+	}
+	burst := rule.RateLimitBurst
+	if burst == 0 {
+		burst = int(math.Ceil(rule.RateLimit))
+	}
+	l := rate.NewLimiter(rate.Limit(rule.RateLimit), burst)
+	f.limiters[key] = f.limiterOrder.PushFront(&samplingLimiter{key: key, limiter: l})
+	return l
+}
+
+// samplingOperation returns the operation of the observation's payload,
+// which sampling rules are matched against.  Observations with request info
+// use the request's method and path, since observations written while
+// handling a request are composed into a single event.
+func samplingOperation(payload interface{}) string {
+	switch p := payload.(type) {
+	case *observation:
+		if op := requestOperation(p.RequestInfo); op != "" {
+			return op
+		}
+		return string(p.Op)
+	case map[string]interface{}:
+		// observations are composed into a map by the gate
+		if info, ok := p[RequestInfoField].(*RequestInfo); ok {
+			if op := requestOperation(info); op != "" {
+				return op
+			}
+		}
+		if details, ok := p[DetailsField].([]gated.EventPayloadDetails); ok && len(details) > 0 {
+			if op, ok := details[0].Payload[OpField].(string); ok {
+				return op
+			}
+		}
+	}
+	return ""
+}
+
+// requestOperation returns the method and path, without its query, of the
+// request info.
+func requestOperation(info *RequestInfo) string {
+	if info == nil || info.Path == "" {
+		return ""
+	}
+	path := info.Path
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return strings.TrimSpace(info.Method + " " + path)
+}
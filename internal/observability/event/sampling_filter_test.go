@@ -0,0 +1,347 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/gated"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newSamplingFilter(t *testing.T) {
+	t.Parallel()
+	rate := func(r float64) *float64 { return &r }
+	summarize := func(context.Context, map[string]interface{}) error { return nil }
+	tests := []struct {
+		name            string
+		c               *SamplingConfig
+		summarize       samplingSummaryFunc
+		wantErrContains string
+		wantInterval    time.Duration
+	}{
+		{
+			name:            "missing-config",
+			summarize:       summarize,
+			wantErrContains: "missing sampling config",
+		},
+		{
+			name:            "missing-summarize",
+			c:               &SamplingConfig{},
+			wantErrContains: "missing summary func",
+		},
+		{
+			name:            "invalid-config",
+			c:               &SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{AuditType}, SampleRate: rate(0.5)}}},
+			summarize:       summarize,
+			wantErrContains: "audit events can't be sampled",
+		},
+		{
+			name:         "default-interval",
+			c:            &SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5)}}},
+			summarize:    summarize,
+			wantInterval: DefaultSamplingSummaryInterval,
+		},
+		{
+			name:         "interval",
+			c:            &SamplingConfig{SummaryInterval: time.Second, Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5)}}},
+			summarize:    summarize,
+			wantInterval: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			f, err := newSamplingFilter("sink-name", tt.c, tt.summarize)
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.Nil(f)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantInterval, f.summaryInterval)
+			assert.Equal(eventlogger.NodeTypeFilter, f.Type())
+		})
+	}
+}
+
+func Test_samplingFilter_Process(t *testing.T) {
+	t.Parallel()
+	rate := func(r float64) *float64 { return &r }
+	ctx := context.Background()
+
+	type summary struct {
+		l    sync.Mutex
+		data []map[string]interface{}
+	}
+	newFilter := func(t *testing.T, s *summary, rules ...*SamplingRule) *samplingFilter {
+		t.Helper()
+		f, err := newSamplingFilter("sink-name", &SamplingConfig{Rules: rules, SummaryInterval: time.Hour}, func(_ context.Context, data map[string]interface{}) error {
+			s.l.Lock()
+			defer s.l.Unlock()
+			s.data = append(s.data, data)
+			return nil
+		})
+		require.NoError(t, err)
+		return f
+	}
+	newObservationEvent := func(op Op) *eventlogger.Event {
+		return &eventlogger.Event{Type: eventlogger.EventType(ObservationType), Payload: &observation{Op: op, Version: observationVersion}}
+	}
+
+	t.Run("missing-event", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, &summary{}, &SamplingRule{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5)})
+		got, err := f.Process(ctx, nil)
+		require.Error(err)
+		assert.Nil(got)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+	t.Run("sampled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := &summary{}
+		f := newFilter(t, s, &SamplingRule{EventTypes: []Type{ObservationType}, Operation: "sampled", SampleRate: rate(0.5)})
+		for _, r := range []float64{0.1, 0.5, 0.9, 0.4} {
+			f.random = func() float64 { return r }
+			got, err := f.Process(ctx, newObservationEvent("sampled"))
+			require.NoError(err)
+			assert.Equal(r < 0.5, got != nil, "random %v", r)
+		}
+		// events which don't match a rule are always sent
+		f.random = func() float64 { return 0.9 }
+		got, err := f.Process(ctx, newObservationEvent("not-sampled"))
+		require.NoError(err)
+		assert.NotNil(got)
+
+		require.NoError(f.FlushAll(ctx))
+		require.Len(s.data, 1)
+		assert.Equal("sink-name", s.data[0]["sink"])
+		assert.Equal(uint64(2), s.data[0]["total"])
+		assert.Equal([]interface{}{
+			map[string]interface{}{"event_type": "observation", "operation": "sampled", "sampled": uint64(2), "rate_limited": uint64(0)},
+		}, s.data[0]["dropped"])
+
+		// nothing's been dropped since the last summary
+		require.NoError(f.FlushAll(ctx))
+		assert.Len(s.data, 1)
+	})
+	t.Run("sample-rate-zero", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, &summary{}, &SamplingRule{EventTypes: []Type{ObservationType}, SampleRate: rate(0)})
+		f.random = func() float64 { return 0 }
+		got, err := f.Process(ctx, newObservationEvent("dropped"))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("rate-limited", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := &summary{}
+		f := newFilter(t, s, &SamplingRule{EventTypes: []Type{EveryType}, RateLimit: 1, RateLimitBurst: 2})
+		now := time.Now()
+		f.now = func() time.Time { return now }
+		var sent int
+		for i := 0; i < 5; i++ {
+			got, err := f.Process(ctx, newObservationEvent("limited"))
+			require.NoError(err)
+			if got != nil {
+				sent++
+			}
+		}
+		assert.Equal(2, sent)
+		// each operation has its own bucket
+		got, err := f.Process(ctx, newObservationEvent("other"))
+		require.NoError(err)
+		assert.NotNil(got)
+		// the bucket refills at the rate limit
+		now = now.Add(time.Second)
+		got, err = f.Process(ctx, newObservationEvent("limited"))
+		require.NoError(err)
+		assert.NotNil(got)
+
+		require.NoError(f.FlushAll(ctx))
+		require.Len(s.data, 1)
+		assert.Equal([]interface{}{
+			map[string]interface{}{"event_type": "observation", "operation": "limited", "sampled": uint64(0), "rate_limited": uint64(3)},
+		}, s.data[0]["dropped"])
+	})
+	t.Run("first-matching-rule", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, &summary{},
+			&SamplingRule{EventTypes: []Type{ObservationType}, Operation: "kept", SampleRate: rate(1)},
+			&SamplingRule{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5)},
+		)
+		f.random = func() float64 { return 0.9 }
+		got, err := f.Process(ctx, newObservationEvent("kept"))
+		require.NoError(err)
+		assert.NotNil(got)
+		got, err = f.Process(ctx, newObservationEvent("dropped"))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("limiters-evicted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		f := newFilter(t, &summary{}, &SamplingRule{EventTypes: []Type{ObservationType}, RateLimit: 1, RateLimitBurst: 1})
+		now := time.Now()
+		f.now = func() time.Time { return now }
+		got, err := f.Process(ctx, newObservationEvent("limited"))
+		require.NoError(err)
+		assert.NotNil(got)
+		for i := 1; i < maxSamplingLimiters; i++ {
+			_, err := f.Process(ctx, newObservationEvent(Op(fmt.Sprintf("op-%d", i))))
+			require.NoError(err)
+			// keep the first limiter recently used
+			if i%1000 == 0 {
+				got, err := f.Process(ctx, newObservationEvent("limited"))
+				require.NoError(err)
+				assert.Nil(got)
+			}
+		}
+		_, err = f.Process(ctx, newObservationEvent("evicts-op-1"))
+		require.NoError(err)
+		assert.Len(f.limiters, maxSamplingLimiters)
+		assert.Equal(maxSamplingLimiters, f.limiterOrder.Len())
+		assert.NotContains(f.limiters, samplingKey{eventType: ObservationType, operation: "op-1"})
+		// the recently used limiter is kept, so its bucket is still empty
+		got, err = f.Process(ctx, newObservationEvent("limited"))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("dropped-capped", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := &summary{}
+		f := newFilter(t, s, &SamplingRule{EventTypes: []Type{ObservationType}, SampleRate: rate(0)})
+		for i := 0; i < maxSamplingDropped+2; i++ {
+			_, err := f.Process(ctx, newObservationEvent(Op(fmt.Sprintf("op-%d", i))))
+			require.NoError(err)
+		}
+		assert.Len(f.dropped, maxSamplingDropped+1)
+		assert.Equal(uint64(2), f.dropped[samplingKey{eventType: ObservationType, operation: samplingOtherOperation}].sampled)
+
+		require.NoError(f.FlushAll(ctx))
+		require.Len(s.data, 1)
+		assert.Equal(uint64(maxSamplingDropped+2), s.data[0]["total"])
+	})
+	t.Run("summary-interval", func(t *testing.T) {
+		require := require.New(t)
+		s := &summary{}
+		f := newFilter(t, s, &SamplingRule{EventTypes: []Type{ObservationType}, SampleRate: rate(0.5)})
+		f.summaryInterval = 10 * time.Millisecond
+		f.random = func() float64 { return 0.9 }
+		_, err := f.Process(ctx, newObservationEvent("dropped"))
+		require.NoError(err)
+		require.Eventually(func() bool {
+			s.l.Lock()
+			defer s.l.Unlock()
+			return len(s.data) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func Test_samplingOperation(t *testing.T) {
+	t.Parallel()
+	info := &RequestInfo{Method: "GET", Path: "/v1/targets?scope_id=global"}
+	tests := []struct {
+		name    string
+		payload interface{}
+		want    string
+	}{
+		{name: "sys", payload: &sysEvent{Op: "sys-op"}},
+		{name: "observation", payload: &observation{Op: "obs-op"}, want: "obs-op"},
+		{name: "observation-request", payload: &observation{Op: "obs-op", RequestInfo: info}, want: "GET /v1/targets"},
+		{name: "composed-observation", payload: map[string]interface{}{RequestInfoField: info}, want: "GET /v1/targets"},
+		{
+			name: "composed-observation-details",
+			payload: map[string]interface{}{
+				DetailsField: []gated.EventPayloadDetails{{Payload: map[string]interface{}{OpField: "detail-op"}}},
+			},
+			want: "detail-op",
+		},
+		{name: "unknown", payload: "payload"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, samplingOperation(tt.payload))
+		})
+	}
+}
+
+func TestEventer_Sampling(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+		Name:  "test",
+	})
+	var buf bytes.Buffer
+	c := EventerConfig{
+		ObservationsEnabled: true,
+		SysEventsEnabled:    true,
+		Sinks: []*SinkConfig{
+			{
+				Name:         "sampled",
+				EventTypes:   []Type{ObservationType, SystemType},
+				Format:       JSONSinkFormat,
+				Type:         WriterSink,
+				WriterConfig: &WriterSinkTypeConfig{Writer: &buf},
+				SamplingConfig: &SamplingConfig{
+					SummaryInterval: time.Hour,
+					Rules: []*SamplingRule{
+						{EventTypes: []Type{ObservationType}, Operation: "GET /v1/*", RateLimit: 1, RateLimitBurst: 1},
+					},
+				},
+			},
+		},
+	}
+	e, err := NewEventer(testLogger, testLock, "TestEventer_Sampling", c)
+	require.NoError(err)
+
+	for i := 0; i < 3; i++ {
+		o, err := newObservation("TestEventer_Sampling",
+			WithRequestInfo(&RequestInfo{Method: "GET", Path: "/v1/targets"}),
+			WithHeader("name", "list"),
+			WithFlush(),
+		)
+		require.NoError(err)
+		require.NoError(e.writeObservation(ctx, o))
+	}
+	o, err := newObservation("TestEventer_Sampling",
+		WithRequestInfo(&RequestInfo{Method: "POST", Path: "/v1/targets"}),
+		WithHeader("name", "create"),
+		WithFlush(),
+	)
+	require.NoError(err)
+	require.NoError(e.writeObservation(ctx, o))
+	require.NoError(e.FlushNodes(ctx))
+
+	var names []string
+	var summaries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		got := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(line), &got))
+		data := got["data"].(map[string]interface{})
+		switch got["type"] {
+		case string(ObservationType):
+			names = append(names, data["name"].(string))
+		case string(SystemType):
+			if data["op"] == string(samplingSummaryOp) {
+				summaries = append(summaries, data["data"].(map[string]interface{}))
+			}
+		}
+	}
+	assert.Equal([]string{"list", "create"}, names)
+	require.Len(summaries, 1)
+	assert.Equal("sampled", summaries[0]["sink"])
+	assert.Equal(float64(2), summaries[0]["total"])
+}
@@ -15,11 +15,15 @@ type SinkConfig struct {
 	AllowFilters   []string              `hcl:"allow_filters"`    // AllowFilters define a set predicates for including an event in the sink. If any filter matches, the event will be included. The filter should be in a format supported by hashicorp/go-bexpr.
 	DenyFilters    []string              `hcl:"deny_filters"`     // DenyFilters define a set predicates for excluding an event in the sink. If any filter matches, the event will be excluded. The filter should be in a format supported by hashicorp/go-bexpr.
 	Format         SinkFormat            `hcl:"format"`           // Format defines the format for the sink (JSONSinkFormat or TextSinkFormat).
-	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink, FileSink, or WriterSink).
+	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink, FileSink, WriterSink, SyslogSink, HttpSink, or KafkaSink).
 	StderrConfig   *StderrSinkTypeConfig `hcl:"stderr"`           // StderrConfig defines parameters for a stderr output.
 	FileConfig     *FileSinkTypeConfig   `hcl:"file"`             // FileConfig defines parameters for a file output.
 	WriterConfig   *WriterSinkTypeConfig `hcl:"-"`                // WriterConfig defines parameters for an io.Writer output. This is not available via HCL.
+	SyslogConfig   *SyslogSinkTypeConfig `hcl:"syslog"`           // SyslogConfig defines parameters for a syslog output.
+	HttpConfig     *HttpSinkTypeConfig   `hcl:"http"`             // HttpConfig defines parameters for an http output.
+	KafkaConfig    *KafkaSinkTypeConfig  `hcl:"kafka"`            // KafkaConfig defines parameters for a kafka output.
 	AuditConfig    *AuditConfig          `hcl:"audit_config"`     // AuditConfig defines optional parameters for audit events (if EventTypes contains audit)
+	SamplingConfig *SamplingConfig       `hcl:"sampling_config"`  // SamplingConfig defines optional sampling and rate limiting of events other than audit events
 }
 
 func (sc *SinkConfig) Validate() error {
@@ -41,6 +45,15 @@ func (sc *SinkConfig) Validate() error {
 	if sc.WriterConfig != nil {
 		foundSinkTypeConfigs++
 	}
+	if sc.SyslogConfig != nil {
+		foundSinkTypeConfigs++
+	}
+	if sc.HttpConfig != nil {
+		foundSinkTypeConfigs++
+	}
+	if sc.KafkaConfig != nil {
+		foundSinkTypeConfigs++
+	}
 	if foundSinkTypeConfigs > 1 {
 		return fmt.Errorf("%s: too many sink type config blocks: %w", op, ErrInvalidParameter)
 	}
@@ -58,8 +71,8 @@ func (sc *SinkConfig) Validate() error {
 		if sc.FileConfig == nil {
 			return fmt.Errorf(`%s: missing "file" block: %w`, op, ErrInvalidParameter)
 		}
-		if sc.FileConfig.FileName == "" {
-			return fmt.Errorf("%s: missing file name: %w", op, ErrInvalidParameter)
+		if err := sc.FileConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
 		}
 	case WriterSink:
 		if sc.WriterConfig == nil {
@@ -68,6 +81,34 @@ func (sc *SinkConfig) Validate() error {
 		if sc.WriterConfig.Writer == nil {
 			return fmt.Errorf("%s: missing writer: %w", op, ErrInvalidParameter)
 		}
+	case SyslogSink:
+		if sc.SyslogConfig == nil {
+			return fmt.Errorf(`%s: missing "syslog" block: %w`, op, ErrInvalidParameter)
+		}
+		if err := sc.SyslogConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case HttpSink:
+		if sc.HttpConfig == nil {
+			return fmt.Errorf(`%s: missing "http" block: %w`, op, ErrInvalidParameter)
+		}
+		if err := sc.HttpConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case KafkaSink:
+		if sc.KafkaConfig == nil {
+			return fmt.Errorf(`%s: missing "kafka" block: %w`, op, ErrInvalidParameter)
+		}
+		if err := sc.KafkaConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if sc.KafkaConfig.PartitionKey != "" {
+			switch sc.Format {
+			case JSONSinkFormat, JSONHclogSinkFormat:
+			default:
+				return fmt.Errorf("%s: kafka partition key requires a json format: %w", op, ErrInvalidParameter)
+			}
+		}
 	}
 	if sc.Name == "" {
 		return fmt.Errorf("%s: missing sink name: %w", op, ErrInvalidParameter)
@@ -82,13 +123,38 @@ func (sc *SinkConfig) Validate() error {
 		}
 		// well, if there's an event type of audit, we need to check the audit
 		// config, if it's optionally provided.  We are intentionally only
-		// checking the FilterOverrides, because there's no way to specify the
+		// checking the FilterOverrides and FieldOverrides, because there's no way to specify the
 		// wrapper in a config.
 		if (et == AuditType || et == EveryType) && sc.AuditConfig != nil && sc.AuditConfig.FilterOverrides != nil {
 			if err := sc.AuditConfig.FilterOverrides.Validate(); err != nil {
 				return fmt.Errorf("%s: invalid audit config: %w", op, err)
 			}
 		}
+		if (et == AuditType || et == EveryType) && sc.AuditConfig != nil && sc.AuditConfig.FieldOverrides != nil {
+			if err := sc.AuditConfig.FieldOverrides.Validate(); err != nil {
+				return fmt.Errorf("%s: invalid audit config: %w", op, err)
+			}
+		}
+	}
+	if sc.SamplingConfig != nil {
+		if err := sc.SamplingConfig.Validate(); err != nil {
+			return fmt.Errorf("%s: invalid sampling config: %w", op, err)
+		}
+	}
+	if sc.AuditConfig != nil && sc.AuditConfig.HashChain {
+		switch sc.Format {
+		case JSONSinkFormat, JSONHclogSinkFormat:
+		default:
+			return fmt.Errorf("%s: audit hash chaining requires a json format: %w", op, ErrInvalidParameter)
+		}
+		// every line written by a chained sink is part of its chain, so
+		// unchained lines can be reported when it's verified
+		if len(sc.EventTypes) != 1 || sc.EventTypes[0] != AuditType {
+			return fmt.Errorf("%s: audit hash chaining requires a sink of only audit events: %w", op, ErrInvalidParameter)
+		}
+		if sc.AuditConfig.HashChainCheckpointInterval < 0 {
+			return fmt.Errorf("%s: hash chain checkpoint interval must not be negative: %w", op, ErrInvalidParameter)
+		}
 	}
 
 	return nil
@@ -105,6 +171,7 @@ type FileSinkTypeConfig struct {
 	RotateDuration    time.Duration `mapstructure:"rotate_duration"`                         // RotateDuration defines how often a FileSink should be rotated
 	RotateDurationHCL string        `hcl:"rotate_duration" json:"-"`                         // RotateDurationHCL defines hcl string version of RotateDuration
 	RotateMaxFiles    int           `hcl:"rotate_max_files" mapstructure:"rotate_max_files"` // RotateMaxFiles defines how may historical rotated files should be kept for a FileSink
+	RotateCompress    bool          `hcl:"rotate_compress"  mapstructure:"rotate_compress"`  // RotateCompress defines if rotated files should be compressed with gzip
 }
 
 // WriterSinkTypeConfig contains configuration structures for writer sink types
@@ -2,6 +2,7 @@ package event
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -204,6 +205,163 @@ func TestSinkConfig_Validate(t *testing.T) {
 			wantErrIs:       ErrInvalidParameter,
 			wantErrContains: `too many sink type config blocks`,
 		},
+		{
+			name: "missing-syslog-block",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "syslog" block`,
+		},
+		{
+			name: "invalid-syslog-config",
+			sc: SinkConfig{
+				Name:         "sink-name",
+				EventTypes:   []Type{AuditType},
+				Type:         SyslogSink,
+				Format:       JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{Network: SyslogUdpNetwork},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing syslog address",
+		},
+		{
+			name: "missing-http-block",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "http" block`,
+		},
+		{
+			name: "valid-http",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{Url: "https://collector.example.com/events"},
+			},
+		},
+		{
+			name: "missing-kafka-block",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       KafkaSink,
+				Format:     JSONSinkFormat,
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "kafka" block`,
+		},
+		{
+			name: "kafka-partition-key-text-format",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       KafkaSink,
+				Format:     TextSinkFormat,
+				KafkaConfig: &KafkaSinkTypeConfig{
+					Brokers:      []string{"kafka.example.com:9092"},
+					Topic:        "boundary-events",
+					PartitionKey: "/data/auth/user_info/id",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "kafka partition key requires a json format",
+		},
+		{
+			name: "valid-kafka",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       KafkaSink,
+				Format:     JSONSinkFormat,
+				KafkaConfig: &KafkaSinkTypeConfig{
+					Brokers:      []string{"kafka.example.com:9092"},
+					Topic:        "boundary.{{ .Type }}",
+					PartitionKey: "/data/auth/user_info/id",
+				},
+			},
+		},
+		{
+			name: "valid-syslog",
+			sc: SinkConfig{
+				Name:         "sink-name",
+				EventTypes:   []Type{AuditType},
+				Type:         SyslogSink,
+				Format:       TextHclogSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514"},
+			},
+		},
+		{
+			name: "hash-chain-text-format",
+			sc: SinkConfig{
+				Name:        "sink-name",
+				EventTypes:  []Type{AuditType},
+				Type:        FileSink,
+				FileConfig:  &FileSinkTypeConfig{FileName: "tmp.file"},
+				Format:      TextSinkFormat,
+				AuditConfig: &AuditConfig{HashChain: true},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "audit hash chaining requires a json format",
+		},
+		{
+			name: "hash-chain-not-only-audit",
+			sc: SinkConfig{
+				Name:        "sink-name",
+				EventTypes:  []Type{AuditType, ErrorType},
+				Type:        FileSink,
+				FileConfig:  &FileSinkTypeConfig{FileName: "tmp.file"},
+				Format:      JSONSinkFormat,
+				AuditConfig: &AuditConfig{HashChain: true},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "audit hash chaining requires a sink of only audit events",
+		},
+		{
+			name: "hash-chain-negative-checkpoint-interval",
+			sc: SinkConfig{
+				Name:        "sink-name",
+				EventTypes:  []Type{AuditType},
+				Type:        FileSink,
+				FileConfig:  &FileSinkTypeConfig{FileName: "tmp.file"},
+				Format:      JSONSinkFormat,
+				AuditConfig: &AuditConfig{HashChain: true, HashChainCheckpointInterval: -time.Minute},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "hash chain checkpoint interval must not be negative",
+		},
+		{
+			name: "valid-hash-chain",
+			sc: SinkConfig{
+				Name:        "sink-name",
+				EventTypes:  []Type{AuditType},
+				Type:        FileSink,
+				FileConfig:  &FileSinkTypeConfig{FileName: "tmp.file"},
+				Format:      JSONHclogSinkFormat,
+				AuditConfig: &AuditConfig{HashChain: true, HashChainCheckpointInterval: time.Minute},
+			},
+		},
+		{
+			name: "invalid-sampling-config",
+			sc: SinkConfig{
+				Name:           "sink-name",
+				EventTypes:     []Type{ObservationType},
+				Type:           StderrSink,
+				Format:         JSONSinkFormat,
+				SamplingConfig: &SamplingConfig{Rules: []*SamplingRule{{EventTypes: []Type{ObservationType}}}},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "invalid sampling config",
+		},
 		{
 			name: "valid",
 			sc: SinkConfig{
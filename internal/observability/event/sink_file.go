@@ -0,0 +1,404 @@
+package event
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+)
+
+const (
+	fileSinkMode    = 0o600
+	fileSinkDirMode = 0o700
+
+	// compressedFileExt is appended to the name of rotated files which have
+	// been compressed.
+	compressedFileExt = ".gz"
+
+	// compressedTmpFileExt is appended to the name of a file while it's being
+	// compressed.
+	compressedTmpFileExt = ".gz.tmp"
+)
+
+// Validate a FileSinkTypeConfig
+func (c *FileSinkTypeConfig) Validate() error {
+	const op = "event.(FileSinkTypeConfig).Validate"
+	switch {
+	case c.FileName == "":
+		return fmt.Errorf("%s: missing file name: %w", op, ErrInvalidParameter)
+	case c.RotateBytes < 0:
+		return fmt.Errorf("%s: rotate bytes must not be negative: %w", op, ErrInvalidParameter)
+	case c.RotateDuration < 0:
+		return fmt.Errorf("%s: rotate duration must not be negative: %w", op, ErrInvalidParameter)
+	case c.RotateMaxFiles < 0:
+		return fmt.Errorf("%s: rotate max files must not be negative: %w", op, ErrInvalidParameter)
+	case c.RotateCompress && c.RotateBytes == 0 && c.RotateDuration == 0:
+		return fmt.Errorf("%s: rotate compress requires either rotate bytes or rotate duration: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// fileSink is an eventlogger sink which writes formatted events to a file.
+// Events are always written to the configured file name, which is rotated to
+// a timestamped file name (optionally compressed with gzip) when it reaches
+// the configured size or age.  Reopen will close and reopen the file, so the
+// sink works with external rotation tools (e.g. logrotate) which move the file
+// and then send a SIGHUP.
+type fileSink struct {
+	path        string
+	fileName    string
+	format      SinkFormat
+	maxBytes    int64
+	maxDuration time.Duration
+	maxFiles    int
+	compress    bool
+
+	f            *os.File
+	bytesWritten int64
+	lastCreated  time.Time
+	l            sync.Mutex
+
+	// rotatedDone is closed once the most recently rotated file has been
+	// compressed and pruned, which is done in the background, one rotated
+	// file after another, so writing events isn't blocked by it
+	rotatedDone chan struct{}
+}
+
+var _ eventlogger.Node = &fileSink{}
+
+func newFileSink(c *FileSinkTypeConfig, format SinkFormat) (*fileSink, error) {
+	const op = "event.newFileSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing file config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &fileSink{
+		path:        c.Path,
+		fileName:    c.FileName,
+		format:      format,
+		maxBytes:    int64(c.RotateBytes),
+		maxDuration: c.RotateDuration,
+		maxFiles:    c.RotateMaxFiles,
+		compress:    c.RotateCompress,
+	}, nil
+}
+
+// Type describes the type of the node as a Sink.
+func (s *fileSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Name returns a representation of the sink's name
+func (s *fileSink) Name() string {
+	return fmt.Sprintf("sink:%s", filepath.Join(s.path, s.fileName))
+}
+
+// Reopen will close and reopen the sink's file.  If the file has been moved,
+// then a new file is created with the sink's file name.
+func (s *fileSink) Reopen() error {
+	const op = "event.(fileSink).Reopen"
+	s.l.Lock()
+	defer s.l.Unlock()
+	if err := s.close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.open(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Process writes the formatted event to the sink's file, rotating the file
+// first when it's due.
+func (s *fileSink) Process(_ context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(fileSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	format := string(s.format)
+	if format == "" {
+		format = eventlogger.JSONFormat
+	}
+	val, ok := e.Format(format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled: %w", op, ErrInvalidParameter)
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.f == nil {
+		if err := s.open(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if s.rotationDue() {
+		if err := s.rotate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	n, err := s.f.Write(val)
+	s.bytesWritten += int64(n)
+	if err != nil {
+		// opportunistically try to reopen the file once
+		_ = s.close()
+		if err := s.open(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		n, err = s.f.Write(val)
+		s.bytesWritten += int64(n)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", op, ErrIo, err)
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// open must be called while holding the lock
+func (s *fileSink) open() error {
+	const op = "event.(fileSink).open"
+	if s.path != "" {
+		if err := os.MkdirAll(s.path, fileSinkDirMode); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	f, err := os.OpenFile(filepath.Join(s.path, s.fileName), os.O_APPEND|os.O_WRONLY|os.O_CREATE, fileSinkMode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.f = f
+	s.bytesWritten = info.Size()
+	switch {
+	case info.Size() == 0:
+		s.lastCreated = time.Now()
+	case s.lastCreated.IsZero():
+		// the file was written before the sink was started, so its age is
+		// taken from when it was last modified
+		s.lastCreated = info.ModTime()
+	}
+	return nil
+}
+
+// close must be called while holding the lock
+func (s *fileSink) close() error {
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// rotationDue must be called while holding the lock
+func (s *fileSink) rotationDue() bool {
+	switch {
+	case s.maxBytes > 0 && s.bytesWritten >= s.maxBytes:
+		return true
+	case s.maxDuration > 0 && time.Since(s.lastCreated) >= s.maxDuration:
+		return true
+	default:
+		return false
+	}
+}
+
+// rotate must be called while holding the lock.  The current file is moved to
+// a timestamped file name and a new file is opened.  The rotated file is then
+// compressed and the oldest rotated files are pruned in the background.
+func (s *fileSink) rotate() error {
+	const op = "event.(fileSink).rotate"
+	if err := s.close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rotated := filepath.Join(s.path, s.rotatedFileName(time.Now()))
+	if err := os.Rename(filepath.Join(s.path, s.fileName), rotated); err != nil {
+		return fmt.Errorf("%s: unable to rotate file: %w", op, err)
+	}
+	if err := s.open(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	prev, done := s.rotatedDone, make(chan struct{})
+	s.rotatedDone = done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		s.compressAndPrune(rotated)
+	}()
+	return nil
+}
+
+// compressAndPrune compresses the rotated file, when configured, and prunes
+// the oldest rotated files.  Failures don't stop events from being written to
+// the new file, so they're reported with an error event.
+func (s *fileSink) compressAndPrune(rotated string) {
+	const op = "event.(fileSink).compressAndPrune"
+	ctx := context.Background()
+	if s.compress {
+		if err := compressFile(rotated); err != nil {
+			WriteError(ctx, op, err, WithInfoMsg("unable to compress rotated file", "file", rotated))
+		}
+	}
+	if err := s.prune(); err != nil {
+		WriteError(ctx, op, err, WithInfoMsg("unable to prune rotated files", "path", s.path))
+	}
+}
+
+// rotatedFileName returns the file name with a timestamp inserted before its
+// extension.
+func (s *fileSink) rotatedFileName(t time.Time) string {
+	return fmt.Sprintf(s.rotatedFileNamePattern(), strconv.FormatInt(t.UnixNano(), 10))
+}
+
+func (s *fileSink) rotatedFileNamePattern() string {
+	ext := filepath.Ext(s.fileName)
+	return strings.TrimSuffix(s.fileName, ext) + "-%s" + ext
+}
+
+// prune removes the oldest rotated files (compressed or not), keeping at most
+// maxFiles of them.
+func (s *fileSink) prune() error {
+	const op = "event.(fileSink).prune"
+	if s.maxFiles == 0 {
+		return nil
+	}
+	rotated, err := s.rotatedFiles()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for i := 0; i < len(rotated)-s.maxFiles; i++ {
+		if err := os.Remove(rotated[i]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// writtenFiles returns the sink's file followed by its rotated files, from the
+// newest to the oldest.
+func (s *fileSink) writtenFiles() ([]string, error) {
+	const op = "event.(fileSink).writtenFiles"
+	rotated, err := s.rotatedFiles()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	files := make([]string, 0, len(rotated)+1)
+	files = append(files, filepath.Join(s.path, s.fileName))
+	for i := len(rotated) - 1; i >= 0; i-- {
+		files = append(files, rotated[i])
+	}
+	return files, nil
+}
+
+// rotatedFiles returns the rotated files (compressed or not), from the oldest
+// to the newest.  Only files whose name has a timestamp where the pattern
+// has a wildcard are rotated files, so other files in the directory, such as
+// "audit-old.log", are never returned.
+func (s *fileSink) rotatedFiles() ([]string, error) {
+	const op = "event.(fileSink).rotatedFiles"
+	glob := filepath.Join(s.path, fmt.Sprintf(s.rotatedFileNamePattern(), "*"))
+	matches, err := filepath.Glob(glob)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	compressed, err := filepath.Glob(glob + compressedFileExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// when the file name has no extension, the compressed files are matched
+	// by both globs.
+	ext := filepath.Ext(s.fileName)
+	prefix := strings.TrimSuffix(s.fileName, ext) + "-"
+	seen := make(map[string]struct{}, len(matches)+len(compressed))
+	rotated := make([]string, 0, len(matches)+len(compressed))
+	for _, m := range append(matches, compressed...) {
+		if _, ok := seen[m]; ok {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(m), compressedFileExt)
+		if !isTimestamp(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)) {
+			continue
+		}
+		seen[m] = struct{}{}
+		rotated = append(rotated, m)
+	}
+	// timestamps have the same number of digits, so sorting the names will
+	// sort the files from oldest to newest
+	sort.Strings(rotated)
+	return rotated, nil
+}
+
+// isTimestamp returns true if s is the UnixNano timestamp of a rotated file,
+// which only has digits.
+func isTimestamp(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compressFile will compress the file with gzip, replacing it with a file of
+// the same name with a ".gz" extension.
+func compressFile(name string) (retErr error) {
+	const op = "event.compressFile"
+	src, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer src.Close()
+
+	// write to a temp file first, so a partially compressed file is never
+	// mistaken for a complete one.
+	tmpName := name + compressedTmpFileExt
+	dst, err := os.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileSinkMode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = dst.Close()
+			_ = os.Remove(tmpName)
+		}
+	}()
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(name)
+	if _, err := io.Copy(zw, src); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Rename(tmpName, name+compressedFileExt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
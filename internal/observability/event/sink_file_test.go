@@ -0,0 +1,283 @@
+package event
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkTypeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               *FileSinkTypeConfig
+		wantErrContains string
+	}{
+		{
+			name: "valid",
+			c:    &FileSinkTypeConfig{FileName: "audit.log"},
+		},
+		{
+			name: "valid-rotation",
+			c: &FileSinkTypeConfig{
+				FileName:       "audit.log",
+				RotateBytes:    1024,
+				RotateDuration: time.Hour,
+				RotateMaxFiles: 3,
+				RotateCompress: true,
+			},
+		},
+		{
+			name:            "missing-file-name",
+			c:               &FileSinkTypeConfig{},
+			wantErrContains: "missing file name",
+		},
+		{
+			name:            "negative-bytes",
+			c:               &FileSinkTypeConfig{FileName: "audit.log", RotateBytes: -1},
+			wantErrContains: "rotate bytes must not be negative",
+		},
+		{
+			name:            "negative-duration",
+			c:               &FileSinkTypeConfig{FileName: "audit.log", RotateDuration: -time.Second},
+			wantErrContains: "rotate duration must not be negative",
+		},
+		{
+			name:            "negative-max-files",
+			c:               &FileSinkTypeConfig{FileName: "audit.log", RotateMaxFiles: -1},
+			wantErrContains: "rotate max files must not be negative",
+		},
+		{
+			name:            "compress-without-rotation",
+			c:               &FileSinkTypeConfig{FileName: "audit.log", RotateCompress: true},
+			wantErrContains: "rotate compress requires either rotate bytes or rotate duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := tt.c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func TestFileSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testEvent := func(value string) *eventlogger.Event {
+		e := &eventlogger.Event{Type: eventlogger.EventType(AuditType), CreatedAt: time.Now()}
+		e.FormattedAs(string(JSONSinkFormat), []byte(value+"\n"))
+		return e
+	}
+	readFile := func(t *testing.T, name string) string {
+		t.Helper()
+		b, err := os.ReadFile(name)
+		require.NoError(t, err)
+		return string(b)
+	}
+	readGzipFile := func(t *testing.T, name string) string {
+		t.Helper()
+		f, err := os.Open(name)
+		require.NoError(t, err)
+		defer f.Close()
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		b, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		return string(b)
+	}
+	rotatedFiles := func(t *testing.T, s *fileSink, dir string) []string {
+		t.Helper()
+		// wait for the rotated files to be compressed and pruned
+		if s.rotatedDone != nil {
+			<-s.rotatedDone
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, "audit-*"))
+		require.NoError(t, err)
+		return matches
+	}
+
+	t.Run("no-rotation", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log"}, JSONSinkFormat)
+		require.NoError(err)
+		for _, v := range []string{"one", "two"} {
+			got, err := s.Process(ctx, testEvent(v))
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		assert.Equal("one\ntwo\n", readFile(t, filepath.Join(dir, "audit.log")))
+		assert.Empty(rotatedFiles(t, s, dir))
+	})
+	t.Run("rotate-bytes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log", RotateBytes: 4}, JSONSinkFormat)
+		require.NoError(err)
+		for _, v := range []string{"one", "two", "three"} {
+			_, err := s.Process(ctx, testEvent(v))
+			require.NoError(err)
+		}
+		assert.Equal("three\n", readFile(t, filepath.Join(dir, "audit.log")))
+		rotated := rotatedFiles(t, s, dir)
+		require.Len(rotated, 2)
+		assert.Equal("one\n", readFile(t, rotated[0]))
+		assert.Equal("two\n", readFile(t, rotated[1]))
+	})
+	t.Run("rotate-duration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log", RotateDuration: time.Hour}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent("one"))
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent("two"))
+		require.NoError(err)
+		assert.Empty(rotatedFiles(t, s, dir))
+
+		s.lastCreated = time.Now().Add(-2 * time.Hour)
+		_, err = s.Process(ctx, testEvent("three"))
+		require.NoError(err)
+		assert.Equal("three\n", readFile(t, filepath.Join(dir, "audit.log")))
+		rotated := rotatedFiles(t, s, dir)
+		require.Len(rotated, 1)
+		assert.Equal("one\ntwo\n", readFile(t, rotated[0]))
+	})
+	t.Run("rotate-compress-max-files", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(&FileSinkTypeConfig{
+			Path:           dir,
+			FileName:       "audit.log",
+			RotateBytes:    4,
+			RotateMaxFiles: 2,
+			RotateCompress: true,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		for _, v := range []string{"one", "two", "three", "four"} {
+			_, err := s.Process(ctx, testEvent(v))
+			require.NoError(err)
+		}
+		assert.Equal("four\n", readFile(t, filepath.Join(dir, "audit.log")))
+		rotated := rotatedFiles(t, s, dir)
+		require.Len(rotated, 2)
+		for _, r := range rotated {
+			assert.True(strings.HasSuffix(r, ".log.gz"))
+		}
+		assert.Equal("two\n", readGzipFile(t, rotated[0]))
+		assert.Equal("three\n", readGzipFile(t, rotated[1]))
+	})
+	t.Run("max-files-other-files", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		others := []string{"audit-old.log", "audit-backup.log", "audit-1.log.bak"}
+		for _, o := range others {
+			require.NoError(os.WriteFile(filepath.Join(dir, o), []byte("other\n"), fileSinkMode))
+		}
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log", RotateBytes: 4, RotateMaxFiles: 1}, JSONSinkFormat)
+		require.NoError(err)
+		for _, v := range []string{"one", "two", "three"} {
+			_, err := s.Process(ctx, testEvent(v))
+			require.NoError(err)
+		}
+		if s.rotatedDone != nil {
+			<-s.rotatedDone
+		}
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 1)
+		assert.Equal("two\n", readFile(t, rotated[0]))
+		// files which aren't rotated files are never pruned
+		for _, o := range others {
+			assert.Equal("other\n", readFile(t, filepath.Join(dir, o)))
+		}
+	})
+	t.Run("rotate-duration-existing-file", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		name := filepath.Join(dir, "audit.log")
+		require.NoError(os.WriteFile(name, []byte("one\n"), fileSinkMode))
+		modified := time.Now().Add(-2 * time.Hour)
+		require.NoError(os.Chtimes(name, modified, modified))
+
+		// the age of a file written before the sink started is taken from
+		// when it was last modified
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log", RotateDuration: time.Hour}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent("two"))
+		require.NoError(err)
+		assert.Equal("two\n", readFile(t, name))
+		rotated := rotatedFiles(t, s, dir)
+		require.Len(rotated, 1)
+		assert.Equal("one\n", readFile(t, rotated[0]))
+
+		// the new file's age isn't reset by reopening it
+		s.lastCreated = time.Now().Add(-2 * time.Hour)
+		require.NoError(s.Reopen())
+		_, err = s.Process(ctx, testEvent("three"))
+		require.NoError(err)
+		assert.Equal("three\n", readFile(t, name))
+	})
+	t.Run("reopen-after-move", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(&FileSinkTypeConfig{Path: dir, FileName: "audit.log"}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent("one"))
+		require.NoError(err)
+
+		// simulate logrotate moving the file before a SIGHUP
+		moved := filepath.Join(dir, "audit.log.1")
+		require.NoError(os.Rename(filepath.Join(dir, "audit.log"), moved))
+		require.NoError(s.Reopen())
+
+		_, err = s.Process(ctx, testEvent("two"))
+		require.NoError(err)
+		assert.Equal("one\n", readFile(t, moved))
+		assert.Equal("two\n", readFile(t, filepath.Join(dir, "audit.log")))
+	})
+	t.Run("missing-event", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := newFileSink(&FileSinkTypeConfig{Path: t.TempDir(), FileName: "audit.log"}, JSONSinkFormat)
+		require.NoError(err)
+		got, err := s.Process(ctx, nil)
+		require.Error(err)
+		assert.Nil(got)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+}
+
+func Test_newFileSink(t *testing.T) {
+	t.Parallel()
+	t.Run("missing-config", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := newFileSink(nil, JSONSinkFormat)
+		require.Error(err)
+		assert.Nil(s)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+	t.Run("invalid-config", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := newFileSink(&FileSinkTypeConfig{}, JSONSinkFormat)
+		require.Error(err)
+		assert.Nil(s)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,430 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+const (
+	// DefaultHttpBatchSize is the number of events sent in a batch when no
+	// batch size is configured
+	DefaultHttpBatchSize = 100
+
+	// DefaultHttpFlushInterval is how long events are buffered before being
+	// sent when no flush interval is configured
+	DefaultHttpFlushInterval = 5 * time.Second
+
+	// httpRequestTimeout is the timeout of each request made by an http sink
+	httpRequestTimeout = 30 * time.Second
+
+	// httpSpillFileExt is the extension of the files of an http sink's spill
+	// queue
+	httpSpillFileExt = ".batch"
+)
+
+// HttpSinkTypeConfig contains configuration structures for http sink types
+type HttpSinkTypeConfig struct {
+	Url              string            `hcl:"url"             mapstructure:"url"`             // Url defines the endpoint batches of events are POSTed to
+	Headers          map[string]string `hcl:"headers"         mapstructure:"headers"`         // Headers defines additional headers sent with each request
+	BatchSize        int               `hcl:"batch_size"      mapstructure:"batch_size"`      // BatchSize defines the maximum number of events sent in a single request (defaults to 100)
+	FlushInterval    time.Duration     `mapstructure:"flush_interval"`                        // FlushInterval defines how long events are buffered before being sent (defaults to 5s)
+	FlushIntervalHCL string            `hcl:"flush_interval"  json:"-"`                       // FlushIntervalHCL defines hcl string version of FlushInterval
+	SpillPath        string            `hcl:"spill_path"      mapstructure:"spill_path"`      // SpillPath defines a directory where batches are queued while the endpoint can't be reached
+	TlsCaCert        string            `hcl:"tls_ca_cert"     mapstructure:"tls_ca_cert"`     // TlsCaCert defines a PEM file of CA certificates used to verify the endpoint
+	TlsClientCert    string            `hcl:"tls_client_cert" mapstructure:"tls_client_cert"` // TlsClientCert defines a PEM file with a client certificate to present to the endpoint
+	TlsClientKey     string            `hcl:"tls_client_key"  mapstructure:"tls_client_key"`  // TlsClientKey defines a PEM file with the key of the client certificate
+	TlsServerName    string            `hcl:"tls_server_name" mapstructure:"tls_server_name"` // TlsServerName overrides the name used to verify the endpoint's certificate
+	TlsSkipVerify    bool              `hcl:"tls_skip_verify" mapstructure:"tls_skip_verify"` // TlsSkipVerify disables verification of the endpoint's certificate
+}
+
+// Validate a HttpSinkTypeConfig
+func (c *HttpSinkTypeConfig) Validate() error {
+	const op = "event.(HttpSinkTypeConfig).Validate"
+	if c.Url == "" {
+		return fmt.Errorf("%s: missing url: %w", op, ErrInvalidParameter)
+	}
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return fmt.Errorf("%s: invalid url: %w", op, ErrInvalidParameter)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%s: url scheme must be http or https: %w", op, ErrInvalidParameter)
+	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("%s: batch size must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.FlushInterval < 0 {
+		return fmt.Errorf("%s: flush interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if u.Scheme != "https" && (c.TlsCaCert != "" || c.TlsClientCert != "" || c.TlsClientKey != "" || c.TlsServerName != "" || c.TlsSkipVerify) {
+		return fmt.Errorf("%s: tls parameters are only supported with an https url: %w", op, ErrInvalidParameter)
+	}
+	if (c.TlsClientCert == "") != (c.TlsClientKey == "") {
+		return fmt.Errorf("%s: tls client cert and key must be provided together: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// httpSink is an eventlogger sink which buffers formatted events and POSTs
+// them in batches, one event per line, to an endpoint. Batches which can't be
+// delivered after retrying are written to the spill path, when configured,
+// and sent before any newer batch once the endpoint can be reached again.
+type httpSink struct {
+	format        string
+	url           string
+	headers       map[string]string
+	batchSize     int
+	flushInterval time.Duration
+	spillPath     string
+	client        *http.Client
+
+	// retries and backoff control resending a batch when a request fails
+	retries uint
+	backoff backoff
+
+	// l protects the pending batch and its flush timer
+	l       sync.Mutex
+	pending [][]byte
+	timer   *time.Timer
+
+	// sendL serializes sending batches so they're delivered in order, and
+	// protects spillSeq and spilled
+	sendL    sync.Mutex
+	spillSeq uint64
+	// spilled is set while there may be batches in the spill path, so they
+	// are only looked for when there are some to send
+	spilled bool
+}
+
+func newHttpSink(c *HttpSinkTypeConfig, format SinkFormat) (*httpSink, error) {
+	const op = "event.newHttpSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing http config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	transport := cleanhttp.DefaultPooledTransport()
+	if strings.HasPrefix(c.Url, "https") {
+		tlsConfig, err := newSinkTlsConfig(c.TlsServerName, c.TlsCaCert, c.TlsClientCert, c.TlsClientKey, c.TlsSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if c.SpillPath != "" {
+		if err := os.MkdirAll(c.SpillPath, 0o700); err != nil {
+			return nil, fmt.Errorf("%s: unable to create spill path: %w", op, err)
+		}
+	}
+
+	s := &httpSink{
+		format:        string(format),
+		url:           c.Url,
+		headers:       c.Headers,
+		batchSize:     c.BatchSize,
+		flushInterval: c.FlushInterval,
+		spillPath:     c.SpillPath,
+		client:        &http.Client{Transport: transport, Timeout: httpRequestTimeout},
+		retries:       stdRetryCount,
+		backoff:       expBackoff{},
+	}
+	if s.batchSize == 0 {
+		s.batchSize = DefaultHttpBatchSize
+	}
+	if s.flushInterval == 0 {
+		s.flushInterval = DefaultHttpFlushInterval
+	}
+	if s.spillPath != "" {
+		// Batches spilled before a restart are sent before newer ones
+		files, err := s.spilledFiles()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		s.spilled = len(files) > 0
+	}
+	return s, nil
+}
+
+// Type defines the sink as a NodeTypeSink
+func (s *httpSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen does nothing for this type of sink.
+func (s *httpSink) Reopen() error { return nil }
+
+// Process adds the formatted event to the pending batch, sending the batch
+// once it reaches the batch size. Events are acknowledged once they're added
+// to the batch, so the failure to deliver a batch sent once the flush
+// interval passes is reported with an error event.
+func (s *httpSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(httpSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	format := s.format
+	if format == "" {
+		format = eventlogger.JSONFormat
+	}
+	val, ok := e.Format(format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled", op)
+	}
+	line := make([]byte, 0, len(val)+1)
+	line = append(line, bytes.TrimRight(val, "\n")...)
+	line = append(line, '\n')
+
+	s.l.Lock()
+	s.pending = append(s.pending, line)
+	var batch [][]byte
+	switch {
+	case len(s.pending) >= s.batchSize:
+		batch = s.takePending()
+	case len(s.pending) == 1:
+		s.timer = time.AfterFunc(s.flushInterval, s.flushPending)
+	}
+	s.l.Unlock()
+
+	if batch != nil {
+		if err := s.send(ctx, batch); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// FlushAll sends the pending batch, if any, along with any spilled batches.
+func (s *httpSink) FlushAll(ctx context.Context) error {
+	const op = "event.(httpSink).FlushAll"
+	s.l.Lock()
+	batch := s.takePending()
+	s.l.Unlock()
+	if err := s.send(ctx, batch); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// flushPending sends the pending batch once the flush interval has passed.
+// A batch which can't be delivered or spilled is dropped, which is reported
+// with an error event.
+func (s *httpSink) flushPending() {
+	const op = "event.(httpSink).flushPending"
+	s.l.Lock()
+	batch := s.takePending()
+	s.l.Unlock()
+	if err := s.send(context.Background(), batch); err != nil {
+		WriteError(context.Background(), op, err, WithInfoMsg("dropped batch of events", "url", s.url, "events", len(batch)))
+	}
+}
+
+// takePending returns the pending batch and stops its flush timer. The caller
+// must hold the lock.
+func (s *httpSink) takePending() [][]byte {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.pending
+	s.pending = nil
+	return batch
+}
+
+// send delivers any spilled batches followed by the provided batch. If the
+// endpoint can't be reached the batch is spilled instead, when a spill path
+// is configured.
+func (s *httpSink) send(ctx context.Context, batch [][]byte) error {
+	const op = "event.(httpSink).send"
+	s.sendL.Lock()
+	defer s.sendL.Unlock()
+
+	var body []byte
+	if len(batch) > 0 {
+		body = bytes.Join(batch, nil)
+	}
+
+	if err := s.drainSpilled(ctx); err != nil {
+		if body == nil {
+			return nil
+		}
+		// Spill the batch behind the ones already queued to keep events in
+		// order.
+		if serr := s.spill(body); serr != nil {
+			return fmt.Errorf("%s: %w", op, serr)
+		}
+		return nil
+	}
+	if body == nil {
+		return nil
+	}
+
+	err := s.post(ctx, body)
+	switch {
+	case err == nil:
+		return nil
+	case s.spillPath == "" || isPermanentHttpError(err):
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if serr := s.spill(body); serr != nil {
+		return fmt.Errorf("%s: %w", op, serr)
+	}
+	return nil
+}
+
+// drainSpilled sends the spilled batches, oldest first, removing each once
+// it's delivered. The caller must hold the send lock.
+func (s *httpSink) drainSpilled(ctx context.Context) error {
+	const op = "event.(httpSink).drainSpilled"
+	if !s.spilled {
+		return nil
+	}
+	files, err := s.spilledFiles()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, f := range files {
+		body, err := os.ReadFile(f)
+		if err != nil {
+			return fmt.Errorf("%s: unable to read spilled batch: %w", op, err)
+		}
+		err = s.post(ctx, body)
+		if err != nil && !isPermanentHttpError(err) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		// Batches the endpoint permanently rejected are removed as well, so
+		// they don't block delivery of newer ones.
+		if err := os.Remove(f); err != nil {
+			return fmt.Errorf("%s: unable to remove spilled batch: %w", op, err)
+		}
+	}
+	s.spilled = false
+	return nil
+}
+
+// spilledFiles returns the paths of the spilled batches, oldest first.
+func (s *httpSink) spilledFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.spillPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spill path: %w", err)
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != httpSpillFileExt {
+			continue
+		}
+		files = append(files, filepath.Join(s.spillPath, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// spill writes the batch to the spill path. The file is written under a
+// temporary name first so partially written batches are never sent. The
+// caller must hold the send lock.
+func (s *httpSink) spill(body []byte) error {
+	const op = "event.(httpSink).spill"
+	if s.spillPath == "" {
+		return fmt.Errorf("%s: unable to deliver batch and no spill path configured", op)
+	}
+	s.spillSeq++
+	name := fmt.Sprintf("%020d-%010d", time.Now().UnixNano(), s.spillSeq)
+	tmp := filepath.Join(s.spillPath, name+".tmp")
+	if err := os.WriteFile(tmp, body, 0o600); err != nil {
+		return fmt.Errorf("%s: unable to write spilled batch: %w", op, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.spillPath, name+httpSpillFileExt)); err != nil {
+		return fmt.Errorf("%s: unable to write spilled batch: %w", op, err)
+	}
+	s.spilled = true
+	return nil
+}
+
+// httpStatusError is returned when the endpoint responds with a status other
+// than 2xx.
+type httpStatusError struct {
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("endpoint responded with status %d", e.statusCode)
+}
+
+// isPermanentHttpError reports whether retrying the request won't help.
+func isPermanentHttpError(err error) bool {
+	var se *httpStatusError
+	if !errors.As(err, &se) {
+		return false
+	}
+	switch {
+	case se.statusCode == http.StatusRequestTimeout, se.statusCode == http.StatusTooManyRequests:
+		return false
+	default:
+		return se.statusCode >= 400 && se.statusCode < 500
+	}
+}
+
+// post sends the body to the endpoint, retrying with a backoff.
+func (s *httpSink) post(ctx context.Context, body []byte) error {
+	const op = "event.(httpSink).post"
+	var err error
+	for attempt := uint(0); attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%s: %w", op, ctx.Err())
+			case <-time.After(s.backoff.duration(attempt)):
+			}
+		}
+		if err = s.postOnce(ctx, body); err == nil || isPermanentHttpError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: unable to send events: %w", op, err)
+	}
+	return nil
+}
+
+func (s *httpSink) postOnce(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	switch SinkFormat(s.format) {
+	case JSONSinkFormat, JSONHclogSinkFormat:
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	default:
+		req.Header.Set("Content-Type", "text/plain")
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpStatusError{statusCode: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package event
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpSinkTypeConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		c               HttpSinkTypeConfig
+		wantErrContains string
+	}{
+		{
+			name:            "missing-url",
+			c:               HttpSinkTypeConfig{},
+			wantErrContains: "missing url",
+		},
+		{
+			name:            "invalid-scheme",
+			c:               HttpSinkTypeConfig{Url: "ftp://collector.example.com"},
+			wantErrContains: "url scheme must be http or https",
+		},
+		{
+			name:            "negative-batch-size",
+			c:               HttpSinkTypeConfig{Url: "https://collector.example.com", BatchSize: -1},
+			wantErrContains: "batch size must not be negative",
+		},
+		{
+			name:            "negative-flush-interval",
+			c:               HttpSinkTypeConfig{Url: "https://collector.example.com", FlushInterval: -time.Second},
+			wantErrContains: "flush interval must not be negative",
+		},
+		{
+			name:            "tls-params-without-https",
+			c:               HttpSinkTypeConfig{Url: "http://collector.example.com", TlsSkipVerify: true},
+			wantErrContains: "tls parameters are only supported with an https url",
+		},
+		{
+			name:            "tls-key-without-cert",
+			c:               HttpSinkTypeConfig{Url: "https://collector.example.com", TlsClientKey: "key.pem"},
+			wantErrContains: "tls client cert and key must be provided together",
+		},
+		{
+			name: "valid",
+			c: HttpSinkTypeConfig{
+				Url:           "https://collector.example.com/events",
+				Headers:       map[string]string{"Authorization": "Bearer token"},
+				BatchSize:     10,
+				FlushInterval: time.Second,
+				SpillPath:     "/var/spool/boundary",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := tt.c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+// testHttpCollector is an http endpoint which records the batches of events
+// it receives, responding with the configured status.
+type testHttpCollector struct {
+	l       sync.Mutex
+	status  int
+	batches []string
+	headers []http.Header
+}
+
+func (c *testHttpCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	if c.status != http.StatusOK {
+		w.WriteHeader(c.status)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	c.batches = append(c.batches, string(body))
+	c.headers = append(c.headers, r.Header.Clone())
+}
+
+func (c *testHttpCollector) setStatus(status int) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	c.status = status
+}
+
+func (c *testHttpCollector) received() []string {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return append([]string(nil), c.batches...)
+}
+
+func testHttpEvent(t *testing.T, value string) *eventlogger.Event {
+	t.Helper()
+	e := &eventlogger.Event{Type: eventlogger.EventType(AuditType), CreatedAt: time.Now()}
+	e.FormattedAs(string(JSONSinkFormat), []byte(value+"\n"))
+	return e
+}
+
+func TestHttpSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("batch-size", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		collector := &testHttpCollector{status: http.StatusOK}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{
+			Url:           srv.URL,
+			Headers:       map[string]string{"Authorization": "Bearer token"},
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+		}, JSONSinkFormat)
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		assert.Empty(collector.received())
+
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		assert.Equal([]string{"{\"id\":\"1\"}\n{\"id\":\"2\"}\n"}, collector.received())
+		assert.Equal("Bearer token", collector.headers[0].Get("Authorization"))
+		assert.Equal("application/x-ndjson", collector.headers[0].Get("Content-Type"))
+
+		// A partial batch is sent when flushed
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"3"}`))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		assert.Equal([]string{"{\"id\":\"1\"}\n{\"id\":\"2\"}\n", "{\"id\":\"3\"}\n"}, collector.received())
+	})
+
+	t.Run("flush-interval", func(t *testing.T) {
+		require := require.New(t)
+		collector := &testHttpCollector{status: http.StatusOK}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{Url: srv.URL, FlushInterval: 10 * time.Millisecond}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		require.Eventually(func() bool { return len(collector.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("spill-and-drain", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		collector := &testHttpCollector{status: http.StatusServiceUnavailable}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+		spillPath := filepath.Join(t.TempDir(), "spill")
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{
+			Url:           srv.URL,
+			BatchSize:     1,
+			FlushInterval: time.Hour,
+			SpillPath:     spillPath,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		backoff := &testSinkBackoff{}
+		s.backoff = backoff
+
+		// The endpoint is down, so the batches are spilled after retrying
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		assert.Equal(uint(stdRetryCount), backoff.calls)
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		files, err := s.spilledFiles()
+		require.NoError(err)
+		assert.Len(files, 2)
+		assert.Empty(collector.received())
+
+		// Once the endpoint is back the spilled batches are sent first
+		collector.setStatus(http.StatusOK)
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"3"}`))
+		require.NoError(err)
+		assert.Equal([]string{"{\"id\":\"1\"}\n", "{\"id\":\"2\"}\n", "{\"id\":\"3\"}\n"}, collector.received())
+		files, err = s.spilledFiles()
+		require.NoError(err)
+		assert.Empty(files)
+		assert.False(s.spilled)
+	})
+
+	t.Run("drain-previously-spilled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		collector := &testHttpCollector{status: http.StatusServiceUnavailable}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+		spillPath := t.TempDir()
+		c := &HttpSinkTypeConfig{Url: srv.URL, BatchSize: 1, FlushInterval: time.Hour, SpillPath: spillPath}
+
+		s, err := newHttpSink(c, JSONSinkFormat)
+		require.NoError(err)
+		assert.False(s.spilled)
+		s.backoff = &testSinkBackoff{}
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+
+		// A new sink using the same spill path sends the batches spilled
+		// before it was created
+		collector.setStatus(http.StatusOK)
+		s, err = newHttpSink(c, JSONSinkFormat)
+		require.NoError(err)
+		assert.True(s.spilled)
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.NoError(err)
+		assert.Equal([]string{"{\"id\":\"1\"}\n", "{\"id\":\"2\"}\n"}, collector.received())
+		assert.False(s.spilled)
+	})
+
+	t.Run("no-spill-path", func(t *testing.T) {
+		require := require.New(t)
+		collector := &testHttpCollector{status: http.StatusServiceUnavailable}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{Url: srv.URL, BatchSize: 1}, JSONSinkFormat)
+		require.NoError(err)
+		s.backoff = &testSinkBackoff{}
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.Error(err)
+		assert.Contains(t, err.Error(), "endpoint responded with status 503")
+	})
+
+	t.Run("permanent-error-not-spilled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		collector := &testHttpCollector{status: http.StatusBadRequest}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+		spillPath := t.TempDir()
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{Url: srv.URL, BatchSize: 1, SpillPath: spillPath}, JSONSinkFormat)
+		require.NoError(err)
+		backoff := &testSinkBackoff{}
+		s.backoff = backoff
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.Error(err)
+		assert.Zero(backoff.calls)
+		entries, err := os.ReadDir(spillPath)
+		require.NoError(err)
+		assert.Empty(entries)
+	})
+
+	t.Run("mtls", func(t *testing.T) {
+		require := require.New(t)
+		certs := testSinkCerts(t)
+		collector := &testHttpCollector{status: http.StatusOK}
+		srv := httptest.NewUnstartedServer(collector)
+		srv.TLS = &tls.Config{
+			Certificates: []tls.Certificate{certs.serverCert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    certs.caPool,
+		}
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{
+			Url:           srv.URL,
+			BatchSize:     1,
+			TlsCaCert:     certs.caFile,
+			TlsClientCert: certs.clientCert,
+			TlsClientKey:  certs.clientKey,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"1"}`))
+		require.NoError(err)
+		require.Len(collector.received(), 1)
+
+		// Without the client certificate the endpoint refuses the connection
+		s, err = newHttpSink(&HttpSinkTypeConfig{Url: srv.URL, BatchSize: 1, TlsCaCert: certs.caFile}, JSONSinkFormat)
+		require.NoError(err)
+		s.backoff = &testSinkBackoff{}
+		_, err = s.Process(ctx, testHttpEvent(t, `{"id":"2"}`))
+		require.Error(err)
+		require.Len(collector.received(), 1)
+	})
+
+	t.Run("text-format", func(t *testing.T) {
+		require := require.New(t)
+		collector := &testHttpCollector{status: http.StatusOK}
+		srv := httptest.NewServer(collector)
+		t.Cleanup(srv.Close)
+
+		s, err := newHttpSink(&HttpSinkTypeConfig{Url: srv.URL, BatchSize: 1}, TextHclogSinkFormat)
+		require.NoError(err)
+		e := &eventlogger.Event{Type: eventlogger.EventType(SystemType), CreatedAt: time.Now()}
+		e.FormattedAs(string(TextHclogSinkFormat), []byte("text event"))
+		_, err = s.Process(ctx, e)
+		require.NoError(err)
+		require.Equal([]string{"text event\n"}, collector.received())
+		require.True(strings.HasPrefix(collector.headers[0].Get("Content-Type"), "text/plain"))
+	})
+}
@@ -0,0 +1,427 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/mitchellh/pointerstructure"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	KafkaSaslPlain       = "plain"         // KafkaSaslPlain authenticates with the PLAIN sasl mechanism
+	KafkaSaslScramSha256 = "scram-sha-256" // KafkaSaslScramSha256 authenticates with the SCRAM-SHA-256 sasl mechanism
+	KafkaSaslScramSha512 = "scram-sha-512" // KafkaSaslScramSha512 authenticates with the SCRAM-SHA-512 sasl mechanism
+
+	// DefaultKafkaBatchSize is the number of events sent in a batch when no
+	// batch size is configured
+	DefaultKafkaBatchSize = 100
+
+	// DefaultKafkaFlushInterval is how long events are buffered before being
+	// sent when no flush interval is configured
+	DefaultKafkaFlushInterval = time.Second
+
+	// kafkaDialTimeout is the timeout when dialing a kafka broker
+	kafkaDialTimeout = 10 * time.Second
+
+	// kafkaWriteTimeout is the timeout of each produce request made by a
+	// kafka sink
+	kafkaWriteTimeout = 10 * time.Second
+
+	// kafkaBatchTimeout is how long the kafka writer waits for more messages
+	// before sending a partial batch.  Events are already batched by the
+	// sink, so this is kept short.
+	kafkaBatchTimeout = 10 * time.Millisecond
+
+	// kafkaClientId is the client id sent to the kafka brokers
+	kafkaClientId = "boundary"
+)
+
+// kafkaTopicRegexp matches the names kafka accepts for topics
+var kafkaTopicRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// KafkaSinkTypeConfig contains configuration structures for kafka sink types
+type KafkaSinkTypeConfig struct {
+	Brokers           []string          `hcl:"brokers"            mapstructure:"brokers"`            // Brokers defines the host:port addresses of the brokers used to connect to the kafka cluster
+	Topic             string            `hcl:"topic"              mapstructure:"topic"`              // Topic defines the topic events are written to, which may be a template using the event's {{ .Type }}
+	PartitionKey      string            `hcl:"partition_key"      mapstructure:"partition_key"`      // PartitionKey defines a JSON pointer into the formatted event whose value is used as the message key (e.g. /data/auth/user_info/id)
+	DeliveryGuarantee DeliveryGuarantee `hcl:"delivery_guarantee" mapstructure:"delivery_guarantee"` // DeliveryGuarantee defines whether delivery of each event is acknowledged by every in-sync replica before it's considered sent (defaults to best-effort)
+	BatchSize         int               `hcl:"batch_size"         mapstructure:"batch_size"`         // BatchSize defines the maximum number of events sent in a single batch with a best effort delivery guarantee (defaults to 100)
+	FlushInterval     time.Duration     `mapstructure:"flush_interval"`                              // FlushInterval defines how long events are buffered with a best effort delivery guarantee (defaults to 1s)
+	FlushIntervalHCL  string            `hcl:"flush_interval"     json:"-"`                          // FlushIntervalHCL defines hcl string version of FlushInterval
+	SaslMechanism     string            `hcl:"sasl_mechanism"     mapstructure:"sasl_mechanism"`     // SaslMechanism defines the sasl mechanism used to authenticate (plain, scram-sha-256 or scram-sha-512)
+	SaslUsername      string            `hcl:"sasl_username"      mapstructure:"sasl_username"`      // SaslUsername defines the username used to authenticate with sasl
+	SaslPassword      string            `hcl:"sasl_password"      mapstructure:"sasl_password"`      // SaslPassword defines the password used to authenticate with sasl
+	TlsEnabled        bool              `hcl:"tls_enabled"        mapstructure:"tls_enabled"`        // TlsEnabled defines if connections to the brokers use tls
+	TlsCaCert         string            `hcl:"tls_ca_cert"        mapstructure:"tls_ca_cert"`        // TlsCaCert defines a PEM file of CA certificates used to verify the brokers
+	TlsClientCert     string            `hcl:"tls_client_cert"    mapstructure:"tls_client_cert"`    // TlsClientCert defines a PEM file with a client certificate to present to the brokers
+	TlsClientKey      string            `hcl:"tls_client_key"     mapstructure:"tls_client_key"`     // TlsClientKey defines a PEM file with the key of the client certificate
+	TlsServerName     string            `hcl:"tls_server_name"    mapstructure:"tls_server_name"`    // TlsServerName overrides the name used to verify the brokers' certificates
+	TlsSkipVerify     bool              `hcl:"tls_skip_verify"    mapstructure:"tls_skip_verify"`    // TlsSkipVerify disables verification of the brokers' certificates
+}
+
+// Validate a KafkaSinkTypeConfig
+func (c *KafkaSinkTypeConfig) Validate() error {
+	const op = "event.(KafkaSinkTypeConfig).Validate"
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("%s: missing brokers: %w", op, ErrInvalidParameter)
+	}
+	for _, b := range c.Brokers {
+		if _, _, err := net.SplitHostPort(b); err != nil {
+			return fmt.Errorf("%s: broker '%s' is not a valid host:port: %w", op, b, ErrInvalidParameter)
+		}
+	}
+	if c.Topic == "" {
+		return fmt.Errorf("%s: missing topic: %w", op, ErrInvalidParameter)
+	}
+	if _, err := kafkaTopics(c.Topic); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if c.PartitionKey != "" {
+		if _, err := pointerstructure.Parse(c.PartitionKey); err != nil || !strings.HasPrefix(c.PartitionKey, "/") {
+			return fmt.Errorf("%s: partition key '%s' is not a valid JSON pointer: %w", op, c.PartitionKey, ErrInvalidParameter)
+		}
+	}
+	if err := c.DeliveryGuarantee.validate(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("%s: batch size must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.FlushInterval < 0 {
+		return fmt.Errorf("%s: flush interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	switch c.SaslMechanism {
+	case "":
+		if c.SaslUsername != "" || c.SaslPassword != "" {
+			return fmt.Errorf("%s: sasl username and password require a sasl mechanism: %w", op, ErrInvalidParameter)
+		}
+	case KafkaSaslPlain, KafkaSaslScramSha256, KafkaSaslScramSha512:
+		if c.SaslUsername == "" || c.SaslPassword == "" {
+			return fmt.Errorf("%s: missing sasl username or password: %w", op, ErrInvalidParameter)
+		}
+	default:
+		return fmt.Errorf("%s: '%s' is not a valid sasl mechanism: %w", op, c.SaslMechanism, ErrInvalidParameter)
+	}
+	if !c.TlsEnabled && (c.TlsCaCert != "" || c.TlsClientCert != "" || c.TlsClientKey != "" || c.TlsServerName != "" || c.TlsSkipVerify) {
+		return fmt.Errorf("%s: tls parameters require tls to be enabled: %w", op, ErrInvalidParameter)
+	}
+	if (c.TlsClientCert == "") != (c.TlsClientKey == "") {
+		return fmt.Errorf("%s: tls client cert and key must be provided together: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// kafkaTopics renders the topic template for each type of event, returning
+// the topic each type is written to.
+func kafkaTopics(topic string) (map[Type]string, error) {
+	const op = "event.kafkaTopics"
+	tmpl, err := template.New("topic").Option("missingkey=error").Parse(topic)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid topic template: %s: %w", op, err, ErrInvalidParameter)
+	}
+	topics := map[Type]string{}
+	for _, t := range []Type{AuditType, ObservationType, ErrorType, SystemType} {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, struct{ Type string }{Type: string(t)}); err != nil {
+			return nil, fmt.Errorf("%s: invalid topic template: %s: %w", op, err, ErrInvalidParameter)
+		}
+		if !kafkaTopicRegexp.MatchString(b.String()) {
+			return nil, fmt.Errorf("%s: '%s' is not a valid topic for %s events: %w", op, b.String(), t, ErrInvalidParameter)
+		}
+		topics[t] = b.String()
+	}
+	return topics, nil
+}
+
+// kafkaMessageWriter writes messages to kafka, and is satisfied by a
+// kafka.Writer
+type kafkaMessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// kafkaSink is an eventlogger sink which writes formatted events to kafka
+// topics. With an enforced delivery guarantee, each event is written as it's
+// processed and isn't sent until every in-sync replica has acknowledged it,
+// so failures are returned to the eventer.  Otherwise, events are buffered
+// and written in batches which the partition's leader acknowledges.
+type kafkaSink struct {
+	format        string
+	topics        map[Type]string
+	partitionKey  *pointerstructure.Pointer
+	guarantee     DeliveryGuarantee
+	batchSize     int
+	flushInterval time.Duration
+	writer        kafkaMessageWriter
+
+	// l protects the pending batch and its flush timer
+	l       sync.Mutex
+	pending []kafka.Message
+	timer   *time.Timer
+
+	// sendL serializes sending batches so they're delivered in order
+	sendL sync.Mutex
+}
+
+func newKafkaSink(c *KafkaSinkTypeConfig, format SinkFormat) (*kafkaSink, error) {
+	const op = "event.newKafkaSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing kafka config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	topics, err := kafkaTopics(c.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	transport := &kafka.Transport{
+		ClientID:    kafkaClientId,
+		DialTimeout: kafkaDialTimeout,
+	}
+	if c.TlsEnabled {
+		transport.TLS, err = newSinkTlsConfig(c.TlsServerName, c.TlsCaCert, c.TlsClientCert, c.TlsClientKey, c.TlsSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if c.SaslMechanism != "" {
+		transport.SASL, err = newKafkaSaslMechanism(c.SaslMechanism, c.SaslUsername, c.SaslPassword)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	s := &kafkaSink{
+		format:        string(format),
+		topics:        topics,
+		guarantee:     c.DeliveryGuarantee,
+		batchSize:     c.BatchSize,
+		flushInterval: c.FlushInterval,
+	}
+	if c.PartitionKey != "" {
+		s.partitionKey, err = pointerstructure.Parse(c.PartitionKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if s.batchSize == 0 {
+		s.batchSize = DefaultKafkaBatchSize
+	}
+	if s.flushInterval == 0 {
+		s.flushInterval = DefaultKafkaFlushInterval
+	}
+	s.writer = &kafka.Writer{
+		Addr: kafka.TCP(c.Brokers...),
+		// messages with the same key are written to the same partition, and
+		// those without a key are spread across the partitions
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: s.guarantee.kafkaRequiredAcks(),
+		MaxAttempts:  stdRetryCount + 1,
+		BatchSize:    s.batchSize,
+		BatchTimeout: kafkaBatchTimeout,
+		WriteTimeout: kafkaWriteTimeout,
+		Transport:    transport,
+	}
+	return s, nil
+}
+
+func newKafkaSaslMechanism(mechanism, username, password string) (sasl.Mechanism, error) {
+	const op = "event.newKafkaSaslMechanism"
+	var m sasl.Mechanism
+	var err error
+	switch mechanism {
+	case KafkaSaslPlain:
+		m = plain.Mechanism{Username: username, Password: password}
+	case KafkaSaslScramSha256:
+		m, err = scram.Mechanism(scram.SHA256, username, password)
+	case KafkaSaslScramSha512:
+		m, err = scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("%s: '%s' is not a valid sasl mechanism: %w", op, mechanism, ErrInvalidParameter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return m, nil
+}
+
+// Type defines the sink as a NodeTypeSink
+func (s *kafkaSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen does nothing for this type of sink.
+func (s *kafkaSink) Reopen() error { return nil }
+
+// Process writes the formatted event to its topic.  With an enforced delivery
+// guarantee the event is written before returning, otherwise it's added to
+// the pending batch, which is sent once it reaches the batch size. Buffered
+// events are acknowledged once they're added to the batch, so the failure to
+// deliver a batch sent once the flush interval passes is reported with an
+// error event.
+func (s *kafkaSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(kafkaSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	format := s.format
+	if format == "" {
+		format = eventlogger.JSONFormat
+	}
+	val, ok := e.Format(format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled", op)
+	}
+	topic, ok := s.topics[Type(e.Type)]
+	if !ok {
+		return nil, fmt.Errorf("%s: no topic for %s events: %w", op, e.Type, ErrInvalidParameter)
+	}
+	msg := kafka.Message{
+		Topic: topic,
+		Key:   s.key(val),
+		Value: bytes.TrimRight(val, "\n"),
+		Time:  e.CreatedAt,
+	}
+
+	if s.guarantee.enforced() {
+		if err := s.send(ctx, []kafka.Message{msg}); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		// Sinks are leafs, so do not return the event, since nothing more
+		// can happen to it downstream.
+		return nil, nil
+	}
+
+	s.l.Lock()
+	s.pending = append(s.pending, msg)
+	var batch []kafka.Message
+	switch {
+	case len(s.pending) >= s.batchSize:
+		batch = s.takePending()
+	case len(s.pending) == 1:
+		s.timer = time.AfterFunc(s.flushInterval, s.flushPending)
+	}
+	s.l.Unlock()
+
+	if batch != nil {
+		if err := s.send(ctx, batch); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// FlushAll sends the pending batch, if any.
+func (s *kafkaSink) FlushAll(ctx context.Context) error {
+	const op = "event.(kafkaSink).FlushAll"
+	s.l.Lock()
+	batch := s.takePending()
+	s.l.Unlock()
+	if err := s.send(ctx, batch); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// flushPending writes the pending batch once the flush interval has passed,
+// reporting a batch the brokers didn't accept with an error event.
+func (s *kafkaSink) flushPending() {
+	const op = "event.(kafkaSink).flushPending"
+	s.l.Lock()
+	batch := s.takePending()
+	s.l.Unlock()
+	if err := s.send(context.Background(), batch); err != nil {
+		WriteError(context.Background(), op, err, WithInfoMsg("dropped batch of events", "events", len(batch)))
+	}
+}
+
+// Close sends the pending batch, if any, and closes the writer along with
+// its connections to the brokers.
+func (s *kafkaSink) Close(ctx context.Context) error {
+	const op = "event.(kafkaSink).Close"
+	flushErr := s.FlushAll(ctx)
+	s.sendL.Lock()
+	defer s.sendL.Unlock()
+	if err := s.writer.Close(); err != nil {
+		return fmt.Errorf("%s: unable to close writer: %w", op, err)
+	}
+	if flushErr != nil {
+		return fmt.Errorf("%s: %w", op, flushErr)
+	}
+	return nil
+}
+
+// takePending returns the pending batch and stops its flush timer. The caller
+// must hold the lock.
+func (s *kafkaSink) takePending() []kafka.Message {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.pending
+	s.pending = nil
+	return batch
+}
+
+// send writes the messages, returning once the brokers have acknowledged
+// them as required by the delivery guarantee.  The writer retries failed
+// writes with a backoff.
+func (s *kafkaSink) send(ctx context.Context, msgs []kafka.Message) error {
+	const op = "event.(kafkaSink).send"
+	if len(msgs) == 0 {
+		return nil
+	}
+	s.sendL.Lock()
+	defer s.sendL.Unlock()
+	if err := s.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("%s: unable to send events: %w", op, err)
+	}
+	return nil
+}
+
+// key returns the message key of the formatted event, which is the value at
+// the partition key's JSON pointer.  Events without a value at the pointer,
+// or which aren't JSON, have no key.
+func (s *kafkaSink) key(val []byte) []byte {
+	if s.partitionKey == nil {
+		return nil
+	}
+	var m interface{}
+	if err := json.Unmarshal(val, &m); err != nil {
+		return nil
+	}
+	v, err := s.partitionKey.Get(m)
+	if err != nil || v == nil {
+		return nil
+	}
+	switch k := v.(type) {
+	case string:
+		if k == "" {
+			return nil
+		}
+		return []byte(k)
+	default:
+		b, err := json.Marshal(k)
+		if err != nil {
+			return nil
+		}
+		return b
+	}
+}
@@ -0,0 +1,653 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+	"github.com/segmentio/kafka-go/protocol/saslauthenticate"
+	"github.com/segmentio/kafka-go/protocol/saslhandshake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaSinkTypeConfig_Validate(t *testing.T) {
+	t.Parallel()
+	valid := func() KafkaSinkTypeConfig {
+		return KafkaSinkTypeConfig{
+			Brokers: []string{"kafka.example.com:9092"},
+			Topic:   "boundary.{{ .Type }}",
+		}
+	}
+	tests := []struct {
+		name            string
+		c               func() KafkaSinkTypeConfig
+		wantErrContains string
+	}{
+		{
+			name: "missing-brokers",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Brokers = nil
+				return c
+			},
+			wantErrContains: "missing brokers",
+		},
+		{
+			name: "invalid-broker",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Brokers = []string{"kafka.example.com"}
+				return c
+			},
+			wantErrContains: "is not a valid host:port",
+		},
+		{
+			name: "missing-topic",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Topic = ""
+				return c
+			},
+			wantErrContains: "missing topic",
+		},
+		{
+			name: "invalid-topic-template",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Topic = "boundary.{{ .Type"
+				return c
+			},
+			wantErrContains: "invalid topic template",
+		},
+		{
+			name: "unknown-topic-template-field",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Topic = "boundary.{{ .Name }}"
+				return c
+			},
+			wantErrContains: "invalid topic template",
+		},
+		{
+			name: "invalid-topic",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.Topic = "boundary events"
+				return c
+			},
+			wantErrContains: "is not a valid topic",
+		},
+		{
+			name: "invalid-partition-key",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.PartitionKey = "data.user"
+				return c
+			},
+			wantErrContains: "is not a valid JSON pointer",
+		},
+		{
+			name: "invalid-delivery-guarantee",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.DeliveryGuarantee = "always"
+				return c
+			},
+			wantErrContains: "not a valid delivery guarantee",
+		},
+		{
+			name: "negative-batch-size",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.BatchSize = -1
+				return c
+			},
+			wantErrContains: "batch size must not be negative",
+		},
+		{
+			name: "negative-flush-interval",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.FlushInterval = -time.Second
+				return c
+			},
+			wantErrContains: "flush interval must not be negative",
+		},
+		{
+			name: "invalid-sasl-mechanism",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.SaslMechanism = "gssapi"
+				return c
+			},
+			wantErrContains: "is not a valid sasl mechanism",
+		},
+		{
+			name: "sasl-missing-password",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.SaslMechanism = KafkaSaslPlain
+				c.SaslUsername = "boundary"
+				return c
+			},
+			wantErrContains: "missing sasl username or password",
+		},
+		{
+			name: "sasl-credentials-without-mechanism",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.SaslUsername = "boundary"
+				return c
+			},
+			wantErrContains: "require a sasl mechanism",
+		},
+		{
+			name: "tls-params-without-tls",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.TlsSkipVerify = true
+				return c
+			},
+			wantErrContains: "tls parameters require tls to be enabled",
+		},
+		{
+			name: "tls-key-without-cert",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.TlsEnabled = true
+				c.TlsClientKey = "/etc/boundary/key.pem"
+				return c
+			},
+			wantErrContains: "tls client cert and key must be provided together",
+		},
+		{
+			name: "valid",
+			c: func() KafkaSinkTypeConfig {
+				c := valid()
+				c.PartitionKey = "/data/auth/user_info/id"
+				c.DeliveryGuarantee = Enforced
+				c.BatchSize = 10
+				c.FlushInterval = time.Second
+				c.SaslMechanism = KafkaSaslScramSha512
+				c.SaslUsername = "boundary"
+				c.SaslPassword = "secret"
+				c.TlsEnabled = true
+				return c
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			c := tt.c()
+			err := c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func Test_kafkaTopics(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	topics, err := kafkaTopics("boundary.{{ .Type }}")
+	require.NoError(err)
+	assert.Equal(map[Type]string{
+		AuditType:       "boundary.audit",
+		ObservationType: "boundary.observation",
+		ErrorType:       "boundary.error",
+		SystemType:      "boundary.system",
+	}, topics)
+
+	topics, err = kafkaTopics("boundary-events")
+	require.NoError(err)
+	assert.Equal("boundary-events", topics[AuditType])
+	assert.Equal("boundary-events", topics[SystemType])
+}
+
+// testKafkaRecord is a record received by a testKafkaBroker
+type testKafkaRecord struct {
+	topic     string
+	partition int32
+	key       string
+	value     string
+	acks      int16
+}
+
+// testKafkaBroker is an in-process stand-in for a single kafka broker.  It
+// speaks enough of the kafka protocol for a producer to look up the topics'
+// partitions, optionally authenticate with the PLAIN sasl mechanism, and
+// produce records, which it keeps in memory.
+type testKafkaBroker struct {
+	t          *testing.T
+	ln         net.Listener
+	topics     []string
+	partitions int
+
+	l            sync.Mutex
+	saslUsername string
+	saslPassword string
+	errorCode    int16
+	records      []testKafkaRecord
+}
+
+func newTestKafkaBroker(t *testing.T, partitions int, topics ...string) *testKafkaBroker {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &testKafkaBroker{t: t, ln: ln, topics: topics, partitions: partitions}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *testKafkaBroker) addr() string {
+	return b.ln.Addr().String()
+}
+
+// setSasl requires clients to authenticate with the PLAIN sasl mechanism
+// using the username and password
+func (b *testKafkaBroker) setSasl(username, password string) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.saslUsername, b.saslPassword = username, password
+}
+
+// setErrorCode sets the kafka error code returned for produced records
+func (b *testKafkaBroker) setErrorCode(code int16) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.errorCode = code
+}
+
+func (b *testKafkaBroker) received() []testKafkaRecord {
+	b.l.Lock()
+	defer b.l.Unlock()
+	return append([]testKafkaRecord(nil), b.records...)
+}
+
+func (b *testKafkaBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	b.l.Lock()
+	username, password := b.saslUsername, b.saslPassword
+	b.l.Unlock()
+	authenticated := username == ""
+	for {
+		version, correlationId, _, msg, err := protocol.ReadRequest(r)
+		if err != nil {
+			return
+		}
+		var resp protocol.Message
+		switch req := msg.(type) {
+		case *apiversions.Request:
+			resp = &apiversions.Response{
+				ApiKeys: []apiversions.ApiKeyResponse{
+					{ApiKey: int16(protocol.Produce), MinVersion: 0, MaxVersion: 7},
+					{ApiKey: int16(protocol.Metadata), MinVersion: 0, MaxVersion: 8},
+					{ApiKey: int16(protocol.SaslHandshake), MinVersion: 1, MaxVersion: 1},
+					{ApiKey: int16(protocol.ApiVersions), MinVersion: 0, MaxVersion: 2},
+					{ApiKey: int16(protocol.SaslAuthenticate), MinVersion: 0, MaxVersion: 1},
+				},
+			}
+		case *saslhandshake.Request:
+			hr := &saslhandshake.Response{Mechanisms: []string{"PLAIN"}}
+			if req.Mechanism != "PLAIN" {
+				// UNSUPPORTED_SASL_MECHANISM
+				hr.ErrorCode = 33
+			}
+			resp = hr
+		case *saslauthenticate.Request:
+			ar := &saslauthenticate.Response{}
+			if string(req.AuthBytes) == "\x00"+username+"\x00"+password {
+				authenticated = true
+			} else {
+				// SASL_AUTHENTICATION_FAILED
+				ar.ErrorCode = 58
+				ar.ErrorMessage = "authentication failed"
+			}
+			resp = ar
+		case *metadata.Request:
+			if !authenticated {
+				return
+			}
+			host, port, err := net.SplitHostPort(b.addr())
+			require.NoError(b.t, err)
+			p, err := strconv.Atoi(port)
+			require.NoError(b.t, err)
+			mr := &metadata.Response{
+				Brokers: []metadata.ResponseBroker{{NodeID: 0, Host: host, Port: int32(p)}},
+			}
+			names := req.TopicNames
+			if names == nil {
+				names = b.topics
+			}
+			for _, name := range names {
+				topic := metadata.ResponseTopic{Name: name}
+				if !testKafkaHasTopic(b.topics, name) {
+					// UNKNOWN_TOPIC_OR_PARTITION
+					topic.ErrorCode = 3
+					mr.Topics = append(mr.Topics, topic)
+					continue
+				}
+				for i := 0; i < b.partitions; i++ {
+					topic.Partitions = append(topic.Partitions, metadata.ResponsePartition{
+						PartitionIndex: int32(i),
+						ReplicaNodes:   []int32{0},
+						IsrNodes:       []int32{0},
+					})
+				}
+				mr.Topics = append(mr.Topics, topic)
+			}
+			resp = mr
+		case *produce.Request:
+			if !authenticated {
+				return
+			}
+			pr := &produce.Response{}
+			b.l.Lock()
+			for _, topic := range req.Topics {
+				rt := produce.ResponseTopic{Topic: topic.Topic}
+				for _, partition := range topic.Partitions {
+					rt.Partitions = append(rt.Partitions, produce.ResponsePartition{
+						Partition: partition.Partition,
+						ErrorCode: b.errorCode,
+					})
+					if b.errorCode != 0 {
+						continue
+					}
+					for {
+						rec, err := partition.RecordSet.Records.ReadRecord()
+						if err != nil {
+							break
+						}
+						b.records = append(b.records, testKafkaRecord{
+							topic:     topic.Topic,
+							partition: partition.Partition,
+							key:       testKafkaBytes(rec.Key),
+							value:     testKafkaBytes(rec.Value),
+							acks:      req.Acks,
+						})
+					}
+				}
+				pr.Topics = append(pr.Topics, rt)
+			}
+			b.l.Unlock()
+			if req.Acks == 0 {
+				continue
+			}
+			resp = pr
+		default:
+			return
+		}
+		if err := protocol.WriteResponse(conn, version, correlationId, resp); err != nil {
+			return
+		}
+	}
+}
+
+func testKafkaHasTopic(topics []string, name string) bool {
+	for _, t := range topics {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+func testKafkaBytes(b protocol.Bytes) string {
+	if b == nil {
+		return ""
+	}
+	defer b.Close()
+	v, _ := io.ReadAll(b)
+	return string(v)
+}
+
+func testKafkaEvent(t *testing.T, typ Type, value string) *eventlogger.Event {
+	t.Helper()
+	e := &eventlogger.Event{Type: eventlogger.EventType(typ), CreatedAt: time.Now()}
+	e.FormattedAs(string(JSONSinkFormat), []byte(value+"\n"))
+	return e
+}
+
+func TestKafkaSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("best-effort-batch", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		broker := newTestKafkaBroker(t, 3, "boundary.audit", "boundary.observation", "boundary.error", "boundary.system")
+
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:       []string{broker.addr()},
+			Topic:         "boundary.{{ .Type }}",
+			PartitionKey:  "/data/user",
+			BatchSize:     3,
+			FlushInterval: time.Hour,
+		}, JSONSinkFormat)
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"1","data":{"user":"u_1"}}`))
+		require.NoError(err)
+		_, err = s.Process(ctx, testKafkaEvent(t, ObservationType, `{"id":"2","data":{"user":"u_2"}}`))
+		require.NoError(err)
+		assert.Empty(broker.received())
+
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"3","data":{"user":"u_1"}}`))
+		require.NoError(err)
+		got := broker.received()
+		require.Len(got, 3)
+		byId := map[string]testKafkaRecord{}
+		for _, r := range got {
+			byId[r.value] = r
+			// best effort delivery is acknowledged by the leader
+			assert.Equal(int16(1), r.acks)
+		}
+		first, second, third := byId[`{"id":"1","data":{"user":"u_1"}}`], byId[`{"id":"2","data":{"user":"u_2"}}`], byId[`{"id":"3","data":{"user":"u_1"}}`]
+		assert.Equal("boundary.audit", first.topic)
+		assert.Equal("u_1", first.key)
+		assert.Equal("boundary.observation", second.topic)
+		assert.Equal("u_2", second.key)
+		assert.Equal("boundary.audit", third.topic)
+		assert.Equal("u_1", third.key)
+		// events with the same key are written to the same partition
+		assert.Equal(first.partition, third.partition)
+
+		// A partial batch is sent when flushed, and events without a value
+		// at the partition key have no key
+		_, err = s.Process(ctx, testKafkaEvent(t, SystemType, `{"id":"4"}`))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		got = broker.received()
+		require.Len(got, 4)
+		assert.Equal("boundary.system", got[3].topic)
+		assert.Equal("", got[3].key)
+		assert.Equal(`{"id":"4"}`, got[3].value)
+	})
+
+	t.Run("best-effort-flush-interval", func(t *testing.T) {
+		require := require.New(t)
+		broker := newTestKafkaBroker(t, 1, "boundary-events")
+
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:       []string{broker.addr()},
+			Topic:         "boundary-events",
+			FlushInterval: 10 * time.Millisecond,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"1"}`))
+		require.NoError(err)
+		require.Eventually(func() bool { return len(broker.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		broker := newTestKafkaBroker(t, 1, "boundary-events")
+
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:       []string{broker.addr()},
+			Topic:         "boundary-events",
+			FlushInterval: time.Hour,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"1"}`))
+		require.NoError(err)
+		assert.Empty(broker.received())
+
+		// the pending batch is sent before the writer is closed
+		require.NoError(s.Close(ctx))
+		assert.Len(broker.received(), 1)
+		assert.Error(s.send(ctx, []kafka.Message{{Topic: "boundary-events", Value: []byte(`{"id":"2"}`)}}))
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		broker := newTestKafkaBroker(t, 1, "boundary-events")
+
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:           []string{broker.addr()},
+			Topic:             "boundary-events",
+			DeliveryGuarantee: Enforced,
+			FlushInterval:     time.Hour,
+		}, JSONSinkFormat)
+		require.NoError(err)
+
+		// each event is written before Process returns, and acknowledged by
+		// every in-sync replica
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"1"}`))
+		require.NoError(err)
+		got := broker.received()
+		require.Len(got, 1)
+		assert.Equal(int16(-1), got[0].acks)
+		assert.Equal(`{"id":"1"}`, got[0].value)
+
+		// failures are returned, so the eventer knows the event wasn't
+		// delivered
+		// NOT_ENOUGH_REPLICAS
+		broker.setErrorCode(19)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"2"}`))
+		require.Error(err)
+		assert.Contains(err.Error(), "unable to send events")
+		assert.Len(broker.received(), 1)
+
+		broker.setErrorCode(0)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"3"}`))
+		require.NoError(err)
+		assert.Len(broker.received(), 2)
+	})
+
+	t.Run("sasl-plain", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		broker := newTestKafkaBroker(t, 1, "boundary-events")
+		broker.setSasl("boundary", "secret")
+
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:           []string{broker.addr()},
+			Topic:             "boundary-events",
+			DeliveryGuarantee: Enforced,
+			SaslMechanism:     KafkaSaslPlain,
+			SaslUsername:      "boundary",
+			SaslPassword:      "secret",
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"1"}`))
+		require.NoError(err)
+		assert.Len(broker.received(), 1)
+
+		s, err = newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers:           []string{broker.addr()},
+			Topic:             "boundary-events",
+			DeliveryGuarantee: Enforced,
+			SaslMechanism:     KafkaSaslPlain,
+			SaslUsername:      "boundary",
+			SaslPassword:      "wrong",
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testKafkaEvent(t, AuditType, `{"id":"2"}`))
+		require.Error(err)
+		assert.Len(broker.received(), 1)
+	})
+
+	t.Run("missing-event", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := newKafkaSink(&KafkaSinkTypeConfig{
+			Brokers: []string{"127.0.0.1:9092"},
+			Topic:   "boundary-events",
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, nil)
+		require.Error(err)
+		assert.ErrorIs(err, ErrInvalidParameter)
+	})
+}
+
+func TestKafkaSink_key(t *testing.T) {
+	t.Parallel()
+	s, err := newKafkaSink(&KafkaSinkTypeConfig{
+		Brokers:      []string{"127.0.0.1:9092"},
+		Topic:        "boundary-events",
+		PartitionKey: "/data/connection/session_id",
+	}, JSONSinkFormat)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		val  string
+		want []byte
+	}{
+		{
+			name: "string",
+			val:  `{"data":{"connection":{"session_id":"s_1234567890"}}}`,
+			want: []byte("s_1234567890"),
+		},
+		{
+			name: "number",
+			val:  `{"data":{"connection":{"session_id":42}}}`,
+			want: []byte("42"),
+		},
+		{
+			name: "missing",
+			val:  `{"data":{}}`,
+		},
+		{
+			name: "empty",
+			val:  `{"data":{"connection":{"session_id":""}}}`,
+		},
+		{
+			name: "not-json",
+			val:  `session_id=s_1234567890`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.key([]byte(tt.val)))
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+)
+
+const (
+	SyslogUdpNetwork  = "udp"  // SyslogUdpNetwork sends messages over UDP
+	SyslogTcpNetwork  = "tcp"  // SyslogTcpNetwork sends messages over TCP
+	SyslogTlsNetwork  = "tls"  // SyslogTlsNetwork sends messages over TCP using TLS
+	SyslogUnixNetwork = "unix" // SyslogUnixNetwork sends messages to a local unix socket
+
+	// DefaultSyslogFacility is the facility used when none is configured
+	DefaultSyslogFacility = "local0"
+
+	// DefaultSyslogAppName is the app name used when none is configured
+	DefaultSyslogAppName = "boundary"
+
+	// syslogDialTimeout is the timeout when dialing a syslog server
+	syslogDialTimeout = 10 * time.Second
+
+	// syslogTimestampFormat is the RFC 5424 timestamp format, which only
+	// allows up to microsecond precision
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+var syslogSeverities = map[string]int{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+// defaultSyslogSeverities maps event types to the severity their messages
+// are sent with, unless overridden in the sink's config
+var defaultSyslogSeverities = map[Type]string{
+	ErrorType:       "err",
+	AuditType:       "notice",
+	ObservationType: "info",
+	SystemType:      "info",
+}
+
+// SyslogSinkTypeConfig contains configuration structures for syslog sink types
+type SyslogSinkTypeConfig struct {
+	Network       string            `hcl:"network"         mapstructure:"network"`         // Network defines how to connect to the syslog server (udp, tcp, tls or unix)
+	Address       string            `hcl:"address"         mapstructure:"address"`         // Address defines the host:port of the syslog server, or the path of the socket for the unix network
+	Facility      string            `hcl:"facility"        mapstructure:"facility"`        // Facility defines the syslog facility of the messages (defaults to local0)
+	AppName       string            `hcl:"app_name"        mapstructure:"app_name"`        // AppName defines the syslog app name of the messages (defaults to boundary)
+	Severities    map[string]string `hcl:"severities"      mapstructure:"severities"`      // Severities defines overrides of the severity used for each event type
+	TlsCaCert     string            `hcl:"tls_ca_cert"     mapstructure:"tls_ca_cert"`     // TlsCaCert defines a PEM file of CA certificates used to verify the server when using tls
+	TlsClientCert string            `hcl:"tls_client_cert" mapstructure:"tls_client_cert"` // TlsClientCert defines a PEM file with a client certificate to present when using tls
+	TlsClientKey  string            `hcl:"tls_client_key"  mapstructure:"tls_client_key"`  // TlsClientKey defines a PEM file with the key of the client certificate
+	TlsServerName string            `hcl:"tls_server_name" mapstructure:"tls_server_name"` // TlsServerName overrides the name used to verify the server's certificate
+	TlsSkipVerify bool              `hcl:"tls_skip_verify" mapstructure:"tls_skip_verify"` // TlsSkipVerify disables verification of the server's certificate
+}
+
+// Validate a SyslogSinkTypeConfig
+func (c *SyslogSinkTypeConfig) Validate() error {
+	const op = "event.(SyslogSinkTypeConfig).Validate"
+	switch c.Network {
+	case SyslogUdpNetwork, SyslogTcpNetwork, SyslogTlsNetwork, SyslogUnixNetwork:
+	case "":
+		return fmt.Errorf("%s: missing syslog network: %w", op, ErrInvalidParameter)
+	default:
+		return fmt.Errorf("%s: '%s' is not a valid syslog network: %w", op, c.Network, ErrInvalidParameter)
+	}
+	if c.Address == "" {
+		return fmt.Errorf("%s: missing syslog address: %w", op, ErrInvalidParameter)
+	}
+	if c.Facility != "" {
+		if _, ok := syslogFacilities[c.Facility]; !ok {
+			return fmt.Errorf("%s: '%s' is not a valid syslog facility: %w", op, c.Facility, ErrInvalidParameter)
+		}
+	}
+	if len(c.AppName) > 48 || strings.ContainsAny(c.AppName, " \t\n") {
+		return fmt.Errorf("%s: syslog app name must be at most 48 characters without whitespace: %w", op, ErrInvalidParameter)
+	}
+	for t, s := range c.Severities {
+		if err := Type(t).Validate(); err != nil || Type(t) == EveryType {
+			return fmt.Errorf("%s: '%s' is not a valid event type for a syslog severity: %w", op, t, ErrInvalidParameter)
+		}
+		if _, ok := syslogSeverities[s]; !ok {
+			return fmt.Errorf("%s: '%s' is not a valid syslog severity: %w", op, s, ErrInvalidParameter)
+		}
+	}
+	if c.Network != SyslogTlsNetwork && (c.TlsCaCert != "" || c.TlsClientCert != "" || c.TlsClientKey != "" || c.TlsServerName != "" || c.TlsSkipVerify) {
+		return fmt.Errorf("%s: tls parameters are only supported with the tls syslog network: %w", op, ErrInvalidParameter)
+	}
+	if (c.TlsClientCert == "") != (c.TlsClientKey == "") {
+		return fmt.Errorf("%s: tls client cert and key must be provided together: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// syslogSink is an eventlogger sink which sends formatted events to a syslog
+// server as RFC 5424 messages. Messages sent over stream networks are framed
+// using octet counting (RFC 6587).
+type syslogSink struct {
+	format     string
+	network    string
+	address    string
+	tlsConfig  *tls.Config
+	facility   int
+	appName    string
+	hostname   string
+	procId     string
+	severities map[Type]int
+
+	// retries and backoff control reconnecting to the server when writing
+	// a message fails
+	retries uint
+	backoff backoff
+
+	l    sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(c *SyslogSinkTypeConfig, format SinkFormat) (*syslogSink, error) {
+	const op = "event.newSyslogSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing syslog config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	facility := c.Facility
+	if facility == "" {
+		facility = DefaultSyslogFacility
+	}
+	appName := c.AppName
+	if appName == "" {
+		appName = DefaultSyslogAppName
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	severities := make(map[Type]int, len(defaultSyslogSeverities))
+	for t, s := range defaultSyslogSeverities {
+		severities[t] = syslogSeverities[s]
+	}
+	for t, s := range c.Severities {
+		severities[Type(t)] = syslogSeverities[s]
+	}
+
+	s := &syslogSink{
+		format:     string(format),
+		network:    c.Network,
+		address:    c.Address,
+		facility:   syslogFacilities[facility],
+		appName:    appName,
+		hostname:   hostname,
+		procId:     strconv.Itoa(os.Getpid()),
+		severities: severities,
+		retries:    stdRetryCount,
+		backoff:    expBackoff{},
+	}
+	if c.Network == SyslogTlsNetwork {
+		if s.tlsConfig, err = syslogTlsConfig(c); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return s, nil
+}
+
+func syslogTlsConfig(c *SyslogSinkTypeConfig) (*tls.Config, error) {
+	const op = "event.syslogTlsConfig"
+	serverName := c.TlsServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(c.Address)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to parse syslog address: %w", op, err)
+		}
+		serverName = host
+	}
+	tlsConfig, err := newSinkTlsConfig(serverName, c.TlsCaCert, c.TlsClientCert, c.TlsClientKey, c.TlsSkipVerify)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tlsConfig, nil
+}
+
+// Type defines the sink as a NodeTypeSink
+func (s *syslogSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen closes the connection to the syslog server, which is reestablished
+// when the next event is processed.
+func (s *syslogSink) Reopen() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.closeConn()
+}
+
+// Process sends the formatted event to the syslog server, reconnecting with
+// a backoff if sending fails.
+func (s *syslogSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(syslogSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	format := s.format
+	if format == "" {
+		format = eventlogger.JSONFormat
+	}
+	val, ok := e.Format(format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled", op)
+	}
+	msg := s.message(e, val)
+
+	var err error
+	for attempt := uint(0); attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%s: %w", op, ctx.Err())
+			case <-time.After(s.backoff.duration(attempt)):
+			}
+		}
+		var conn net.Conn
+		if conn, err = s.connection(ctx); err != nil {
+			continue
+		}
+		if err = s.write(conn, msg); err == nil {
+			// Sinks are leafs, so do not return the event, since nothing
+			// more can happen to it downstream.
+			return nil, nil
+		}
+		s.dropConn(conn)
+	}
+	return nil, fmt.Errorf("%s: unable to send event to syslog server: %w", op, err)
+}
+
+// message returns the RFC 5424 message for the formatted event, framed for
+// the sink's network.
+func (s *syslogSink) message(e *eventlogger.Event, val []byte) []byte {
+	severity, ok := s.severities[Type(e.Type)]
+	if !ok {
+		severity = syslogSeverities["info"]
+	}
+	msgId := string(e.Type)
+	if msgId == "" {
+		msgId = "-"
+	}
+	createdAt := e.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s - ",
+		s.facility*8+severity,
+		createdAt.UTC().Format(syslogTimestampFormat),
+		s.hostname,
+		s.appName,
+		s.procId,
+		msgId,
+	)
+	b.Write(bytes.TrimRight(val, "\n"))
+
+	switch s.network {
+	case SyslogTcpNetwork, SyslogTlsNetwork:
+		return append([]byte(strconv.Itoa(b.Len())+" "), b.Bytes()...)
+	default:
+		return b.Bytes()
+	}
+}
+
+// connection returns the connection to the server, dialing one if there's
+// none. The lock is only held while the connection is swapped, so dialing a
+// slow server doesn't block other events.
+func (s *syslogSink) connection(ctx context.Context) (net.Conn, error) {
+	s.l.Lock()
+	conn := s.conn
+	s.l.Unlock()
+	if conn != nil {
+		return conn, nil
+	}
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.conn != nil {
+		// Another event connected first, so use its connection
+		_ = conn.Close()
+		return s.conn, nil
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+// dropConn closes the connection if it's still the current one, so the next
+// event reconnects.
+func (s *syslogSink) dropConn(conn net.Conn) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.conn == conn {
+		_ = s.closeConn()
+	}
+}
+
+// write sends the message on the connection. Writes to a connection are
+// safe for concurrent use, so the lock isn't held.
+func (s *syslogSink) write(conn net.Conn, msg []byte) error {
+	if s.network == SyslogUnixNetwork && conn.RemoteAddr() != nil && conn.RemoteAddr().Network() == "unix" {
+		// Messages on a local stream socket are newline delimited
+		msg = append(msg, '\n')
+	}
+	_, err := conn.Write(msg)
+	return err
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: syslogDialTimeout}
+	switch s.network {
+	case SyslogTlsNetwork:
+		td := &tls.Dialer{NetDialer: d, Config: s.tlsConfig}
+		return td.DialContext(ctx, "tcp", s.address)
+	case SyslogUnixNetwork:
+		// Local syslog daemons usually listen on a datagram socket, but fall
+		// back to a stream socket like the standard library does.
+		conn, err := d.DialContext(ctx, "unixgram", s.address)
+		if err == nil {
+			return conn, nil
+		}
+		return d.DialContext(ctx, "unix", s.address)
+	default:
+		return d.DialContext(ctx, s.network, s.address)
+	}
+}
+
+// closeConn closes the current connection, if any. The caller must hold the
+// lock.
+func (s *syslogSink) closeConn() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+	return nil
+}
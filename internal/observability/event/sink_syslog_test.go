@@ -0,0 +1,373 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogSinkTypeConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		c               SyslogSinkTypeConfig
+		wantErrContains string
+	}{
+		{
+			name:            "missing-network",
+			c:               SyslogSinkTypeConfig{Address: "127.0.0.1:514"},
+			wantErrContains: "missing syslog network",
+		},
+		{
+			name:            "invalid-network",
+			c:               SyslogSinkTypeConfig{Network: "sctp", Address: "127.0.0.1:514"},
+			wantErrContains: "'sctp' is not a valid syslog network",
+		},
+		{
+			name:            "missing-address",
+			c:               SyslogSinkTypeConfig{Network: SyslogUdpNetwork},
+			wantErrContains: "missing syslog address",
+		},
+		{
+			name:            "invalid-facility",
+			c:               SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514", Facility: "local9"},
+			wantErrContains: "'local9' is not a valid syslog facility",
+		},
+		{
+			name:            "invalid-app-name",
+			c:               SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514", AppName: "bound ary"},
+			wantErrContains: "syslog app name must be at most 48 characters",
+		},
+		{
+			name:            "invalid-severity-event-type",
+			c:               SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514", Severities: map[string]string{"*": "info"}},
+			wantErrContains: "'*' is not a valid event type for a syslog severity",
+		},
+		{
+			name:            "invalid-severity",
+			c:               SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514", Severities: map[string]string{"audit": "loud"}},
+			wantErrContains: "'loud' is not a valid syslog severity",
+		},
+		{
+			name:            "tls-params-without-tls",
+			c:               SyslogSinkTypeConfig{Network: SyslogTcpNetwork, Address: "127.0.0.1:514", TlsSkipVerify: true},
+			wantErrContains: "tls parameters are only supported with the tls syslog network",
+		},
+		{
+			name:            "tls-cert-without-key",
+			c:               SyslogSinkTypeConfig{Network: SyslogTlsNetwork, Address: "127.0.0.1:514", TlsClientCert: "cert.pem"},
+			wantErrContains: "tls client cert and key must be provided together",
+		},
+		{
+			name: "valid",
+			c: SyslogSinkTypeConfig{
+				Network:    SyslogTlsNetwork,
+				Address:    "127.0.0.1:6514",
+				Facility:   "auth",
+				AppName:    "boundary",
+				Severities: map[string]string{"audit": "info", "error": "crit"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := tt.c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSyslogSink_message(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2022, 7, 4, 12, 30, 15, 123456789, time.UTC)
+	tests := []struct {
+		name       string
+		network    string
+		severities map[string]string
+		eventType  Type
+		want       string
+	}{
+		{
+			name:      "udp-audit",
+			network:   SyslogUdpNetwork,
+			eventType: AuditType,
+			want:      "<133>1 2022-07-04T12:30:15.123456Z host boundary 42 audit - {\"id\":\"1\"}",
+		},
+		{
+			name:      "udp-error",
+			network:   SyslogUdpNetwork,
+			eventType: ErrorType,
+			want:      "<131>1 2022-07-04T12:30:15.123456Z host boundary 42 error - {\"id\":\"1\"}",
+		},
+		{
+			name:       "udp-severity-override",
+			network:    SyslogUdpNetwork,
+			severities: map[string]string{"audit": "crit"},
+			eventType:  AuditType,
+			want:       "<130>1 2022-07-04T12:30:15.123456Z host boundary 42 audit - {\"id\":\"1\"}",
+		},
+		{
+			name:      "tcp-octet-counting",
+			network:   SyslogTcpNetwork,
+			eventType: ObservationType,
+			want:      "76 <134>1 2022-07-04T12:30:15.123456Z host boundary 42 observation - {\"id\":\"1\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			s, err := newSyslogSink(&SyslogSinkTypeConfig{
+				Network:    tt.network,
+				Address:    "127.0.0.1:514",
+				Severities: tt.severities,
+			}, JSONSinkFormat)
+			require.NoError(err)
+			s.hostname = "host"
+			s.procId = "42"
+
+			e := &eventlogger.Event{Type: eventlogger.EventType(tt.eventType), CreatedAt: createdAt}
+			got := s.message(e, []byte("{\"id\":\"1\"}\n"))
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestSyslogSink_Process(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testEvent := func(t *testing.T, value string) *eventlogger.Event {
+		t.Helper()
+		e := &eventlogger.Event{Type: eventlogger.EventType(AuditType), CreatedAt: time.Now()}
+		e.FormattedAs(string(JSONSinkFormat), []byte(value+"\n"))
+		return e
+	}
+
+	t.Run("udp", func(t *testing.T) {
+		require := require.New(t)
+		l, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(err)
+		t.Cleanup(func() { l.Close() })
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: l.LocalAddr().String()}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(t, "udp-event"))
+		require.NoError(err)
+
+		buf := make([]byte, 1024)
+		require.NoError(l.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		n, _, err := l.ReadFrom(buf)
+		require.NoError(err)
+		got := string(buf[:n])
+		assert.True(t, strings.HasPrefix(got, "<133>1 "), got)
+		assert.True(t, strings.HasSuffix(got, " audit - udp-event"), got)
+	})
+
+	t.Run("unix", func(t *testing.T) {
+		require := require.New(t)
+		path := filepath.Join(t.TempDir(), "syslog.sock")
+		l, err := net.ListenPacket("unixgram", path)
+		require.NoError(err)
+		t.Cleanup(func() { l.Close() })
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogUnixNetwork, Address: path}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(t, "unix-event"))
+		require.NoError(err)
+
+		buf := make([]byte, 1024)
+		require.NoError(l.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		n, _, err := l.ReadFrom(buf)
+		require.NoError(err)
+		assert.True(t, strings.HasSuffix(string(buf[:n]), " audit - unix-event"))
+	})
+
+	t.Run("tcp-reconnect", func(t *testing.T) {
+		require := require.New(t)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(err)
+		addr := l.Addr().String()
+		msgs := acceptSyslogMessages(t, l)
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogTcpNetwork, Address: addr}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(t, "first-event"))
+		require.NoError(err)
+		assert.True(t, strings.HasSuffix(receiveSyslogMessage(t, msgs), " audit - first-event"))
+
+		// Reopening drops the connection, and the next event reconnects
+		require.NoError(s.Reopen())
+		_, err = s.Process(ctx, testEvent(t, "second-event"))
+		require.NoError(err)
+		assert.True(t, strings.HasSuffix(receiveSyslogMessage(t, msgs), " audit - second-event"))
+
+		// Once the server is gone, sending fails after retrying
+		require.NoError(l.Close())
+		require.NoError(s.Reopen())
+		s.backoff = &testSinkBackoff{}
+		_, err = s.Process(ctx, testEvent(t, "third-event"))
+		require.Error(err)
+		assert.Contains(t, err.Error(), "unable to send event to syslog server")
+		assert.Equal(t, uint(stdRetryCount), s.backoff.(*testSinkBackoff).calls)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		require := require.New(t)
+		certs := testSinkCerts(t)
+		l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certs.serverCert}})
+		require.NoError(err)
+		msgs := acceptSyslogMessages(t, l)
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{
+			Network:   SyslogTlsNetwork,
+			Address:   l.Addr().String(),
+			TlsCaCert: certs.caFile,
+		}, JSONSinkFormat)
+		require.NoError(err)
+		_, err = s.Process(ctx, testEvent(t, "tls-event"))
+		require.NoError(err)
+		assert.True(t, strings.HasSuffix(receiveSyslogMessage(t, msgs), " audit - tls-event"))
+	})
+
+	t.Run("tls-untrusted", func(t *testing.T) {
+		require := require.New(t)
+		certs := testSinkCerts(t)
+		l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certs.serverCert}})
+		require.NoError(err)
+		acceptSyslogMessages(t, l)
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogTlsNetwork, Address: l.Addr().String()}, JSONSinkFormat)
+		require.NoError(err)
+		s.backoff = &testSinkBackoff{}
+		_, err = s.Process(ctx, testEvent(t, "tls-event"))
+		require.Error(err)
+	})
+
+	t.Run("backoff-without-lock", func(t *testing.T) {
+		require := require.New(t)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(err)
+		addr := l.Addr().String()
+		require.NoError(l.Close())
+
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogTcpNetwork, Address: addr}, JSONSinkFormat)
+		require.NoError(err)
+		b := &blockingSinkBackoff{waiting: make(chan struct{}), release: make(chan struct{})}
+		s.backoff = b
+		processErr := make(chan error)
+		go func() {
+			_, err := s.Process(ctx, testEvent(t, "blocked-event"))
+			processErr <- err
+		}()
+
+		// While the event backs off from the unavailable server, the sink's
+		// lock is available to others
+		<-b.waiting
+		reopened := make(chan error)
+		go func() { reopened <- s.Reopen() }()
+		select {
+		case err := <-reopened:
+			require.NoError(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("reopen blocked by an event backing off")
+		}
+		close(b.release)
+		require.Error(<-processErr)
+	})
+
+	t.Run("missing-event", func(t *testing.T) {
+		s, err := newSyslogSink(&SyslogSinkTypeConfig{Network: SyslogUdpNetwork, Address: "127.0.0.1:514"}, JSONSinkFormat)
+		require.NoError(t, err)
+		_, err = s.Process(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+type testSinkBackoff struct {
+	calls uint
+}
+
+func (b *testSinkBackoff) duration(uint) time.Duration {
+	b.calls++
+	return time.Millisecond
+}
+
+// blockingSinkBackoff signals the first time it's used and then blocks
+// every use until released.
+type blockingSinkBackoff struct {
+	once    sync.Once
+	waiting chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingSinkBackoff) duration(uint) time.Duration {
+	b.once.Do(func() { close(b.waiting) })
+	<-b.release
+	return time.Millisecond
+}
+
+// acceptSyslogMessages accepts connections on the listener and returns a
+// channel of the octet counted messages received over them.
+func acceptSyslogMessages(t *testing.T, l net.Listener) <-chan string {
+	t.Helper()
+	msgs := make(chan string, 10)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					length, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+					if err != nil {
+						return
+					}
+					buf := make([]byte, n)
+					if _, err := io.ReadFull(r, buf); err != nil {
+						return
+					}
+					msgs <- string(buf)
+				}
+			}()
+		}
+	}()
+	return msgs
+}
+
+func receiveSyslogMessage(t *testing.T, msgs <-chan string) string {
+	t.Helper()
+	select {
+	case msg := <-msgs:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for syslog message")
+		return ""
+	}
+}
@@ -0,0 +1,39 @@
+package event
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// newSinkTlsConfig returns the tls config used by sinks which send events to
+// a server. caCert, clientCert and clientKey are paths to PEM files and are
+// optional.
+func newSinkTlsConfig(serverName, caCert, clientCert, clientKey string, skipVerify bool) (*tls.Config, error) {
+	const op = "event.newSinkTlsConfig"
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         serverName,
+		InsecureSkipVerify: skipVerify,
+	}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to read tls ca cert: %w", op, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates found in tls ca cert: %w", op, ErrInvalidParameter)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to load tls client cert: %w", op, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
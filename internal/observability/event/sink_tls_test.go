@@ -0,0 +1,143 @@
+package event
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCerts struct {
+	caFile     string
+	caPool     *x509.CertPool
+	serverCert tls.Certificate
+	clientCert string
+	clientKey  string
+}
+
+// testSinkCerts returns a CA, written to a file, along with a server
+// certificate for 127.0.0.1 and a client certificate, written to files, which
+// are both signed by it.
+func testSinkCerts(t *testing.T) *testCerts {
+	t.Helper()
+	require := require.New(t)
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sink-test-ca"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(err)
+	caCert, err := x509.ParseCertificate(caDer)
+	require.NoError(err)
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage) ([]byte, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(time.Hour),
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(err)
+		return der, key
+	}
+	serverDer, serverKey := issue(2, "127.0.0.1", x509.ExtKeyUsageServerAuth)
+	clientDer, clientKey := issue(3, "sink-test-client", x509.ExtKeyUsageClientAuth)
+
+	certs := &testCerts{
+		caFile:     filepath.Join(dir, "ca.pem"),
+		caPool:     x509.NewCertPool(),
+		serverCert: tls.Certificate{Certificate: [][]byte{serverDer}, PrivateKey: serverKey},
+		clientCert: filepath.Join(dir, "client.pem"),
+		clientKey:  filepath.Join(dir, "client-key.pem"),
+	}
+	certs.caPool.AddCert(caCert)
+	require.NoError(os.WriteFile(certs.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDer}), 0o600))
+	require.NoError(os.WriteFile(certs.clientCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientDer}), 0o600))
+	keyDer, err := x509.MarshalECPrivateKey(clientKey)
+	require.NoError(err)
+	require.NoError(os.WriteFile(certs.clientKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certs
+}
+
+func Test_newSinkTlsConfig(t *testing.T) {
+	t.Parallel()
+	certs := testSinkCerts(t)
+	notPem := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPem, []byte("not a pem"), 0o600))
+
+	tests := []struct {
+		name            string
+		caCert          string
+		clientCert      string
+		clientKey       string
+		wantErrContains string
+	}{
+		{
+			name: "no-files",
+		},
+		{
+			name:       "all-files",
+			caCert:     certs.caFile,
+			clientCert: certs.clientCert,
+			clientKey:  certs.clientKey,
+		},
+		{
+			name:            "missing-ca-cert",
+			caCert:          filepath.Join(t.TempDir(), "missing.pem"),
+			wantErrContains: "unable to read tls ca cert",
+		},
+		{
+			name:            "invalid-ca-cert",
+			caCert:          notPem,
+			wantErrContains: "no certificates found in tls ca cert",
+		},
+		{
+			name:            "invalid-client-cert",
+			clientCert:      notPem,
+			clientKey:       certs.clientKey,
+			wantErrContains: "unable to load tls client cert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := newSinkTlsConfig("127.0.0.1", tt.caCert, tt.clientCert, tt.clientKey, false)
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal("127.0.0.1", got.ServerName)
+			assert.Equal(tt.caCert != "", got.RootCAs != nil)
+			assert.Equal(tt.clientCert != "", len(got.Certificates) == 1)
+		})
+	}
+}
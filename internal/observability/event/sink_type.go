@@ -8,14 +8,17 @@ const (
 	StderrSink SinkType = "stderr" // StderrSink is written to stderr
 	FileSink   SinkType = "file"   // FileSink is written to a file
 	WriterSink SinkType = "writer" // WriterSink is written to an io.Writer
+	SyslogSink SinkType = "syslog" // SyslogSink is sent to a syslog server
+	HttpSink   SinkType = "http"   // HttpSink is POSTed in batches to an http endpoint
+	KafkaSink  SinkType = "kafka"  // KafkaSink is written to kafka topics
 )
 
-type SinkType string // SinkType defines the type of sink in a config stanza (file, stderr, writer)
+type SinkType string // SinkType defines the type of sink in a config stanza (file, stderr, writer, syslog, http, kafka)
 
 func (t SinkType) Validate() error {
 	const op = "event.(SinkType).validate"
 	switch t {
-	case StderrSink, FileSink, WriterSink:
+	case StderrSink, FileSink, WriterSink, SyslogSink, HttpSink, KafkaSink:
 		return nil
 	default:
 		return fmt.Errorf("%s: '%s' is not a valid sink type: %w", op, t, ErrInvalidParameter)
@@ -0,0 +1,380 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+	"github.com/hashicorp/eventlogger/filters/gated"
+)
+
+const (
+	tailPipeline = "tail-pipeline" // tailPipeline is a pipeline for tailing events of every type
+
+	// tailBufferSize is the number of events buffered for each tail
+	// subscriber.  Events are dropped for a subscriber when its buffer is
+	// full, so a slow subscriber never delays the delivery of events to sinks.
+	tailBufferSize = 256
+)
+
+// Tail subscribes to the events of the given types which are sent by the
+// eventer, until the ctx is done.  The returned channel receives each event
+// as a JSON formatted cloudevent and is closed when the subscription ends.
+//
+// Audit events are always tailed with both their sensitive and secret data
+// redacted, regardless of the filter operations configured for audit sinks.
+// The audit field overrides of the configured sinks are honored, using the
+// most restrictive classification when sinks disagree.
+//
+// Supports the options of: WithAllow and WithDeny, which are bexpr filters
+// evaluated against the cloudevent in the same way as a sink's allow and deny
+// filters.
+func (e *Eventer) Tail(ctx context.Context, types []Type, opt ...Option) (<-chan []byte, error) {
+	const op = "event.(Eventer).Tail"
+	if len(types) == 0 {
+		return nil, fmt.Errorf("%s: missing event types: %w", op, ErrInvalidParameter)
+	}
+	for _, t := range types {
+		if err := t.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	opts := getOpts(opt...)
+	_, fmtNode, err := newFmtFilterNode(e.serverName, SinkConfig{
+		Format:       JSONSinkFormat,
+		AllowFilters: opts.withAllow,
+		DenyFilters:  opts.withDeny,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid filter: %w: %s", op, ErrInvalidParameter, err)
+	}
+	subs, err := e.tailSubscribers()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	id, err := NewId("tail")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	sub := &tailSubscriber{
+		id:        id,
+		types:     make(map[Type]struct{}, len(types)),
+		formatter: fmtNode,
+		events:    make(chan []byte, tailBufferSize),
+	}
+	for _, t := range types {
+		sub.types[t] = struct{}{}
+	}
+	if err := e.subscribeTail(subs, sub); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	go func() {
+		<-ctx.Done()
+		e.unsubscribeTail(subs, sub)
+	}()
+	return sub.events, nil
+}
+
+// subscribeTail adds the subscriber, registering the tail pipelines with the
+// broker when it's the only subscriber.
+func (e *Eventer) subscribeTail(subs *tailSubscribers, sub *tailSubscriber) error {
+	const op = "event.(Eventer).subscribeTail"
+	e.tailLock.Lock()
+	defer e.tailLock.Unlock()
+	if subs.subscribe(sub) > 1 {
+		return nil
+	}
+	for i, p := range subs.pipelines {
+		if err := e.broker.RegisterPipeline(p); err != nil {
+			for _, registered := range subs.pipelines[:i] {
+				_ = e.broker.RemovePipeline(registered.EventType, registered.PipelineID)
+			}
+			subs.unsubscribe(sub)
+			return fmt.Errorf("%s: failed to register %s tail pipeline: %w", op, p.EventType, err)
+		}
+	}
+	return nil
+}
+
+// unsubscribeTail removes the subscriber, removing the tail pipelines from
+// the broker when it was the last subscriber, so events are no longer
+// processed by the tail's nodes.
+func (e *Eventer) unsubscribeTail(subs *tailSubscribers, sub *tailSubscriber) {
+	const op = "event.(Eventer).unsubscribeTail"
+	e.tailLock.Lock()
+	defer e.tailLock.Unlock()
+	if subs.unsubscribe(sub) > 0 {
+		return
+	}
+	for _, p := range subs.pipelines {
+		if err := e.broker.RemovePipeline(p.EventType, p.PipelineID); err != nil {
+			WriteError(context.Background(), op, err, WithInfoMsg("unable to remove tail pipeline", "event_type", p.EventType))
+		}
+	}
+}
+
+// tailSubscribers returns the eventer's tail subscribers, registering the
+// tail nodes with the broker the first time it's called.  The nodes are
+// registered lazily, so eventers which are never tailed don't pay for them,
+// and the tail pipelines are only registered while there are subscribers.
+func (e *Eventer) tailSubscribers() (*tailSubscribers, error) {
+	const op = "event.(Eventer).tailSubscribers"
+	e.tailLock.Lock()
+	defer e.tailLock.Unlock()
+	if e.tail != nil {
+		return e.tail, nil
+	}
+
+	subs := &tailSubscribers{subscribers: map[string]*tailSubscriber{}}
+	id, err := NewId("tail-fmt")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	fmtId := eventlogger.NodeID(id)
+	if err := e.broker.RegisterNode(fmtId, &tailFormatterFilter{subs}); err != nil {
+		return nil, fmt.Errorf("%s: unable to register tail formatter filter: %w", op, err)
+	}
+	id, err = NewId("tail-sink")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	sinkId := eventlogger.NodeID(id)
+	if err := e.broker.RegisterNode(sinkId, &tailSink{subs}); err != nil {
+		return nil, fmt.Errorf("%s: unable to register tail sink: %w", op, err)
+	}
+
+	encryptFilter, err := NewAuditEncryptFilter()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// redacting doesn't require a wrapper, so the tail's encrypt filter never
+	// needs to be rotated.
+	encryptFilter.FilterOperationOverrides = map[encrypt.DataClassification]encrypt.FilterOperation{
+		encrypt.SensitiveClassification: encrypt.RedactOperation,
+		encrypt.SecretClassification:    encrypt.RedactOperation,
+	}
+	var encryptNode eventlogger.Node = encryptFilter
+	if fo := e.tailFieldOverrides(); len(fo) > 0 {
+		encryptNode, err = newAuditFieldOverrideFilter(encryptFilter, fo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	id, err = NewId("encrypt-tail")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	encryptFilterId := eventlogger.NodeID(id)
+	if err := e.broker.RegisterNode(encryptFilterId, encryptNode); err != nil {
+		return nil, fmt.Errorf("%s: unable to register tail encrypt filter: %w", op, err)
+	}
+
+	for _, t := range []Type{AuditType, ObservationType, ErrorType, SystemType} {
+		var nodeIds []eventlogger.NodeID
+		switch t {
+		case AuditType, ObservationType:
+			// the gate has no broker, so expired gated events are simply
+			// deleted rather than being resent to every pipeline.
+			id, err := NewId(fmt.Sprintf("gated-tail-%s", t))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			gateId := eventlogger.NodeID(id)
+			if err := e.broker.RegisterNode(gateId, &gated.Filter{}); err != nil {
+				return nil, fmt.Errorf("%s: unable to register tail gated filter: %w", op, err)
+			}
+			nodeIds = append(nodeIds, gateId)
+		}
+		if t == AuditType {
+			nodeIds = append(nodeIds, encryptFilterId)
+		}
+		pipeId, err := NewId(tailPipeline)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		subs.pipelines = append(subs.pipelines, eventlogger.Pipeline{
+			EventType:  eventlogger.EventType(t),
+			PipelineID: eventlogger.PipelineID(pipeId),
+			// order of nodes is important!  gate (aggregate), then encrypt,
+			// then filter/format and write to subscribers
+			NodeIDs: append(nodeIds, fmtId, sinkId),
+		})
+	}
+	e.tail = subs
+	return e.tail, nil
+}
+
+// tailFieldOverrides merges the audit field overrides of every audit sink,
+// using the most restrictive classification for each field.
+func (e *Eventer) tailFieldOverrides() AuditFieldOverrides {
+	restriction := map[DataClassification]int{
+		PublicClassification:    0,
+		SensitiveClassification: 1,
+		SecretClassification:    2,
+	}
+	var merged AuditFieldOverrides
+	for _, p := range e.auditPipelines {
+		if p.sinkConfig == nil || p.sinkConfig.AuditConfig == nil {
+			continue
+		}
+		for path, c := range p.sinkConfig.AuditConfig.FieldOverrides {
+			if merged == nil {
+				merged = AuditFieldOverrides{}
+			}
+			if current, ok := merged[path]; !ok || restriction[c] > restriction[current] {
+				merged[path] = c
+			}
+		}
+	}
+	return merged
+}
+
+// tailSubscriber is a single subscription to an eventer's events
+type tailSubscriber struct {
+	id        string
+	types     map[Type]struct{}
+	formatter eventlogger.Node
+	events    chan []byte
+}
+
+// wants returns true if the subscriber wants events of the given type
+func (s *tailSubscriber) wants(t Type) bool {
+	if _, ok := s.types[EveryType]; ok {
+		return true
+	}
+	_, ok := s.types[t]
+	return ok
+}
+
+// tailSubscribers is the set of tail subscribers shared by the tail
+// pipelines' formatter filter and sink.
+type tailSubscribers struct {
+	subscribers map[string]*tailSubscriber
+	// pipelines are the tail pipelines, which are registered with the broker
+	// while there are subscribers.
+	pipelines []eventlogger.Pipeline
+	l         sync.RWMutex
+}
+
+// subscribe adds the subscriber and returns the number of subscribers.
+func (s *tailSubscribers) subscribe(sub *tailSubscriber) int {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.subscribers[sub.id] = sub
+	return len(s.subscribers)
+}
+
+// unsubscribe removes the subscriber, closing its events, and returns the
+// number of remaining subscribers.
+func (s *tailSubscribers) unsubscribe(sub *tailSubscriber) int {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if _, ok := s.subscribers[sub.id]; ok {
+		delete(s.subscribers, sub.id)
+		close(sub.events)
+	}
+	return len(s.subscribers)
+}
+
+// tailFormatterFilter is an eventlogger formatter filter which formats the
+// event with each interested subscriber's filters.  The formatted values are
+// keyed by subscriber id, so the tail sink can write them to the matching
+// subscribers.
+type tailFormatterFilter struct {
+	*tailSubscribers
+}
+
+var _ eventlogger.Node = &tailFormatterFilter{}
+
+// Type describes the type of the node as a Formatter Filter.
+func (f *tailFormatterFilter) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeFormatterFilter
+}
+
+// Reopen is a no op for tail formatter filters.
+func (f *tailFormatterFilter) Reopen() error {
+	return nil
+}
+
+// Process formats the event for every interested subscriber, and filters the
+// event out when no subscribers want it.  Errors are never returned, since a
+// failure to tail an event is no reason to fail sending it.
+func (f *tailFormatterFilter) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	if e == nil {
+		return nil, nil
+	}
+	// the event is shared with the other pipelines, so the formatted values
+	// are written to a copy of it.
+	newEvent := func() *eventlogger.Event {
+		return &eventlogger.Event{
+			Type:      e.Type,
+			CreatedAt: e.CreatedAt,
+			Payload:   e.Payload,
+		}
+	}
+	f.l.RLock()
+	defer f.l.RUnlock()
+	var tailed *eventlogger.Event
+	for id, sub := range f.subscribers {
+		if !sub.wants(Type(e.Type)) {
+			continue
+		}
+		formatted, err := sub.formatter.Process(ctx, newEvent())
+		if err != nil || formatted == nil {
+			continue
+		}
+		val, ok := formatted.Format(string(JSONSinkFormat))
+		if !ok {
+			continue
+		}
+		if tailed == nil {
+			tailed = newEvent()
+		}
+		tailed.FormattedAs(id, bytes.TrimSpace(val))
+	}
+	return tailed, nil
+}
+
+// tailSink is an eventlogger sink which writes formatted events to the tail
+// subscribers without blocking.  Events are dropped for subscribers which
+// aren't keeping up.
+type tailSink struct {
+	*tailSubscribers
+}
+
+var _ eventlogger.Node = &tailSink{}
+
+// Type describes the type of the node as a Sink.
+func (s *tailSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Reopen is a no op for tail sinks.
+func (s *tailSink) Reopen() error {
+	return nil
+}
+
+// Process writes the event's formatted values to their subscribers.
+func (s *tailSink) Process(_ context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	if e == nil {
+		return nil, nil
+	}
+	s.l.RLock()
+	defer s.l.RUnlock()
+	for id, sub := range s.subscribers {
+		val, ok := e.Format(id)
+		if !ok {
+			continue
+		}
+		select {
+		case sub.events <- val:
+		default:
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
@@ -0,0 +1,242 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/eventlogger/filters/encrypt"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestEventer_Tail(t *testing.T) {
+	t.Parallel()
+
+	newTestEventer := func(t *testing.T, fo AuditFieldOverrides) *Eventer {
+		t.Helper()
+		testLock := &sync.Mutex{}
+		testLogger := hclog.New(&hclog.LoggerOptions{
+			Mutex: testLock,
+			Name:  "test",
+		})
+		c := EventerConfig{
+			AuditEnabled:        true,
+			ObservationsEnabled: true,
+			SysEventsEnabled:    true,
+			Sinks: []*SinkConfig{
+				{
+					Name:         "writer",
+					EventTypes:   []Type{EveryType},
+					Format:       JSONSinkFormat,
+					Type:         WriterSink,
+					WriterConfig: &WriterSinkTypeConfig{Writer: &bytes.Buffer{}},
+					AuditConfig:  &AuditConfig{FieldOverrides: fo},
+				},
+			},
+		}
+		e, err := NewEventer(testLogger, testLock, "TestEventer_Tail", c, WithAuditWrapper(testWrapper(t)))
+		require.NoError(t, err)
+		return e
+	}
+	receive := func(t *testing.T, events <-chan []byte) map[string]interface{} {
+		t.Helper()
+		select {
+		case b := <-events:
+			got := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(b, &got))
+			return got
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for tailed event")
+		}
+		return nil
+	}
+	assertNoEvent := func(t *testing.T, events <-chan []byte) {
+		t.Helper()
+		select {
+		case b := <-events:
+			assert.Failf(t, "unexpected tailed event", "%s", b)
+		default:
+		}
+	}
+
+	t.Run("invalid-parameters", func(t *testing.T) {
+		e := newTestEventer(t, nil)
+		tests := []struct {
+			name            string
+			types           []Type
+			opt             []Option
+			wantErrContains string
+		}{
+			{
+				name:            "missing-types",
+				wantErrContains: "missing event types",
+			},
+			{
+				name:            "invalid-type",
+				types:           []Type{"bad"},
+				wantErrContains: "'bad' is not a valid event type",
+			},
+			{
+				name:            "invalid-filter",
+				types:           []Type{ObservationType},
+				opt:             []Option{WithAllow("foo=;22")},
+				wantErrContains: "invalid allow filter",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				events, err := e.Tail(context.Background(), tt.types, tt.opt...)
+				require.Error(err)
+				assert.Nil(events)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+			})
+		}
+	})
+	t.Run("observations", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		e := newTestEventer(t, nil)
+		events, err := e.Tail(ctx, []Type{ObservationType}, WithAllow(`"/data/name" == "tailed"`))
+		require.NoError(err)
+
+		for _, name := range []string{"not-tailed", "tailed"} {
+			o, err := newObservation("TestEventer_Tail", WithHeader("name", name), WithFlush())
+			require.NoError(err)
+			require.NoError(e.writeObservation(ctx, o))
+		}
+		require.NoError(e.writeSysEvent(ctx, &sysEvent{Op: "TestEventer_Tail", Version: sysVersion, Data: map[string]interface{}{"name": "tailed"}}))
+
+		got := receive(t, events)
+		assert.Equal(string(ObservationType), got["type"])
+		assert.Equal("tailed", got["data"].(map[string]interface{})["name"])
+		assertNoEvent(t, events)
+
+		cancel()
+		require.Eventually(func() bool {
+			_, ok := <-events
+			return !ok
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("remove-pipelines", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		e := newTestEventer(t, nil)
+		b := &testMockBroker{}
+		e.broker = b
+
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		defer cancel1()
+		events1, err := e.Tail(ctx1, []Type{EveryType})
+		require.NoError(err)
+		require.Len(b.pipelines, 4)
+
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		defer cancel2()
+		events2, err := e.Tail(ctx2, []Type{ObservationType})
+		require.NoError(err)
+		assert.Len(b.pipelines, 4)
+
+		cancel1()
+		require.Eventually(func() bool {
+			_, ok := <-events1
+			return !ok
+		}, 5*time.Second, 10*time.Millisecond)
+		e.tailLock.Lock()
+		assert.Len(b.pipelines, 4)
+		e.tailLock.Unlock()
+
+		cancel2()
+		require.Eventually(func() bool {
+			_, ok := <-events2
+			return !ok
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Eventually(func() bool {
+			e.tailLock.Lock()
+			defer e.tailLock.Unlock()
+			return len(b.pipelines) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		ctx3, cancel3 := context.WithCancel(context.Background())
+		defer cancel3()
+		_, err = e.Tail(ctx3, []Type{EveryType})
+		require.NoError(err)
+		e.tailLock.Lock()
+		assert.Len(b.pipelines, 4)
+		e.tailLock.Unlock()
+	})
+	t.Run("audit-redaction", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		e := newTestEventer(t, AuditFieldOverrides{"Account.id": SecretClassification})
+		events, err := e.Tail(ctx, []Type{EveryType})
+		require.NoError(err)
+
+		a, err := newAudit("TestEventer_Tail",
+			WithRequestInfo(TestRequestInfo(t)),
+			WithRequest(&Request{
+				Operation: "POST",
+				Details: &pbs.CreateAccountRequest{
+					Item: &accounts.Account{
+						Id:          "acct-id",
+						Description: wrapperspb.String("public-description"),
+						Attrs: &accounts.Account_PasswordAccountAttributes{
+							PasswordAccountAttributes: &accounts.PasswordAccountAttributes{
+								LoginName: "sensitive-login-name",
+								Password:  wrapperspb.String("secret-password"),
+							},
+						},
+					},
+				},
+			}),
+			WithFlush(),
+		)
+		require.NoError(err)
+		require.NoError(e.writeAudit(ctx, a))
+
+		got := receive(t, events)
+		assert.Equal(string(AuditType), got["type"])
+		raw, err := json.Marshal(got)
+		require.NoError(err)
+		assert.Contains(string(raw), "public-description")
+		assert.Contains(string(raw), encrypt.RedactedData)
+		assert.NotContains(string(raw), "sensitive-login-name")
+		assert.NotContains(string(raw), "secret-password")
+		assert.NotContains(string(raw), "acct-id")
+	})
+}
+
+func TestEventer_tailFieldOverrides(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	e := &Eventer{
+		auditPipelines: []pipeline{
+			{sinkConfig: &SinkConfig{AuditConfig: &AuditConfig{FieldOverrides: AuditFieldOverrides{
+				"Target.name":        PublicClassification,
+				"Target.description": SecretClassification,
+			}}}},
+			{sinkConfig: &SinkConfig{AuditConfig: &AuditConfig{FieldOverrides: AuditFieldOverrides{
+				"Target.name":        SensitiveClassification,
+				"Target.description": PublicClassification,
+				"Account.id":         PublicClassification,
+			}}}},
+			{sinkConfig: &SinkConfig{}},
+		},
+	}
+	assert.Equal(AuditFieldOverrides{
+		"Target.name":        SensitiveClassification,
+		"Target.description": SecretClassification,
+		"Account.id":         PublicClassification,
+	}, e.tailFieldOverrides())
+	assert.Nil((&Eventer{}).tailFieldOverrides())
+}
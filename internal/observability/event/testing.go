@@ -197,6 +197,24 @@ func testAuth(t testing.TB) *Auth {
 	}
 }
 
+func testConnection(t testing.TB) *Connection {
+	t.Helper()
+	return &Connection{
+		SessionId:       "s_1234567890",
+		ConnectionId:    "sc_1234567890",
+		TargetId:        "ttcp_1234567890",
+		UserId:          "u_1234567890",
+		WorkerId:        "w_1234567890",
+		Endpoint:        "tcp://127.0.0.1:22",
+		ClientAddress:   "127.0.0.1:54321",
+		UserClientIp:    "127.0.0.1",
+		EndpointAddress: "127.0.0.1:22",
+		BytesUp:         100,
+		BytesDown:       200,
+		ClosedReason:    "closed by end-user",
+	}
+}
+
 func testRequest(t testing.TB) *Request {
 	t.Helper()
 	return &Request{
@@ -292,6 +310,16 @@ func (b *testMockBroker) RegisterPipeline(def eventlogger.Pipeline) error {
 	return nil
 }
 
+func (b *testMockBroker) RemovePipeline(t eventlogger.EventType, id eventlogger.PipelineID) error {
+	for i, p := range b.pipelines {
+		if p.EventType == t && p.PipelineID == id {
+			b.pipelines = append(b.pipelines[:i], b.pipelines[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
 func (b *testMockBroker) Send(ctx context.Context, t eventlogger.EventType, payload interface{}) (eventlogger.Status, error) {
 	if b.errorOnSend != nil {
 		return eventlogger.Status{}, b.errorOnSend
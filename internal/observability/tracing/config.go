@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"fmt"
+)
+
+// DefaultServiceName is the service name reported with spans when one isn't
+// configured.
+const DefaultServiceName = "boundary"
+
+// Config supplies all the configuration needed to export traces to an
+// OpenTelemetry collector using the OTLP gRPC protocol.
+type Config struct {
+	OtlpEndpoint string            `hcl:"otlp_endpoint"` // OtlpEndpoint is the host:port of the collector.
+	OtlpInsecure bool              `hcl:"otlp_insecure"` // OtlpInsecure disables TLS when connecting to the collector.
+	OtlpHeaders  map[string]string `hcl:"otlp_headers"`  // OtlpHeaders are sent with every export request, e.g. for authentication.
+	ServiceName  string            `hcl:"service_name"`  // ServiceName is the service name reported with spans.
+	SampleRatio  *float64          `hcl:"sample_ratio"`  // SampleRatio is the ratio of new traces which are sampled, defaulting to 1.
+}
+
+// Validate will Validate the config.
+func (c *Config) Validate() error {
+	const op = "tracing.(Config).Validate"
+	if c.OtlpEndpoint == "" {
+		return fmt.Errorf("%s: missing otlp endpoint: %w", op, ErrInvalidParameter)
+	}
+	if c.SampleRatio != nil && (*c.SampleRatio < 0 || *c.SampleRatio > 1) {
+		return fmt.Errorf("%s: sample ratio must be between 0 and 1: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+func (c *Config) serviceName() string {
+	if c.ServiceName == "" {
+		return DefaultServiceName
+	}
+	return c.ServiceName
+}
+
+func (c *Config) sampleRatio() float64 {
+	if c.SampleRatio == nil {
+		return 1
+	}
+	return *c.SampleRatio
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+	ratio := func(r float64) *float64 { return &r }
+	tests := []struct {
+		name            string
+		c               Config
+		wantErrContains string
+	}{
+		{
+			name: "valid",
+			c:    Config{OtlpEndpoint: "localhost:4317"},
+		},
+		{
+			name: "valid-with-all",
+			c: Config{
+				OtlpEndpoint: "localhost:4317",
+				OtlpInsecure: true,
+				OtlpHeaders:  map[string]string{"api-key": "secret"},
+				ServiceName:  "boundary-test",
+				SampleRatio:  ratio(0.5),
+			},
+		},
+		{
+			name:            "missing-endpoint",
+			c:               Config{},
+			wantErrContains: "missing otlp endpoint",
+		},
+		{
+			name:            "negative-ratio",
+			c:               Config{OtlpEndpoint: "localhost:4317", SampleRatio: ratio(-0.1)},
+			wantErrContains: "sample ratio must be between 0 and 1",
+		},
+		{
+			name:            "ratio-too-large",
+			c:               Config{OtlpEndpoint: "localhost:4317", SampleRatio: ratio(1.1)},
+			wantErrContains: "sample ratio must be between 0 and 1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			err := tt.c.Validate()
+			if tt.wantErrContains != "" {
+				require.Error(err)
+				assert.ErrorIs(err, ErrInvalidParameter)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
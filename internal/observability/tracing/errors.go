@@ -0,0 +1,7 @@
+package tracing
+
+import (
+	"errors"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
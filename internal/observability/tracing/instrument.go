@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns an interceptor which starts a span for each
+// unary request to a gRPC server, continuing any trace propagated by the
+// client.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor returns an interceptor which starts a span for each
+// stream of a gRPC server, continuing any trace propagated by the client.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}
+
+// UnaryClientInterceptor returns an interceptor which starts a span for each
+// unary request made by a gRPC client, and propagates the trace to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return otelgrpc.UnaryClientInterceptor()
+}
+
+// StreamClientInterceptor returns an interceptor which starts a span for each
+// stream opened by a gRPC client, and propagates the trace to the server.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return otelgrpc.StreamClientInterceptor()
+}
+
+// HttpHandler wraps the handler so a span named for the operation is started
+// for each request, continuing any trace propagated by the client.
+func HttpHandler(h http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(h, operation)
+}
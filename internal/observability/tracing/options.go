@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		if o != nil {
+			o(&opts)
+		}
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withSpanExporter sdktrace.SpanExporter
+	withInstanceId   string
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithSpanExporter provides an optional exporter which is used instead of an
+// OTLP exporter, such as an in-memory exporter for tests.
+func WithSpanExporter(e sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.withSpanExporter = e
+	}
+}
+
+// WithInstanceId provides an optional service instance id which is reported
+// with spans.
+func WithInstanceId(id string) Option {
+	return func(o *options) {
+		o.withInstanceId = id
+	}
+}
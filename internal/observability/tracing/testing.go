@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestCollector is an in-process collector for tests, which records the spans
+// of the global tracer provider.
+type TestCollector struct {
+	t        testing.TB
+	exporter *tracetest.InMemoryExporter
+	flush    func(context.Context) error
+}
+
+// NewTestCollector installs a tracer provider which exports every span to an
+// in-memory collector.  The previous global provider is restored when the
+// test is cleaned up.
+func NewTestCollector(t testing.TB) *TestCollector {
+	t.Helper()
+	require := require.New(t)
+	prevProvider := otel.GetTracerProvider()
+	prevPropagator := otel.GetTextMapPropagator()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp, err := NewTracerProvider(context.Background(), &Config{OtlpEndpoint: "localhost:4317"}, WithSpanExporter(exp))
+	require.NoError(err)
+	SetTracerProvider(tp)
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return &TestCollector{t: t, exporter: exp, flush: tp.ForceFlush}
+}
+
+// Spans returns the spans which have ended.
+func (c *TestCollector) Spans() tracetest.SpanStubs {
+	c.t.Helper()
+	require.NoError(c.t, c.flush(context.Background()))
+	return c.exporter.GetSpans()
+}
+
+// Reset removes the spans collected so far.
+func (c *TestCollector) Reset() {
+	c.exporter.Reset()
+}
@@ -0,0 +1,95 @@
+// Package tracing provides OpenTelemetry tracing for Boundary.  When tracing
+// is configured, spans are started for API requests, cluster requests between
+// workers and controllers, repository calls to the database, and scheduled
+// job runs, and the trace context is propagated between them.  When tracing
+// isn't configured the global no-op tracer provider is used, and starting a
+// span does nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/hashicorp/boundary"
+
+// NewTracerProvider creates a tracer provider which exports spans using the
+// config's OTLP endpoint.  Supported options: WithSpanExporter and
+// WithInstanceId.  The caller must call Shutdown on the provider to flush any
+// spans which haven't been exported.
+func NewTracerProvider(ctx context.Context, c *Config, opt ...Option) (*sdktrace.TracerProvider, error) {
+	const op = "tracing.NewTracerProvider"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing config: %w", op, ErrInvalidParameter)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	opts := getOpts(opt...)
+
+	exp := opts.withSpanExporter
+	if exp == nil {
+		clientOpts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(c.OtlpEndpoint),
+		}
+		if c.OtlpInsecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		if len(c.OtlpHeaders) > 0 {
+			clientOpts = append(clientOpts, otlptracegrpc.WithHeaders(c.OtlpHeaders))
+		}
+		var err error
+		exp, err = otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to create otlp exporter: %w", op, err)
+		}
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(c.serviceName()),
+	}
+	if opts.withInstanceId != "" {
+		attrs = append(attrs, semconv.ServiceInstanceIDKey.String(opts.withInstanceId))
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.sampleRatio()))),
+	), nil
+}
+
+// SetTracerProvider installs the tracer provider as the global provider used
+// by all of Boundary's instrumentation, along with the W3C trace context and
+// baggage propagators.
+func SetTracerProvider(tp trace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// StartSpan starts a span with the name and attributes as a child of any span
+// in the ctx.  The span must be ended by the caller, typically with EndSpan.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording the error if it isn't nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,170 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	ctx := context.Background()
+	t.Run("missing-config", func(t *testing.T) {
+		_, err := NewTracerProvider(ctx, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("invalid-config", func(t *testing.T) {
+		_, err := NewTracerProvider(ctx, &Config{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+	t.Run("otlp-exporter", func(t *testing.T) {
+		tp, err := NewTracerProvider(ctx, &Config{OtlpEndpoint: "localhost:4317", OtlpInsecure: true})
+		require.NoError(t, err)
+		require.NotNil(t, tp)
+		assert.NoError(t, tp.Shutdown(ctx))
+	})
+	t.Run("resource", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		exp := tracetest.NewInMemoryExporter()
+		tp, err := NewTracerProvider(ctx, &Config{OtlpEndpoint: "localhost:4317", ServiceName: "test-service"}, WithSpanExporter(exp), WithInstanceId("test-instance"))
+		require.NoError(err)
+		_, span := tp.Tracer("test").Start(ctx, "test-span")
+		span.End()
+		require.NoError(tp.ForceFlush(ctx))
+
+		spans := exp.GetSpans()
+		require.Len(spans, 1)
+		attrs := spans[0].Resource.Attributes()
+		assert.Contains(attrs, semconv.ServiceNameKey.String("test-service"))
+		assert.Contains(attrs, semconv.ServiceInstanceIDKey.String("test-instance"))
+	})
+	t.Run("sample-none", func(t *testing.T) {
+		require := require.New(t)
+		exp := tracetest.NewInMemoryExporter()
+		none := 0.0
+		tp, err := NewTracerProvider(ctx, &Config{OtlpEndpoint: "localhost:4317", SampleRatio: &none}, WithSpanExporter(exp))
+		require.NoError(err)
+		_, span := tp.Tracer("test").Start(ctx, "test-span")
+		span.End()
+		require.NoError(tp.ForceFlush(ctx))
+		require.Empty(exp.GetSpans())
+	})
+}
+
+func TestStartSpan(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := NewTestCollector(t)
+	ctx := context.Background()
+
+	parentCtx, parent := StartSpan(ctx, "parent")
+	_, child := StartSpan(parentCtx, "child", attribute.String("test.key", "test-value"))
+	EndSpan(child, errors.New("test error"))
+	EndSpan(parent, nil)
+
+	spans := c.Spans()
+	require.Len(spans, 2)
+	assert.Equal("child", spans[0].Name)
+	assert.Equal(parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(spans[0].Attributes, attribute.String("test.key", "test-value"))
+	assert.Equal(codes.Error, spans[0].Status.Code)
+	assert.Equal("test error", spans[0].Status.Description)
+	require.Len(spans[0].Events, 1)
+	assert.Equal("exception", spans[0].Events[0].Name)
+
+	assert.Equal("parent", spans[1].Name)
+	assert.False(spans[1].Parent.IsValid())
+	assert.Equal(codes.Unset, spans[1].Status.Code)
+
+	c.Reset()
+	assert.Empty(c.Spans())
+}
+
+func TestInstrumentation(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := NewTestCollector(t)
+	ctx := context.Background()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StreamInterceptor(StreamServerInterceptor()),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	require.NoError(err)
+	t.Cleanup(func() { cc.Close() })
+	client := healthpb.NewHealthClient(cc)
+
+	h := HttpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := client.Check(r.Context(), &healthpb.HealthCheckRequest{}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}), "test-api")
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	// the trace is started by the http client, as a user's client would
+	reqCtx, root := StartSpan(ctx, "client")
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, ts.URL, nil)
+	require.NoError(err)
+	otel.GetTextMapPropagator().Inject(reqCtx, propagation.HeaderCarrier(req.Header))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	root.End()
+
+	spans := c.Spans()
+	byName := map[string]tracetest.SpanStub{}
+	for _, s := range spans {
+		assert.Equal(root.SpanContext().TraceID(), s.SpanContext.TraceID(), "span %q is not part of the trace", s.Name)
+		byName[s.Name] = s
+	}
+	const method = "grpc.health.v1.Health/Check"
+	require.Contains(byName, "test-api")
+	require.Contains(byName, method)
+	httpSpan := byName["test-api"]
+	assert.Equal(root.SpanContext().SpanID(), httpSpan.Parent.SpanID())
+
+	var clientSpan, serverSpan tracetest.SpanStub
+	for _, s := range spans {
+		if s.Name != method {
+			continue
+		}
+		switch s.SpanKind.String() {
+		case "client":
+			clientSpan = s
+		case "server":
+			serverSpan = s
+		}
+	}
+	assert.Equal(httpSpan.SpanContext.SpanID(), clientSpan.Parent.SpanID())
+	assert.Equal(clientSpan.SpanContext.SpanID(), serverSpan.Parent.SpanID())
+}
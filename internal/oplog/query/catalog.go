@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	authstore "github.com/hashicorp/boundary/internal/auth/store"
+	credstaticstore "github.com/hashicorp/boundary/internal/credential/static/store"
+	vaultstore "github.com/hashicorp/boundary/internal/credential/vault/store"
+	pluginhoststore "github.com/hashicorp/boundary/internal/host/plugin/store"
+	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	hostplgstore "github.com/hashicorp/boundary/internal/plugin/host/store"
+	targetstore "github.com/hashicorp/boundary/internal/target/store"
+	tcpstore "github.com/hashicorp/boundary/internal/target/tcp/store"
+)
+
+// catalogTypes are the types whose writes are recorded in the oplog. The
+// messages of an oplog entry are named by the table name of the domain type
+// which was written, and are unmarshaled into the store type it embeds since a
+// domain type can't be unmarshaled into directly.
+var catalogTypes = []oplog.Type{
+	// iam
+	{Interface: new(iamstore.Scope), Name: "iam_scope"},
+	{Interface: new(iamstore.User), Name: "iam_user"},
+	{Interface: new(iamstore.Group), Name: "iam_group"},
+	{Interface: new(iamstore.GroupMemberUser), Name: "iam_group_member_user"},
+	{Interface: new(iamstore.Role), Name: "iam_role"},
+	{Interface: new(iamstore.RoleGrant), Name: "iam_role_grant"},
+	{Interface: new(iamstore.UserRole), Name: "iam_user_role"},
+	{Interface: new(iamstore.GroupRole), Name: "iam_group_role"},
+	{Interface: new(iamstore.ManagedGroupRole), Name: "iam_managed_group_role"},
+	{Interface: new(authstore.Account), Name: "auth_account"},
+
+	// auth methods
+	{Interface: new(pwstore.AuthMethod), Name: "auth_password_method"},
+	{Interface: new(pwstore.Account), Name: "auth_password_account"},
+	{Interface: new(pwstore.Argon2Configuration), Name: "auth_password_argon2_conf"},
+	{Interface: new(pwstore.Argon2Credential), Name: "auth_password_argon2_cred"},
+	{Interface: new(oidcstore.AuthMethod), Name: "auth_oidc_method"},
+	{Interface: new(oidcstore.Account), Name: "auth_oidc_account"},
+	{Interface: new(oidcstore.AccountClaimMap), Name: "auth_oidc_account_claim_map"},
+	{Interface: new(oidcstore.AudClaim), Name: "auth_oidc_aud_claim"},
+	{Interface: new(oidcstore.Certificate), Name: "auth_oidc_certificate"},
+	{Interface: new(oidcstore.ClaimsScope), Name: "auth_oidc_scope"},
+	{Interface: new(oidcstore.ManagedGroup), Name: "auth_oidc_managed_group"},
+	{Interface: new(oidcstore.ManagedGroupMemberAccount), Name: "auth_oidc_managed_group_member_account"},
+	{Interface: new(oidcstore.SigningAlg), Name: "auth_oidc_signing_alg"},
+
+	// credentials
+	{Interface: new(credstaticstore.CredentialStore), Name: "credential_static_store"},
+	{Interface: new(credstaticstore.UsernamePasswordCredential), Name: "credential_static_username_password_credential"},
+	{Interface: new(vaultstore.CredentialStore), Name: "credential_vault_store"},
+	{Interface: new(vaultstore.Token), Name: "credential_vault_token"},
+	{Interface: new(vaultstore.ClientCertificate), Name: "credential_vault_client_certificate"},
+	{Interface: new(vaultstore.CredentialLibrary), Name: "credential_vault_library"},
+	{Interface: new(vaultstore.UserPasswordOverride), Name: "credential_vault_library_user_password_mapping_override"},
+
+	// hosts
+	{Interface: new(staticstore.HostCatalog), Name: "static_host_catalog"},
+	{Interface: new(staticstore.Host), Name: "static_host"},
+	{Interface: new(staticstore.HostSet), Name: "static_host_set"},
+	{Interface: new(staticstore.HostSetMember), Name: "static_host_set_member"},
+	{Interface: new(pluginhoststore.HostCatalog), Name: "host_plugin_catalog"},
+	{Interface: new(pluginhoststore.HostCatalogSecret), Name: "host_plugin_catalog_secret"},
+	{Interface: new(pluginhoststore.Host), Name: "host_plugin_host"},
+	{Interface: new(pluginhoststore.HostSet), Name: "host_plugin_set"},
+	{Interface: new(pluginhoststore.HostSetMember), Name: "host_plugin_set_member"},
+	{Interface: new(hostplgstore.Plugin), Name: "plugin_host"},
+
+	// targets
+	{Interface: new(tcpstore.Target), Name: "target_tcp"},
+	{Interface: new(targetstore.TargetHostSet), Name: "target_host_set"},
+	{Interface: new(targetstore.CredentialLibrary), Name: "target_credential_library"},
+	{Interface: new(targetstore.StaticCredential), Name: "target_static_credential"},
+}
+
+// newTypeCatalog returns a catalog of the catalogTypes.
+func newTypeCatalog(ctx context.Context) (*oplog.TypeCatalog, error) {
+	return oplog.NewTypeCatalog(ctx, catalogTypes...)
+}
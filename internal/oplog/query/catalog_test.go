@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	hostplugin "github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog"
+	pluginhost "github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_newTypeCatalog(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	types, err := newTypeCatalog(ctx)
+	require.NoError(t, err)
+	assert.Len(t, *types, len(catalogTypes))
+
+	// each domain type must be in the catalog under its table name, as the
+	// store type it embeds.
+	domainTypes := []oplog.ReplayableMessage{
+		&iam.Scope{}, &iam.User{}, &iam.Group{}, &iam.GroupMemberUser{}, &iam.Role{}, &iam.RoleGrant{},
+		&iam.UserRole{}, &iam.GroupRole{}, &iam.ManagedGroupRole{},
+		&password.AuthMethod{}, &password.Account{}, &password.Argon2Configuration{}, &password.Argon2Credential{},
+		&oidc.AuthMethod{}, &oidc.Account{}, &oidc.AccountClaimMap{}, &oidc.AudClaim{}, &oidc.Certificate{},
+		&oidc.ClaimsScope{}, &oidc.ManagedGroup{}, &oidc.ManagedGroupMemberAccount{}, &oidc.SigningAlg{},
+		&credstatic.CredentialStore{}, &credstatic.UsernamePasswordCredential{},
+		&vault.CredentialStore{}, &vault.Token{}, &vault.ClientCertificate{}, &vault.CredentialLibrary{},
+		&vault.UserPasswordOverride{},
+		&static.HostCatalog{}, &static.Host{}, &static.HostSet{}, &static.HostSetMember{},
+		&hostplugin.HostCatalog{}, &hostplugin.HostCatalogSecret{}, &hostplugin.Host{}, &hostplugin.HostSet{},
+		&hostplugin.HostSetMember{}, &pluginhost.Plugin{},
+		&tcp.Target{}, &target.TargetHostSet{}, &target.CredentialLibrary{}, &target.StaticCredential{},
+	}
+	messageType := reflect.TypeOf((*proto.Message)(nil)).Elem()
+	for _, d := range domainTypes {
+		typ := reflect.TypeOf(d).Elem()
+		t.Run(typ.String(), func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var storeType reflect.Type
+			for i := 0; i < typ.NumField(); i++ {
+				if f := typ.Field(i); f.Anonymous && f.Type.Implements(messageType) {
+					storeType = f.Type
+				}
+			}
+			require.NotNil(storeType, "no embedded store type")
+
+			got, err := types.Get(ctx, d.TableName())
+			require.NoError(err)
+			assert.Equal(storeType, reflect.TypeOf(got))
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Package query reads oplog entries back out of the database, decrypting them
+// with the oplog key of the scope they were written in and unmarshaling their
+// messages with a type catalog of the types whose writes are recorded in the
+// oplog. It's used to audit the changes made to Boundary's resources.
+package query
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// Entry is an oplog entry along with the messages it recorded.
+type Entry struct {
+	Id         uint32
+	CreateTime time.Time
+	// Version is the version of the oplog the entry was written with.
+	Version string
+	// AggregateName is the name of the table of the aggregate the entry was
+	// written for.
+	AggregateName string
+	Metadata      oplog.Metadata
+	// Messages are the writes recorded by the entry, in the order they were
+	// made. Messages is nil if the entry couldn't be decrypted, for example
+	// because the key version which encrypted it has been destroyed.
+	Messages []*Message
+}
+
+// ScopeId returns the ID of the scope the entry was written for, or an empty
+// string if the entry's metadata doesn't record one.
+func (e *Entry) ScopeId() string {
+	return e.metadataValue("scope-id")
+}
+
+// ResourcePublicId returns the public ID of the resource the entry was
+// written for, or an empty string if the entry's metadata doesn't record one.
+func (e *Entry) ResourcePublicId() string {
+	return e.metadataValue("resource-public-id")
+}
+
+func (e *Entry) metadataValue(key string) string {
+	if v := e.Metadata[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Message is a write recorded by an oplog entry.
+type Message struct {
+	// TypeName is the name of the table which was written.
+	TypeName       string
+	OpType         oplog.OpType
+	FieldMaskPaths []string
+	SetToNullPaths []string
+	// Message is the row which was written, with the fields which hold secrets
+	// cleared.
+	Message proto.Message
+}
+
+// redactedFields are the fields of the catalogTypes which hold secrets that
+// aren't encrypted in the database, keyed by type name.
+var redactedFields = map[string][]string{
+	"auth_password_argon2_cred": {"DerivedKey"},
+}
+
+// redact clears the fields of the message which hold secrets: the fields which
+// are encrypted in the database, both plaintext and ciphertext, which are
+// tagged with "wrapping", and the redactedFields of the type.
+func redact(typeName string, m proto.Message) {
+	v := reflect.ValueOf(m).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("wrapping"); ok {
+			v.Field(i).Set(reflect.Zero(t.Field(i).Type))
+		}
+	}
+	for _, name := range redactedFields[typeName] {
+		if f := v.FieldByName(name); f.IsValid() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
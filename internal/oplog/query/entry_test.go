@@ -0,0 +1,89 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	vaultstore "github.com/hashicorp/boundary/internal/credential/vault/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestEntry_metadata(t *testing.T) {
+	t.Parallel()
+	e := &Entry{
+		Metadata: oplog.Metadata{
+			"scope-id":           []string{"o_1234567890"},
+			"resource-public-id": []string{"r_1234567890", "r_0987654321"},
+			"op-type":            nil,
+		},
+	}
+	assert.Equal(t, "o_1234567890", e.ScopeId())
+	assert.Equal(t, "r_1234567890", e.ResourcePublicId())
+	assert.Empty(t, (&Entry{}).ScopeId())
+	assert.Empty(t, (&Entry{}).ResourcePublicId())
+}
+
+func Test_redact(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		typeName string
+		msg      proto.Message
+		want     proto.Message
+	}{
+		{
+			name:     "wrapping-fields",
+			typeName: "credential_vault_token",
+			msg: &vaultstore.Token{
+				StoreId:   "csvlt_1234567890",
+				Token:     []byte("token"),
+				CtToken:   []byte("ct-token"),
+				TokenHmac: []byte("hmac"),
+				KeyId:     "kdkv_1234567890",
+			},
+			want: &vaultstore.Token{
+				StoreId:   "csvlt_1234567890",
+				TokenHmac: []byte("hmac"),
+				KeyId:     "kdkv_1234567890",
+			},
+		},
+		{
+			name:     "redacted-fields",
+			typeName: "auth_password_argon2_cred",
+			msg: &pwstore.Argon2Credential{
+				PrivateId:  "arg2cred_1234567890",
+				CtSalt:     []byte("ct-salt"),
+				Salt:       []byte("salt"),
+				DerivedKey: []byte("derived-key"),
+			},
+			want: &pwstore.Argon2Credential{
+				PrivateId: "arg2cred_1234567890",
+			},
+		},
+		{
+			name:     "no-secrets",
+			typeName: "iam_role_grant",
+			msg: &iamstore.RoleGrant{
+				RoleId:   "r_1234567890",
+				RawGrant: "id=*;type=*;actions=read",
+			},
+			want: &iamstore.RoleGrant{
+				RoleId:   "r_1234567890",
+				RawGrant: "id=*;type=*;actions=read",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			redact(tt.typeName, tt.msg)
+			assert.Empty(t, cmp.Diff(tt.want, tt.msg, protocmp.Transform()))
+		})
+	}
+}
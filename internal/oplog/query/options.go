@@ -0,0 +1,80 @@
+package query
+
+import (
+	"time"
+)
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit            int
+	withResourcePublicId string
+	withScopeId          string
+	withTable            string
+	withStartTime        time.Time
+	withEndTime          time.Time
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are returned. If
+// WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithResourcePublicId provides an option to only return the entries written
+// for the resource with the public id.
+func WithResourcePublicId(id string) Option {
+	return func(o *options) {
+		o.withResourcePublicId = id
+	}
+}
+
+// WithScopeId provides an option to only return the entries written for
+// resources in the scope.
+func WithScopeId(id string) Option {
+	return func(o *options) {
+		o.withScopeId = id
+	}
+}
+
+// WithTable provides an option to only return the entries whose aggregate is
+// the table, for example "iam_role".
+func WithTable(name string) Option {
+	return func(o *options) {
+		o.withTable = name
+	}
+}
+
+// WithStartTime provides an option to only return the entries written at or
+// after the time.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime provides an option to only return the entries written before
+// the time.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithLimit(100))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withLimit = 100
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithResourcePublicId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithResourcePublicId("r_1234567890"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withResourcePublicId = "r_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithScopeId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithScopeId("o_1234567890"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withScopeId = "o_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithTable", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithTable("iam_role"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withTable = "iam_role"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithStartTime(now))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withStartTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithEndTime(now))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withEndTime = now
+		assert.Equal(opts, testOpts)
+	})
+}
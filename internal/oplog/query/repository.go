@@ -0,0 +1,211 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// entryMetadataWhere selects the entries which have a metadata key with a
+// value.
+const entryMetadataWhere = `
+id in (
+  select entry_id
+    from oplog_metadata
+   where key = ?
+     and value = ?
+)`
+
+// A Repository retrieves oplog entries. It is not safe to use a repository
+// concurrently.
+type Repository struct {
+	reader db.Reader
+	kms    *kms.Kms
+	types  *oplog.TypeCatalog
+
+	// defaultLimit provides a default for limiting the number of results
+	// returned from the repo during a ListRequest
+	defaultLimit int
+}
+
+// NewRepository creates a new Repository. The returned repository is not safe
+// for concurrent go routines to access it. WithLimit option is used as a repo
+// wide default limit applied to ListEntries.
+func NewRepository(ctx context.Context, r db.Reader, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	const op = "query.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db reader")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+
+	types, err := newTypeCatalog(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &Repository{
+		reader:       r,
+		kms:          kms,
+		types:        types,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// ListEntries returns the oplog entries, newest first, with their messages
+// decrypted. The entries can be filtered with WithResourcePublicId,
+// WithScopeId, WithTable, WithStartTime and WithEndTime. WithLimit overrides
+// the repository's default limit. An entry which can't be decrypted or
+// unmarshaled is returned without its messages rather than failing the list.
+func (r *Repository) ListEntries(ctx context.Context, opt ...Option) ([]*Entry, error) {
+	const op = "query.(Repository).ListEntries"
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	var where []string
+	var args []interface{}
+	if opts.withResourcePublicId != "" {
+		where = append(where, entryMetadataWhere)
+		args = append(args, "resource-public-id", opts.withResourcePublicId)
+	}
+	if opts.withScopeId != "" {
+		where = append(where, entryMetadataWhere)
+		args = append(args, "scope-id", opts.withScopeId)
+	}
+	if opts.withTable != "" {
+		where = append(where, "aggregate_name = ?")
+		args = append(args, opts.withTable)
+	}
+	if !opts.withStartTime.IsZero() {
+		where = append(where, "create_time >= ?")
+		args = append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		where = append(where, "create_time < ?")
+		args = append(args, opts.withEndTime)
+	}
+
+	var storeEntries []*store.Entry
+	if err := r.reader.SearchWhere(ctx, &storeEntries, strings.Join(where, " and "), args, db.WithLimit(limit), db.WithOrder("create_time desc, id desc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(storeEntries) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(storeEntries))
+	for _, e := range storeEntries {
+		ids = append(ids, e.Id)
+	}
+	var storeMetadata []*store.Metadata
+	if err := r.reader.SearchWhere(ctx, &storeMetadata, "entry_id in (?)", []interface{}{ids}, db.WithLimit(-1), db.WithOrder("id asc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list metadata"))
+	}
+	metadata := make(map[uint32]oplog.Metadata, len(storeEntries))
+	for _, m := range storeMetadata {
+		md, ok := metadata[m.EntryId]
+		if !ok {
+			md = oplog.Metadata{}
+			metadata[m.EntryId] = md
+		}
+		if m.Value == "" {
+			// a key without values is stored with an empty value.
+			if _, ok := md[m.Key]; !ok {
+				md[m.Key] = nil
+			}
+			continue
+		}
+		md[m.Key] = append(md[m.Key], m.Value)
+	}
+
+	// entries are mostly encrypted with a few key versions, so their wrappers
+	// are shared between entries.
+	wrappers := map[string]wrapping.Wrapper{}
+	entries := make([]*Entry, 0, len(storeEntries))
+	for _, se := range storeEntries {
+		e := &Entry{
+			Id:            se.Id,
+			Version:       se.Version,
+			AggregateName: se.AggregateName,
+			Metadata:      metadata[se.Id],
+		}
+		if se.CreateTime != nil {
+			e.CreateTime = se.CreateTime.GetTimestamp().AsTime()
+		}
+		msgs, err := r.decodeMessages(ctx, se, wrappers)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to decode oplog entry", "entry_id", se.Id))
+		} else {
+			e.Messages = msgs
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// decodeMessages decrypts the entry with the oplog key version which
+// encrypted it and returns its messages with their secrets redacted. The
+// wrappers of the key versions are cached in wrappers by key id.
+func (r *Repository) decodeMessages(ctx context.Context, se *store.Entry, wrappers map[string]wrapping.Wrapper) ([]*Message, error) {
+	const op = "query.(Repository).decodeMessages"
+	blobInfo := new(wrapping.BlobInfo)
+	if err := proto.Unmarshal(se.CtData, blobInfo); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode), errors.WithMsg("unable to decode entry data"))
+	}
+	keyId := blobInfo.GetKeyInfo().GetKeyId()
+	w, ok := wrappers[keyId]
+	if !ok {
+		// the scope-id metadata isn't always the scope whose key encrypted the
+		// entry (a new org is written with the global key), so the scope is
+		// found from the key version.
+		scopeId, err := r.kms.LookupKeyVersionScopeId(ctx, keyId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		w, err = r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog, kms.WithKeyId(keyId))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+		}
+		wrappers[keyId] = w
+	}
+
+	entry := &oplog.Entry{Entry: se, Cipherer: w}
+	if err := entry.DecryptData(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	msgs, err := entry.UnmarshalData(ctx, r.types)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret := make([]*Message, 0, len(msgs))
+	for _, m := range msgs {
+		redact(m.TypeName, m.Message)
+		ret = append(ret, &Message{
+			TypeName:       m.TypeName,
+			OpType:         m.OpType,
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+			Message:        m.Message,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_New(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	tests := []struct {
+		name      string
+		r         db.Reader
+		kms       *kms.Kms
+		opts      []Option
+		wantLimit int
+		wantErr   errors.Code
+	}{
+		{
+			name:      "valid",
+			r:         rw,
+			kms:       kmsCache,
+			wantLimit: db.DefaultLimit,
+		},
+		{
+			name:      "valid-with-limit",
+			r:         rw,
+			kms:       kmsCache,
+			opts:      []Option{WithLimit(5)},
+			wantLimit: 5,
+		},
+		{
+			name:    "nil-reader",
+			kms:     kmsCache,
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-kms",
+			r:       rw,
+			wantErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRepository(ctx, tt.r, tt.kms, tt.opts...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantLimit, got.defaultLimit)
+			assert.NotNil(got.types)
+		})
+	}
+}
+
+func TestRepository_ListEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	start := time.Now().Add(-time.Minute)
+
+	role, err := iam.NewRole(org.PublicId, iam.WithName("auditors"))
+	require.NoError(t, err)
+	role, err = iamRepo.CreateRole(ctx, role)
+	require.NoError(t, err)
+	_, err = iamRepo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{"id=*;type=*;actions=read"})
+	require.NoError(t, err)
+
+	repo, err := NewRepository(ctx, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("resource", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, WithResourcePublicId(role.PublicId))
+		require.NoError(err)
+		require.Len(got, 2)
+
+		// newest first: the grants, then the role's creation.
+		grants, create := got[0], got[1]
+		assert.Equal("iam_role", grants.AggregateName)
+		assert.Equal(role.PublicId, grants.ResourcePublicId())
+		assert.Equal(org.PublicId, grants.ScopeId())
+		assert.Equal([]string{oplog.OpType_OP_TYPE_CREATE.String()}, grants.Metadata["op-type"])
+		assert.False(grants.CreateTime.Before(create.CreateTime))
+
+		require.Len(grants.Messages, 2)
+		assert.Equal("iam_role", grants.Messages[0].TypeName)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, grants.Messages[0].OpType)
+		assert.Equal([]string{"Version"}, grants.Messages[0].FieldMaskPaths)
+		assert.Equal("iam_role_grant", grants.Messages[1].TypeName)
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE_ITEMS, grants.Messages[1].OpType)
+		grant, ok := grants.Messages[1].Message.(*iamstore.RoleGrant)
+		require.True(ok)
+		assert.Equal(role.PublicId, grant.RoleId)
+		assert.Equal("id=*;type=*;actions=read", grant.RawGrant)
+
+		require.Len(create.Messages, 1)
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE, create.Messages[0].OpType)
+		r, ok := create.Messages[0].Message.(*iamstore.Role)
+		require.True(ok)
+		assert.Equal(role.PublicId, r.PublicId)
+		assert.Equal("auditors", r.Name)
+	})
+
+	t.Run("scope-and-table", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, WithScopeId(org.PublicId), WithTable("iam_role"))
+		require.NoError(err)
+		assert.Len(got, 2)
+		for _, e := range got {
+			assert.Equal("iam_role", e.AggregateName)
+			assert.Equal(org.PublicId, e.ScopeId())
+		}
+
+		// the org was created with the global oplog key.
+		got, err = repo.ListEntries(ctx, WithResourcePublicId(org.PublicId), WithTable("iam_scope"))
+		require.NoError(err)
+		require.NotEmpty(got)
+		assert.NotNil(got[len(got)-1].Messages)
+	})
+
+	t.Run("time-range", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, WithResourcePublicId(role.PublicId), WithStartTime(start), WithEndTime(time.Now().Add(time.Minute)))
+		require.NoError(err)
+		assert.Len(got, 2)
+
+		got, err = repo.ListEntries(ctx, WithResourcePublicId(role.PublicId), WithStartTime(time.Now().Add(time.Minute)))
+		require.NoError(err)
+		assert.Empty(got)
+
+		got, err = repo.ListEntries(ctx, WithResourcePublicId(role.PublicId), WithEndTime(start))
+		require.NoError(err)
+		assert.Empty(got)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, WithResourcePublicId(role.PublicId), WithLimit(1))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Len(got[0].Messages, 2)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListEntries(ctx, WithResourcePublicId("r_unknown"))
+		require.NoError(err)
+		assert.Empty(got)
+	})
+}
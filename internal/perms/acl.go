@@ -221,7 +221,10 @@ func topLevelType(typ resource.Type) bool {
 		resource.Session,
 		resource.Target,
 		resource.User,
-		resource.Worker:
+		resource.Worker,
+		resource.Event,
+		resource.Job,
+		resource.Oplog:
 		return true
 	}
 	return false
@@ -484,6 +484,10 @@ func TestSchedulerFinalStatusUpdate(t *testing.T) {
 	run := waitForRunStatus(t, repo, runId, string(job.Failed))
 	assert.Equal(uint32(10), run.TotalCount)
 	assert.Equal(uint32(10), run.CompletedCount)
+	assert.Equal("scary error", run.ErrorMessage)
+	dbJob, err := repo.LookupJob(context.Background(), tj.name)
+	require.NoError(err)
+	assert.Equal(uint32(1), dbJob.ConsecutiveFailures)
 
 	// Wait for scheduler to run job again
 	<-jobReady
@@ -505,6 +509,10 @@ func TestSchedulerFinalStatusUpdate(t *testing.T) {
 	run = waitForRunStatus(t, repo, runId, string(job.Completed))
 	assert.Equal(uint32(20), run.TotalCount)
 	assert.Equal(uint32(20), run.CompletedCount)
+	assert.Empty(run.ErrorMessage)
+	dbJob, err = repo.LookupJob(context.Background(), tj.name)
+	require.NoError(err)
+	assert.Equal(uint32(0), dbJob.ConsecutiveFailures)
 
 	baseCnl()
 	close(testDone)
@@ -28,6 +28,9 @@ type options struct {
 	withLimit        int
 	withName         string
 	withControllerId string
+	withSchedule     string
+	withTimeZone     string
+	withErrorMessage string
 }
 
 func getDefaultOptions() options {
@@ -57,7 +60,7 @@ func WithRunJobsLimit(l int) Option {
 	}
 }
 
-// WithLimit provides an option to provide a limit for ListJobs. Intentionally
+// WithLimit provides an option to provide a limit for ListJobs and ListRuns. Intentionally
 // allowing negative integers. If WithLimit < 0, then unlimited results are
 // returned. If WithLimit == 0, then default limits are used for results.
 func WithLimit(l int) Option {
@@ -66,7 +69,8 @@ func WithLimit(l int) Option {
 	}
 }
 
-// WithName provides an option to provide the name to match when calling ListJobs
+// WithName provides an option to provide the job name to match when calling
+// ListJobs or ListRuns
 func WithName(n string) Option {
 	return func(o *options) {
 		o.withName = n
@@ -79,3 +83,23 @@ func WithControllerId(id string) Option {
 		o.withControllerId = id
 	}
 }
+
+// WithSchedule provides an option to provide the cron expression, and the time
+// zone it is evaluated in, used to compute the next run time of a job when
+// calling UpsertJob. Changing the schedule of an existing job resets its next
+// run time using WithNextRunIn. Without it, the schedule stored with an
+// existing job is kept.
+func WithSchedule(expression, timeZone string) Option {
+	return func(o *options) {
+		o.withSchedule = expression
+		o.withTimeZone = timeZone
+	}
+}
+
+// WithErrorMessage provides an option to provide the error message of a failed run
+// when calling FailRun.
+func WithErrorMessage(msg string) Option {
+	return func(o *options) {
+		o.withErrorMessage = msg
+	}
+}
@@ -57,4 +57,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withControllerId = "controller_id"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSchedule", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithSchedule("0 3 * * *", "America/New_York"))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withSchedule = "0 3 * * *"
+		testOpts.withTimeZone = "America/New_York"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -21,17 +21,33 @@ const upsertJobQuery = `
 	  plugin_id, 
 	  name, 
 	  description, 
-	  next_scheduled_run 
+	  next_scheduled_run,
+	  schedule,
+	  time_zone
 	) values (
 	  @plugin_id, -- plugin_id
 	  @name, -- name
 	  @description, -- description
-	  wt_add_seconds_to_now(@next_scheduled_run) -- next_scheduled_run
+	  wt_add_seconds_to_now(@next_scheduled_run), -- next_scheduled_run
+	  nullif(@schedule, ''), -- schedule
+	  nullif(@time_zone, '') -- time_zone
 	)
 	on conflict on constraint  
 	  job_pkey
 	do update set 
-	  description = @description
+	  description = @description,
+	  schedule = coalesce(nullif(@schedule, ''), job.schedule),
+	  time_zone = case
+	    when nullif(@schedule, '') is not null then nullif(@time_zone, '')
+	    else job.time_zone
+	  end,
+	  next_scheduled_run = case
+	    when nullif(@schedule, '') is not null
+	      and (job.schedule is distinct from nullif(@schedule, '')
+	        or job.time_zone is distinct from nullif(@time_zone, ''))
+	    then wt_add_seconds_to_now(@next_scheduled_run)
+	    else job.next_scheduled_run
+	  end
 	returning *;
 `
 
@@ -90,7 +106,8 @@ const failRunQuery = `
 	  completed_count = ?,
 	  total_count     = ?,
 	  status          = 'failed',
-	  end_time        = current_timestamp
+	  end_time        = current_timestamp,
+	  error_message   = nullif(?, '')
 	where
 	  private_id = ?
 	  and status = 'running'
@@ -110,9 +127,24 @@ const interruptRunsQuery = `
 	returning *;
 `
 
+const deleteRunsEndedBeforeQuery = `
+	delete
+	from job_run
+	where
+	  status != 'running'
+	  and end_time <= wt_add_seconds_to_now(?)
+`
+
 const deleteJobByName = `
 	delete 
 	from job 
 	where 
 	  name = ?
 `
+
+const listLatestRunsQuery = `
+	select distinct on (job_plugin_id, job_name)
+	  *
+	from job_run
+	order by job_plugin_id, job_name, create_time desc;
+`
@@ -12,13 +12,13 @@ import (
 )
 
 // UpsertJob inserts a job into the repository or updates its current description
-// and returns a new *Job.
+// and schedule and returns a new *Job.
 //
 // • name must be provided and is the name of the job.
 //
 // • description must be provided and is the user-friendly description of the job.
 //
-// WithNextRunIn is the only valid options.
+// WithNextRunIn and WithSchedule are the only valid options.
 func (r *Repository) UpsertJob(ctx context.Context, name, description string, opt ...Option) (*Job, error) {
 	const op = "job.(Repository).UpsertJob"
 	if name == "" {
@@ -40,6 +40,8 @@ func (r *Repository) UpsertJob(ctx context.Context, name, description string, op
 				sql.Named("name", name),
 				sql.Named("description", description),
 				sql.Named("next_scheduled_run", int(opts.withNextRunIn.Round(time.Second).Seconds())),
+				sql.Named("schedule", opts.withSchedule),
+				sql.Named("time_zone", opts.withTimeZone),
 			})
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithoutEvent())
@@ -112,6 +112,50 @@ func TestRepository_UpsertJob(t *testing.T) {
 		assert.Equal("test-dup-name", got2.Name)
 		assert.Equal("updated description", got2.Description)
 	})
+
+	t.Run("schedule", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(err)
+		require.NotNil(repo)
+
+		got, err := repo.UpsertJob(context.Background(), "test-schedule", "description", WithNextRunIn(time.Hour))
+		require.NoError(err)
+		assert.Empty(got.Schedule)
+		assert.Empty(got.TimeZone)
+		firstRun := got.NextScheduledRun.AsTime()
+
+		// Adding a schedule resets the next run
+		got, err = repo.UpsertJob(context.Background(), "test-schedule", "description",
+			WithNextRunIn(2*time.Hour), WithSchedule("0 3 * * *", "America/New_York"))
+		require.NoError(err)
+		assert.Equal("0 3 * * *", got.Schedule)
+		assert.Equal("America/New_York", got.TimeZone)
+		scheduledRun := got.NextScheduledRun.AsTime()
+		assert.True(scheduledRun.After(firstRun))
+
+		// Re-registering the same schedule keeps the next run
+		got, err = repo.UpsertJob(context.Background(), "test-schedule", "description",
+			WithNextRunIn(3*time.Hour), WithSchedule("0 3 * * *", "America/New_York"))
+		require.NoError(err)
+		assert.True(scheduledRun.Equal(got.NextScheduledRun.AsTime()))
+
+		// Changing the time zone resets the next run
+		got, err = repo.UpsertJob(context.Background(), "test-schedule", "description",
+			WithNextRunIn(3*time.Hour), WithSchedule("0 3 * * *", "Europe/London"))
+		require.NoError(err)
+		assert.Equal("Europe/London", got.TimeZone)
+		assert.True(got.NextScheduledRun.AsTime().After(scheduledRun))
+		scheduledRun = got.NextScheduledRun.AsTime()
+
+		// Registering without a schedule, as a controller without the
+		// schedule configured does, keeps the schedule and the next run
+		got, err = repo.UpsertJob(context.Background(), "test-schedule", "description", WithNextRunIn(time.Minute))
+		require.NoError(err)
+		assert.Equal("0 3 * * *", got.Schedule)
+		assert.Equal("Europe/London", got.TimeZone)
+		assert.True(scheduledRun.Equal(got.NextScheduledRun.AsTime()))
+	})
 }
 
 func TestRepository_LookupJob(t *testing.T) {
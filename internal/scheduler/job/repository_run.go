@@ -3,6 +3,7 @@ package job
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
@@ -204,13 +205,15 @@ func (r *Repository) CompleteRun(ctx context.Context, runId string, nextRunIn ti
 // Once a run has been persisted with a final run status (completed, failed
 // or interrupted), any future calls to FailRun will return an error with Code
 // errors.InvalidJobRunState.
-// All options are ignored.
-func (r *Repository) FailRun(ctx context.Context, runId string, completed, total int, _ ...Option) (*Run, error) {
+// WithErrorMessage is the only valid option.
+func (r *Repository) FailRun(ctx context.Context, runId string, completed, total int, opt ...Option) (*Run, error) {
 	const op = "job.(Repository).FailRun"
 	if runId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing run id")
 	}
 
+	opts := getOpts(opt...)
+
 	run := allocRun()
 	run.PrivateId = runId
 	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
@@ -219,7 +222,7 @@ func (r *Repository) FailRun(ctx context.Context, runId string, completed, total
 			// persisted by the scheduler's monitor jobs loop.
 			// Add an on update sql trigger to protect the job_run table, once progress
 			// values are used in the critical path.
-			rows, err := w.Query(ctx, failRunQuery, []interface{}{completed, total, runId})
+			rows, err := w.Query(ctx, failRunQuery, []interface{}{completed, total, opts.withErrorMessage, runId})
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
@@ -329,6 +332,86 @@ func (r *Repository) LookupRun(ctx context.Context, runId string, _ ...Option) (
 	return run, nil
 }
 
+// ListRuns returns a slice of Runs, most recently created first.
+//
+// WithName and WithLimit are the only valid options.
+func (r *Repository) ListRuns(ctx context.Context, opt ...Option) ([]*Run, error) {
+	const op = "job.(Repository).ListRuns"
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var args []interface{}
+	var where []string
+	if opts.withName != "" {
+		where, args = append(where, "job_name = ?"), append(args, opts.withName)
+	}
+
+	var runs []*Run
+	err := r.reader.SearchWhere(ctx, &runs, strings.Join(where, " and "), args, db.WithLimit(limit), db.WithOrder("create_time desc"))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return runs, nil
+}
+
+// ListLatestRuns returns the most recently created Run of every job that
+// has been run, ordered by job plugin id and job name.
+//
+// All options are ignored.
+func (r *Repository) ListLatestRuns(ctx context.Context, _ ...Option) ([]*Run, error) {
+	const op = "job.(Repository).ListLatestRuns"
+	rows, err := r.reader.Query(ctx, listLatestRunsQuery, nil)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var runs []*Run
+	for rows.Next() {
+		run := allocRun()
+		if err := r.reader.ScanRows(ctx, rows, run); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan rows for job run"))
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return runs, nil
+}
+
+// DeleteRunsEndedBefore deletes the runs which ended (completed, failed or
+// were interrupted) at least threshold ago, and returns the number of runs
+// deleted. Running runs are never deleted.
+//
+// All options are ignored.
+func (r *Repository) DeleteRunsEndedBefore(ctx context.Context, threshold time.Duration, _ ...Option) (int, error) {
+	const op = "job.(Repository).DeleteRunsEndedBefore"
+	if threshold <= 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "threshold must be greater than zero")
+	}
+
+	var rowsDeleted int
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			// threshold is seconds in past so * -1
+			rowsDeleted, err = w.Exec(ctx, deleteRunsEndedBeforeQuery, []interface{}{-1 * int(threshold.Round(time.Second).Seconds())})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rowsDeleted, nil
+}
+
 // deleteRun deletes the job for the provided runId from the repository
 // returning a count of the number of records deleted.
 //
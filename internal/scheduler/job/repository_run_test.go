@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/scheduler/job/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestRepository_RunJobs(t *testing.T) {
@@ -634,6 +636,7 @@ func TestRepository_FailRun(t *testing.T) {
 
 	type args struct {
 		completed, total int
+		errorMessage     string
 	}
 	tests := []struct {
 		name        string
@@ -714,6 +717,18 @@ func TestRepository_FailRun(t *testing.T) {
 			},
 			args: args{completed: 10, total: 20},
 		},
+		{
+			name: "valid-with-error-message",
+			orig: &Run{
+				JobRun: &store.JobRun{
+					JobName:      job.Name,
+					JobPluginId:  job.PluginId,
+					ControllerId: server.PrivateId,
+					Status:       Running.string(),
+				},
+			},
+			args: args{completed: 10, total: 20, errorMessage: "vault token renewal failed"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -732,7 +747,7 @@ func TestRepository_FailRun(t *testing.T) {
 				privateId = tt.orig.PrivateId
 			}
 
-			got, err := repo.FailRun(context.Background(), privateId, tt.args.completed, tt.args.total)
+			got, err := repo.FailRun(context.Background(), privateId, tt.args.completed, tt.args.total, WithErrorMessage(tt.args.errorMessage))
 			if tt.wantErr {
 				require.Error(err)
 				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
@@ -752,6 +767,7 @@ func TestRepository_FailRun(t *testing.T) {
 			assert.Equal(Failed.string(), got.Status)
 			assert.Equal(tt.args.completed, int(got.CompletedCount))
 			assert.Equal(tt.args.total, int(got.TotalCount))
+			assert.Equal(tt.args.errorMessage, got.ErrorMessage)
 
 			// Delete job run so it does not clash with future runs
 			_, err = repo.deleteRun(context.Background(), privateId)
@@ -1204,6 +1220,108 @@ func TestRepository_LookupJobRun(t *testing.T) {
 	}
 }
 
+func TestRepository_ListRuns(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+
+	server := testController(t, conn, wrapper)
+	job1 := testJob(t, conn, "job1", "description", wrapper)
+	job2 := testJob(t, conn, "job2", "description", wrapper)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	run1, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+	require.NoError(t, err)
+	require.NotNil(t, run1)
+	run1, err = repo.CompleteRun(context.Background(), run1.PrivateId, time.Hour, 0, 0)
+	require.NoError(t, err)
+	run2, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+	require.NoError(t, err)
+	require.NotNil(t, run2)
+	run3, err := testRun(conn, job2.PluginId, job2.Name, server.PrivateId)
+	require.NoError(t, err)
+	require.NotNil(t, run3)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []*Run
+	}{
+		{
+			name: "no-options",
+			want: []*Run{run3, run2, run1},
+		},
+		{
+			name: "with-name",
+			opts: []Option{WithName(job1.Name)},
+			want: []*Run{run2, run1},
+		},
+		{
+			name: "with-name-and-limit",
+			opts: []Option{WithName(job1.Name), WithLimit(1)},
+			want: []*Run{run2},
+		},
+		{
+			name: "with-fake-name",
+			opts: []Option{WithName("fake-name")},
+			want: []*Run{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListRuns(context.Background(), tt.opts...)
+			require.NoError(err)
+			assert.Empty(cmp.Diff(tt.want, got, protocmp.Transform()))
+		})
+	}
+}
+
+func TestRepository_ListLatestRuns(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+
+	server := testController(t, conn, wrapper)
+	job1 := testJob(t, conn, "job1", "description", wrapper)
+	job2 := testJob(t, conn, "job2", "description", wrapper)
+	testJob(t, conn, "job3", "description", wrapper)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	got, err := repo.ListLatestRuns(context.Background())
+	require.NoError(err)
+	assert.Empty(got)
+
+	run1, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+	require.NoError(err)
+	require.NotNil(run1)
+	_, err = repo.CompleteRun(context.Background(), run1.PrivateId, time.Hour, 0, 0)
+	require.NoError(err)
+	run2, err := testRun(conn, job1.PluginId, job1.Name, server.PrivateId)
+	require.NoError(err)
+	require.NotNil(run2)
+	run3, err := testRun(conn, job2.PluginId, job2.Name, server.PrivateId)
+	require.NoError(err)
+	require.NotNil(run3)
+
+	got, err = repo.ListLatestRuns(context.Background())
+	require.NoError(err)
+	assert.Empty(cmp.Diff([]*Run{run2, run3}, got, protocmp.Transform()))
+}
+
 func TestRepository_deleteJobRun(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1265,3 +1383,103 @@ func TestRepository_deleteJobRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_DeleteRunsEndedBefore(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+
+	job := testJob(t, conn, "name", "description", wrapper)
+	server := testController(t, conn, wrapper)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("invalid-threshold", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.DeleteRunsEndedBefore(context.Background(), 0)
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Equal("job.(Repository).DeleteRunsEndedBefore: threshold must be greater than zero: parameter violation: error #100", err.Error())
+		assert.Zero(got)
+	})
+
+	t.Run("deletes-ended-runs-older-than-threshold", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ended, err := testRun(conn, job.PluginId, job.Name, server.PrivateId)
+		require.NoError(err)
+		_, err = repo.CompleteRun(context.Background(), ended.PrivateId, time.Hour, 0, 0)
+		require.NoError(err)
+		_, err = rw.Exec(context.Background(), "update job_run set end_time = now() - interval '2 hours' where private_id = ?", []interface{}{ended.PrivateId})
+		require.NoError(err)
+
+		recent, err := testRun(conn, job.PluginId, job.Name, server.PrivateId)
+		require.NoError(err)
+		_, err = repo.FailRun(context.Background(), recent.PrivateId, 0, 0)
+		require.NoError(err)
+
+		running, err := testRunWithUpdateTime(conn, job.PluginId, job.Name, server.PrivateId, time.Now().Add(-3*time.Hour))
+		require.NoError(err)
+
+		got, err := repo.DeleteRunsEndedBefore(context.Background(), time.Hour)
+		require.NoError(err)
+		assert.Equal(1, got)
+
+		r, err := repo.LookupRun(context.Background(), ended.PrivateId)
+		require.NoError(err)
+		assert.Nil(r)
+		r, err = repo.LookupRun(context.Background(), recent.PrivateId)
+		require.NoError(err)
+		assert.NotNil(r)
+		r, err = repo.LookupRun(context.Background(), running.PrivateId)
+		require.NoError(err)
+		assert.NotNil(r)
+	})
+}
+
+func TestRepository_ConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+
+	job := testJob(t, conn, "name", "description", wrapper)
+	server := testController(t, conn, wrapper)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	assertConsecutiveFailures := func(t *testing.T, want uint32) {
+		t.Helper()
+		got, err := repo.LookupJob(context.Background(), job.Name)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, want, got.ConsecutiveFailures)
+	}
+	assertConsecutiveFailures(t, 0)
+
+	run, err := testRun(conn, job.PluginId, job.Name, server.PrivateId)
+	require.NoError(t, err)
+	_, err = repo.FailRun(context.Background(), run.PrivateId, 0, 0, WithErrorMessage("failed"))
+	require.NoError(t, err)
+	assertConsecutiveFailures(t, 1)
+
+	run, err = testRunWithUpdateTime(conn, job.PluginId, job.Name, server.PrivateId, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	runs, err := repo.InterruptRuns(context.Background(), time.Minute)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, run.PrivateId, runs[0].PrivateId)
+	assertConsecutiveFailures(t, 2)
+
+	run, err = testRun(conn, job.PluginId, job.Name, server.PrivateId)
+	require.NoError(t, err)
+	_, err = repo.CompleteRun(context.Background(), run.PrivateId, time.Hour, 0, 0)
+	require.NoError(t, err)
+	assertConsecutiveFailures(t, 0)
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// runCleanupJob deletes the history of job runs which ended longer ago than
+// the scheduler's run retention.
+type runCleanupJob struct {
+	jobRepoFn jobRepoFactory
+
+	// the amount of time after a run ends that its history is kept.
+	retention time.Duration
+
+	// the number of runs deleted in the most recent run
+	deletedInRun int
+}
+
+// Status reports the job’s current status.  The status is periodically persisted by
+// the scheduler when a job is running, and will be used to verify a job is making progress.
+func (c *runCleanupJob) Status() JobStatus {
+	return JobStatus{
+		Completed: c.deletedInRun,
+		Total:     c.deletedInRun,
+	}
+}
+
+// Run performs the required work depending on the implementation.
+// The context is used to notify the job that it should exit early.
+func (c *runCleanupJob) Run(ctx context.Context) error {
+	const op = "scheduler.(runCleanupJob).Run"
+	c.deletedInRun = 0
+
+	repo, err := c.jobRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	c.deletedInRun, err = repo.DeleteRunsEndedBefore(ctx, c.retention)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.  This
+// method is invoked after a run has successfully completed and the next run time
+// is being persisted by the scheduler.  If an error is returned, the error will be logged
+// but the duration returned will still be used in scheduling.  If a zero duration is returned
+// the job will be scheduled to run again immediately.
+func (c *runCleanupJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+// Name is the unique name of the job.
+func (c *runCleanupJob) Name() string {
+	return "job_run_cleanup"
+}
+
+// Description is the human readable description of the job.
+func (c *runCleanupJob) Description() string {
+	return "Delete the history of job runs which ended longer ago than the job run retention"
+}
+
+// RegisterJobs registers the jobs the scheduler runs to maintain the history
+// of job runs.
+func (s *Scheduler) RegisterJobs(ctx context.Context) error {
+	const op = "scheduler.(Scheduler).RegisterJobs"
+	cleanup := &runCleanupJob{
+		jobRepoFn: s.jobRepoFn,
+		retention: s.runRetention,
+	}
+	if err := s.RegisterJob(ctx, cleanup); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("job run cleanup job"))
+	}
+	return nil
+}
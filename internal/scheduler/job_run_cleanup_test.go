@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCleanupJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iam.TestRepo(t, conn, wrapper)
+
+	sched := TestScheduler(t, conn, wrapper, WithRunRetention(time.Hour))
+	repo, err := job.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tj := testJob{name: "cleanup-test", description: "description", fn: func(context.Context) error { return nil }}
+	require.NoError(t, sched.RegisterJob(ctx, tj))
+
+	runs, err := repo.RunJobs(ctx, sched.serverId, job.WithRunJobsLimit(-1))
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	_, err = repo.CompleteRun(ctx, runs[0].PrivateId, 0, 0, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, sched.RegisterJobs(ctx))
+	regJob, ok := sched.registeredJobs.Load("job_run_cleanup")
+	require.True(t, ok)
+	cleanup := regJob.(*runCleanupJob)
+	assert.Equal(t, time.Hour, cleanup.retention)
+
+	// The run ended within the retention so is kept
+	require.NoError(t, cleanup.Run(ctx))
+	assert.Equal(t, 0, cleanup.Status().Completed)
+
+	_, err = rw.Exec(ctx, "update job_run set end_time = now() - interval '2 hours' where private_id = ?", []interface{}{runs[0].PrivateId})
+	require.NoError(t, err)
+
+	require.NoError(t, cleanup.Run(ctx))
+	assert.Equal(t, 1, cleanup.Status().Completed)
+	assert.Equal(t, 1, cleanup.Status().Total)
+	got, err := repo.LookupRun(ctx, runs[0].PrivateId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	labelJobName   = "job_name"
+	labelRunStatus = "status"
+
+	schedulerSubsystem = "controller_scheduler"
+)
+
+var (
+	// jobRunFailures counts the job runs which failed or were interrupted.
+	jobRunFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: schedulerSubsystem,
+			Name:      "job_run_failures_total",
+			Help:      "Count of job runs which failed or were interrupted, by job name and run status.",
+		},
+		[]string{labelJobName, labelRunStatus},
+	)
+
+	// jobConsecutiveFailures reports the number of runs of a job which have
+	// failed or been interrupted since the job last completed a run.
+	jobConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: globals.MetricNamespace,
+			Subsystem: schedulerSubsystem,
+			Name:      "job_consecutive_failures",
+			Help:      "Number of runs of a job which have failed or been interrupted since it last completed a run, by job name.",
+		},
+		[]string{labelJobName},
+	)
+)
+
+// InitializeCollectors registers the scheduler collectors onto `r`. It panics
+// upon the first registration that causes an error.
+func InitializeCollectors(r prometheus.Registerer) {
+	if r == nil {
+		return
+	}
+	r.MustRegister(jobRunFailures, jobConsecutiveFailures)
+}
+
+// recordRunFailure increments the count of failed or interrupted runs of a job.
+func recordRunFailure(jobName string, status job.Status) {
+	jobRunFailures.With(prometheus.Labels{labelJobName: jobName, labelRunStatus: string(status)}).Inc()
+}
+
+// recordConsecutiveFailures sets the consecutive failures of a job.
+func recordConsecutiveFailures(jobName string, consecutiveFailures uint32) {
+	jobConsecutiveFailures.With(prometheus.Labels{labelJobName: jobName}).Set(float64(consecutiveFailures))
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordRunFailure(t *testing.T) {
+	assert := assert.New(t)
+	jobRunFailures.Reset()
+
+	recordRunFailure("vault_token_renewal", job.Failed)
+	recordRunFailure("vault_token_renewal", job.Failed)
+	recordRunFailure("vault_token_renewal", job.Interrupted)
+
+	assert.Equal(float64(2), testutil.ToFloat64(jobRunFailures.With(prometheus.Labels{labelJobName: "vault_token_renewal", labelRunStatus: "failed"})))
+	assert.Equal(float64(1), testutil.ToFloat64(jobRunFailures.With(prometheus.Labels{labelJobName: "vault_token_renewal", labelRunStatus: "interrupted"})))
+}
+
+func TestRecordConsecutiveFailures(t *testing.T) {
+	assert := assert.New(t)
+	jobConsecutiveFailures.Reset()
+
+	recordConsecutiveFailures("vault_token_renewal", 3)
+	assert.Equal(float64(3), testutil.ToFloat64(jobConsecutiveFailures.With(prometheus.Labels{labelJobName: "vault_token_renewal"})))
+
+	recordConsecutiveFailures("vault_token_renewal", 0)
+	assert.Equal(float64(0), testutil.ToFloat64(jobConsecutiveFailures.With(prometheus.Labels{labelJobName: "vault_token_renewal"})))
+}
@@ -7,6 +7,7 @@ const (
 	defaultRunJobsInterval    = time.Minute
 	defaultMonitorInterval    = 30 * time.Second
 	defaultInterruptThreshold = 5 * time.Minute
+	defaultRunRetention       = 7 * 24 * time.Hour
 )
 
 // getOpts - iterate the inbound Options and return a struct
@@ -29,6 +30,9 @@ type options struct {
 	withMonitorInterval    time.Duration
 	withInterruptThreshold time.Duration
 	withRunNow             bool
+	withSchedule           *Schedule
+	withJobSchedules       map[string]*Schedule
+	withRunRetention       time.Duration
 }
 
 func getDefaultOptions() options {
@@ -37,6 +41,7 @@ func getDefaultOptions() options {
 		withRunJobInterval:     defaultRunJobsInterval,
 		withMonitorInterval:    defaultMonitorInterval,
 		withInterruptThreshold: defaultInterruptThreshold,
+		withRunRetention:       defaultRunRetention,
 	}
 }
 
@@ -106,3 +111,32 @@ func WithRunNow(b bool) Option {
 		o.withRunNow = b
 	}
 }
+
+// WithSchedule provides an option to provide the cron schedule used to compute the
+// next run time of a job, rather than the job's NextRunIn. The first run is
+// scheduled at the next time matching the schedule.
+func WithSchedule(s *Schedule) Option {
+	return func(o *options) {
+		o.withSchedule = s
+	}
+}
+
+// WithJobSchedules provides an option to provide schedules, keyed by job name, which
+// override the schedules jobs are registered with.
+func WithJobSchedules(m map[string]*Schedule) Option {
+	return func(o *options) {
+		o.withJobSchedules = m
+	}
+}
+
+// WithRunRetention provides an option to provide the duration for which the history
+// of a job run is kept after the run ends.
+// If WithRunRetention == 0, then default retention is used.
+func WithRunRetention(d time.Duration) Option {
+	return func(o *options) {
+		o.withRunRetention = d
+		if o.withRunRetention == 0 {
+			o.withRunRetention = defaultRunRetention
+		}
+	}
+}
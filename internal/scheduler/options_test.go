@@ -1,10 +1,12 @@
 package scheduler
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test_GetOpts provides unit tests for GetOpts and all the options
@@ -74,4 +76,38 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withRunNow = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithSchedule", func(t *testing.T) {
+		assert := assert.New(t)
+		s, err := ParseSchedule(context.Background(), "0 3 * * *", "")
+		require.NoError(t, err)
+		opts := getOpts(WithSchedule(s))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withSchedule = s
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithJobSchedules", func(t *testing.T) {
+		assert := assert.New(t)
+		s, err := ParseSchedule(context.Background(), "0 3 * * *", "")
+		require.NoError(t, err)
+		opts := getOpts(WithJobSchedules(map[string]*Schedule{"job": s}))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withJobSchedules = map[string]*Schedule{"job": s}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRunRetention", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRunRetention(time.Hour))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.withRunRetention = time.Hour
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithZeroRunRetention", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRunRetention(0))
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+	})
 }
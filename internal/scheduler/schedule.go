@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Schedule is a cron schedule, which determines the times a job runs.
+type Schedule struct {
+	expression string
+	location   *time.Location
+
+	minute, hour, dom, month, dow uint64
+	// domRestricted and dowRestricted are set when the day of month or day
+	// of week fields are not "*". When both are set a day matches if either
+	// field matches, following cron.
+	domRestricted, dowRestricted bool
+}
+
+// scheduleField describes one of the five fields of a cron expression.
+type scheduleField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = scheduleField{name: "minute", min: 0, max: 59}
+	hourField   = scheduleField{name: "hour", min: 0, max: 23}
+	domField    = scheduleField{name: "day of month", min: 1, max: 31}
+	monthField  = scheduleField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday.
+	dowField = scheduleField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// scheduleDescriptors are the supported shorthands for common expressions.
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxScheduleSearch bounds the search for the next time matching a schedule,
+// so a schedule which never matches, such as "0 0 31 2 *", is reported
+// rather than searched for forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// ParseSchedule parses a standard five field cron expression ("minute hour
+// day-of-month month day-of-week") evaluated in the named time zone. Fields
+// support "*", values, ranges ("1-5"), steps ("*/15", "0-30/10") and lists
+// ("1,15"); month and day of week fields also accept three letter names. The
+// descriptors "@yearly", "@annually", "@monthly", "@weekly", "@daily",
+// "@midnight" and "@hourly" are also supported.
+//
+// An empty timeZone evaluates the expression in UTC.
+func ParseSchedule(ctx context.Context, expression, timeZone string) (*Schedule, error) {
+	const op = "scheduler.ParseSchedule"
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing expression")
+	}
+	loc := time.UTC
+	if timeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid time zone %q: %s", timeZone, err))
+		}
+	}
+
+	expanded := expression
+	if d, ok := scheduleDescriptors[strings.ToLower(expression)]; ok {
+		expanded = d
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("expression %q must have 5 fields, found %d", expression, len(fields)))
+	}
+
+	s := &Schedule{
+		expression: expression,
+		location:   loc,
+	}
+	var err error
+	for i, f := range []struct {
+		field  scheduleField
+		target *uint64
+	}{
+		{minuteField, &s.minute},
+		{hourField, &s.hour},
+		{domField, &s.dom},
+		{monthField, &s.month},
+		{dowField, &s.dow},
+	} {
+		if *f.target, err = parseScheduleField(fields[i], f.field); err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid expression %q: %s", expression, err))
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return s, nil
+}
+
+// parseScheduleField returns the values of the field as a bit set.
+func parseScheduleField(in string, f scheduleField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(in, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepStr, f.name)
+			}
+		}
+		var lo, hi int
+		switch {
+		case rng == "*":
+			lo, hi = f.min, f.max
+		default:
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+			hi = lo
+			switch {
+			case isRange:
+				if hi, err = f.value(hiStr); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				// "5/15" is the same as "5-<max>/15"
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s field", rng, f.name)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (f scheduleField) value(in string) (int, error) {
+	if v, ok := f.names[strings.ToLower(in)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(in)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", in, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d] in %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
+
+// Expression returns the cron expression of the schedule.
+func (s *Schedule) Expression() string {
+	return s.expression
+}
+
+// TimeZone returns the name of the time zone the schedule is evaluated in.
+func (s *Schedule) TimeZone() string {
+	return s.location.String()
+}
+
+// String returns the expression and time zone of the schedule.
+func (s *Schedule) String() string {
+	return fmt.Sprintf("%s (%s)", s.expression, s.TimeZone())
+}
+
+// Next returns the first time matching the schedule which is after t, or the
+// zero time if there is no such time in the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	orig := t
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+		case !s.dayMatches(t):
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location))
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t.In(orig.Location())
+		}
+	}
+	return time.Time{}
+}
+
+// advance returns next, moved forward an hour at a time until it is after t.
+// When next falls in a daylight saving time gap time.Date can normalize it to a
+// time before t.
+func advance(t, next time.Time) time.Time {
+	for !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
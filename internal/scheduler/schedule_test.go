@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		expression   string
+		timeZone     string
+		wantTimeZone string
+		wantErr      bool
+	}{
+		{name: "every-minute", expression: "* * * * *", wantTimeZone: "UTC"},
+		{name: "daily", expression: "0 3 * * *", timeZone: "America/New_York", wantTimeZone: "America/New_York"},
+		{name: "lists-ranges-steps", expression: "*/15 1-5,22 1,15 */2 mon-fri", wantTimeZone: "UTC"},
+		{name: "names", expression: "0 0 * JAN,jul sun", wantTimeZone: "UTC"},
+		{name: "sunday-as-7", expression: "0 0 * * 7", wantTimeZone: "UTC"},
+		{name: "descriptor", expression: "@daily", wantTimeZone: "UTC"},
+		{name: "surrounding-space", expression: "  0 0 * * *  ", wantTimeZone: "UTC"},
+		{name: "empty", expression: "", wantErr: true},
+		{name: "too-few-fields", expression: "* * * *", wantErr: true},
+		{name: "too-many-fields", expression: "* * * * * *", wantErr: true},
+		{name: "minute-out-of-range", expression: "60 * * * *", wantErr: true},
+		{name: "day-of-month-zero", expression: "* * 0 * *", wantErr: true},
+		{name: "bad-name", expression: "* * * foo *", wantErr: true},
+		{name: "reversed-range", expression: "* 5-1 * * *", wantErr: true},
+		{name: "zero-step", expression: "*/0 * * * *", wantErr: true},
+		{name: "bad-step", expression: "*/x * * * *", wantErr: true},
+		{name: "unknown-descriptor", expression: "@fortnightly", wantErr: true},
+		{name: "bad-time-zone", expression: "0 0 * * *", timeZone: "Mars/Olympus_Mons", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := ParseSchedule(context.Background(), tt.expression, tt.timeZone)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantTimeZone, got.TimeZone())
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		timeZone   string
+		from       time.Time
+		want       time.Time
+	}{
+		{
+			name:       "every-minute",
+			expression: "* * * * *",
+			from:       time.Date(2022, 3, 1, 10, 15, 30, 0, time.UTC),
+			want:       time.Date(2022, 3, 1, 10, 16, 0, 0, time.UTC),
+		},
+		{
+			name:       "strictly-after",
+			expression: "15 10 * * *",
+			from:       time.Date(2022, 3, 1, 10, 15, 0, 0, time.UTC),
+			want:       time.Date(2022, 3, 2, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:       "daily-later-today",
+			expression: "0 3 * * *",
+			from:       time.Date(2022, 3, 1, 1, 0, 0, 0, time.UTC),
+			want:       time.Date(2022, 3, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "steps",
+			expression: "*/20 * * * *",
+			from:       time.Date(2022, 3, 1, 10, 41, 0, 0, time.UTC),
+			want:       time.Date(2022, 3, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "year-rollover",
+			expression: "@yearly",
+			from:       time.Date(2022, 3, 1, 10, 41, 0, 0, time.UTC),
+			want:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "weekday",
+			expression: "30 9 * * mon-fri",
+			// Saturday
+			from: time.Date(2022, 3, 5, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 3, 7, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "sunday-as-7",
+			expression: "0 0 * * 7",
+			// Wednesday
+			from: time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day-of-month-or-day-of-week",
+			expression: "0 0 15 * fri",
+			// Tuesday the 1st, next Friday is the 4th
+			from: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap-day",
+			expression: "0 0 29 2 *",
+			from:       time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "never",
+			expression: "0 0 31 2 *",
+			from:       time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:       time.Time{},
+		},
+		{
+			name:       "time-zone",
+			expression: "0 3 * * *",
+			timeZone:   "America/New_York",
+			from:       time.Date(2022, 1, 10, 12, 0, 0, 0, time.UTC),
+			want:       time.Date(2022, 1, 11, 3, 0, 0, 0, newYork),
+		},
+		{
+			name:       "half-hour-offset-time-zone",
+			expression: "0 * * * *",
+			timeZone:   "Asia/Kolkata",
+			from:       time.Date(2022, 1, 10, 12, 10, 0, 0, time.UTC),
+			want:       time.Date(2022, 1, 10, 18, 0, 0, 0, kolkata),
+		},
+		{
+			name:       "daylight-saving-gap",
+			expression: "30 2 * * *",
+			timeZone:   "America/New_York",
+			// 2:30 does not exist on 2022-03-13 in New York
+			from: time.Date(2022, 3, 13, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 3, 14, 2, 30, 0, 0, newYork),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			s, err := ParseSchedule(context.Background(), tt.expression, tt.timeZone)
+			require.NoError(err)
+			got := s.Next(tt.from)
+			assert.True(tt.want.Equal(got), "Next(%s) = %s, want %s", tt.from, got, tt.want)
+		})
+	}
+}
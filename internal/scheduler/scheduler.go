@@ -8,7 +8,9 @@ import (
 
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/observability/tracing"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"go.opentelemetry.io/otel/attribute"
 	ua "go.uber.org/atomic"
 )
 
@@ -33,6 +35,8 @@ type Scheduler struct {
 	runJobsInterval    time.Duration
 	monitorInterval    time.Duration
 	interruptThreshold time.Duration
+	jobSchedules       map[string]*Schedule
+	runRetention       time.Duration
 	runNow             chan struct{}
 }
 
@@ -42,8 +46,8 @@ type Scheduler struct {
 //
 // • jobRepoFn must be provided and is a function that returns the job repository
 //
-// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval and WithInterruptThreshold are
-// the only valid options.
+// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval, WithInterruptThreshold,
+// WithJobSchedules and WithRunRetention are the only valid options.
 func New(serverId string, jobRepoFn jobRepoFactory, opt ...Option) (*Scheduler, error) {
 	const op = "scheduler.New"
 	if serverId == "" {
@@ -63,6 +67,8 @@ func New(serverId string, jobRepoFn jobRepoFactory, opt ...Option) (*Scheduler,
 		runJobsInterval:    opts.withRunJobInterval,
 		monitorInterval:    opts.withMonitorInterval,
 		interruptThreshold: opts.withInterruptThreshold,
+		jobSchedules:       opts.withJobSchedules,
+		runRetention:       opts.withRunRetention,
 		runNow:             make(chan struct{}, 1),
 	}, nil
 }
@@ -71,7 +77,8 @@ func New(serverId string, jobRepoFn jobRepoFactory, opt ...Option) (*Scheduler,
 //
 // • job must be provided and is an implementer of the Job interface.
 //
-// WithNextRunIn is the only valid options.
+// WithNextRunIn and WithSchedule are the only valid options. A schedule provided to the
+// scheduler with WithJobSchedules for the job takes precedence over both.
 func (s *Scheduler) RegisterJob(ctx context.Context, j Job, opt ...Option) error {
 	const op = "scheduler.(Scheduler).RegisterJob"
 	if err := validateJob(j); err != nil {
@@ -89,7 +96,19 @@ func (s *Scheduler) RegisterJob(ctx context.Context, j Job, opt ...Option) error
 	}
 
 	opts := getOpts(opt...)
-	_, err = repo.UpsertJob(ctx, j.Name(), j.Description(), job.WithNextRunIn(opts.withNextRunIn))
+	sched := opts.withSchedule
+	if override, ok := s.jobSchedules[j.Name()]; ok {
+		sched = override
+	}
+	repoOpts := []job.Option{job.WithNextRunIn(opts.withNextRunIn)}
+	if sched != nil {
+		nextRunIn, err := untilNext(ctx, sched, time.Now())
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("job %q", j.Name())))
+		}
+		repoOpts = []job.Option{job.WithNextRunIn(nextRunIn), job.WithSchedule(sched.Expression(), sched.TimeZone())}
+	}
+	_, err = repo.UpsertJob(ctx, j.Name(), j.Description(), repoOpts...)
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
@@ -220,9 +239,13 @@ func (s *Scheduler) schedule(ctx context.Context, wg *sync.WaitGroup) {
 		err := s.runJob(ctx, wg, r)
 		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error starting job"))
-			if _, inner := repo.FailRun(ctx, r.PrivateId, 0, 0); inner != nil {
+			failedRun, inner := repo.FailRun(ctx, r.PrivateId, 0, 0, job.WithErrorMessage(err.Error()))
+			if inner != nil {
 				event.WriteError(ctx, op, inner, event.WithInfoMsg("error updating failed job run"))
 			}
+			if failedRun != nil {
+				s.runEnded(ctx, repo, failedRun)
+			}
 		}
 	}
 }
@@ -252,34 +275,82 @@ func (s *Scheduler) runJob(ctx context.Context, wg *sync.WaitGroup, r *job.Run)
 	go func() {
 		defer rj.cancelCtx()
 		defer wg.Done()
+		jobContext, span := tracing.StartSpan(jobContext, "scheduler.job.run",
+			attribute.String("job.name", j.Name()),
+			attribute.String("job.run_id", r.PrivateId),
+		)
 		runErr := j.Run(jobContext)
+		tracing.EndSpan(span, runErr)
 
 		// Get final status report to update run progress with
 		status := j.Status()
+		var endedRun *job.Run
 		var updateErr error
 		switch {
 		case ctx.Err() != nil:
 			// Base context is no longer valid, skip repo updates as they will fail and exit
 		case runErr == nil:
-			nextRun, inner := j.NextRunIn(ctx)
+			nextRun, inner := s.nextRunIn(ctx, repo, j)
 			if inner != nil {
 				event.WriteError(ctx, op, inner, event.WithInfoMsg("error getting next run time", "name", j.Name()))
 			}
-			_, updateErr = repo.CompleteRun(ctx, r.PrivateId, nextRun, status.Completed, status.Total)
+			endedRun, updateErr = repo.CompleteRun(ctx, r.PrivateId, nextRun, status.Completed, status.Total)
 		default:
 			event.WriteError(ctx, op, runErr, event.WithInfoMsg("job run failed", "run id", r.PrivateId, "name", j.Name()))
-			_, updateErr = repo.FailRun(ctx, r.PrivateId, status.Completed, status.Total)
+			endedRun, updateErr = repo.FailRun(ctx, r.PrivateId, status.Completed, status.Total, job.WithErrorMessage(runErr.Error()))
 		}
 
 		if updateErr != nil {
 			event.WriteError(ctx, op, updateErr, event.WithInfoMsg("error updating job run", "name", j.Name()))
 		}
+		if endedRun != nil {
+			s.runEnded(ctx, repo, endedRun)
+		}
 		s.runningJobs.Delete(j.Name())
 	}()
 
 	return nil
 }
 
+// nextRunIn returns the duration until the next run of the job. If the job has a
+// schedule it is computed from the schedule persisted with the job, so every
+// controller agrees on the next run, otherwise the job computes it.
+func (s *Scheduler) nextRunIn(ctx context.Context, repo *job.Repository, j Job) (time.Duration, error) {
+	const op = "scheduler.(Scheduler).nextRunIn"
+	persisted, err := repo.LookupJob(ctx, j.Name())
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	if persisted == nil || persisted.GetSchedule() == "" {
+		return j.NextRunIn(ctx)
+	}
+	sched, err := ParseSchedule(ctx, persisted.GetSchedule(), persisted.GetTimeZone())
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	nextRunIn, err := untilNext(ctx, sched, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	return nextRunIn, nil
+}
+
+// untilNext returns the duration from now until the next time matching the
+// schedule, rounded up to the second so a job never runs before its scheduled
+// time.
+func untilNext(ctx context.Context, sched *Schedule, now time.Time) (time.Duration, error) {
+	const op = "scheduler.untilNext"
+	next := sched.Next(now)
+	if next.IsZero() {
+		return 0, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("schedule %s never matches", sched))
+	}
+	d := next.Sub(now)
+	if r := d.Truncate(time.Second); r < d {
+		d = r + time.Second
+	}
+	return d, nil
+}
+
 func (s *Scheduler) monitorJobs(ctx context.Context) {
 	const op = "scheduler.(Scheduler).monitorJobs"
 	timer := time.NewTimer(0)
@@ -306,15 +377,56 @@ func (s *Scheduler) monitorJobs(ctx context.Context) {
 				break
 			}
 
-			_, err = repo.InterruptRuns(ctx, s.interruptThreshold)
+			runs, err := repo.InterruptRuns(ctx, s.interruptThreshold)
 			if err != nil {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error interrupting job runs"))
 			}
+			for _, r := range runs {
+				s.runEnded(ctx, repo, r)
+			}
 		}
 		timer.Reset(s.monitorInterval)
 	}
 }
 
+// runEnded records a run which has ended. A run which failed or was interrupted
+// is counted and emitted as a system event, along with the number of consecutive
+// failures of its job.
+func (s *Scheduler) runEnded(ctx context.Context, repo *job.Repository, r *job.Run) {
+	const op = "scheduler.(Scheduler).runEnded"
+	runStatus := job.Status(r.GetStatus())
+	if runStatus == job.Failed || runStatus == job.Interrupted {
+		recordRunFailure(r.GetJobName(), runStatus)
+	}
+
+	j, err := repo.LookupJob(ctx, r.GetJobName())
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up job", "name", r.GetJobName()))
+	}
+	if j != nil {
+		recordConsecutiveFailures(r.GetJobName(), j.GetConsecutiveFailures())
+	}
+
+	if runStatus == job.Failed || runStatus == job.Interrupted {
+		args := []interface{}{
+			"name", r.GetJobName(),
+			"run id", r.GetPrivateId(),
+			"status", r.GetStatus(),
+			"controller id", r.GetControllerId(),
+			"duration", r.GetEndTime().AsTime().Sub(r.GetCreateTime().AsTime()).String(),
+			"completed", r.GetCompletedCount(),
+			"total", r.GetTotalCount(),
+		}
+		if r.GetErrorMessage() != "" {
+			args = append(args, "error", r.GetErrorMessage())
+		}
+		if j != nil {
+			args = append(args, "consecutive failures", j.GetConsecutiveFailures())
+		}
+		event.WriteSysEvent(ctx, op, "job run did not complete", args...)
+	}
+}
+
 func (s *Scheduler) updateRunningJobProgress(ctx context.Context, j *runningJob) error {
 	repo, err := s.jobRepoFn()
 	if err != nil {
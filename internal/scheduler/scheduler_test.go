@@ -278,6 +278,63 @@ func TestScheduler_RegisterJob(t *testing.T) {
 		_, ok = sched.registeredJobs.Load(tj.name)
 		assert.True(ok)
 	})
+	t.Run("with-schedule", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tj := testJob{
+			name:        "with-schedule",
+			description: "description",
+		}
+		s, err := ParseSchedule(context.Background(), "0 3 * * *", "America/New_York")
+		require.NoError(err)
+		err = sched.RegisterJob(context.Background(), tj, WithSchedule(s))
+		require.NoError(err)
+
+		repo, err := job.NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+		require.NoError(err)
+		dbJob, err := repo.LookupJob(context.Background(), tj.name)
+		require.NoError(err)
+		require.NotNil(dbJob)
+		assert.Equal("0 3 * * *", dbJob.Schedule)
+		assert.Equal("America/New_York", dbJob.TimeZone)
+		assert.WithinDuration(s.Next(time.Now()), dbJob.NextScheduledRun.AsTime(), 5*time.Second)
+	})
+	t.Run("with-job-schedules-override", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tj := testJob{
+			name:        "with-job-schedules-override",
+			description: "description",
+		}
+		registered, err := ParseSchedule(context.Background(), "0 3 * * *", "")
+		require.NoError(err)
+		override, err := ParseSchedule(context.Background(), "30 4 * * sun", "Europe/London")
+		require.NoError(err)
+		sched1 := TestScheduler(t, conn, wrapper, WithJobSchedules(map[string]*Schedule{tj.name: override}))
+		err = sched1.RegisterJob(context.Background(), tj, WithSchedule(registered))
+		require.NoError(err)
+
+		repo, err := job.NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+		require.NoError(err)
+		dbJob, err := repo.LookupJob(context.Background(), tj.name)
+		require.NoError(err)
+		require.NotNil(dbJob)
+		assert.Equal("30 4 * * sun", dbJob.Schedule)
+		assert.Equal("Europe/London", dbJob.TimeZone)
+		assert.WithinDuration(override.Next(time.Now()), dbJob.NextScheduledRun.AsTime(), 5*time.Second)
+	})
+	t.Run("with-schedule-that-never-matches", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tj := testJob{
+			name:        "with-schedule-that-never-matches",
+			description: "description",
+		}
+		s, err := ParseSchedule(context.Background(), "0 0 31 2 *", "")
+		require.NoError(err)
+		err = sched.RegisterJob(context.Background(), tj, WithSchedule(s))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, ok := sched.registeredJobs.Load(tj.name)
+		assert.False(ok)
+	})
 }
 
 func TestScheduler_UpdateJobNextRunInAtLeast(t *testing.T) {
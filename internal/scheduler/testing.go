@@ -21,8 +21,8 @@ import (
 // TestScheduler creates a mock controller and a new Scheduler attached to that controller id.
 // The Scheduler returned should only be used for tests.  The mock controller is not run.
 //
-// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval and WithInterruptThreshold are
-// the only valid options.
+// WithRunJobsLimit, WithRunJobsInterval, WithMonitorInterval, WithInterruptThreshold,
+// WithJobSchedules and WithRunRetention are the only valid options.
 func TestScheduler(t testing.TB, conn *db.DB, wrapper wrapping.Wrapper, opt ...Option) *Scheduler {
 	t.Helper()
 
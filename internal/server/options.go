@@ -21,28 +21,34 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName                           string
-	withPublicId                       string
-	withDescription                    string
-	withAddress                        string
-	withLimit                          int
-	withLiveness                       time.Duration
-	withUpdateTags                     bool
-	withWorkerTags                     []*Tag
-	withWorkerKeyIdentifier            string
-	withWorkerKeys                     WorkerKeys
-	withControllerEncryptionPrivateKey []byte
-	withKeyId                          string
-	withNonce                          []byte
-	withNewIdFunc                      func(context.Context) (string, error)
-	withFetchNodeCredentialsRequest    *types.FetchNodeCredentialsRequest
-	withTestPkiWorkerAuthorized        bool
-	withTestPkiWorkerKeyId             *string
+	withName                               string
+	withPublicId                           string
+	withDescription                        string
+	withAddress                            string
+	withLimit                              int
+	withLiveness                           time.Duration
+	withUpdateTags                         bool
+	withWorkerTags                         []*Tag
+	withWorkerKeyIdentifier                string
+	withWorkerKeys                         WorkerKeys
+	withControllerEncryptionPrivateKey     []byte
+	withKeyId                              string
+	withNonce                              []byte
+	withNewIdFunc                          func(context.Context) (string, error)
+	withFetchNodeCredentialsRequest        *types.FetchNodeCredentialsRequest
+	withTestPkiWorkerAuthorized            bool
+	withTestPkiWorkerKeyId                 *string
+	withAuthRotationStatus                 *AuthRotationStatus
+	withReleaseVersion                     string
+	withFeatures                           []string
+	withCreateControllerLedActivationToken bool
+	withActivationTokenTtl                 time.Duration
 }
 
 func getDefaultOptions() options {
 	return options{
-		withNewIdFunc: newWorkerId,
+		withNewIdFunc:          newWorkerId,
+		withActivationTokenTtl: DefaultActivationTokenTtl,
 	}
 }
 
@@ -163,3 +169,44 @@ func WithTestPkiWorkerAuthorizedKeyId(id *string) Option {
 		o.withTestPkiWorkerKeyId = id
 	}
 }
+
+// WithAuthRotationStatus provides the credential rotation status reported by
+// a pki worker.
+func WithAuthRotationStatus(status *AuthRotationStatus) Option {
+	return func(o *options) {
+		o.withAuthRotationStatus = status
+	}
+}
+
+// WithReleaseVersion provides the version of Boundary reported by a worker.
+func WithReleaseVersion(releaseVersion string) Option {
+	return func(o *options) {
+		o.withReleaseVersion = releaseVersion
+	}
+}
+
+// WithFeatures provides the protocol features reported by a worker.
+func WithFeatures(features ...string) Option {
+	return func(o *options) {
+		o.withFeatures = features
+	}
+}
+
+// WithCreateControllerLedActivationToken specifies that a single use
+// activation token should be generated for the worker being created, which the
+// worker can present to be authorized.
+func WithCreateControllerLedActivationToken(with bool) Option {
+	return func(o *options) {
+		o.withCreateControllerLedActivationToken = with
+	}
+}
+
+// WithActivationTokenTtl provides how long a generated activation token
+// remains valid. A zero or negative ttl leaves the default in place.
+func WithActivationTokenTtl(ttl time.Duration) Option {
+	return func(o *options) {
+		if ttl > 0 {
+			o.withActivationTokenTtl = ttl
+		}
+	}
+}
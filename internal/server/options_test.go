@@ -158,4 +158,48 @@ func Test_GetOpts(t *testing.T) {
 		opts.withNewIdFunc = nil
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithReleaseVersion", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithReleaseVersion("0.9.1"))
+		testOpts := getDefaultOptions()
+		testOpts.withReleaseVersion = "0.9.1"
+		testOpts.withNewIdFunc = nil
+		opts.withNewIdFunc = nil
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithFeatures", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithFeatures("feature1", "feature2"))
+		testOpts := getDefaultOptions()
+		testOpts.withFeatures = []string{"feature1", "feature2"}
+		testOpts.withNewIdFunc = nil
+		opts.withNewIdFunc = nil
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithCreateControllerLedActivationToken", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithCreateControllerLedActivationToken(true))
+		testOpts := getDefaultOptions()
+		testOpts.withCreateControllerLedActivationToken = true
+		testOpts.withNewIdFunc = nil
+		opts.withNewIdFunc = nil
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithActivationTokenTtl", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithActivationTokenTtl(time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withActivationTokenTtl = time.Hour
+		testOpts.withNewIdFunc = nil
+		opts.withNewIdFunc = nil
+		assert.Equal(opts, testOpts)
+
+		// A zero ttl leaves the default in place
+		opts = getOpts(WithActivationTokenTtl(0))
+		opts.withNewIdFunc = nil
+		testOpts = getDefaultOptions()
+		testOpts.withNewIdFunc = nil
+		assert.Equal(DefaultActivationTokenTtl, opts.withActivationTokenTtl)
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -10,6 +10,10 @@ import (
 // PublicId prefixes for the resources in the server package.
 const (
 	WorkerPrefix = "w"
+
+	// WorkerActivationTokenPrefix is the prefix for the private ids of worker
+	// activation tokens.
+	WorkerActivationTokenPrefix = "wat"
 )
 
 func newWorkerId(ctx context.Context) (string, error) {
@@ -31,3 +35,11 @@ func newWorkerIdFromScopeAndName(ctx context.Context, scope, name string) (strin
 	}
 	return id, nil
 }
+
+func newWorkerActivationTokenId(ctx context.Context) (string, error) {
+	id, err := db.NewPrivateId(WorkerActivationTokenPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "server.newWorkerActivationTokenId")
+	}
+	return id, nil
+}
@@ -11,6 +11,33 @@ const (
 	and
 		worker_id = ?`
 
+	deleteFeaturesByWorkerIdSql = `
+	delete
+	from server_worker_feature
+	where
+		worker_id = ?`
+
+	redeemWorkerActivationTokenQuery = `
+		update server_worker_activation_token t
+		   set redeemed_time = now()
+		  from server_worker w
+		 where t.token_hash = @token_hash
+		   and t.worker_id = w.public_id
+		   and t.scope_id = w.scope_id
+		   and t.redeemed_time is null
+		   and t.revoked_time is null
+		   and t.expiration_time > now()
+		returning t.worker_id;
+	`
+
+	revokeWorkerActivationTokensQuery = `
+		update server_worker_activation_token
+		   set revoked_time = now()
+		 where worker_id = @worker_id
+		   and redeemed_time is null
+		   and revoked_time is null;
+	`
+
 	deleteWorkerAuthQuery = `
 		delete from worker_auth_authorized
  		where worker_key_identifier = @worker_key_identifier;
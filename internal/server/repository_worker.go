@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
@@ -14,6 +17,8 @@ import (
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/registration"
+	"github.com/hashicorp/nodeenrollment/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -217,8 +222,14 @@ func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...
 // If the worker is a kms worker that hasn't been seen yet, it'll attempt to
 // create a new one, but will return an error if another worker (kms or other)
 // has the same name.  This returns the Worker object with the changes applied.
-// The WithPublicId, WithKeyId, and WithUpdateTags options are
-// the only ones used. All others are ignored.
+// For pki workers, WithAuthRotationStatus records the worker's reported
+// credential rotation status, and any pending rotation request is cleared
+// since it is relayed in the response to this status. WithReleaseVersion and
+// WithFeatures record the version and features reported by the worker;
+// features are only replaced when a release version is provided. The
+// WithPublicId, WithKeyId, WithUpdateTags, WithAuthRotationStatus,
+// WithReleaseVersion, and WithFeatures options are the only ones used. All
+// others are ignored.
 // Workers are intentionally not oplogged.
 func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.UpsertWorkerStatus"
@@ -282,7 +293,24 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				// "description" since we want description changes for PKI-based
 				// workers to come via API only. We can't really guard on this
 				// in the DB so we need to be sure to not include it here.
-				n, err := w.Update(ctx, workerClone, []string{"address"}, nil)
+				fieldMask := []string{"address"}
+				var nullFields []string
+				if rs := opts.withAuthRotationStatus; rs != nil {
+					workerClone.CertificateExpiration = rs.CertificateExpiration
+					workerClone.LastRotationTime = rs.LastRotationTime
+					workerClone.LastRotationError = rs.LastRotationError
+					fieldMask, nullFields = dbcommon.BuildUpdatePaths(
+						map[string]interface{}{
+							"address":               workerClone.Address,
+							"CertificateExpiration": workerClone.CertificateExpiration,
+							"LastRotationTime":      workerClone.LastRotationTime,
+							"LastRotationError":     workerClone.LastRotationError,
+						},
+						[]string{"address", "CertificateExpiration", "LastRotationTime", "LastRotationError"},
+						nil,
+					)
+				}
+				n, err := w.Update(ctx, workerClone, fieldMask, nullFields)
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update status of pki worker"))
 				}
@@ -319,6 +347,18 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				}
 			}
 
+			// The version and features are only reported alongside tags, so
+			// they are only updated when they are provided.
+			if opts.withReleaseVersion != "" {
+				workerClone.ReleaseVersion = opts.withReleaseVersion
+				if _, err := w.Update(ctx, workerClone, []string{"ReleaseVersion"}, nil); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("error updating worker release version"))
+				}
+				if err := setWorkerFeatures(ctx, w, workerClone.GetPublicId(), opts.withFeatures); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("error setting worker features"))
+				}
+			}
+
 			// If we've been told to update tags, we need to clean out old
 			// ones and add new ones. Within the current transaction, simply
 			// delete all tags for the given worker, then add the new ones
@@ -338,6 +378,18 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				return errors.Wrap(ctx, err, op, errors.WithMsg("error converting worker aggregate to worker"))
 			}
 
+			// A requested rotation is delivered to the worker in the response
+			// to this status, so the request can be cleared now. The returned
+			// worker still reports it as requested so the caller knows to
+			// relay it.
+			if opts.withKeyId != "" && ret.GetRotateAuthRequested() {
+				cleared := allocWorker()
+				cleared.PublicId = ret.GetPublicId()
+				if _, err := w.Update(ctx, &cleared, []string{"RotateAuthRequested"}, nil); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("error clearing worker auth rotation request"))
+				}
+			}
+
 			return nil
 		},
 	)
@@ -392,6 +444,101 @@ func setWorkerTags(ctx context.Context, w db.Writer, id string, ts TagSource, ta
 	return nil
 }
 
+// setWorkerFeatures replaces all existing features for the worker id with the
+// ones provided.  This function should be called from inside a db transaction.
+// Worker features are intentionally not oplogged.
+func setWorkerFeatures(ctx context.Context, w db.Writer, id string, features []string) error {
+	const op = "server.setWorkerFeatures"
+	switch {
+	case id == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "worker id is empty")
+	case isNil(w):
+		return errors.New(ctx, errors.InvalidParameter, op, "db.Writer is nil")
+	}
+	_, err := w.Exec(ctx, deleteFeaturesByWorkerIdSql, []interface{}{id})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("couldn't delete existing features for worker %q", id)))
+	}
+
+	if len(features) > 0 {
+		seen := make(map[string]struct{}, len(features))
+		uFeatures := make([]interface{}, 0, len(features))
+		for _, f := range features {
+			if _, ok := seen[f]; ok {
+				continue
+			}
+			seen[f] = struct{}{}
+			uFeatures = append(uFeatures, &store.WorkerFeature{
+				WorkerId: id,
+				Feature:  f,
+			})
+		}
+		if err = w.CreateItems(ctx, uFeatures); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("error creating features for worker %q", id)))
+		}
+	}
+
+	return nil
+}
+
+// RequestWorkerAuthRotation marks the pki worker with the given id so that it
+// is told to rotate its credentials in the response to its next status
+// update. The worker's version is used for optimistic locking but is not
+// incremented. An error is returned if the worker is a kms worker.
+func (r *Repository) RequestWorkerAuthRotation(ctx context.Context, publicId string, version uint32, _ ...Option) (*Worker, error) {
+	const op = "server.(Repository).RequestWorkerAuthRotation"
+	switch {
+	case publicId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	case version == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "version is zero")
+	}
+
+	var ret *Worker
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			wAgg := &workerAggregate{PublicId: publicId}
+			if err := reader.LookupById(ctx, wAgg); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if wAgg.Type != PkiWorkerType.String() {
+				return errors.New(ctx, errors.InvalidParameter, op, "cannot rotate the credentials of a KMS worker")
+			}
+
+			worker := allocWorker()
+			worker.PublicId = publicId
+			worker.RotateAuthRequested = true
+			rowsUpdated, err := w.Update(ctx, &worker, []string{"RotateAuthRequested"}, nil, db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			switch rowsUpdated {
+			case 0:
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("worker %q with version %d not found", publicId, version))
+			case 1:
+			default:
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+
+			wAgg = &workerAggregate{PublicId: publicId}
+			if err := reader.LookupById(ctx, wAgg); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if ret, err = wAgg.toWorker(ctx); err != nil {
+				return err
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", publicId)))
+	}
+	return ret, nil
+}
+
 // UpdateWorker will update a worker in the repository and return the resulting
 // worker. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
@@ -482,8 +629,14 @@ func (r *Repository) UpdateWorker(ctx context.Context, worker *Worker, version u
 // ReportedStatus and Tags are intentionally ignored when creating a worker (not
 // included).  Currently, a worker can only be created in the global scope
 //
-// Options supported: WithFetchNodeCredentialsRequest and WithNewIdFunc (this
-// option is likely only useful for tests)
+// If WithCreateControllerLedActivationToken is provided, a single use
+// activation token is generated for the worker; it expires after the duration
+// provided by WithActivationTokenTtl, and is returned only on the returned
+// worker.
+//
+// Options supported: WithFetchNodeCredentialsRequest,
+// WithCreateControllerLedActivationToken, WithActivationTokenTtl and
+// WithNewIdFunc (this option is likely only useful for tests)
 func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.CreateWorker"
 	switch {
@@ -502,6 +655,9 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 	}
 
 	opts := getOpts(opt...)
+	if opts.withFetchNodeCredentialsRequest != nil && opts.withCreateControllerLedActivationToken {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "fetch node credentials request and controller led activation token are mutually exclusive")
+	}
 	var err error
 	if worker.PublicId, err = opts.withNewIdFunc(ctx); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate worker id"))
@@ -523,6 +679,23 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 		}
 	}
 
+	var activationToken string
+	var activationTokenRecord *WorkerActivationToken
+	if opts.withCreateControllerLedActivationToken {
+		var tokenHash []byte
+		activationToken, tokenHash, err = generateActivationToken(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		activationTokenRecord, err = newWorkerActivationToken(ctx, worker.PublicId, worker.ScopeId, tokenHash, time.Now().Add(opts.withActivationTokenTtl))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if activationTokenRecord.PrivateId, err = newWorkerActivationTokenId(ctx); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
 	var returnedWorker *Worker
 	if _, err := r.writer.DoTx(
 		ctx,
@@ -551,6 +724,14 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to store node information"))
 				}
 			}
+			if activationTokenRecord != nil {
+				token := activationTokenRecord.clone()
+				if err := w.Create(ctx, token); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create activation token"))
+				}
+				returnedWorker.controllerGeneratedActivationToken = activationToken
+				returnedWorker.activationTokenExpiration = token.GetExpirationTime()
+			}
 			return nil
 		},
 	); err != nil {
@@ -558,3 +739,118 @@ func (r *Repository) CreateWorker(ctx context.Context, worker *Worker, opt ...Op
 	}
 	return returnedWorker, nil
 }
+
+// RevokeWorkerActivationTokens revokes any activation token generated for the
+// worker which has not yet been redeemed, and returns the worker. A
+// RecordNotFound error is returned if the worker does not exist or has no
+// activation token which can be revoked.
+func (r *Repository) RevokeWorkerActivationTokens(ctx context.Context, workerId string, _ ...Option) (*Worker, error) {
+	const op = "server.(Repository).RevokeWorkerActivationTokens"
+	if workerId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	}
+
+	var ret *Worker
+	if _, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Exec(ctx, revokeWorkerActivationTokensQuery, []any{sql.Named("worker_id", workerId)})
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke activation tokens"))
+			}
+			if rowsUpdated == 0 {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("no activation token to revoke for worker %s", workerId))
+			}
+			wAgg := &workerAggregate{PublicId: workerId}
+			if err := reader.LookupById(ctx, wAgg); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if ret, err = wAgg.toWorker(ctx); err != nil {
+				return err
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
+// AuthorizeWorkerWithActivationToken authorizes the node which sent the
+// provided fetch request if the registration nonce in the request is an
+// activation token generated by CreateWorker which has not expired, been
+// revoked or been redeemed, and whose worker is still in the scope the token
+// was created in. The token is redeemed in the same transaction in which the
+// node is authorized, so it can only ever be used once. The id of the
+// authorized worker is returned. A RecordNotFound error is returned if the
+// nonce does not correspond to a redeemable activation token.
+func (r *Repository) AuthorizeWorkerWithActivationToken(ctx context.Context, req *types.FetchNodeCredentialsRequest, _ ...Option) (string, error) {
+	const op = "server.(Repository).AuthorizeWorkerWithActivationToken"
+	switch {
+	case req == nil:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing fetch node credentials request")
+	case len(req.GetBundle()) == 0:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing fetch node credentials request bundle")
+	}
+	reqInfo := new(types.FetchNodeCredentialsInfo)
+	if err := proto.Unmarshal(req.GetBundle(), reqInfo); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to unmarshal fetch node credentials request bundle"))
+	}
+	if len(reqInfo.GetNonce()) == 0 {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing registration nonce")
+	}
+	tokenHash := sha256.Sum256(reqInfo.GetNonce())
+
+	// used to encrypt the privKey within the NodeInformation
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scope.Global.String(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+
+	var workerId string
+	if _, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			rows, err := w.Query(ctx, redeemWorkerActivationTokenQuery, []any{sql.Named("token_hash", tokenHash[:])})
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to redeem activation token"))
+			}
+			defer rows.Close()
+			workerId = ""
+			for rows.Next() {
+				if err := rows.Scan(&workerId); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan redeemed worker id"))
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if workerId == "" {
+				return errors.New(ctx, errors.RecordNotFound, op, "no redeemable activation token found")
+			}
+
+			workerAuthRepo, err := NewRepositoryStorage(ctx, read, w, r.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create worker auth repository"))
+			}
+			nodeInfo, err := registration.AuthorizeNode(ctx, workerAuthRepo, req, nodeenrollment.WithSkipStorage(true))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to authorize node"))
+			}
+			if nodeInfo.State, err = AttachWorkerIdToState(ctx, workerId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := StoreNodeInformationTx(ctx, w, databaseWrapper, nodeInfo); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to store node information"))
+			}
+			return nil
+		},
+	); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return workerId, nil
+}
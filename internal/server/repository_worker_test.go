@@ -2,6 +2,7 @@ package server_test
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -20,11 +22,14 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
 	"github.com/hashicorp/go-kms-wrapping/extras/kms/v2/migrations"
+	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/rotation"
 	"github.com/hashicorp/nodeenrollment/storage/file"
 	"github.com/hashicorp/nodeenrollment/types"
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -436,6 +441,108 @@ func TestUpsertWorkerStatus(t *testing.T) {
 	})
 }
 
+func TestUpsertWorkerStatus_ReleaseVersion(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := server.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	status := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("versioned"))
+	worker, err := repo.UpsertWorkerStatus(ctx, status)
+	require.NoError(t, err)
+	assert.Empty(t, worker.GetReleaseVersion())
+	assert.Empty(t, worker.GetFeatures())
+
+	worker, err = repo.UpsertWorkerStatus(ctx, status,
+		server.WithReleaseVersion("0.9.1"),
+		server.WithFeatures("feature1", "feature2", "feature1"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.1", worker.GetReleaseVersion())
+	assert.ElementsMatch(t, []string{"feature1", "feature2"}, worker.GetFeatures())
+
+	// Features are left alone when no version is reported
+	worker, err = repo.UpsertWorkerStatus(ctx, status)
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.1", worker.GetReleaseVersion())
+	assert.ElementsMatch(t, []string{"feature1", "feature2"}, worker.GetFeatures())
+
+	worker, err = repo.UpsertWorkerStatus(ctx, status,
+		server.WithReleaseVersion("0.9.2"),
+		server.WithFeatures("feature3"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.2", worker.GetReleaseVersion())
+	assert.Equal(t, []string{"feature3"}, worker.GetFeatures())
+}
+
+func TestRequestWorkerAuthRotation(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := server.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	var pkiWorkerKeyId string
+	pkiWorker := server.TestPkiWorker(t, conn, wrapper, server.WithTestPkiWorkerAuthorizedKeyId(&pkiWorkerKeyId))
+	kmsWorker := server.TestKmsWorker(t, conn, wrapper)
+
+	t.Run("missing public id", func(t *testing.T) {
+		_, err := repo.RequestWorkerAuthRotation(ctx, "", pkiWorker.GetVersion())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("zero version", func(t *testing.T) {
+		_, err := repo.RequestWorkerAuthRotation(ctx, pkiWorker.GetPublicId(), 0)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("kms worker", func(t *testing.T) {
+		_, err := repo.RequestWorkerAuthRotation(ctx, kmsWorker.GetPublicId(), kmsWorker.GetVersion())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("wrong version", func(t *testing.T) {
+		_, err := repo.RequestWorkerAuthRotation(ctx, pkiWorker.GetPublicId(), pkiWorker.GetVersion()+1)
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+	})
+	t.Run("requested and cleared by status", func(t *testing.T) {
+		w, err := repo.RequestWorkerAuthRotation(ctx, pkiWorker.GetPublicId(), pkiWorker.GetVersion())
+		require.NoError(t, err)
+		assert.True(t, w.GetRotateAuthRequested())
+		// Requesting a rotation does not change the version
+		assert.Equal(t, pkiWorker.GetVersion(), w.GetVersion())
+
+		expiration := timestamp.New(time.Now().Add(time.Hour).Truncate(time.Second))
+		status := server.NewWorker(scope.Global.String(), server.WithAddress("pki_address"))
+		w, err = repo.UpsertWorkerStatus(ctx, status,
+			server.WithKeyId(pkiWorkerKeyId),
+			server.WithAuthRotationStatus(&server.AuthRotationStatus{
+				CertificateExpiration: expiration,
+				LastRotationError:     "rotation failed",
+			}))
+		require.NoError(t, err)
+		// The request is relayed to the worker in the response to this status
+		assert.True(t, w.GetRotateAuthRequested())
+		assert.Equal(t, expiration.AsTime(), w.GetCertificateExpiration().AsTime())
+		assert.Nil(t, w.GetLastRotationTime())
+		assert.Equal(t, "rotation failed", w.GetLastRotationError())
+
+		lastRotation := timestamp.New(time.Now().Truncate(time.Second))
+		w, err = repo.UpsertWorkerStatus(ctx, status,
+			server.WithKeyId(pkiWorkerKeyId),
+			server.WithAuthRotationStatus(&server.AuthRotationStatus{
+				CertificateExpiration: expiration,
+				LastRotationTime:      lastRotation,
+			}))
+		require.NoError(t, err)
+		assert.False(t, w.GetRotateAuthRequested())
+		assert.Equal(t, lastRotation.AsTime(), w.GetLastRotationTime().AsTime())
+		assert.Empty(t, w.GetLastRotationError())
+	})
+}
+
 func TestTagUpdatingListing(t *testing.T) {
 	require := require.New(t)
 	conn, _ := db.TestSetup(t, "postgres")
@@ -915,6 +1022,124 @@ func TestRepository_CreateWorker(t *testing.T) {
 	}
 }
 
+func TestRepository_WorkerActivationToken(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	require.NoError(t, testKms.CreateKeys(testCtx, scope.Global.String(), kms.WithRandomReader(rand.Reader)))
+	testRepo, err := server.NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	rootStorage, err := server.NewRepositoryStorage(testCtx, rw, rw, testKms)
+	require.NoError(t, err)
+	_, err = rotation.RotateRootCertificates(testCtx, rootStorage)
+	require.NoError(t, err)
+
+	// newFetchReq creates a fetch request the way a worker configured with
+	// the provided activation token would
+	newFetchReq := func(t *testing.T, token string) *types.FetchNodeCredentialsRequest {
+		t.Helper()
+		fileStorage, err := file.New(testCtx)
+		require.NoError(t, err)
+		t.Cleanup(fileStorage.Cleanup)
+		nodeCreds, err := types.NewNodeCredentials(testCtx, fileStorage, nodeenrollment.WithSkipStorage(true))
+		require.NoError(t, err)
+		nodeCreds.RegistrationNonce, err = base58.Decode(strings.TrimPrefix(token, globals.ControllerLedActivationTokenPrefix))
+		require.NoError(t, err)
+		req, err := nodeCreds.CreateFetchNodeCredentialsRequest(testCtx)
+		require.NoError(t, err)
+		return req
+	}
+
+	t.Run("mutually-exclusive-options", func(t *testing.T) {
+		_, err := testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()),
+			server.WithCreateControllerLedActivationToken(true),
+			server.WithFetchNodeCredentialsRequest(&types.FetchNodeCredentialsRequest{}))
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("redeem-once", func(t *testing.T) {
+		w, err := testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()), server.WithCreateControllerLedActivationToken(true))
+		require.NoError(t, err)
+		token := w.GetControllerGeneratedActivationToken()
+		require.True(t, strings.HasPrefix(token, globals.ControllerLedActivationTokenPrefix))
+		assert.WithinDuration(t, time.Now().Add(server.DefaultActivationTokenTtl), w.GetActivationTokenExpiration().AsTime(), time.Minute)
+
+		req := newFetchReq(t, token)
+		gotId, err := testRepo.AuthorizeWorkerWithActivationToken(testCtx, req)
+		require.NoError(t, err)
+		assert.Equal(t, w.GetPublicId(), gotId)
+
+		// The worker is now authorized
+		keyId, err := nodeenrollment.KeyIdFromPkix(mustFetchInfo(t, req).GetCertificatePublicKeyPkix())
+		require.NoError(t, err)
+		gotId, err = testRepo.LookupWorkerIdByKeyId(testCtx, keyId)
+		require.NoError(t, err)
+		assert.Equal(t, w.GetPublicId(), gotId)
+
+		// The token cannot be used again, even with different credentials
+		_, err = testRepo.AuthorizeWorkerWithActivationToken(testCtx, newFetchReq(t, token))
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+
+		// Nor can it be revoked once redeemed
+		_, err = testRepo.RevokeWorkerActivationTokens(testCtx, w.GetPublicId())
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("revoked", func(t *testing.T) {
+		w, err := testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()), server.WithCreateControllerLedActivationToken(true))
+		require.NoError(t, err)
+		got, err := testRepo.RevokeWorkerActivationTokens(testCtx, w.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, w.GetPublicId(), got.GetPublicId())
+
+		_, err = testRepo.AuthorizeWorkerWithActivationToken(testCtx, newFetchReq(t, w.GetControllerGeneratedActivationToken()))
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("expired", func(t *testing.T) {
+		w, err := testRepo.CreateWorker(testCtx, server.NewWorker(scope.Global.String()),
+			server.WithCreateControllerLedActivationToken(true),
+			server.WithActivationTokenTtl(time.Second))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Second), w.GetActivationTokenExpiration().AsTime(), 5*time.Second)
+		time.Sleep(2 * time.Second)
+
+		_, err = testRepo.AuthorizeWorkerWithActivationToken(testCtx, newFetchReq(t, w.GetControllerGeneratedActivationToken()))
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("unknown-token", func(t *testing.T) {
+		fileStorage, err := file.New(testCtx)
+		require.NoError(t, err)
+		defer fileStorage.Cleanup()
+		nodeCreds, err := types.NewNodeCredentials(testCtx, fileStorage, nodeenrollment.WithSkipStorage(true))
+		require.NoError(t, err)
+		req, err := nodeCreds.CreateFetchNodeCredentialsRequest(testCtx)
+		require.NoError(t, err)
+
+		_, err = testRepo.AuthorizeWorkerWithActivationToken(testCtx, req)
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("revoke-missing-worker-id", func(t *testing.T) {
+		_, err := testRepo.RevokeWorkerActivationTokens(testCtx, "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func mustFetchInfo(t *testing.T, req *types.FetchNodeCredentialsRequest) *types.FetchNodeCredentialsInfo {
+	t.Helper()
+	info := new(types.FetchNodeCredentialsInfo)
+	require.NoError(t, proto.Unmarshal(req.GetBundle(), info))
+	return info
+}
+
 func TestRepository_UpdateWorker(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
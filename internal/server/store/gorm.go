@@ -9,3 +9,9 @@ func (Controller) TableName() string {
 func (WorkerTag) TableName() string {
 	return "server_worker_tag"
 }
+
+// TableName overrides the table name used by WorkerFeature to
+// `server_worker_feature`
+func (*WorkerFeature) TableName() string {
+	return "server_worker_feature"
+}
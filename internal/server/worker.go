@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/server/store"
+	"github.com/hashicorp/boundary/version"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -57,9 +58,16 @@ func AttachWorkerIdToState(ctx context.Context, workerId string) (*structpb.Stru
 type Worker struct {
 	*store.Worker
 
-	activeConnectionCount uint32 `gorm:"-"`
-	apiTags               []*Tag `gorm:"-"`
-	configTags            []*Tag `gorm:"-"`
+	activeConnectionCount uint32   `gorm:"-"`
+	apiTags               []*Tag   `gorm:"-"`
+	configTags            []*Tag   `gorm:"-"`
+	features              []string `gorm:"-"`
+
+	// controllerGeneratedActivationToken and activationTokenExpiration are
+	// only populated on the worker returned when creating a worker with
+	// WithCreateControllerLedActivationToken.
+	controllerGeneratedActivationToken string               `gorm:"-"`
+	activationTokenExpiration          *timestamp.Timestamp `gorm:"-"`
 
 	// inputTags is not specified to be api or config tags and is not intended
 	// to be read by clients.  Since config tags and api tags are applied in
@@ -116,6 +124,10 @@ func (w *Worker) clone() *Worker {
 			cWorker.inputTags = append(cWorker.inputTags, &Tag{Key: t.Key, Value: t.Value})
 		}
 	}
+	if w.features != nil {
+		cWorker.features = make([]string, len(w.features))
+		copy(cWorker.features, w.features)
+	}
 	return cWorker
 }
 
@@ -152,6 +164,45 @@ func (w *Worker) GetConfigTags() map[string][]string {
 	return tags
 }
 
+// GetFeatures returns the protocol features the worker last reported
+// supporting.
+func (w *Worker) GetFeatures() []string {
+	return w.features
+}
+
+// GetControllerGeneratedActivationToken returns the activation token generated
+// when the worker was created, if any. The token is never stored so this is
+// only populated on the worker returned from its creation.
+func (w *Worker) GetControllerGeneratedActivationToken() string {
+	return w.controllerGeneratedActivationToken
+}
+
+// GetActivationTokenExpiration returns the expiration time of the activation
+// token generated when the worker was created, if any.
+func (w *Worker) GetActivationTokenExpiration() *timestamp.Timestamp {
+	return w.activationTokenExpiration
+}
+
+// SupportsFeature reports whether the worker supports the provided feature.
+// Workers which have never reported a version predate feature reporting and
+// are assumed to support only the legacy set of features.
+func (w *Worker) SupportsFeature(f version.Feature) bool {
+	if w.GetReleaseVersion() == "" {
+		for _, lf := range version.LegacyFeatures() {
+			if lf == f {
+				return true
+			}
+		}
+		return false
+	}
+	for _, wf := range w.features {
+		if wf == f.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // GetLastStatusTime contains the last time the worker has reported to the
 // controller its connection status.  If the worker has never reported to a
 // controller then nil is returned.
@@ -162,6 +213,19 @@ func (w *Worker) GetLastStatusTime() *timestamp.Timestamp {
 	return w.Worker.GetLastStatusTime()
 }
 
+// AuthRotationStatus is the credential rotation status reported by a pki
+// worker along with its status.
+type AuthRotationStatus struct {
+	// CertificateExpiration is when the worker's current credentials expire
+	CertificateExpiration *timestamp.Timestamp
+	// LastRotationTime is the last time the worker successfully rotated its
+	// credentials
+	LastRotationTime *timestamp.Timestamp
+	// LastRotationError is the error from the worker's most recent failed
+	// rotation, if any
+	LastRotationError string
+}
+
 // TableName overrides the table name used by Worker to `server_worker`
 func (Worker) TableName() string {
 	return "server_worker"
@@ -184,6 +248,14 @@ type workerAggregate struct {
 	// Config Fields
 	LastStatusTime   *timestamp.Timestamp
 	WorkerConfigTags string
+	// Auth rotation fields
+	CertificateExpiration *timestamp.Timestamp
+	LastRotationTime      *timestamp.Timestamp
+	LastRotationError     string
+	RotateAuthRequested   bool
+	// Version fields
+	ReleaseVersion string
+	Features       string
 }
 
 func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
@@ -200,6 +272,13 @@ func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
 			Version:        a.Version,
 			LastStatusTime: a.LastStatusTime,
 			Type:           a.Type,
+
+			CertificateExpiration: a.CertificateExpiration,
+			LastRotationTime:      a.LastRotationTime,
+			LastRotationError:     a.LastRotationError,
+			RotateAuthRequested:   a.RotateAuthRequested,
+
+			ReleaseVersion: a.ReleaseVersion,
 		},
 		activeConnectionCount: a.ActiveConnectionCount,
 	}
@@ -215,6 +294,10 @@ func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
 	}
 	worker.configTags = tags
 
+	if a.Features != "" {
+		worker.features = strings.Split(a.Features, ",")
+	}
+
 	return worker, nil
 }
 
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/server/store"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultActivationTokenTtl is how long a generated activation token remains
+// valid if no ttl is provided.
+const DefaultActivationTokenTtl = 24 * time.Hour
+
+// WorkerActivationToken is a single use token generated by the controller
+// which a worker can present, before it expires, to be authorized. Only the
+// hash of the token is stored.
+type WorkerActivationToken struct {
+	*store.WorkerActivationToken
+	tableName string `gorm:"-"`
+}
+
+func newWorkerActivationToken(ctx context.Context, workerId, scopeId string, tokenHash []byte, expiration time.Time) (*WorkerActivationToken, error) {
+	const op = "server.newWorkerActivationToken"
+	switch {
+	case workerId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case len(tokenHash) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token hash")
+	case expiration.IsZero():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing expiration time")
+	}
+	return &WorkerActivationToken{
+		WorkerActivationToken: &store.WorkerActivationToken{
+			WorkerId:       workerId,
+			ScopeId:        scopeId,
+			TokenHash:      tokenHash,
+			ExpirationTime: &timestamp.Timestamp{Timestamp: timestamppb.New(expiration)},
+		},
+	}, nil
+}
+
+func allocWorkerActivationToken() *WorkerActivationToken {
+	return &WorkerActivationToken{
+		WorkerActivationToken: &store.WorkerActivationToken{},
+	}
+}
+
+func (t *WorkerActivationToken) clone() *WorkerActivationToken {
+	cp := proto.Clone(t.WorkerActivationToken)
+	return &WorkerActivationToken{
+		WorkerActivationToken: cp.(*store.WorkerActivationToken),
+	}
+}
+
+// TableName returns the table name.
+func (t *WorkerActivationToken) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "server_worker_activation_token"
+}
+
+// SetTableName sets the table name.
+func (t *WorkerActivationToken) SetTableName(n string) {
+	t.tableName = n
+}
+
+// generateActivationToken returns a new encoded activation token along with
+// the hash of its value which is stored in place of the token.
+func generateActivationToken(ctx context.Context) (string, []byte, error) {
+	const op = "server.generateActivationToken"
+	value := make([]byte, nodeenrollment.NonceSize)
+	if _, err := rand.Read(value); err != nil {
+		return "", nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate activation token"))
+	}
+	hash := sha256.Sum256(value)
+	return globals.ControllerLedActivationTokenPrefix + base58.Encode(value), hash[:], nil
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateActivationToken(t *testing.T) {
+	ctx := context.Background()
+
+	token, hash, err := generateActivationToken(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, globals.ControllerLedActivationTokenPrefix))
+
+	nonce, err := base58.Decode(strings.TrimPrefix(token, globals.ControllerLedActivationTokenPrefix))
+	require.NoError(t, err)
+	assert.Len(t, nonce, nodeenrollment.NonceSize)
+	wantHash := sha256.Sum256(nonce)
+	assert.Equal(t, wantHash[:], hash)
+
+	otherToken, _, err := generateActivationToken(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, otherToken)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/server/store"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -39,6 +40,21 @@ func TestWorkerCanonicalTags(t *testing.T) {
 	assert.ElementsMatch(t, got["key3"], []string{"configs key3 unique"})
 }
 
+func TestWorkerSupportsFeature(t *testing.T) {
+	t.Run("legacy worker", func(t *testing.T) {
+		w := NewWorker(scope.Global.String())
+		assert.True(t, w.SupportsFeature(version.TcpProxyFeature))
+		assert.False(t, w.SupportsFeature(version.RotateAuthFeature))
+	})
+	t.Run("reporting worker", func(t *testing.T) {
+		w := NewWorker(scope.Global.String())
+		w.ReleaseVersion = "0.9.1"
+		w.features = []string{version.RotateAuthFeature.String()}
+		assert.False(t, w.SupportsFeature(version.TcpProxyFeature))
+		assert.True(t, w.SupportsFeature(version.RotateAuthFeature))
+	})
+}
+
 func TestWorkerAggregate(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
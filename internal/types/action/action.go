@@ -8,52 +8,62 @@ import (
 type Type uint
 
 const (
-	Unknown                   Type = 0
-	List                      Type = 1
-	Create                    Type = 2
-	Update                    Type = 3
-	Read                      Type = 4
-	Delete                    Type = 5
-	Authenticate              Type = 6
-	All                       Type = 7
-	AuthorizeSession          Type = 8
-	AddGrants                 Type = 9
-	RemoveGrants              Type = 10
-	SetGrants                 Type = 11
-	AddPrincipals             Type = 12
-	SetPrincipals             Type = 13
-	RemovePrincipals          Type = 14
-	Deauthenticate            Type = 15
-	AddMembers                Type = 16
-	SetMembers                Type = 17
-	RemoveMembers             Type = 18
-	SetPassword               Type = 19
-	ChangePassword            Type = 20
-	AddHosts                  Type = 21
-	SetHosts                  Type = 22
-	RemoveHosts               Type = 23
-	AddHostSets               Type = 24 // DEPRECATED
-	SetHostSets               Type = 25 // DEPRECATED
-	RemoveHostSets            Type = 26 // DEPRECATED
-	Cancel                    Type = 27
-	AddAccounts               Type = 28
-	SetAccounts               Type = 29
-	RemoveAccounts            Type = 30
-	ReadSelf                  Type = 31
-	CancelSelf                Type = 32
-	ChangeState               Type = 33
-	DeleteSelf                Type = 34
-	NoOp                      Type = 35
-	AddCredentialLibraries    Type = 36 // DEPRECATED
-	SetCredentialLibraries    Type = 37 // DEPRECATED
-	RemoveCredentialLibraries Type = 38 // DEPRECATED
-	AddCredentialSources      Type = 39
-	SetCredentialSources      Type = 40
-	RemoveCredentialSources   Type = 41
-	AddHostSources            Type = 42
-	SetHostSources            Type = 43
-	RemoveHostSources         Type = 44
-	CreateWorkerLed           Type = 45
+	Unknown                       Type = 0
+	List                          Type = 1
+	Create                        Type = 2
+	Update                        Type = 3
+	Read                          Type = 4
+	Delete                        Type = 5
+	Authenticate                  Type = 6
+	All                           Type = 7
+	AuthorizeSession              Type = 8
+	AddGrants                     Type = 9
+	RemoveGrants                  Type = 10
+	SetGrants                     Type = 11
+	AddPrincipals                 Type = 12
+	SetPrincipals                 Type = 13
+	RemovePrincipals              Type = 14
+	Deauthenticate                Type = 15
+	AddMembers                    Type = 16
+	SetMembers                    Type = 17
+	RemoveMembers                 Type = 18
+	SetPassword                   Type = 19
+	ChangePassword                Type = 20
+	AddHosts                      Type = 21
+	SetHosts                      Type = 22
+	RemoveHosts                   Type = 23
+	AddHostSets                   Type = 24 // DEPRECATED
+	SetHostSets                   Type = 25 // DEPRECATED
+	RemoveHostSets                Type = 26 // DEPRECATED
+	Cancel                        Type = 27
+	AddAccounts                   Type = 28
+	SetAccounts                   Type = 29
+	RemoveAccounts                Type = 30
+	ReadSelf                      Type = 31
+	CancelSelf                    Type = 32
+	ChangeState                   Type = 33
+	DeleteSelf                    Type = 34
+	NoOp                          Type = 35
+	AddCredentialLibraries        Type = 36 // DEPRECATED
+	SetCredentialLibraries        Type = 37 // DEPRECATED
+	RemoveCredentialLibraries     Type = 38 // DEPRECATED
+	AddCredentialSources          Type = 39
+	SetCredentialSources          Type = 40
+	RemoveCredentialSources       Type = 41
+	AddHostSources                Type = 42
+	SetHostSources                Type = 43
+	RemoveHostSources             Type = 44
+	CreateWorkerLed               Type = 45
+	RotateAuth                    Type = 46
+	CreateControllerLed           Type = 47
+	RevokeActivationToken         Type = 48
+	Tail                          Type = 49
+	RotateKeys                    Type = 50
+	ListKeys                      Type = 51
+	DestroyKeyVersion             Type = 52
+	ListKeyVersionDestructionJobs Type = 53
+	SetKms                        Type = 54
+	RunNow                        Type = 55
 
 	// When adding new actions, be sure to update:
 	//
@@ -61,51 +71,61 @@ const (
 )
 
 var Map = map[string]Type{
-	Create.String():                    Create,
-	List.String():                      List,
-	Update.String():                    Update,
-	Read.String():                      Read,
-	Delete.String():                    Delete,
-	Authenticate.String():              Authenticate,
-	All.String():                       All,
-	AuthorizeSession.String():          AuthorizeSession,
-	AddGrants.String():                 AddGrants,
-	RemoveGrants.String():              RemoveGrants,
-	SetGrants.String():                 SetGrants,
-	AddPrincipals.String():             AddPrincipals,
-	SetPrincipals.String():             SetPrincipals,
-	RemovePrincipals.String():          RemovePrincipals,
-	Deauthenticate.String():            Deauthenticate,
-	AddMembers.String():                AddMembers,
-	SetMembers.String():                SetMembers,
-	RemoveMembers.String():             RemoveMembers,
-	SetPassword.String():               SetPassword,
-	ChangePassword.String():            ChangePassword,
-	AddHosts.String():                  AddHosts,
-	SetHosts.String():                  SetHosts,
-	RemoveHosts.String():               RemoveHosts,
-	AddHostSets.String():               AddHostSets,
-	SetHostSets.String():               SetHostSets,
-	RemoveHostSets.String():            RemoveHostSets,
-	Cancel.String():                    Cancel,
-	AddAccounts.String():               AddAccounts,
-	SetAccounts.String():               SetAccounts,
-	RemoveAccounts.String():            RemoveAccounts,
-	ReadSelf.String():                  ReadSelf,
-	CancelSelf.String():                CancelSelf,
-	ChangeState.String():               ChangeState,
-	DeleteSelf.String():                DeleteSelf,
-	NoOp.String():                      NoOp,
-	AddCredentialLibraries.String():    AddCredentialLibraries,
-	SetCredentialLibraries.String():    SetCredentialLibraries,
-	RemoveCredentialLibraries.String(): RemoveCredentialLibraries,
-	AddCredentialSources.String():      AddCredentialSources,
-	SetCredentialSources.String():      SetCredentialSources,
-	RemoveCredentialSources.String():   RemoveCredentialSources,
-	AddHostSources.String():            AddHostSources,
-	SetHostSources.String():            SetHostSources,
-	RemoveHostSources.String():         RemoveHostSources,
-	CreateWorkerLed.String():           CreateWorkerLed,
+	Create.String():                        Create,
+	List.String():                          List,
+	Update.String():                        Update,
+	Read.String():                          Read,
+	Delete.String():                        Delete,
+	Authenticate.String():                  Authenticate,
+	All.String():                           All,
+	AuthorizeSession.String():              AuthorizeSession,
+	AddGrants.String():                     AddGrants,
+	RemoveGrants.String():                  RemoveGrants,
+	SetGrants.String():                     SetGrants,
+	AddPrincipals.String():                 AddPrincipals,
+	SetPrincipals.String():                 SetPrincipals,
+	RemovePrincipals.String():              RemovePrincipals,
+	Deauthenticate.String():                Deauthenticate,
+	AddMembers.String():                    AddMembers,
+	SetMembers.String():                    SetMembers,
+	RemoveMembers.String():                 RemoveMembers,
+	SetPassword.String():                   SetPassword,
+	ChangePassword.String():                ChangePassword,
+	AddHosts.String():                      AddHosts,
+	SetHosts.String():                      SetHosts,
+	RemoveHosts.String():                   RemoveHosts,
+	AddHostSets.String():                   AddHostSets,
+	SetHostSets.String():                   SetHostSets,
+	RemoveHostSets.String():                RemoveHostSets,
+	Cancel.String():                        Cancel,
+	AddAccounts.String():                   AddAccounts,
+	SetAccounts.String():                   SetAccounts,
+	RemoveAccounts.String():                RemoveAccounts,
+	ReadSelf.String():                      ReadSelf,
+	CancelSelf.String():                    CancelSelf,
+	ChangeState.String():                   ChangeState,
+	DeleteSelf.String():                    DeleteSelf,
+	NoOp.String():                          NoOp,
+	AddCredentialLibraries.String():        AddCredentialLibraries,
+	SetCredentialLibraries.String():        SetCredentialLibraries,
+	RemoveCredentialLibraries.String():     RemoveCredentialLibraries,
+	AddCredentialSources.String():          AddCredentialSources,
+	SetCredentialSources.String():          SetCredentialSources,
+	RemoveCredentialSources.String():       RemoveCredentialSources,
+	AddHostSources.String():                AddHostSources,
+	SetHostSources.String():                SetHostSources,
+	RemoveHostSources.String():             RemoveHostSources,
+	CreateWorkerLed.String():               CreateWorkerLed,
+	RotateAuth.String():                    RotateAuth,
+	CreateControllerLed.String():           CreateControllerLed,
+	RevokeActivationToken.String():         RevokeActivationToken,
+	Tail.String():                          Tail,
+	RotateKeys.String():                    RotateKeys,
+	ListKeys.String():                      ListKeys,
+	DestroyKeyVersion.String():             DestroyKeyVersion,
+	ListKeyVersionDestructionJobs.String(): ListKeyVersionDestructionJobs,
+	SetKms.String():                        SetKms,
+	RunNow.String():                        RunNow,
 }
 
 func (a Type) String() string {
@@ -156,6 +176,16 @@ func (a Type) String() string {
 		"set-host-sources",
 		"remove-host-sources",
 		"create:worker-led",
+		"rotate-auth",
+		"create:controller-led",
+		"revoke-activation-token",
+		"tail",
+		"rotate-keys",
+		"list-keys",
+		"destroy-key-version",
+		"list-key-version-destruction-jobs",
+		"set-kms",
+		"run-now",
 	}[a]
 }
 
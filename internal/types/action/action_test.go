@@ -99,6 +99,46 @@ func TestAction(t *testing.T) {
 			action: CreateWorkerLed,
 			want:   "create:worker-led",
 		},
+		{
+			action: RotateAuth,
+			want:   "rotate-auth",
+		},
+		{
+			action: CreateControllerLed,
+			want:   "create:controller-led",
+		},
+		{
+			action: RevokeActivationToken,
+			want:   "revoke-activation-token",
+		},
+		{
+			action: Tail,
+			want:   "tail",
+		},
+		{
+			action: RotateKeys,
+			want:   "rotate-keys",
+		},
+		{
+			action: ListKeys,
+			want:   "list-keys",
+		},
+		{
+			action: DestroyKeyVersion,
+			want:   "destroy-key-version",
+		},
+		{
+			action: ListKeyVersionDestructionJobs,
+			want:   "list-key-version-destruction-jobs",
+		},
+		{
+			action: SetKms,
+			want:   "set-kms",
+		},
+		{
+			action: RunNow,
+			want:   "run-now",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
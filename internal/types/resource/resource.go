@@ -26,6 +26,9 @@ const (
 	CredentialStore
 	CredentialLibrary
 	Credential
+	Event
+	Job
+	Oplog
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -60,6 +63,9 @@ func (r Type) String() string {
 		"credential-store",
 		"credential-library",
 		"credential",
+		"event",
+		"job",
+		"oplog",
 	}[r]
 }
 
@@ -93,4 +99,7 @@ var Map = map[string]Type{
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
 	Credential.String():        Credential,
+	Event.String():             Event,
+	Job.String():               Job,
+	Oplog.String():             Oplog,
 }
@@ -87,6 +87,18 @@ func Test_Resource(t *testing.T) {
 			typeString: "credential-library",
 			want:       CredentialLibrary,
 		},
+		{
+			typeString: "event",
+			want:       Event,
+		},
+		{
+			typeString: "job",
+			want:       Job,
+		},
+		{
+			typeString: "oplog",
+			want:       Oplog,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
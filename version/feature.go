@@ -0,0 +1,37 @@
+package version
+
+// Feature is a capability that a worker advertises to controllers so that
+// they can avoid sending work to workers that are unable to handle it, e.g.
+// during a mixed-version upgrade.
+type Feature string
+
+const (
+	// TcpProxyFeature indicates the worker can proxy session connections to
+	// tcp targets.
+	TcpProxyFeature Feature = "tcp-proxy"
+
+	// RotateAuthFeature indicates the worker will rotate its credentials when
+	// requested to by a controller.
+	RotateAuthFeature Feature = "rotate-auth"
+)
+
+// SupportedFeatures returns the features supported by this build.
+func SupportedFeatures() []Feature {
+	return []Feature{
+		TcpProxyFeature,
+		RotateAuthFeature,
+	}
+}
+
+// LegacyFeatures returns the features assumed to be supported by workers that
+// predate feature reporting and so do not report a version or any features.
+func LegacyFeatures() []Feature {
+	return []Feature{
+		TcpProxyFeature,
+	}
+}
+
+// String returns the string form of the feature.
+func (f Feature) String() string {
+	return string(f)
+}